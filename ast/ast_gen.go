@@ -7,6 +7,9 @@ type AddToSet struct {
 	Set Value
 
 	Value Value
+
+	// Where the node came from, if it was parsed.
+	Position Position
 }
 
 func (AddToSet) isNode() {}
@@ -18,6 +21,9 @@ type ArgumentDef struct {
 	Name string
 
 	Type Type
+
+	// Where the node came from, if it was parsed.
+	Position Position
 }
 
 func (ArgumentDef) isNode() {}
@@ -29,6 +35,9 @@ type Assignment struct {
 	From Value
 
 	To Value
+
+	// Where the node came from, if it was parsed.
+	Position Position
 }
 
 func (Assignment) isNode() {}
@@ -36,13 +45,35 @@ func (Assignment) isNode() {}
 // isStatement is just a inteface guard to restrict what can be used as a Statement.
 func (Assignment) isStatement() {}
 
+type Binary struct {
+	Left Value
+
+	Operator BinaryOperator
+
+	Right Value
+
+	// Where the node came from, if it was parsed.
+	Position Position
+}
+
+func (Binary) isNode() {}
+
+// isValue is just a inteface guard to restrict what can be used as a Value.
+func (Binary) isValue() {}
+
 type Block struct {
 	Statements []Statement
+
+	// Where the node came from, if it was parsed.
+	Position Position
 }
 
 func (Block) isNode() {}
 
 type Bool struct {
+
+	// Where the node came from, if it was parsed.
+	Position Position
 }
 
 func (Bool) isNode() {}
@@ -51,6 +82,9 @@ func (Bool) isNode() {}
 func (Bool) isType() {}
 
 type Break struct {
+
+	// Where the node came from, if it was parsed.
+	Position Position
 }
 
 func (Break) isNode() {}
@@ -58,10 +92,24 @@ func (Break) isNode() {}
 // isStatement is just a inteface guard to restrict what can be used as a Statement.
 func (Break) isStatement() {}
 
+type Bytes struct {
+
+	// Where the node came from, if it was parsed.
+	Position Position
+}
+
+func (Bytes) isNode() {}
+
+// isType is just a inteface guard to restrict what can be used as a Type.
+func (Bytes) isType() {}
+
 type Call struct {
 	Arguments []Value
 
 	Function Callable
+
+	// Where the node came from, if it was parsed.
+	Position Position
 }
 
 func (Call) isNode() {}
@@ -72,10 +120,29 @@ func (Call) isStatement() {}
 // isValue is just a inteface guard to restrict what can be used as a Value.
 func (Call) isValue() {}
 
+type Comparison struct {
+	Left Value
+
+	Operator ComparisonOperator
+
+	Right Value
+
+	// Where the node came from, if it was parsed.
+	Position Position
+}
+
+func (Comparison) isNode() {}
+
+// isValue is just a inteface guard to restrict what can be used as a Value.
+func (Comparison) isValue() {}
+
 type Conditional struct {
 	Else Optional[Block]
 
 	Ifs []If
+
+	// Where the node came from, if it was parsed.
+	Position Position
 }
 
 func (Conditional) isNode() {}
@@ -87,6 +154,11 @@ type ConstantDef struct {
 	Name string
 
 	Value ConstantValue
+
+	Visibility Visibility
+
+	// Where the node came from, if it was parsed.
+	Position Position
 }
 
 func (ConstantDef) isNode() {}
@@ -95,6 +167,9 @@ func (ConstantDef) isNode() {}
 func (ConstantDef) isDefinition() {}
 
 type Continue struct {
+
+	// Where the node came from, if it was parsed.
+	Position Position
 }
 
 func (Continue) isNode() {}
@@ -102,10 +177,27 @@ func (Continue) isNode() {}
 // isStatement is just a inteface guard to restrict what can be used as a Statement.
 func (Continue) isStatement() {}
 
+type Conversion struct {
+	To Type
+
+	Value Value
+
+	// Where the node came from, if it was parsed.
+	Position Position
+}
+
+func (Conversion) isNode() {}
+
+// isValue is just a inteface guard to restrict what can be used as a Value.
+func (Conversion) isValue() {}
+
 type Declare struct {
 	Name string
 
 	Value Value
+
+	// Where the node came from, if it was parsed.
+	Position Position
 }
 
 func (Declare) isNode() {}
@@ -118,6 +210,9 @@ func (Declare) isDefinition() {}
 
 type EmptyList struct {
 	Type Type
+
+	// Where the node came from, if it was parsed.
+	Position Position
 }
 
 func (EmptyList) isNode() {}
@@ -132,14 +227,25 @@ type EqualOverride struct {
 	Block Block
 
 	OtherName string
+
+	// Where the node came from, if it was parsed.
+	Position Position
 }
 
 func (EqualOverride) isNode() {}
 
+// isDefinition is just a inteface guard to restrict what can be used as a Definition.
+func (EqualOverride) isDefinition() {}
+
 type FieldDef struct {
 	Name string
 
 	Type Type
+
+	Visibility Visibility
+
+	// Where the node came from, if it was parsed.
+	Position Position
 }
 
 func (FieldDef) isNode() {}
@@ -147,6 +253,17 @@ func (FieldDef) isNode() {}
 // isDefinition is just a inteface guard to restrict what can be used as a Definition.
 func (FieldDef) isDefinition() {}
 
+type Float64 struct {
+
+	// Where the node came from, if it was parsed.
+	Position Position
+}
+
+func (Float64) isNode() {}
+
+// isType is just a inteface guard to restrict what can be used as a Type.
+func (Float64) isType() {}
+
 type For struct {
 	AfterEach Optional[Statement]
 
@@ -155,6 +272,9 @@ type For struct {
 	Condition Value
 
 	Initialization Optional[Statement]
+
+	// Where the node came from, if it was parsed.
+	Position Position
 }
 
 func (For) isNode() {}
@@ -168,6 +288,9 @@ type ForEach struct {
 	ItemName string
 
 	Iterable Value
+
+	// Where the node came from, if it was parsed.
+	Position Position
 }
 
 func (ForEach) isNode() {}
@@ -186,6 +309,11 @@ type FunctionDef struct {
 	Name string
 
 	ReturnType Type
+
+	Visibility Visibility
+
+	// Where the node came from, if it was parsed.
+	Position Position
 }
 
 func (FunctionDef) isNode() {}
@@ -193,8 +321,25 @@ func (FunctionDef) isNode() {}
 // isCallable is just a inteface guard to restrict what can be used as a Callable.
 func (FunctionDef) isCallable() {}
 
+type FunctionRef struct {
+	Module string
+
+	Name string
+
+	// Where the node came from, if it was parsed.
+	Position Position
+}
+
+func (FunctionRef) isNode() {}
+
+// isCallable is just a inteface guard to restrict what can be used as a Callable.
+func (FunctionRef) isCallable() {}
+
 type HashOverride struct {
 	Block Block
+
+	// Where the node came from, if it was parsed.
+	Position Position
 }
 
 func (HashOverride) isNode() {}
@@ -203,11 +348,37 @@ type If struct {
 	Block Block
 
 	Condition Value
+
+	// Where the node came from, if it was parsed.
+	Position Position
 }
 
 func (If) isNode() {}
 
+type Import struct {
+	Module string
+
+	// Where the node came from, if it was parsed.
+	Position Position
+}
+
+func (Import) isNode() {}
+
+type Int32 struct {
+
+	// Where the node came from, if it was parsed.
+	Position Position
+}
+
+func (Int32) isNode() {}
+
+// isType is just a inteface guard to restrict what can be used as a Type.
+func (Int32) isType() {}
+
 type Int64 struct {
+
+	// Where the node came from, if it was parsed.
+	Position Position
 }
 
 func (Int64) isNode() {}
@@ -219,12 +390,18 @@ type KeyValue struct {
 	Key Value
 
 	Value Value
+
+	// Where the node came from, if it was parsed.
+	Position Position
 }
 
 func (KeyValue) isNode() {}
 
 type Length struct {
 	Of Value
+
+	// Where the node came from, if it was parsed.
+	Position Position
 }
 
 func (Length) isNode() {}
@@ -234,6 +411,9 @@ func (Length) isValue() {}
 
 type List struct {
 	Item Type
+
+	// Where the node came from, if it was parsed.
+	Position Position
 }
 
 func (List) isNode() {}
@@ -243,6 +423,9 @@ func (List) isType() {}
 
 type LiteralBool struct {
 	Value bool
+
+	// Where the node came from, if it was parsed.
+	Position Position
 }
 
 func (LiteralBool) isNode() {}
@@ -253,8 +436,56 @@ func (LiteralBool) isConstantValue() {}
 // isValue is just a inteface guard to restrict what can be used as a Value.
 func (LiteralBool) isValue() {}
 
+type LiteralBytes struct {
+	Value []uint8
+
+	// Where the node came from, if it was parsed.
+	Position Position
+}
+
+func (LiteralBytes) isNode() {}
+
+// isConstantValue is just a inteface guard to restrict what can be used as a ConstantValue.
+func (LiteralBytes) isConstantValue() {}
+
+// isValue is just a inteface guard to restrict what can be used as a Value.
+func (LiteralBytes) isValue() {}
+
+type LiteralFloat64 struct {
+	Value float64
+
+	// Where the node came from, if it was parsed.
+	Position Position
+}
+
+func (LiteralFloat64) isNode() {}
+
+// isConstantValue is just a inteface guard to restrict what can be used as a ConstantValue.
+func (LiteralFloat64) isConstantValue() {}
+
+// isValue is just a inteface guard to restrict what can be used as a Value.
+func (LiteralFloat64) isValue() {}
+
+type LiteralInt32 struct {
+	Value int32
+
+	// Where the node came from, if it was parsed.
+	Position Position
+}
+
+func (LiteralInt32) isNode() {}
+
+// isConstantValue is just a inteface guard to restrict what can be used as a ConstantValue.
+func (LiteralInt32) isConstantValue() {}
+
+// isValue is just a inteface guard to restrict what can be used as a Value.
+func (LiteralInt32) isValue() {}
+
 type LiteralInt64 struct {
 	Value int64
+
+	// Where the node came from, if it was parsed.
+	Position Position
 }
 
 func (LiteralInt64) isNode() {}
@@ -267,6 +498,9 @@ func (LiteralInt64) isValue() {}
 
 type LiteralList struct {
 	Values []Value
+
+	// Where the node came from, if it was parsed.
+	Position Position
 }
 
 func (LiteralList) isNode() {}
@@ -279,6 +513,9 @@ func (LiteralList) isValue() {}
 
 type LiteralMap struct {
 	Values []KeyValue
+
+	// Where the node came from, if it was parsed.
+	Position Position
 }
 
 func (LiteralMap) isNode() {}
@@ -291,6 +528,9 @@ func (LiteralMap) isValue() {}
 
 type LiteralRune struct {
 	Value rune
+
+	// Where the node came from, if it was parsed.
+	Position Position
 }
 
 func (LiteralRune) isNode() {}
@@ -303,6 +543,9 @@ func (LiteralRune) isValue() {}
 
 type LiteralSet struct {
 	Values []Value
+
+	// Where the node came from, if it was parsed.
+	Position Position
 }
 
 func (LiteralSet) isNode() {}
@@ -315,6 +558,9 @@ func (LiteralSet) isValue() {}
 
 type LiteralString struct {
 	Value string
+
+	// Where the node came from, if it was parsed.
+	Position Position
 }
 
 func (LiteralString) isNode() {}
@@ -325,10 +571,28 @@ func (LiteralString) isConstantValue() {}
 // isValue is just a inteface guard to restrict what can be used as a Value.
 func (LiteralString) isValue() {}
 
+type LiteralUint8 struct {
+	Value uint8
+
+	// Where the node came from, if it was parsed.
+	Position Position
+}
+
+func (LiteralUint8) isNode() {}
+
+// isConstantValue is just a inteface guard to restrict what can be used as a ConstantValue.
+func (LiteralUint8) isConstantValue() {}
+
+// isValue is just a inteface guard to restrict what can be used as a Value.
+func (LiteralUint8) isValue() {}
+
 type Lookup struct {
 	From Value
 
 	Key Value
+
+	// Where the node came from, if it was parsed.
+	Position Position
 }
 
 func (Lookup) isNode() {}
@@ -340,6 +604,9 @@ type Map struct {
 	Key Type
 
 	Value Type
+
+	// Where the node came from, if it was parsed.
+	Position Position
 }
 
 func (Map) isNode() {}
@@ -347,8 +614,32 @@ func (Map) isNode() {}
 // isType is just a inteface guard to restrict what can be used as a Type.
 func (Map) isType() {}
 
+type MethodCall struct {
+	Arguments []Value
+
+	Name string
+
+	Receiver Value
+
+	// Where the node came from, if it was parsed.
+	Position Position
+}
+
+func (MethodCall) isNode() {}
+
+// isStatement is just a inteface guard to restrict what can be used as a Statement.
+func (MethodCall) isStatement() {}
+
+// isValue is just a inteface guard to restrict what can be used as a Value.
+func (MethodCall) isValue() {}
+
 type Model struct {
+	Module string
+
 	Name string
+
+	// Where the node came from, if it was parsed.
+	Position Position
 }
 
 func (Model) isNode() {}
@@ -357,15 +648,20 @@ func (Model) isNode() {}
 func (Model) isType() {}
 
 type ModelDef struct {
-	EqualOverride EqualOverride
+	EqualOverride Optional[EqualOverride]
 
 	Fields []FieldDef
 
-	HashOverride HashOverride
+	HashOverride Optional[HashOverride]
 
 	Methods []FunctionDef
 
 	Name string
+
+	Visibility Visibility
+
+	// Where the node came from, if it was parsed.
+	Position Position
 }
 
 func (ModelDef) isNode() {}
@@ -375,15 +671,23 @@ type Module struct {
 
 	Functions []FunctionDef
 
+	Imports []Import
+
 	Models []ModelDef
 
 	Name string
+
+	// Where the node came from, if it was parsed.
+	Position Position
 }
 
 func (Module) isNode() {}
 
 type New struct {
 	Model Model
+
+	// Where the node came from, if it was parsed.
+	Position Position
 }
 
 func (New) isNode() {}
@@ -393,6 +697,9 @@ func (New) isValue() {}
 
 type Nil struct {
 	Type Type
+
+	// Where the node came from, if it was parsed.
+	Position Position
 }
 
 func (Nil) isNode() {}
@@ -405,6 +712,9 @@ func (Nil) isValue() {}
 
 type Pop struct {
 	List Value
+
+	// Where the node came from, if it was parsed.
+	Position Position
 }
 
 func (Pop) isNode() {}
@@ -419,6 +729,9 @@ type Property struct {
 	Name string
 
 	Of Value
+
+	// Where the node came from, if it was parsed.
+	Position Position
 }
 
 func (Property) isNode() {}
@@ -433,6 +746,9 @@ type Push struct {
 	List Value
 
 	Value Value
+
+	// Where the node came from, if it was parsed.
+	Position Position
 }
 
 func (Push) isNode() {}
@@ -442,6 +758,9 @@ func (Push) isStatement() {}
 
 type Return struct {
 	Value Value
+
+	// Where the node came from, if it was parsed.
+	Position Position
 }
 
 func (Return) isNode() {}
@@ -451,11 +770,17 @@ func (Return) isStatement() {}
 
 type Root struct {
 	Modules []Module
+
+	// Where the node came from, if it was parsed.
+	Position Position
 }
 
 func (Root) isNode() {}
 
 type Rune struct {
+
+	// Where the node came from, if it was parsed.
+	Position Position
 }
 
 func (Rune) isNode() {}
@@ -464,6 +789,9 @@ func (Rune) isNode() {}
 func (Rune) isType() {}
 
 type Self struct {
+
+	// Where the node came from, if it was parsed.
+	Position Position
 }
 
 func (Self) isNode() {}
@@ -473,6 +801,9 @@ func (Self) isValue() {}
 
 type Set struct {
 	Item Type
+
+	// Where the node came from, if it was parsed.
+	Position Position
 }
 
 func (Set) isNode() {}
@@ -484,6 +815,9 @@ type SetContains struct {
 	Set Value
 
 	Value Value
+
+	// Where the node came from, if it was parsed.
+	Position Position
 }
 
 func (SetContains) isNode() {}
@@ -492,6 +826,9 @@ func (SetContains) isNode() {}
 func (SetContains) isValue() {}
 
 type String struct {
+
+	// Where the node came from, if it was parsed.
+	Position Position
 }
 
 func (String) isNode() {}
@@ -499,8 +836,38 @@ func (String) isNode() {}
 // isType is just a inteface guard to restrict what can be used as a Type.
 func (String) isType() {}
 
+type Uint8 struct {
+
+	// Where the node came from, if it was parsed.
+	Position Position
+}
+
+func (Uint8) isNode() {}
+
+// isType is just a inteface guard to restrict what can be used as a Type.
+func (Uint8) isType() {}
+
+type Unary struct {
+	Operator UnaryOperator
+
+	Value Value
+
+	// Where the node came from, if it was parsed.
+	Position Position
+}
+
+func (Unary) isNode() {}
+
+// isValue is just a inteface guard to restrict what can be used as a Value.
+func (Unary) isValue() {}
+
 type Variable struct {
+	Module string
+
 	Name string
+
+	// Where the node came from, if it was parsed.
+	Position Position
 }
 
 func (Variable) isNode() {}
@@ -512,6 +879,9 @@ func (Variable) isAssignable() {}
 func (Variable) isValue() {}
 
 type Void struct {
+
+	// Where the node came from, if it was parsed.
+	Position Position
 }
 
 func (Void) isNode() {}
@@ -0,0 +1,604 @@
+package ast
+
+import (
+	"encoding"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+)
+
+// The binary encoding of a Root starts with binaryMagic and the version of the format as a varint. Then comes the
+// string table: the number of strings, followed by each string as its length and its bytes. Every string in the rest
+// of the encoding, including the names of properties and nodes, is the varint index of a string in the table.
+//
+// A node is encoded as a sequence of fields, and the Root is the rest of the data after the string table. Each field
+// starts with a varint key that holds the name of the property shifted left by two, combined with the wire type of the
+// value that follows:
+//
+//   - wireVarint is a varint. Signed integers are zigzag encoded, and enums are the names of their values.
+//   - wireFixed64 is eight little-endian bytes, which is how floats are encoded.
+//   - wireBytes is a varint length followed by that many bytes. It's used for bytes, nodes, and lists.
+//
+// A node whose type is a node type, like Value, starts with the name of the node before its fields. A list is the
+// number of items followed by the value of each item without a key. Properties that have their zero value, lists that
+// are nil, and optional properties that aren't set are left out. Properties whose type is a node type, a node, or an
+// enum are required unless they're optional, so they can't be left out.
+//
+// Because every field has a wire type, a decoder skips the fields that it doesn't know of. So adding a property that
+// isn't required doesn't change the version, but any change that older encodings or decoders can't handle does.
+
+const (
+	binaryMagic = "AGNOSTIC"
+	// BinaryVersion is the version of the binary encoding that MarshalBinary writes and the latest that UnmarshalBinary
+	// reads.
+	BinaryVersion = 1
+)
+
+type wireType int
+
+const (
+	wireVarint wireType = iota
+	wireFixed64
+	wireBytes
+)
+
+func (r Root) MarshalBinary() ([]byte, error) {
+	e := newBinaryEncoder()
+	r.encodeBinary(e)
+	if e.err != nil {
+		return nil, e.err
+	}
+
+	return e.bytes(), nil
+}
+
+func (r *Root) UnmarshalBinary(data []byte) error {
+	if len(data) < len(binaryMagic) || string(data[:len(binaryMagic)]) != binaryMagic {
+		return errors.New("data isn't a binary encoded AST")
+	}
+
+	reader := binaryReader{data: data[len(binaryMagic):]}
+	version, err := reader.uvarint()
+	if err != nil {
+		return err
+	}
+
+	if version == 0 || version > BinaryVersion {
+		return fmt.Errorf("unsupported version %d of the binary encoding", version)
+	}
+
+	count, err := reader.uvarint()
+	if err != nil {
+		return err
+	}
+
+	d := &binaryDecoder{strings: make([]string, 0, min(count, uint64(len(reader.data))))}
+	for range count {
+		length, err := reader.uvarint()
+		if err != nil {
+			return err
+		}
+
+		s, err := reader.next(length)
+		if err != nil {
+			return err
+		}
+
+		d.strings = append(d.strings, string(s))
+	}
+
+	*r = Root{}
+	return r.decodeBinary(d, reader.data)
+}
+
+// binaryEncoder writes the fields of nodes and collects the string table.
+type binaryEncoder struct {
+	strings map[string]uint64
+	table   []string
+	data    []byte
+	// The first error from encoding, like a required property that isn't set.
+	err error
+}
+
+func newBinaryEncoder() *binaryEncoder {
+	return &binaryEncoder{strings: map[string]uint64{}}
+}
+
+// bytes returns the full encoding, with the fields that were written as the fields of the Root.
+func (e *binaryEncoder) bytes() []byte {
+	result := append([]byte(binaryMagic), binary.AppendUvarint(nil, BinaryVersion)...)
+	result = binary.AppendUvarint(result, uint64(len(e.table)))
+	for _, s := range e.table {
+		result = binary.AppendUvarint(result, uint64(len(s)))
+		result = append(result, s...)
+	}
+
+	return append(result, e.data...)
+}
+
+func (e *binaryEncoder) uvarint(value uint64) {
+	e.data = binary.AppendUvarint(e.data, value)
+}
+
+// intern returns the index of the string in the table, adding it if it isn't there yet.
+func (e *binaryEncoder) intern(s string) uint64 {
+	index, ok := e.strings[s]
+	if !ok {
+		index = uint64(len(e.table))
+		e.strings[s] = index
+		e.table = append(e.table, s)
+	}
+
+	return index
+}
+
+func (e *binaryEncoder) string(s string) {
+	e.uvarint(e.intern(s))
+}
+
+func (e *binaryEncoder) key(name string, wire wireType) {
+	e.uvarint(e.intern(name)<<2 | uint64(wire))
+}
+
+// begin starts a value with the wireBytes type, which is finished by passing the result to end.
+func (e *binaryEncoder) begin() int {
+	return len(e.data)
+}
+
+// end prefixes the bytes written since the matching call to begin with their length.
+func (e *binaryEncoder) end(start int) {
+	var prefix [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(prefix[:], uint64(len(e.data)-start))
+
+	e.data = append(e.data, prefix[:n]...)
+	copy(e.data[start+n:], e.data[start:len(e.data)-n])
+	copy(e.data[start:], prefix[:n])
+}
+
+// binaryReader reads the values of an encoding in order.
+type binaryReader struct {
+	data []byte
+}
+
+var errUnexpectedEnd = errors.New("unexpected end of the binary encoding")
+
+func (r *binaryReader) uvarint() (uint64, error) {
+	value, n := binary.Uvarint(r.data)
+	if n <= 0 {
+		return 0, errUnexpectedEnd
+	}
+
+	r.data = r.data[n:]
+	return value, nil
+}
+
+func (r *binaryReader) next(length uint64) ([]byte, error) {
+	if length > uint64(len(r.data)) {
+		return nil, errUnexpectedEnd
+	}
+
+	result := r.data[:length]
+	r.data = r.data[length:]
+	return result, nil
+}
+
+// value reads a value with the wire type.
+func (r *binaryReader) value(wire wireType) (binaryField, error) {
+	switch wire {
+	case wireVarint:
+		value, err := r.uvarint()
+		return binaryField{wire: wire, number: value}, err
+	case wireFixed64:
+		data, err := r.next(8)
+		if err != nil {
+			return binaryField{}, err
+		}
+
+		return binaryField{wire: wire, number: binary.LittleEndian.Uint64(data)}, nil
+	case wireBytes:
+		length, err := r.uvarint()
+		if err != nil {
+			return binaryField{}, err
+		}
+
+		data, err := r.next(length)
+		return binaryField{wire: wire, data: data}, err
+	default:
+		return binaryField{}, fmt.Errorf("unknown wire type %d", wire)
+	}
+}
+
+// binaryField is the value of a field. Varints and fixed64s are held in number, and wireBytes in data.
+type binaryField struct {
+	wire   wireType
+	number uint64
+	data   []byte
+}
+
+// binaryDecoder decodes nodes using the string table.
+type binaryDecoder struct {
+	strings []string
+}
+
+func (d *binaryDecoder) string(index uint64) (string, error) {
+	if index >= uint64(len(d.strings)) {
+		return "", fmt.Errorf("string %d isn't in the table of %d strings", index, len(d.strings))
+	}
+
+	return d.strings[index], nil
+}
+
+// fields reads each field of the data and calls decode with it. Fields that decode doesn't know of are skipped by
+// returning nil. It returns an error if any of the required fields are missing.
+func (d *binaryDecoder) fields(data []byte, required []string, decode func(name string, f binaryField) error) error {
+	seen := make(map[string]bool, len(required))
+	reader := binaryReader{data: data}
+	for len(reader.data) > 0 {
+		key, err := reader.uvarint()
+		if err != nil {
+			return err
+		}
+
+		name, err := d.string(key >> 2)
+		if err != nil {
+			return err
+		}
+
+		f, err := reader.value(wireType(key & 3))
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+
+		if err := decode(name, f); err != nil {
+			return err
+		}
+
+		seen[name] = true
+	}
+
+	for _, name := range required {
+		if !seen[name] {
+			return fmt.Errorf("missing required field %q", name)
+		}
+	}
+
+	return nil
+}
+
+// binaryNode is a node that's encoded as its fields.
+type binaryNode interface {
+	encodeBinary(e *binaryEncoder)
+}
+
+// codec encodes and decodes values of a type. The codecs have no state, so their zero values are used.
+type codec[T any] interface {
+	wire() wireType
+	// isZero returns whether the value is left out when it's a field.
+	isZero(value T) bool
+	encode(e *binaryEncoder, value T)
+	decode(d *binaryDecoder, f binaryField) (T, error)
+}
+
+func encodeField[T any, C codec[T]](e *binaryEncoder, name string, c C, value T) {
+	if c.isZero(value) {
+		return
+	}
+
+	e.key(name, c.wire())
+	c.encode(e, value)
+}
+
+// encodeRequiredField encodes a field that can't be left out, which fails the encoding if the value is zero.
+func encodeRequiredField[T any, C codec[T]](e *binaryEncoder, node string, name string, c C, value T) {
+	if c.isZero(value) {
+		if e.err == nil {
+			e.err = fmt.Errorf("%s: missing required field %q", node, name)
+		}
+		return
+	}
+
+	encodeField(e, name, c, value)
+}
+
+func decodeField[T any, C codec[T]](d *binaryDecoder, name string, f binaryField, c C, value *T) error {
+	if f.wire != c.wire() {
+		return fmt.Errorf("%s: wire type %d doesn't match the expected %d", name, f.wire, c.wire())
+	}
+
+	decoded, err := c.decode(d, f)
+	if err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+
+	*value = decoded
+	return nil
+}
+
+type boolCodec struct{}
+
+func (boolCodec) wire() wireType         { return wireVarint }
+func (boolCodec) isZero(value bool) bool { return !value }
+func (boolCodec) encode(e *binaryEncoder, v bool) {
+	if v {
+		e.uvarint(1)
+	} else {
+		e.uvarint(0)
+	}
+}
+
+func (boolCodec) decode(_ *binaryDecoder, f binaryField) (bool, error) {
+	return f.number != 0, nil
+}
+
+type stringCodec struct{}
+
+func (stringCodec) wire() wireType                    { return wireVarint }
+func (stringCodec) isZero(value string) bool          { return value == "" }
+func (stringCodec) encode(e *binaryEncoder, v string) { e.string(v) }
+
+func (stringCodec) decode(d *binaryDecoder, f binaryField) (string, error) {
+	return d.string(f.number)
+}
+
+// signedCodec zigzag encodes signed integers so that small negative numbers stay short.
+type signedCodec[T int32 | int64] struct{}
+
+func (signedCodec[T]) wire() wireType      { return wireVarint }
+func (signedCodec[T]) isZero(value T) bool { return value == 0 }
+func (signedCodec[T]) encode(e *binaryEncoder, v T) {
+	e.uvarint(uint64(int64(v)<<1) ^ uint64(int64(v)>>63))
+}
+
+func (signedCodec[T]) decode(_ *binaryDecoder, f binaryField) (T, error) {
+	value := int64(f.number>>1) ^ -int64(f.number&1)
+	if int64(T(value)) != value {
+		return 0, fmt.Errorf("%d is out of range for %s", value, reflect.TypeFor[T]())
+	}
+
+	return T(value), nil
+}
+
+type uint8Codec struct{}
+
+func (uint8Codec) wire() wireType                   { return wireVarint }
+func (uint8Codec) isZero(value uint8) bool          { return value == 0 }
+func (uint8Codec) encode(e *binaryEncoder, v uint8) { e.uvarint(uint64(v)) }
+
+func (uint8Codec) decode(_ *binaryDecoder, f binaryField) (uint8, error) {
+	if f.number > math.MaxUint8 {
+		return 0, fmt.Errorf("%d is out of range for uint8", f.number)
+	}
+
+	return uint8(f.number), nil
+}
+
+type float64Codec struct{}
+
+func (float64Codec) wire() wireType { return wireFixed64 }
+
+// isZero doesn't leave out negative zero, so that it keeps its sign.
+func (float64Codec) isZero(value float64) bool { return math.Float64bits(value) == 0 }
+
+func (float64Codec) encode(e *binaryEncoder, v float64) {
+	e.data = binary.LittleEndian.AppendUint64(e.data, math.Float64bits(v))
+}
+
+func (float64Codec) decode(_ *binaryDecoder, f binaryField) (float64, error) {
+	return math.Float64frombits(f.number), nil
+}
+
+type bytesCodec struct{}
+
+func (bytesCodec) wire() wireType            { return wireBytes }
+func (bytesCodec) isZero(value []uint8) bool { return value == nil }
+func (bytesCodec) encode(e *binaryEncoder, v []uint8) {
+	e.uvarint(uint64(len(v)))
+	e.data = append(e.data, v...)
+}
+
+func (bytesCodec) decode(_ *binaryDecoder, f binaryField) ([]uint8, error) {
+	return append([]uint8{}, f.data...), nil
+}
+
+// enumCodec encodes an enum as the name of its value, so that values can be added to an enum in any order.
+type enumCodec[E interface {
+	comparable
+	encoding.TextMarshaler
+}, P interface {
+	*E
+	encoding.TextUnmarshaler
+}] struct{}
+
+func (enumCodec[E, P]) wire() wireType { return wireVarint }
+
+// isZero never leaves out an enum because its first value might change.
+func (enumCodec[E, P]) isZero(E) bool { return false }
+
+func (enumCodec[E, P]) encode(e *binaryEncoder, v E) {
+	// An invalid enum value is encoded as an empty name, which fails to decode.
+	text, _ := v.MarshalText()
+	e.string(string(text))
+}
+
+func (enumCodec[E, P]) decode(d *binaryDecoder, f binaryField) (E, error) {
+	var value E
+	text, err := d.string(f.number)
+	if err != nil {
+		return value, err
+	}
+
+	err = P(&value).UnmarshalText([]byte(text))
+	return value, err
+}
+
+// nodeCodec encodes a node whose type is known, so it's encoded as just its fields.
+type nodeCodec[N binaryNode, P interface {
+	*N
+	decodeBinary(d *binaryDecoder, data []byte) error
+}] struct{}
+
+func (nodeCodec[N, P]) wire() wireType { return wireBytes }
+func (nodeCodec[N, P]) isZero(N) bool  { return false }
+
+func (nodeCodec[N, P]) encode(e *binaryEncoder, v N) {
+	start := e.begin()
+	v.encodeBinary(e)
+	e.end(start)
+}
+
+func (nodeCodec[N, P]) decode(d *binaryDecoder, f binaryField) (N, error) {
+	var node N
+	err := P(&node).decodeBinary(d, f.data)
+	return node, err
+}
+
+// kindCodec encodes a node type, so the node is encoded along with its kind.
+type kindCodec[T Node] struct{}
+
+func (kindCodec[T]) wire() wireType      { return wireBytes }
+func (kindCodec[T]) isZero(value T) bool { return any(value) == nil }
+func (kindCodec[T]) encode(e *binaryEncoder, v T) {
+	start := e.begin()
+	e.string(kindOf(v))
+	any(v).(binaryNode).encodeBinary(e)
+	e.end(start)
+}
+
+func (kindCodec[T]) decode(d *binaryDecoder, f binaryField) (T, error) {
+	var zero T
+	reader := binaryReader{data: f.data}
+	index, err := reader.uvarint()
+	if err != nil {
+		return zero, err
+	}
+
+	kind, err := d.string(index)
+	if err != nil {
+		return zero, err
+	}
+
+	node, err := decodeKindBinary(d, kind, reader.data)
+	if err != nil {
+		return zero, err
+	}
+
+	typed, ok := node.(T)
+	if !ok {
+		return zero, fmt.Errorf("%s isn't a %s", kind, reflect.TypeFor[T]().Name())
+	}
+
+	return typed, nil
+}
+
+type listCodec[T any, C codec[T]] struct{}
+
+func (listCodec[T, C]) wire() wireType        { return wireBytes }
+func (listCodec[T, C]) isZero(value []T) bool { return value == nil }
+
+func (listCodec[T, C]) encode(e *binaryEncoder, v []T) {
+	var item C
+	start := e.begin()
+	e.uvarint(uint64(len(v)))
+	for _, value := range v {
+		item.encode(e, value)
+	}
+	e.end(start)
+}
+
+func (listCodec[T, C]) decode(d *binaryDecoder, f binaryField) ([]T, error) {
+	var item C
+	reader := binaryReader{data: f.data}
+	count, err := reader.uvarint()
+	if err != nil {
+		return nil, err
+	}
+
+	// Every item takes at least one byte, which limits how much a corrupt count can allocate.
+	result := make([]T, 0, min(count, uint64(len(reader.data))))
+	for range count {
+		itemField, err := reader.value(item.wire())
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := item.decode(d, itemField)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, value)
+	}
+
+	return result, nil
+}
+
+type optionalCodec[T any, C codec[T]] struct{}
+
+func (optionalCodec[T, C]) wire() wireType {
+	var value C
+	return value.wire()
+}
+
+func (optionalCodec[T, C]) isZero(value Optional[T]) bool { return !value.IsSet() }
+
+func (optionalCodec[T, C]) encode(e *binaryEncoder, v Optional[T]) {
+	var value C
+	value.encode(e, v.Value())
+}
+
+func (optionalCodec[T, C]) decode(d *binaryDecoder, f binaryField) (Optional[T], error) {
+	var value C
+	decoded, err := value.decode(d, f)
+	if err != nil {
+		return Optional[T]{}, err
+	}
+
+	return OptionalWithValue(decoded), nil
+}
+
+type positionCodec struct{}
+
+func (positionCodec) wire() wireType             { return wireBytes }
+func (positionCodec) isZero(value Position) bool { return value == Position{} }
+func (positionCodec) encode(e *binaryEncoder, v Position) {
+	start := e.begin()
+	encodeField(e, "filename", stringCodec{}, v.Filename)
+	encodeField(e, "line", signedCodec[int64]{}, int64(v.Line))
+	encodeField(e, "column", signedCodec[int64]{}, int64(v.Column))
+	encodeField(e, "offset", signedCodec[int64]{}, int64(v.Offset))
+	encodeField(e, "endOffset", signedCodec[int64]{}, int64(v.EndOffset))
+	e.end(start)
+}
+
+func (positionCodec) decode(d *binaryDecoder, f binaryField) (Position, error) {
+	var position Position
+	err := d.fields(f.data, nil, func(name string, f binaryField) error {
+		var target *int
+		switch name {
+		case "filename":
+			return decodeField(d, name, f, stringCodec{}, &position.Filename)
+		case "line":
+			target = &position.Line
+		case "column":
+			target = &position.Column
+		case "offset":
+			target = &position.Offset
+		case "endOffset":
+			target = &position.EndOffset
+		default:
+			return nil
+		}
+
+		var value int64
+		if err := decodeField(d, name, f, signedCodec[int64]{}, &value); err != nil {
+			return err
+		}
+
+		*target = int(value)
+		return nil
+	})
+
+	return position, err
+}
@@ -0,0 +1,226 @@
+package ast
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBinary(t *testing.T) {
+	data, err := exampleRoot.MarshalBinary()
+	require.NoError(t, err)
+
+	var decoded Root
+	require.NoError(t, decoded.UnmarshalBinary(data))
+	assert.Equal(t, exampleRoot, decoded)
+}
+
+func TestBinary_UnknownFields(t *testing.T) {
+	// The module is written by hand so that it can have fields that don't exist.
+	root := newBinaryEncoder()
+	root.key("modules", wireBytes)
+	list := root.begin()
+	root.uvarint(1)
+	module := root.begin()
+	root.key("future", wireVarint)
+	root.uvarint(1000)
+	encodeField(root, "name", stringCodec{}, "example")
+	root.key("futureNode", wireBytes)
+	node := root.begin()
+	root.key("value", wireFixed64)
+	root.data = binary.LittleEndian.AppendUint64(root.data, 0)
+	root.end(node)
+	root.end(module)
+	root.end(list)
+
+	var decoded Root
+	require.NoError(t, decoded.UnmarshalBinary(root.bytes()))
+	assert.Equal(t, Root{Modules: []Module{{Name: "example"}}}, decoded)
+}
+
+func TestBinary_Errors(t *testing.T) {
+	valid, err := exampleRoot.MarshalBinary()
+	require.NoError(t, err)
+
+	wrongWire := newBinaryEncoder()
+	wrongWire.key("modules", wireVarint)
+	wrongWire.uvarint(0)
+
+	// A function that has none of its fields.
+	missingField := newBinaryEncoder()
+	missingField.key("modules", wireBytes)
+	modules := missingField.begin()
+	missingField.uvarint(1)
+	module := missingField.begin()
+	missingField.key("functions", wireBytes)
+	functions := missingField.begin()
+	missingField.uvarint(1)
+	function := missingField.begin()
+	missingField.end(function)
+	missingField.end(functions)
+	missingField.end(module)
+	missingField.end(modules)
+
+	tests := []struct {
+		name     string
+		data     []byte
+		expected string
+	}{
+		{
+			name:     "Not binary",
+			data:     []byte(`{"modules": []}`),
+			expected: "data isn't a binary encoded AST",
+		},
+		{
+			name:     "Newer version",
+			data:     binary.AppendUvarint([]byte(binaryMagic), BinaryVersion+1),
+			expected: "unsupported version 2 of the binary encoding",
+		},
+		{
+			name:     "Truncated",
+			data:     valid[:len(valid)-1],
+			expected: "modules: unexpected end of the binary encoding",
+		},
+		{
+			name:     "Missing string",
+			data:     append(binary.AppendUvarint(binary.AppendUvarint([]byte(binaryMagic), BinaryVersion), 0), 4),
+			expected: "string 1 isn't in the table of 0 strings",
+		},
+		{
+			name:     "Wrong wire type",
+			data:     wrongWire.bytes(),
+			expected: "modules: wire type 0 doesn't match the expected 2",
+		},
+		{
+			name:     "Missing required field",
+			data:     missingField.bytes(),
+			expected: `modules: functions: missing required field "block"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var decoded Root
+			assert.EqualError(t, decoded.UnmarshalBinary(test.data), test.expected)
+		})
+	}
+
+	t.Run("Every prefix", func(t *testing.T) {
+		// Prefixes can be valid encodings of part of the AST, but decoding them must never panic.
+		for i := range valid {
+			var decoded Root
+			_ = decoded.UnmarshalBinary(valid[:i])
+		}
+	})
+}
+
+func TestBinary_MarshalMissingRequiredField(t *testing.T) {
+	root := Root{
+		Modules: []Module{
+			{
+				Name: "example",
+				Functions: []FunctionDef{
+					{
+						Name:       "run",
+						ReturnType: Int64{},
+						Block:      Block{Statements: []Statement{Return{}}},
+					},
+				},
+			},
+		},
+	}
+
+	_, err := root.MarshalBinary()
+	assert.EqualError(t, err, `Return: missing required field "value"`)
+}
+
+// benchmarkRoot returns a large AST, like the ones generated from schemas.
+func benchmarkRoot() Root {
+	var root Root
+	for m := range 10 {
+		module := Module{Name: fmt.Sprintf("module%d", m)}
+		for f := range 100 {
+			position := Position{Filename: module.Name + ".as", Line: f + 1, Column: 2, Offset: f * 40, EndOffset: f*40 + 30}
+			var statements []Statement
+			for s := range 20 {
+				statements = append(statements, Declare{
+					Name: fmt.Sprintf("v%d", s),
+					Value: Binary{
+						Left:     Call{Function: FunctionRef{Name: "helper"}, Arguments: []Value{Variable{Name: "argument"}, LiteralString{Value: "text"}}},
+						Operator: BinaryOperatorAdd,
+						Right:    LiteralInt64{Value: int64(s * 1000)},
+						Position: position,
+					},
+					Position: position,
+				})
+			}
+			statements = append(statements, Return{Value: Variable{Name: "v0"}})
+
+			module.Functions = append(module.Functions, FunctionDef{
+				Name:       fmt.Sprintf("function%d", f),
+				Arguments:  []ArgumentDef{{Name: "argument", Type: Int64{}}},
+				ReturnType: Int64{},
+				Block:      Block{Statements: statements},
+				Position:   position,
+			})
+		}
+
+		root.Modules = append(root.Modules, module)
+	}
+
+	return root
+}
+
+func BenchmarkMarshal(b *testing.B) {
+	root := benchmarkRoot()
+
+	b.Run("Binary", func(b *testing.B) {
+		for range b.N {
+			if _, err := root.MarshalBinary(); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("JSON", func(b *testing.B) {
+		for range b.N {
+			if _, err := json.Marshal(root); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkUnmarshal(b *testing.B) {
+	root := benchmarkRoot()
+
+	binaryData, err := root.MarshalBinary()
+	require.NoError(b, err)
+
+	jsonData, err := json.Marshal(root)
+	require.NoError(b, err)
+
+	b.Run("Binary", func(b *testing.B) {
+		b.ReportMetric(float64(len(binaryData)), "encoded-bytes")
+		for range b.N {
+			var decoded Root
+			if err := decoded.UnmarshalBinary(binaryData); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("JSON", func(b *testing.B) {
+		b.ReportMetric(float64(len(jsonData)), "encoded-bytes")
+		for range b.N {
+			var decoded Root
+			if err := json.Unmarshal(jsonData, &decoded); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -0,0 +1,172 @@
+package ast
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// Nodes are encoded as JSON objects whose keys are the names of their properties in the spec. A property whose type is
+// a node type, like Value, holds an object with an extra "kind" key that names the node it holds. Optional properties
+// that aren't set and positions that are the zero Position are left out, while every other property besides the lists
+// must be present and not null. The enums are encoded as the names of their
+// values. See ast.schema.json for the full format.
+
+// kinded wraps a node type so that the node it holds is encoded along with its kind.
+type kinded[T Node] struct {
+	Node T
+}
+
+func (k kinded[T]) MarshalJSON() ([]byte, error) {
+	if any(k.Node) == nil {
+		return []byte("null"), nil
+	}
+
+	data, err := json.Marshal(k.Node)
+	if err != nil {
+		return nil, err
+	}
+
+	kind, err := json.Marshal(kindOf(k.Node))
+	if err != nil {
+		return nil, err
+	}
+
+	result := append([]byte(`{"kind":`), kind...)
+	if len(data) == len("{}") {
+		return append(result, '}'), nil
+	}
+
+	result = append(result, ',')
+	return append(result, data[1:]...), nil
+}
+
+func (k *kinded[T]) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return errors.New("node is null")
+	}
+
+	var header struct {
+		Kind string `json:"kind"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return err
+	}
+
+	if header.Kind == "" {
+		return errors.New("node has no kind")
+	}
+
+	node, err := unmarshalKind(header.Kind, data)
+	if err != nil {
+		return err
+	}
+
+	typed, ok := node.(T)
+	if !ok {
+		return fmt.Errorf("%s isn't a %s", header.Kind, reflect.TypeFor[T]().Name())
+	}
+
+	k.Node = typed
+	return nil
+}
+
+// requireProperties returns an error if any of the properties is missing from the encoded object or is null.
+func requireProperties(data []byte, keys ...string) error {
+	var properties map[string]json.RawMessage
+	if err := json.Unmarshal(data, &properties); err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		if value, ok := properties[key]; !ok || string(value) == "null" {
+			return fmt.Errorf("missing required property %q", key)
+		}
+	}
+
+	return nil
+}
+
+func wrap[T Node](node T) kinded[T] {
+	return kinded[T]{Node: node}
+}
+
+func unwrap[T Node](k kinded[T]) T {
+	return k.Node
+}
+
+func wrapEach[T Node](nodes []T) []kinded[T] {
+	if nodes == nil {
+		return nil
+	}
+
+	result := make([]kinded[T], 0, len(nodes))
+	for _, node := range nodes {
+		result = append(result, wrap(node))
+	}
+
+	return result
+}
+
+func unwrapEach[T Node](wrapped []kinded[T]) []T {
+	if wrapped == nil {
+		return nil
+	}
+
+	result := make([]T, 0, len(wrapped))
+	for _, k := range wrapped {
+		result = append(result, k.Node)
+	}
+
+	return result
+}
+
+func wrapOptional[T Node](optional Optional[T]) *kinded[T] {
+	if !optional.IsSet() {
+		return nil
+	}
+
+	return &kinded[T]{Node: optional.Value()}
+}
+
+func unwrapOptional[T Node](k *kinded[T]) Optional[T] {
+	if k == nil {
+		return Optional[T]{}
+	}
+
+	return OptionalWithValue(k.Node)
+}
+
+func optionalPointer[T any](optional Optional[T]) *T {
+	if !optional.IsSet() {
+		return nil
+	}
+
+	value := optional.Value()
+	return &value
+}
+
+func pointerOptional[T any](pointer *T) Optional[T] {
+	if pointer == nil {
+		return Optional[T]{}
+	}
+
+	return OptionalWithValue(*pointer)
+}
+
+func positionPointer(position Position) *Position {
+	if position == (Position{}) {
+		return nil
+	}
+
+	return &position
+}
+
+func pointerPosition(pointer *Position) Position {
+	if pointer == nil {
+		return Position{}
+	}
+
+	return *pointer
+}
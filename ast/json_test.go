@@ -0,0 +1,140 @@
+package ast
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// exampleRoot has every kind of property: node types, optionals that are and aren't set, lists that are nil and empty,
+// enums, and positions.
+var exampleRoot = Root{
+	Modules: []Module{
+		{
+			Name:    "example",
+			Imports: []Import{},
+			Functions: []FunctionDef{
+				{
+					Name:       "run",
+					Visibility: VisibilityPrivate,
+					Arguments:  []ArgumentDef{{Name: "data", Type: List{Item: Uint8{}}}},
+					ReturnType: Int64{},
+					Block: Block{
+						Statements: []Statement{
+							For{
+								Initialization: OptionalWithValue[Statement](Declare{Name: "i", Value: LiteralInt64{Value: -9223372036854775808}}),
+								Condition:      Comparison{Left: Variable{Name: "i"}, Operator: ComparisonOperatorLessThan, Right: LiteralFloat64{Value: 0.5}},
+								Block:          Block{Statements: []Statement{Break{}}},
+							},
+							Conditional{
+								Ifs:  []If{{Condition: LiteralBool{Value: true}, Block: Block{}}},
+								Else: OptionalWithValue(Block{Statements: []Statement{}}),
+							},
+							Return{
+								Value: Call{
+									Function:  FunctionRef{Name: "count", Module: "other"},
+									Arguments: []Value{LiteralBytes{Value: []uint8{0, 255}}, LiteralRune{Value: '世'}},
+								},
+								Position: Position{Filename: "example.as", Line: 3, Column: 5, Offset: 20, EndOffset: 40},
+							},
+						},
+					},
+				},
+			},
+		},
+	},
+}
+
+func TestJSON(t *testing.T) {
+	data, err := json.Marshal(exampleRoot)
+	require.NoError(t, err)
+
+	var decoded Root
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, exampleRoot, decoded)
+}
+
+func TestJSON_Format(t *testing.T) {
+	data, err := json.Marshal(Declare{
+		Name: "x",
+		Value: Binary{
+			Left:     LiteralInt64{Value: 1},
+			Operator: BinaryOperatorAdd,
+			Right:    Self{Position: Position{Line: 1, Column: 2}},
+		},
+	})
+	require.NoError(t, err)
+
+	expected := `{
+		"name": "x",
+		"value": {
+			"kind": "Binary",
+			"left": {"kind": "LiteralInt64", "value": 1},
+			"operator": "Add",
+			"right": {"kind": "Self", "position": {"line": 1, "column": 2, "offset": 0, "endOffset": 0}}
+		}
+	}`
+	assert.JSONEq(t, expected, string(data))
+
+	data, err = json.Marshal(Conditional{Ifs: []If{}})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"ifs": []}`, string(data))
+}
+
+func TestJSON_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		json     string
+		expected string
+	}{
+		{
+			name:     "Unknown kind",
+			json:     `{"value": {"kind": "Unknown"}}`,
+			expected: `Return: unknown kind "Unknown"`,
+		},
+		{
+			name:     "Missing kind",
+			json:     `{"value": {"value": 1}}`,
+			expected: "Return: node has no kind",
+		},
+		{
+			name:     "Wrong node type",
+			json:     `{"value": {"kind": "Int64"}}`,
+			expected: "Return: Int64 isn't a Value",
+		},
+		{
+			name:     "Unknown enum value",
+			json:     `{"value": {"kind": "Unary", "operator": "Increment", "value": {"kind": "Self"}}}`,
+			expected: `Return: Unary: unknown UnaryOperator "Increment"`,
+		},
+		{
+			name:     "Missing required property",
+			json:     `{}`,
+			expected: `Return: missing required property "value"`,
+		},
+		{
+			name:     "Null required property",
+			json:     `{"value": null}`,
+			expected: `Return: missing required property "value"`,
+		},
+		{
+			name:     "Missing nested required property",
+			json:     `{"value": {"kind": "Unary", "operator": "Not"}}`,
+			expected: `Return: Unary: missing required property "value"`,
+		},
+		{
+			name:     "Null list item",
+			json:     `{"value": {"kind": "Call", "function": {"kind": "FunctionRef", "name": "f", "module": ""}, "arguments": [null]}}`,
+			expected: "Return: Call: node is null",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var decoded Return
+			assert.EqualError(t, json.Unmarshal([]byte(test.json), &decoded), test.expected)
+		})
+	}
+}
@@ -10,20 +10,28 @@ type NodeMapper[T any] interface {
 
 	MapAssignment(value Assignment) (T, error)
 
+	MapBinary(value Binary) (T, error)
+
 	MapBlock(value Block) (T, error)
 
 	MapBool(value Bool) (T, error)
 
 	MapBreak(value Break) (T, error)
 
+	MapBytes(value Bytes) (T, error)
+
 	MapCall(value Call) (T, error)
 
+	MapComparison(value Comparison) (T, error)
+
 	MapConditional(value Conditional) (T, error)
 
 	MapConstantDef(value ConstantDef) (T, error)
 
 	MapContinue(value Continue) (T, error)
 
+	MapConversion(value Conversion) (T, error)
+
 	MapDeclare(value Declare) (T, error)
 
 	MapEmptyList(value EmptyList) (T, error)
@@ -32,16 +40,24 @@ type NodeMapper[T any] interface {
 
 	MapFieldDef(value FieldDef) (T, error)
 
+	MapFloat64(value Float64) (T, error)
+
 	MapFor(value For) (T, error)
 
 	MapForEach(value ForEach) (T, error)
 
 	MapFunctionDef(value FunctionDef) (T, error)
 
+	MapFunctionRef(value FunctionRef) (T, error)
+
 	MapHashOverride(value HashOverride) (T, error)
 
 	MapIf(value If) (T, error)
 
+	MapImport(value Import) (T, error)
+
+	MapInt32(value Int32) (T, error)
+
 	MapInt64(value Int64) (T, error)
 
 	MapKeyValue(value KeyValue) (T, error)
@@ -52,6 +68,12 @@ type NodeMapper[T any] interface {
 
 	MapLiteralBool(value LiteralBool) (T, error)
 
+	MapLiteralBytes(value LiteralBytes) (T, error)
+
+	MapLiteralFloat64(value LiteralFloat64) (T, error)
+
+	MapLiteralInt32(value LiteralInt32) (T, error)
+
 	MapLiteralInt64(value LiteralInt64) (T, error)
 
 	MapLiteralList(value LiteralList) (T, error)
@@ -64,10 +86,14 @@ type NodeMapper[T any] interface {
 
 	MapLiteralString(value LiteralString) (T, error)
 
+	MapLiteralUint8(value LiteralUint8) (T, error)
+
 	MapLookup(value Lookup) (T, error)
 
 	MapMap(value Map) (T, error)
 
+	MapMethodCall(value MethodCall) (T, error)
+
 	MapModel(value Model) (T, error)
 
 	MapModelDef(value ModelDef) (T, error)
@@ -98,6 +124,10 @@ type NodeMapper[T any] interface {
 
 	MapString(value String) (T, error)
 
+	MapUint8(value Uint8) (T, error)
+
+	MapUnary(value Unary) (T, error)
+
 	MapVariable(value Variable) (T, error)
 
 	MapVoid(value Void) (T, error)
@@ -115,6 +145,9 @@ func MapNode[T any](node Node, mapper NodeMapper[T]) (T, error) {
 	case Assignment:
 		return mapper.MapAssignment(value)
 
+	case Binary:
+		return mapper.MapBinary(value)
+
 	case Block:
 		return mapper.MapBlock(value)
 
@@ -124,9 +157,15 @@ func MapNode[T any](node Node, mapper NodeMapper[T]) (T, error) {
 	case Break:
 		return mapper.MapBreak(value)
 
+	case Bytes:
+		return mapper.MapBytes(value)
+
 	case Call:
 		return mapper.MapCall(value)
 
+	case Comparison:
+		return mapper.MapComparison(value)
+
 	case Conditional:
 		return mapper.MapConditional(value)
 
@@ -136,6 +175,9 @@ func MapNode[T any](node Node, mapper NodeMapper[T]) (T, error) {
 	case Continue:
 		return mapper.MapContinue(value)
 
+	case Conversion:
+		return mapper.MapConversion(value)
+
 	case Declare:
 		return mapper.MapDeclare(value)
 
@@ -148,6 +190,9 @@ func MapNode[T any](node Node, mapper NodeMapper[T]) (T, error) {
 	case FieldDef:
 		return mapper.MapFieldDef(value)
 
+	case Float64:
+		return mapper.MapFloat64(value)
+
 	case For:
 		return mapper.MapFor(value)
 
@@ -157,12 +202,21 @@ func MapNode[T any](node Node, mapper NodeMapper[T]) (T, error) {
 	case FunctionDef:
 		return mapper.MapFunctionDef(value)
 
+	case FunctionRef:
+		return mapper.MapFunctionRef(value)
+
 	case HashOverride:
 		return mapper.MapHashOverride(value)
 
 	case If:
 		return mapper.MapIf(value)
 
+	case Import:
+		return mapper.MapImport(value)
+
+	case Int32:
+		return mapper.MapInt32(value)
+
 	case Int64:
 		return mapper.MapInt64(value)
 
@@ -178,6 +232,15 @@ func MapNode[T any](node Node, mapper NodeMapper[T]) (T, error) {
 	case LiteralBool:
 		return mapper.MapLiteralBool(value)
 
+	case LiteralBytes:
+		return mapper.MapLiteralBytes(value)
+
+	case LiteralFloat64:
+		return mapper.MapLiteralFloat64(value)
+
+	case LiteralInt32:
+		return mapper.MapLiteralInt32(value)
+
 	case LiteralInt64:
 		return mapper.MapLiteralInt64(value)
 
@@ -196,12 +259,18 @@ func MapNode[T any](node Node, mapper NodeMapper[T]) (T, error) {
 	case LiteralString:
 		return mapper.MapLiteralString(value)
 
+	case LiteralUint8:
+		return mapper.MapLiteralUint8(value)
+
 	case Lookup:
 		return mapper.MapLookup(value)
 
 	case Map:
 		return mapper.MapMap(value)
 
+	case MethodCall:
+		return mapper.MapMethodCall(value)
+
 	case Model:
 		return mapper.MapModel(value)
 
@@ -247,6 +316,12 @@ func MapNode[T any](node Node, mapper NodeMapper[T]) (T, error) {
 	case String:
 		return mapper.MapString(value)
 
+	case Uint8:
+		return mapper.MapUint8(value)
+
+	case Unary:
+		return mapper.MapUnary(value)
+
 	case Variable:
 		return mapper.MapVariable(value)
 
@@ -279,20 +354,28 @@ type NodeMapperNoError[T any] interface {
 
 	MapAssignment(value Assignment) T
 
+	MapBinary(value Binary) T
+
 	MapBlock(value Block) T
 
 	MapBool(value Bool) T
 
 	MapBreak(value Break) T
 
+	MapBytes(value Bytes) T
+
 	MapCall(value Call) T
 
+	MapComparison(value Comparison) T
+
 	MapConditional(value Conditional) T
 
 	MapConstantDef(value ConstantDef) T
 
 	MapContinue(value Continue) T
 
+	MapConversion(value Conversion) T
+
 	MapDeclare(value Declare) T
 
 	MapEmptyList(value EmptyList) T
@@ -301,16 +384,24 @@ type NodeMapperNoError[T any] interface {
 
 	MapFieldDef(value FieldDef) T
 
+	MapFloat64(value Float64) T
+
 	MapFor(value For) T
 
 	MapForEach(value ForEach) T
 
 	MapFunctionDef(value FunctionDef) T
 
+	MapFunctionRef(value FunctionRef) T
+
 	MapHashOverride(value HashOverride) T
 
 	MapIf(value If) T
 
+	MapImport(value Import) T
+
+	MapInt32(value Int32) T
+
 	MapInt64(value Int64) T
 
 	MapKeyValue(value KeyValue) T
@@ -321,6 +412,12 @@ type NodeMapperNoError[T any] interface {
 
 	MapLiteralBool(value LiteralBool) T
 
+	MapLiteralBytes(value LiteralBytes) T
+
+	MapLiteralFloat64(value LiteralFloat64) T
+
+	MapLiteralInt32(value LiteralInt32) T
+
 	MapLiteralInt64(value LiteralInt64) T
 
 	MapLiteralList(value LiteralList) T
@@ -333,10 +430,14 @@ type NodeMapperNoError[T any] interface {
 
 	MapLiteralString(value LiteralString) T
 
+	MapLiteralUint8(value LiteralUint8) T
+
 	MapLookup(value Lookup) T
 
 	MapMap(value Map) T
 
+	MapMethodCall(value MethodCall) T
+
 	MapModel(value Model) T
 
 	MapModelDef(value ModelDef) T
@@ -367,6 +468,10 @@ type NodeMapperNoError[T any] interface {
 
 	MapString(value String) T
 
+	MapUint8(value Uint8) T
+
+	MapUnary(value Unary) T
+
 	MapVariable(value Variable) T
 
 	MapVoid(value Void) T
@@ -384,6 +489,9 @@ func MapNodeNoError[T any](node Node, mapper NodeMapperNoError[T]) T {
 	case Assignment:
 		return mapper.MapAssignment(value)
 
+	case Binary:
+		return mapper.MapBinary(value)
+
 	case Block:
 		return mapper.MapBlock(value)
 
@@ -393,9 +501,15 @@ func MapNodeNoError[T any](node Node, mapper NodeMapperNoError[T]) T {
 	case Break:
 		return mapper.MapBreak(value)
 
+	case Bytes:
+		return mapper.MapBytes(value)
+
 	case Call:
 		return mapper.MapCall(value)
 
+	case Comparison:
+		return mapper.MapComparison(value)
+
 	case Conditional:
 		return mapper.MapConditional(value)
 
@@ -405,6 +519,9 @@ func MapNodeNoError[T any](node Node, mapper NodeMapperNoError[T]) T {
 	case Continue:
 		return mapper.MapContinue(value)
 
+	case Conversion:
+		return mapper.MapConversion(value)
+
 	case Declare:
 		return mapper.MapDeclare(value)
 
@@ -417,6 +534,9 @@ func MapNodeNoError[T any](node Node, mapper NodeMapperNoError[T]) T {
 	case FieldDef:
 		return mapper.MapFieldDef(value)
 
+	case Float64:
+		return mapper.MapFloat64(value)
+
 	case For:
 		return mapper.MapFor(value)
 
@@ -426,12 +546,21 @@ func MapNodeNoError[T any](node Node, mapper NodeMapperNoError[T]) T {
 	case FunctionDef:
 		return mapper.MapFunctionDef(value)
 
+	case FunctionRef:
+		return mapper.MapFunctionRef(value)
+
 	case HashOverride:
 		return mapper.MapHashOverride(value)
 
 	case If:
 		return mapper.MapIf(value)
 
+	case Import:
+		return mapper.MapImport(value)
+
+	case Int32:
+		return mapper.MapInt32(value)
+
 	case Int64:
 		return mapper.MapInt64(value)
 
@@ -447,6 +576,15 @@ func MapNodeNoError[T any](node Node, mapper NodeMapperNoError[T]) T {
 	case LiteralBool:
 		return mapper.MapLiteralBool(value)
 
+	case LiteralBytes:
+		return mapper.MapLiteralBytes(value)
+
+	case LiteralFloat64:
+		return mapper.MapLiteralFloat64(value)
+
+	case LiteralInt32:
+		return mapper.MapLiteralInt32(value)
+
 	case LiteralInt64:
 		return mapper.MapLiteralInt64(value)
 
@@ -465,12 +603,18 @@ func MapNodeNoError[T any](node Node, mapper NodeMapperNoError[T]) T {
 	case LiteralString:
 		return mapper.MapLiteralString(value)
 
+	case LiteralUint8:
+		return mapper.MapLiteralUint8(value)
+
 	case Lookup:
 		return mapper.MapLookup(value)
 
 	case Map:
 		return mapper.MapMap(value)
 
+	case MethodCall:
+		return mapper.MapMethodCall(value)
+
 	case Model:
 		return mapper.MapModel(value)
 
@@ -516,6 +660,12 @@ func MapNodeNoError[T any](node Node, mapper NodeMapperNoError[T]) T {
 	case String:
 		return mapper.MapString(value)
 
+	case Uint8:
+		return mapper.MapUint8(value)
+
+	case Unary:
+		return mapper.MapUnary(value)
+
 	case Variable:
 		return mapper.MapVariable(value)
 
@@ -544,20 +694,28 @@ type NodeMapperOnlyError interface {
 
 	MapAssignment(value Assignment) error
 
+	MapBinary(value Binary) error
+
 	MapBlock(value Block) error
 
 	MapBool(value Bool) error
 
 	MapBreak(value Break) error
 
+	MapBytes(value Bytes) error
+
 	MapCall(value Call) error
 
+	MapComparison(value Comparison) error
+
 	MapConditional(value Conditional) error
 
 	MapConstantDef(value ConstantDef) error
 
 	MapContinue(value Continue) error
 
+	MapConversion(value Conversion) error
+
 	MapDeclare(value Declare) error
 
 	MapEmptyList(value EmptyList) error
@@ -566,16 +724,24 @@ type NodeMapperOnlyError interface {
 
 	MapFieldDef(value FieldDef) error
 
+	MapFloat64(value Float64) error
+
 	MapFor(value For) error
 
 	MapForEach(value ForEach) error
 
 	MapFunctionDef(value FunctionDef) error
 
+	MapFunctionRef(value FunctionRef) error
+
 	MapHashOverride(value HashOverride) error
 
 	MapIf(value If) error
 
+	MapImport(value Import) error
+
+	MapInt32(value Int32) error
+
 	MapInt64(value Int64) error
 
 	MapKeyValue(value KeyValue) error
@@ -586,6 +752,12 @@ type NodeMapperOnlyError interface {
 
 	MapLiteralBool(value LiteralBool) error
 
+	MapLiteralBytes(value LiteralBytes) error
+
+	MapLiteralFloat64(value LiteralFloat64) error
+
+	MapLiteralInt32(value LiteralInt32) error
+
 	MapLiteralInt64(value LiteralInt64) error
 
 	MapLiteralList(value LiteralList) error
@@ -598,10 +770,14 @@ type NodeMapperOnlyError interface {
 
 	MapLiteralString(value LiteralString) error
 
+	MapLiteralUint8(value LiteralUint8) error
+
 	MapLookup(value Lookup) error
 
 	MapMap(value Map) error
 
+	MapMethodCall(value MethodCall) error
+
 	MapModel(value Model) error
 
 	MapModelDef(value ModelDef) error
@@ -632,6 +808,10 @@ type NodeMapperOnlyError interface {
 
 	MapString(value String) error
 
+	MapUint8(value Uint8) error
+
+	MapUnary(value Unary) error
+
 	MapVariable(value Variable) error
 
 	MapVoid(value Void) error
@@ -649,6 +829,9 @@ func MapNodeOnlyError(node Node, mapper NodeMapperOnlyError) error {
 	case Assignment:
 		return mapper.MapAssignment(value)
 
+	case Binary:
+		return mapper.MapBinary(value)
+
 	case Block:
 		return mapper.MapBlock(value)
 
@@ -658,9 +841,15 @@ func MapNodeOnlyError(node Node, mapper NodeMapperOnlyError) error {
 	case Break:
 		return mapper.MapBreak(value)
 
+	case Bytes:
+		return mapper.MapBytes(value)
+
 	case Call:
 		return mapper.MapCall(value)
 
+	case Comparison:
+		return mapper.MapComparison(value)
+
 	case Conditional:
 		return mapper.MapConditional(value)
 
@@ -670,6 +859,9 @@ func MapNodeOnlyError(node Node, mapper NodeMapperOnlyError) error {
 	case Continue:
 		return mapper.MapContinue(value)
 
+	case Conversion:
+		return mapper.MapConversion(value)
+
 	case Declare:
 		return mapper.MapDeclare(value)
 
@@ -682,6 +874,9 @@ func MapNodeOnlyError(node Node, mapper NodeMapperOnlyError) error {
 	case FieldDef:
 		return mapper.MapFieldDef(value)
 
+	case Float64:
+		return mapper.MapFloat64(value)
+
 	case For:
 		return mapper.MapFor(value)
 
@@ -691,12 +886,21 @@ func MapNodeOnlyError(node Node, mapper NodeMapperOnlyError) error {
 	case FunctionDef:
 		return mapper.MapFunctionDef(value)
 
+	case FunctionRef:
+		return mapper.MapFunctionRef(value)
+
 	case HashOverride:
 		return mapper.MapHashOverride(value)
 
 	case If:
 		return mapper.MapIf(value)
 
+	case Import:
+		return mapper.MapImport(value)
+
+	case Int32:
+		return mapper.MapInt32(value)
+
 	case Int64:
 		return mapper.MapInt64(value)
 
@@ -712,6 +916,15 @@ func MapNodeOnlyError(node Node, mapper NodeMapperOnlyError) error {
 	case LiteralBool:
 		return mapper.MapLiteralBool(value)
 
+	case LiteralBytes:
+		return mapper.MapLiteralBytes(value)
+
+	case LiteralFloat64:
+		return mapper.MapLiteralFloat64(value)
+
+	case LiteralInt32:
+		return mapper.MapLiteralInt32(value)
+
 	case LiteralInt64:
 		return mapper.MapLiteralInt64(value)
 
@@ -730,12 +943,18 @@ func MapNodeOnlyError(node Node, mapper NodeMapperOnlyError) error {
 	case LiteralString:
 		return mapper.MapLiteralString(value)
 
+	case LiteralUint8:
+		return mapper.MapLiteralUint8(value)
+
 	case Lookup:
 		return mapper.MapLookup(value)
 
 	case Map:
 		return mapper.MapMap(value)
 
+	case MethodCall:
+		return mapper.MapMethodCall(value)
+
 	case Model:
 		return mapper.MapModel(value)
 
@@ -781,6 +1000,12 @@ func MapNodeOnlyError(node Node, mapper NodeMapperOnlyError) error {
 	case String:
 		return mapper.MapString(value)
 
+	case Uint8:
+		return mapper.MapUint8(value)
+
+	case Unary:
+		return mapper.MapUnary(value)
+
 	case Variable:
 		return mapper.MapVariable(value)
 
@@ -794,7 +1019,7 @@ func MapNodeOnlyError(node Node, mapper NodeMapperOnlyError) error {
 
 func MapEachNodeOnlyError(nodes []Node, mapper NodeMapperOnlyError) error {
 	for _, node := range nodes {
-		err := MapNodeNoError(node, mapper)
+		err := MapNodeOnlyError(node, mapper)
 		if err != nil {
 			return err
 		}
@@ -889,7 +1114,7 @@ func MapAssignableOnlyError(node Assignable, mapper AssignableMapperOnlyError) e
 
 func MapEachAssignableOnlyError(nodes []Assignable, mapper AssignableMapperOnlyError) error {
 	for _, node := range nodes {
-		err := MapAssignableNoError(node, mapper)
+		err := MapAssignableOnlyError(node, mapper)
 		if err != nil {
 			return err
 		}
@@ -900,6 +1125,8 @@ func MapEachAssignableOnlyError(nodes []Assignable, mapper AssignableMapperOnlyE
 
 type CallableMapper[T any] interface {
 	MapFunctionDef(value FunctionDef) (T, error)
+
+	MapFunctionRef(value FunctionRef) (T, error)
 }
 
 func MapCallable[T any](node Callable, mapper CallableMapper[T]) (T, error) {
@@ -908,6 +1135,9 @@ func MapCallable[T any](node Callable, mapper CallableMapper[T]) (T, error) {
 	case FunctionDef:
 		return mapper.MapFunctionDef(value)
 
+	case FunctionRef:
+		return mapper.MapFunctionRef(value)
+
 	default:
 		panic("unreachable")
 	}
@@ -929,6 +1159,8 @@ func MapEachCallable[T any](nodes []Callable, mapper CallableMapper[T]) ([]T, er
 
 type CallableMapperNoError[T any] interface {
 	MapFunctionDef(value FunctionDef) T
+
+	MapFunctionRef(value FunctionRef) T
 }
 
 func MapCallableNoError[T any](node Callable, mapper CallableMapperNoError[T]) T {
@@ -937,6 +1169,9 @@ func MapCallableNoError[T any](node Callable, mapper CallableMapperNoError[T]) T
 	case FunctionDef:
 		return mapper.MapFunctionDef(value)
 
+	case FunctionRef:
+		return mapper.MapFunctionRef(value)
+
 	default:
 		panic("unreachable")
 	}
@@ -954,6 +1189,8 @@ func MapEachCallableNoError[T any](nodes []Callable, mapper CallableMapperNoErro
 
 type CallableMapperOnlyError interface {
 	MapFunctionDef(value FunctionDef) error
+
+	MapFunctionRef(value FunctionRef) error
 }
 
 func MapCallableOnlyError(node Callable, mapper CallableMapperOnlyError) error {
@@ -962,6 +1199,9 @@ func MapCallableOnlyError(node Callable, mapper CallableMapperOnlyError) error {
 	case FunctionDef:
 		return mapper.MapFunctionDef(value)
 
+	case FunctionRef:
+		return mapper.MapFunctionRef(value)
+
 	default:
 		panic("unreachable")
 	}
@@ -969,7 +1209,7 @@ func MapCallableOnlyError(node Callable, mapper CallableMapperOnlyError) error {
 
 func MapEachCallableOnlyError(nodes []Callable, mapper CallableMapperOnlyError) error {
 	for _, node := range nodes {
-		err := MapCallableNoError(node, mapper)
+		err := MapCallableOnlyError(node, mapper)
 		if err != nil {
 			return err
 		}
@@ -983,6 +1223,12 @@ type ConstantValueMapper[T any] interface {
 
 	MapLiteralBool(value LiteralBool) (T, error)
 
+	MapLiteralBytes(value LiteralBytes) (T, error)
+
+	MapLiteralFloat64(value LiteralFloat64) (T, error)
+
+	MapLiteralInt32(value LiteralInt32) (T, error)
+
 	MapLiteralInt64(value LiteralInt64) (T, error)
 
 	MapLiteralList(value LiteralList) (T, error)
@@ -995,6 +1241,8 @@ type ConstantValueMapper[T any] interface {
 
 	MapLiteralString(value LiteralString) (T, error)
 
+	MapLiteralUint8(value LiteralUint8) (T, error)
+
 	MapNil(value Nil) (T, error)
 }
 
@@ -1007,6 +1255,15 @@ func MapConstantValue[T any](node ConstantValue, mapper ConstantValueMapper[T])
 	case LiteralBool:
 		return mapper.MapLiteralBool(value)
 
+	case LiteralBytes:
+		return mapper.MapLiteralBytes(value)
+
+	case LiteralFloat64:
+		return mapper.MapLiteralFloat64(value)
+
+	case LiteralInt32:
+		return mapper.MapLiteralInt32(value)
+
 	case LiteralInt64:
 		return mapper.MapLiteralInt64(value)
 
@@ -1025,6 +1282,9 @@ func MapConstantValue[T any](node ConstantValue, mapper ConstantValueMapper[T])
 	case LiteralString:
 		return mapper.MapLiteralString(value)
 
+	case LiteralUint8:
+		return mapper.MapLiteralUint8(value)
+
 	case Nil:
 		return mapper.MapNil(value)
 
@@ -1052,6 +1312,12 @@ type ConstantValueMapperNoError[T any] interface {
 
 	MapLiteralBool(value LiteralBool) T
 
+	MapLiteralBytes(value LiteralBytes) T
+
+	MapLiteralFloat64(value LiteralFloat64) T
+
+	MapLiteralInt32(value LiteralInt32) T
+
 	MapLiteralInt64(value LiteralInt64) T
 
 	MapLiteralList(value LiteralList) T
@@ -1064,6 +1330,8 @@ type ConstantValueMapperNoError[T any] interface {
 
 	MapLiteralString(value LiteralString) T
 
+	MapLiteralUint8(value LiteralUint8) T
+
 	MapNil(value Nil) T
 }
 
@@ -1076,6 +1344,15 @@ func MapConstantValueNoError[T any](node ConstantValue, mapper ConstantValueMapp
 	case LiteralBool:
 		return mapper.MapLiteralBool(value)
 
+	case LiteralBytes:
+		return mapper.MapLiteralBytes(value)
+
+	case LiteralFloat64:
+		return mapper.MapLiteralFloat64(value)
+
+	case LiteralInt32:
+		return mapper.MapLiteralInt32(value)
+
 	case LiteralInt64:
 		return mapper.MapLiteralInt64(value)
 
@@ -1094,6 +1371,9 @@ func MapConstantValueNoError[T any](node ConstantValue, mapper ConstantValueMapp
 	case LiteralString:
 		return mapper.MapLiteralString(value)
 
+	case LiteralUint8:
+		return mapper.MapLiteralUint8(value)
+
 	case Nil:
 		return mapper.MapNil(value)
 
@@ -1117,6 +1397,12 @@ type ConstantValueMapperOnlyError interface {
 
 	MapLiteralBool(value LiteralBool) error
 
+	MapLiteralBytes(value LiteralBytes) error
+
+	MapLiteralFloat64(value LiteralFloat64) error
+
+	MapLiteralInt32(value LiteralInt32) error
+
 	MapLiteralInt64(value LiteralInt64) error
 
 	MapLiteralList(value LiteralList) error
@@ -1129,6 +1415,8 @@ type ConstantValueMapperOnlyError interface {
 
 	MapLiteralString(value LiteralString) error
 
+	MapLiteralUint8(value LiteralUint8) error
+
 	MapNil(value Nil) error
 }
 
@@ -1141,6 +1429,15 @@ func MapConstantValueOnlyError(node ConstantValue, mapper ConstantValueMapperOnl
 	case LiteralBool:
 		return mapper.MapLiteralBool(value)
 
+	case LiteralBytes:
+		return mapper.MapLiteralBytes(value)
+
+	case LiteralFloat64:
+		return mapper.MapLiteralFloat64(value)
+
+	case LiteralInt32:
+		return mapper.MapLiteralInt32(value)
+
 	case LiteralInt64:
 		return mapper.MapLiteralInt64(value)
 
@@ -1159,6 +1456,9 @@ func MapConstantValueOnlyError(node ConstantValue, mapper ConstantValueMapperOnl
 	case LiteralString:
 		return mapper.MapLiteralString(value)
 
+	case LiteralUint8:
+		return mapper.MapLiteralUint8(value)
+
 	case Nil:
 		return mapper.MapNil(value)
 
@@ -1169,7 +1469,7 @@ func MapConstantValueOnlyError(node ConstantValue, mapper ConstantValueMapperOnl
 
 func MapEachConstantValueOnlyError(nodes []ConstantValue, mapper ConstantValueMapperOnlyError) error {
 	for _, node := range nodes {
-		err := MapConstantValueNoError(node, mapper)
+		err := MapConstantValueOnlyError(node, mapper)
 		if err != nil {
 			return err
 		}
@@ -1185,6 +1485,8 @@ type DefinitionMapper[T any] interface {
 
 	MapDeclare(value Declare) (T, error)
 
+	MapEqualOverride(value EqualOverride) (T, error)
+
 	MapFieldDef(value FieldDef) (T, error)
 
 	MapForEach(value ForEach) (T, error)
@@ -1202,6 +1504,9 @@ func MapDefinition[T any](node Definition, mapper DefinitionMapper[T]) (T, error
 	case Declare:
 		return mapper.MapDeclare(value)
 
+	case EqualOverride:
+		return mapper.MapEqualOverride(value)
+
 	case FieldDef:
 		return mapper.MapFieldDef(value)
 
@@ -1234,6 +1539,8 @@ type DefinitionMapperNoError[T any] interface {
 
 	MapDeclare(value Declare) T
 
+	MapEqualOverride(value EqualOverride) T
+
 	MapFieldDef(value FieldDef) T
 
 	MapForEach(value ForEach) T
@@ -1251,6 +1558,9 @@ func MapDefinitionNoError[T any](node Definition, mapper DefinitionMapperNoError
 	case Declare:
 		return mapper.MapDeclare(value)
 
+	case EqualOverride:
+		return mapper.MapEqualOverride(value)
+
 	case FieldDef:
 		return mapper.MapFieldDef(value)
 
@@ -1279,6 +1589,8 @@ type DefinitionMapperOnlyError interface {
 
 	MapDeclare(value Declare) error
 
+	MapEqualOverride(value EqualOverride) error
+
 	MapFieldDef(value FieldDef) error
 
 	MapForEach(value ForEach) error
@@ -1296,6 +1608,9 @@ func MapDefinitionOnlyError(node Definition, mapper DefinitionMapperOnlyError) e
 	case Declare:
 		return mapper.MapDeclare(value)
 
+	case EqualOverride:
+		return mapper.MapEqualOverride(value)
+
 	case FieldDef:
 		return mapper.MapFieldDef(value)
 
@@ -1309,7 +1624,7 @@ func MapDefinitionOnlyError(node Definition, mapper DefinitionMapperOnlyError) e
 
 func MapEachDefinitionOnlyError(nodes []Definition, mapper DefinitionMapperOnlyError) error {
 	for _, node := range nodes {
-		err := MapDefinitionNoError(node, mapper)
+		err := MapDefinitionOnlyError(node, mapper)
 		if err != nil {
 			return err
 		}
@@ -1337,6 +1652,8 @@ type StatementMapper[T any] interface {
 
 	MapForEach(value ForEach) (T, error)
 
+	MapMethodCall(value MethodCall) (T, error)
+
 	MapPop(value Pop) (T, error)
 
 	MapPush(value Push) (T, error)
@@ -1374,6 +1691,9 @@ func MapStatement[T any](node Statement, mapper StatementMapper[T]) (T, error) {
 	case ForEach:
 		return mapper.MapForEach(value)
 
+	case MethodCall:
+		return mapper.MapMethodCall(value)
+
 	case Pop:
 		return mapper.MapPop(value)
 
@@ -1421,6 +1741,8 @@ type StatementMapperNoError[T any] interface {
 
 	MapForEach(value ForEach) T
 
+	MapMethodCall(value MethodCall) T
+
 	MapPop(value Pop) T
 
 	MapPush(value Push) T
@@ -1458,6 +1780,9 @@ func MapStatementNoError[T any](node Statement, mapper StatementMapperNoError[T]
 	case ForEach:
 		return mapper.MapForEach(value)
 
+	case MethodCall:
+		return mapper.MapMethodCall(value)
+
 	case Pop:
 		return mapper.MapPop(value)
 
@@ -1501,6 +1826,8 @@ type StatementMapperOnlyError interface {
 
 	MapForEach(value ForEach) error
 
+	MapMethodCall(value MethodCall) error
+
 	MapPop(value Pop) error
 
 	MapPush(value Push) error
@@ -1538,6 +1865,9 @@ func MapStatementOnlyError(node Statement, mapper StatementMapperOnlyError) erro
 	case ForEach:
 		return mapper.MapForEach(value)
 
+	case MethodCall:
+		return mapper.MapMethodCall(value)
+
 	case Pop:
 		return mapper.MapPop(value)
 
@@ -1554,7 +1884,7 @@ func MapStatementOnlyError(node Statement, mapper StatementMapperOnlyError) erro
 
 func MapEachStatementOnlyError(nodes []Statement, mapper StatementMapperOnlyError) error {
 	for _, node := range nodes {
-		err := MapStatementNoError(node, mapper)
+		err := MapStatementOnlyError(node, mapper)
 		if err != nil {
 			return err
 		}
@@ -1566,6 +1896,12 @@ func MapEachStatementOnlyError(nodes []Statement, mapper StatementMapperOnlyErro
 type TypeMapper[T any] interface {
 	MapBool(value Bool) (T, error)
 
+	MapBytes(value Bytes) (T, error)
+
+	MapFloat64(value Float64) (T, error)
+
+	MapInt32(value Int32) (T, error)
+
 	MapInt64(value Int64) (T, error)
 
 	MapList(value List) (T, error)
@@ -1580,6 +1916,8 @@ type TypeMapper[T any] interface {
 
 	MapString(value String) (T, error)
 
+	MapUint8(value Uint8) (T, error)
+
 	MapVoid(value Void) (T, error)
 }
 
@@ -1589,6 +1927,15 @@ func MapType[T any](node Type, mapper TypeMapper[T]) (T, error) {
 	case Bool:
 		return mapper.MapBool(value)
 
+	case Bytes:
+		return mapper.MapBytes(value)
+
+	case Float64:
+		return mapper.MapFloat64(value)
+
+	case Int32:
+		return mapper.MapInt32(value)
+
 	case Int64:
 		return mapper.MapInt64(value)
 
@@ -1610,6 +1957,9 @@ func MapType[T any](node Type, mapper TypeMapper[T]) (T, error) {
 	case String:
 		return mapper.MapString(value)
 
+	case Uint8:
+		return mapper.MapUint8(value)
+
 	case Void:
 		return mapper.MapVoid(value)
 
@@ -1635,6 +1985,12 @@ func MapEachType[T any](nodes []Type, mapper TypeMapper[T]) ([]T, error) {
 type TypeMapperNoError[T any] interface {
 	MapBool(value Bool) T
 
+	MapBytes(value Bytes) T
+
+	MapFloat64(value Float64) T
+
+	MapInt32(value Int32) T
+
 	MapInt64(value Int64) T
 
 	MapList(value List) T
@@ -1649,6 +2005,8 @@ type TypeMapperNoError[T any] interface {
 
 	MapString(value String) T
 
+	MapUint8(value Uint8) T
+
 	MapVoid(value Void) T
 }
 
@@ -1658,6 +2016,15 @@ func MapTypeNoError[T any](node Type, mapper TypeMapperNoError[T]) T {
 	case Bool:
 		return mapper.MapBool(value)
 
+	case Bytes:
+		return mapper.MapBytes(value)
+
+	case Float64:
+		return mapper.MapFloat64(value)
+
+	case Int32:
+		return mapper.MapInt32(value)
+
 	case Int64:
 		return mapper.MapInt64(value)
 
@@ -1679,6 +2046,9 @@ func MapTypeNoError[T any](node Type, mapper TypeMapperNoError[T]) T {
 	case String:
 		return mapper.MapString(value)
 
+	case Uint8:
+		return mapper.MapUint8(value)
+
 	case Void:
 		return mapper.MapVoid(value)
 
@@ -1700,6 +2070,12 @@ func MapEachTypeNoError[T any](nodes []Type, mapper TypeMapperNoError[T]) []T {
 type TypeMapperOnlyError interface {
 	MapBool(value Bool) error
 
+	MapBytes(value Bytes) error
+
+	MapFloat64(value Float64) error
+
+	MapInt32(value Int32) error
+
 	MapInt64(value Int64) error
 
 	MapList(value List) error
@@ -1714,6 +2090,8 @@ type TypeMapperOnlyError interface {
 
 	MapString(value String) error
 
+	MapUint8(value Uint8) error
+
 	MapVoid(value Void) error
 }
 
@@ -1723,6 +2101,15 @@ func MapTypeOnlyError(node Type, mapper TypeMapperOnlyError) error {
 	case Bool:
 		return mapper.MapBool(value)
 
+	case Bytes:
+		return mapper.MapBytes(value)
+
+	case Float64:
+		return mapper.MapFloat64(value)
+
+	case Int32:
+		return mapper.MapInt32(value)
+
 	case Int64:
 		return mapper.MapInt64(value)
 
@@ -1744,6 +2131,9 @@ func MapTypeOnlyError(node Type, mapper TypeMapperOnlyError) error {
 	case String:
 		return mapper.MapString(value)
 
+	case Uint8:
+		return mapper.MapUint8(value)
+
 	case Void:
 		return mapper.MapVoid(value)
 
@@ -1754,7 +2144,7 @@ func MapTypeOnlyError(node Type, mapper TypeMapperOnlyError) error {
 
 func MapEachTypeOnlyError(nodes []Type, mapper TypeMapperOnlyError) error {
 	for _, node := range nodes {
-		err := MapTypeNoError(node, mapper)
+		err := MapTypeOnlyError(node, mapper)
 		if err != nil {
 			return err
 		}
@@ -1764,14 +2154,26 @@ func MapEachTypeOnlyError(nodes []Type, mapper TypeMapperOnlyError) error {
 }
 
 type ValueMapper[T any] interface {
+	MapBinary(value Binary) (T, error)
+
 	MapCall(value Call) (T, error)
 
+	MapComparison(value Comparison) (T, error)
+
+	MapConversion(value Conversion) (T, error)
+
 	MapEmptyList(value EmptyList) (T, error)
 
 	MapLength(value Length) (T, error)
 
 	MapLiteralBool(value LiteralBool) (T, error)
 
+	MapLiteralBytes(value LiteralBytes) (T, error)
+
+	MapLiteralFloat64(value LiteralFloat64) (T, error)
+
+	MapLiteralInt32(value LiteralInt32) (T, error)
+
 	MapLiteralInt64(value LiteralInt64) (T, error)
 
 	MapLiteralList(value LiteralList) (T, error)
@@ -1784,8 +2186,12 @@ type ValueMapper[T any] interface {
 
 	MapLiteralString(value LiteralString) (T, error)
 
+	MapLiteralUint8(value LiteralUint8) (T, error)
+
 	MapLookup(value Lookup) (T, error)
 
+	MapMethodCall(value MethodCall) (T, error)
+
 	MapNew(value New) (T, error)
 
 	MapNil(value Nil) (T, error)
@@ -1798,15 +2204,26 @@ type ValueMapper[T any] interface {
 
 	MapSetContains(value SetContains) (T, error)
 
+	MapUnary(value Unary) (T, error)
+
 	MapVariable(value Variable) (T, error)
 }
 
 func MapValue[T any](node Value, mapper ValueMapper[T]) (T, error) {
 	switch value := node.(type) {
 
+	case Binary:
+		return mapper.MapBinary(value)
+
 	case Call:
 		return mapper.MapCall(value)
 
+	case Comparison:
+		return mapper.MapComparison(value)
+
+	case Conversion:
+		return mapper.MapConversion(value)
+
 	case EmptyList:
 		return mapper.MapEmptyList(value)
 
@@ -1816,6 +2233,15 @@ func MapValue[T any](node Value, mapper ValueMapper[T]) (T, error) {
 	case LiteralBool:
 		return mapper.MapLiteralBool(value)
 
+	case LiteralBytes:
+		return mapper.MapLiteralBytes(value)
+
+	case LiteralFloat64:
+		return mapper.MapLiteralFloat64(value)
+
+	case LiteralInt32:
+		return mapper.MapLiteralInt32(value)
+
 	case LiteralInt64:
 		return mapper.MapLiteralInt64(value)
 
@@ -1834,9 +2260,15 @@ func MapValue[T any](node Value, mapper ValueMapper[T]) (T, error) {
 	case LiteralString:
 		return mapper.MapLiteralString(value)
 
+	case LiteralUint8:
+		return mapper.MapLiteralUint8(value)
+
 	case Lookup:
 		return mapper.MapLookup(value)
 
+	case MethodCall:
+		return mapper.MapMethodCall(value)
+
 	case New:
 		return mapper.MapNew(value)
 
@@ -1855,6 +2287,9 @@ func MapValue[T any](node Value, mapper ValueMapper[T]) (T, error) {
 	case SetContains:
 		return mapper.MapSetContains(value)
 
+	case Unary:
+		return mapper.MapUnary(value)
+
 	case Variable:
 		return mapper.MapVariable(value)
 
@@ -1878,14 +2313,26 @@ func MapEachValue[T any](nodes []Value, mapper ValueMapper[T]) ([]T, error) {
 }
 
 type ValueMapperNoError[T any] interface {
+	MapBinary(value Binary) T
+
 	MapCall(value Call) T
 
+	MapComparison(value Comparison) T
+
+	MapConversion(value Conversion) T
+
 	MapEmptyList(value EmptyList) T
 
 	MapLength(value Length) T
 
 	MapLiteralBool(value LiteralBool) T
 
+	MapLiteralBytes(value LiteralBytes) T
+
+	MapLiteralFloat64(value LiteralFloat64) T
+
+	MapLiteralInt32(value LiteralInt32) T
+
 	MapLiteralInt64(value LiteralInt64) T
 
 	MapLiteralList(value LiteralList) T
@@ -1898,8 +2345,12 @@ type ValueMapperNoError[T any] interface {
 
 	MapLiteralString(value LiteralString) T
 
+	MapLiteralUint8(value LiteralUint8) T
+
 	MapLookup(value Lookup) T
 
+	MapMethodCall(value MethodCall) T
+
 	MapNew(value New) T
 
 	MapNil(value Nil) T
@@ -1912,15 +2363,26 @@ type ValueMapperNoError[T any] interface {
 
 	MapSetContains(value SetContains) T
 
+	MapUnary(value Unary) T
+
 	MapVariable(value Variable) T
 }
 
 func MapValueNoError[T any](node Value, mapper ValueMapperNoError[T]) T {
 	switch value := node.(type) {
 
+	case Binary:
+		return mapper.MapBinary(value)
+
 	case Call:
 		return mapper.MapCall(value)
 
+	case Comparison:
+		return mapper.MapComparison(value)
+
+	case Conversion:
+		return mapper.MapConversion(value)
+
 	case EmptyList:
 		return mapper.MapEmptyList(value)
 
@@ -1930,6 +2392,15 @@ func MapValueNoError[T any](node Value, mapper ValueMapperNoError[T]) T {
 	case LiteralBool:
 		return mapper.MapLiteralBool(value)
 
+	case LiteralBytes:
+		return mapper.MapLiteralBytes(value)
+
+	case LiteralFloat64:
+		return mapper.MapLiteralFloat64(value)
+
+	case LiteralInt32:
+		return mapper.MapLiteralInt32(value)
+
 	case LiteralInt64:
 		return mapper.MapLiteralInt64(value)
 
@@ -1948,9 +2419,15 @@ func MapValueNoError[T any](node Value, mapper ValueMapperNoError[T]) T {
 	case LiteralString:
 		return mapper.MapLiteralString(value)
 
+	case LiteralUint8:
+		return mapper.MapLiteralUint8(value)
+
 	case Lookup:
 		return mapper.MapLookup(value)
 
+	case MethodCall:
+		return mapper.MapMethodCall(value)
+
 	case New:
 		return mapper.MapNew(value)
 
@@ -1969,6 +2446,9 @@ func MapValueNoError[T any](node Value, mapper ValueMapperNoError[T]) T {
 	case SetContains:
 		return mapper.MapSetContains(value)
 
+	case Unary:
+		return mapper.MapUnary(value)
+
 	case Variable:
 		return mapper.MapVariable(value)
 
@@ -1988,14 +2468,26 @@ func MapEachValueNoError[T any](nodes []Value, mapper ValueMapperNoError[T]) []T
 }
 
 type ValueMapperOnlyError interface {
+	MapBinary(value Binary) error
+
 	MapCall(value Call) error
 
+	MapComparison(value Comparison) error
+
+	MapConversion(value Conversion) error
+
 	MapEmptyList(value EmptyList) error
 
 	MapLength(value Length) error
 
 	MapLiteralBool(value LiteralBool) error
 
+	MapLiteralBytes(value LiteralBytes) error
+
+	MapLiteralFloat64(value LiteralFloat64) error
+
+	MapLiteralInt32(value LiteralInt32) error
+
 	MapLiteralInt64(value LiteralInt64) error
 
 	MapLiteralList(value LiteralList) error
@@ -2008,8 +2500,12 @@ type ValueMapperOnlyError interface {
 
 	MapLiteralString(value LiteralString) error
 
+	MapLiteralUint8(value LiteralUint8) error
+
 	MapLookup(value Lookup) error
 
+	MapMethodCall(value MethodCall) error
+
 	MapNew(value New) error
 
 	MapNil(value Nil) error
@@ -2022,15 +2518,26 @@ type ValueMapperOnlyError interface {
 
 	MapSetContains(value SetContains) error
 
+	MapUnary(value Unary) error
+
 	MapVariable(value Variable) error
 }
 
 func MapValueOnlyError(node Value, mapper ValueMapperOnlyError) error {
 	switch value := node.(type) {
 
+	case Binary:
+		return mapper.MapBinary(value)
+
 	case Call:
 		return mapper.MapCall(value)
 
+	case Comparison:
+		return mapper.MapComparison(value)
+
+	case Conversion:
+		return mapper.MapConversion(value)
+
 	case EmptyList:
 		return mapper.MapEmptyList(value)
 
@@ -2040,6 +2547,15 @@ func MapValueOnlyError(node Value, mapper ValueMapperOnlyError) error {
 	case LiteralBool:
 		return mapper.MapLiteralBool(value)
 
+	case LiteralBytes:
+		return mapper.MapLiteralBytes(value)
+
+	case LiteralFloat64:
+		return mapper.MapLiteralFloat64(value)
+
+	case LiteralInt32:
+		return mapper.MapLiteralInt32(value)
+
 	case LiteralInt64:
 		return mapper.MapLiteralInt64(value)
 
@@ -2058,9 +2574,15 @@ func MapValueOnlyError(node Value, mapper ValueMapperOnlyError) error {
 	case LiteralString:
 		return mapper.MapLiteralString(value)
 
+	case LiteralUint8:
+		return mapper.MapLiteralUint8(value)
+
 	case Lookup:
 		return mapper.MapLookup(value)
 
+	case MethodCall:
+		return mapper.MapMethodCall(value)
+
 	case New:
 		return mapper.MapNew(value)
 
@@ -2079,6 +2601,9 @@ func MapValueOnlyError(node Value, mapper ValueMapperOnlyError) error {
 	case SetContains:
 		return mapper.MapSetContains(value)
 
+	case Unary:
+		return mapper.MapUnary(value)
+
 	case Variable:
 		return mapper.MapVariable(value)
 
@@ -2089,7 +2614,7 @@ func MapValueOnlyError(node Value, mapper ValueMapperOnlyError) error {
 
 func MapEachValueOnlyError(nodes []Value, mapper ValueMapperOnlyError) error {
 	for _, node := range nodes {
-		err := MapValueNoError(node, mapper)
+		err := MapValueOnlyError(node, mapper)
 		if err != nil {
 			return err
 		}
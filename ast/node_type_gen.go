@@ -8,51 +8,51 @@ type Node interface {
 	isNode()
 }
 
-type Statement interface {
+type Assignable interface {
 	Node
 
-	// isStatement is just a interface guard to restrict what can be used as a Statement.
-	isStatement()
+	// isAssignable is just a interface guard to restrict what can be used as a Assignable.
+	isAssignable()
 }
 
-type Definition interface {
+type Callable interface {
 	Node
 
-	// isDefinition is just a interface guard to restrict what can be used as a Definition.
-	isDefinition()
+	// isCallable is just a interface guard to restrict what can be used as a Callable.
+	isCallable()
 }
 
-type Type interface {
+type ConstantValue interface {
 	Node
 
-	// isType is just a interface guard to restrict what can be used as a Type.
-	isType()
+	// isConstantValue is just a interface guard to restrict what can be used as a ConstantValue.
+	isConstantValue()
 }
 
-type Value interface {
+type Definition interface {
 	Node
 
-	// isValue is just a interface guard to restrict what can be used as a Value.
-	isValue()
+	// isDefinition is just a interface guard to restrict what can be used as a Definition.
+	isDefinition()
 }
 
-type ConstantValue interface {
+type Statement interface {
 	Node
 
-	// isConstantValue is just a interface guard to restrict what can be used as a ConstantValue.
-	isConstantValue()
+	// isStatement is just a interface guard to restrict what can be used as a Statement.
+	isStatement()
 }
 
-type Callable interface {
+type Type interface {
 	Node
 
-	// isCallable is just a interface guard to restrict what can be used as a Callable.
-	isCallable()
+	// isType is just a interface guard to restrict what can be used as a Type.
+	isType()
 }
 
-type Assignable interface {
+type Value interface {
 	Node
 
-	// isAssignable is just a interface guard to restrict what can be used as a Assignable.
-	isAssignable()
+	// isValue is just a interface guard to restrict what can be used as a Value.
+	isValue()
 }
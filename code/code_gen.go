@@ -11,9 +11,9 @@ type AddToSet struct {
 	AddToSetMetadata
 }
 
-func (AddToSet) isNode() {}
+func (*AddToSet) isNode() {}
 
-func (AddToSet) isStatement() {}
+func (*AddToSet) isStatement() {}
 
 type ArgumentDef struct {
 	Name string
@@ -23,9 +23,9 @@ type ArgumentDef struct {
 	ArgumentDefMetadata
 }
 
-func (ArgumentDef) isNode() {}
+func (*ArgumentDef) isNode() {}
 
-func (ArgumentDef) isDefinition() {}
+func (*ArgumentDef) isDefinition() {}
 
 type Assignment struct {
 	From Value
@@ -35,9 +35,23 @@ type Assignment struct {
 	AssignmentMetadata
 }
 
-func (Assignment) isNode() {}
+func (*Assignment) isNode() {}
 
-func (Assignment) isStatement() {}
+func (*Assignment) isStatement() {}
+
+type Binary struct {
+	Left Value
+
+	Operator BinaryOperator
+
+	Right Value
+
+	BinaryMetadata
+}
+
+func (*Binary) isNode() {}
+
+func (*Binary) isValue() {}
 
 type Block struct {
 	Statements []Statement
@@ -45,23 +59,31 @@ type Block struct {
 	BlockMetadata
 }
 
-func (Block) isNode() {}
+func (*Block) isNode() {}
 
 type Bool struct {
 	BoolMetadata
 }
 
-func (Bool) isNode() {}
+func (*Bool) isNode() {}
 
-func (Bool) isType() {}
+func (*Bool) isType() {}
 
 type Break struct {
 	BreakMetadata
 }
 
-func (Break) isNode() {}
+func (*Break) isNode() {}
 
-func (Break) isStatement() {}
+func (*Break) isStatement() {}
+
+type Bytes struct {
+	BytesMetadata
+}
+
+func (*Bytes) isNode() {}
+
+func (*Bytes) isType() {}
 
 type Call struct {
 	Arguments []Value
@@ -71,11 +93,25 @@ type Call struct {
 	CallMetadata
 }
 
-func (Call) isNode() {}
+func (*Call) isNode() {}
+
+func (*Call) isStatement() {}
+
+func (*Call) isValue() {}
 
-func (Call) isStatement() {}
+type Comparison struct {
+	Left Value
 
-func (Call) isValue() {}
+	Operator ComparisonOperator
+
+	Right Value
+
+	ComparisonMetadata
+}
+
+func (*Comparison) isNode() {}
+
+func (*Comparison) isValue() {}
 
 type Conditional struct {
 	Else *Block
@@ -85,29 +121,43 @@ type Conditional struct {
 	ConditionalMetadata
 }
 
-func (Conditional) isNode() {}
+func (*Conditional) isNode() {}
 
-func (Conditional) isStatement() {}
+func (*Conditional) isStatement() {}
 
 type ConstantDef struct {
 	Name string
 
 	Value ConstantValue
 
+	Visibility Visibility
+
 	ConstantDefMetadata
 }
 
-func (ConstantDef) isNode() {}
+func (*ConstantDef) isNode() {}
 
-func (ConstantDef) isDefinition() {}
+func (*ConstantDef) isDefinition() {}
 
 type Continue struct {
 	ContinueMetadata
 }
 
-func (Continue) isNode() {}
+func (*Continue) isNode() {}
+
+func (*Continue) isStatement() {}
+
+type Conversion struct {
+	To Type
+
+	Value Value
+
+	ConversionMetadata
+}
 
-func (Continue) isStatement() {}
+func (*Conversion) isNode() {}
+
+func (*Conversion) isValue() {}
 
 type Declare struct {
 	Name string
@@ -117,11 +167,11 @@ type Declare struct {
 	DeclareMetadata
 }
 
-func (Declare) isNode() {}
+func (*Declare) isNode() {}
 
-func (Declare) isStatement() {}
+func (*Declare) isStatement() {}
 
-func (Declare) isDefinition() {}
+func (*Declare) isDefinition() {}
 
 type EmptyList struct {
 	Type Type
@@ -129,11 +179,11 @@ type EmptyList struct {
 	EmptyListMetadata
 }
 
-func (EmptyList) isNode() {}
+func (*EmptyList) isNode() {}
 
-func (EmptyList) isConstantValue() {}
+func (*EmptyList) isConstantValue() {}
 
-func (EmptyList) isValue() {}
+func (*EmptyList) isValue() {}
 
 type EqualOverride struct {
 	Block *Block
@@ -143,19 +193,31 @@ type EqualOverride struct {
 	EqualOverrideMetadata
 }
 
-func (EqualOverride) isNode() {}
+func (*EqualOverride) isNode() {}
+
+func (*EqualOverride) isDefinition() {}
 
 type FieldDef struct {
 	Name string
 
 	Type Type
 
+	Visibility Visibility
+
 	FieldDefMetadata
 }
 
-func (FieldDef) isNode() {}
+func (*FieldDef) isNode() {}
+
+func (*FieldDef) isDefinition() {}
+
+type Float64 struct {
+	Float64Metadata
+}
+
+func (*Float64) isNode() {}
 
-func (FieldDef) isDefinition() {}
+func (*Float64) isType() {}
 
 type For struct {
 	AfterEach Statement
@@ -169,9 +231,9 @@ type For struct {
 	ForMetadata
 }
 
-func (For) isNode() {}
+func (*For) isNode() {}
 
-func (For) isStatement() {}
+func (*For) isStatement() {}
 
 type ForEach struct {
 	Block *Block
@@ -183,11 +245,11 @@ type ForEach struct {
 	ForEachMetadata
 }
 
-func (ForEach) isNode() {}
+func (*ForEach) isNode() {}
 
-func (ForEach) isDefinition() {}
+func (*ForEach) isDefinition() {}
 
-func (ForEach) isStatement() {}
+func (*ForEach) isStatement() {}
 
 type FunctionDef struct {
 	Arguments []*ArgumentDef
@@ -198,12 +260,26 @@ type FunctionDef struct {
 
 	ReturnType Type
 
+	Visibility Visibility
+
 	FunctionDefMetadata
 }
 
-func (FunctionDef) isNode() {}
+func (*FunctionDef) isNode() {}
+
+func (*FunctionDef) isCallable() {}
+
+type FunctionRef struct {
+	Module string
+
+	Name string
+
+	FunctionRefMetadata
+}
+
+func (*FunctionRef) isNode() {}
 
-func (FunctionDef) isCallable() {}
+func (*FunctionRef) isCallable() {}
 
 type HashOverride struct {
 	Block *Block
@@ -211,7 +287,7 @@ type HashOverride struct {
 	HashOverrideMetadata
 }
 
-func (HashOverride) isNode() {}
+func (*HashOverride) isNode() {}
 
 type If struct {
 	Block *Block
@@ -221,15 +297,31 @@ type If struct {
 	IfMetadata
 }
 
-func (If) isNode() {}
+func (*If) isNode() {}
+
+type Import struct {
+	Module string
+
+	ImportMetadata
+}
+
+func (*Import) isNode() {}
+
+type Int32 struct {
+	Int32Metadata
+}
+
+func (*Int32) isNode() {}
+
+func (*Int32) isType() {}
 
 type Int64 struct {
 	Int64Metadata
 }
 
-func (Int64) isNode() {}
+func (*Int64) isNode() {}
 
-func (Int64) isType() {}
+func (*Int64) isType() {}
 
 type KeyValue struct {
 	Key Value
@@ -239,7 +331,7 @@ type KeyValue struct {
 	KeyValueMetadata
 }
 
-func (KeyValue) isNode() {}
+func (*KeyValue) isNode() {}
 
 type Length struct {
 	Of Value
@@ -247,9 +339,9 @@ type Length struct {
 	LengthMetadata
 }
 
-func (Length) isNode() {}
+func (*Length) isNode() {}
 
-func (Length) isValue() {}
+func (*Length) isValue() {}
 
 type List struct {
 	Item Type
@@ -257,9 +349,9 @@ type List struct {
 	ListMetadata
 }
 
-func (List) isNode() {}
+func (*List) isNode() {}
 
-func (List) isType() {}
+func (*List) isType() {}
 
 type LiteralBool struct {
 	Value bool
@@ -267,11 +359,47 @@ type LiteralBool struct {
 	LiteralBoolMetadata
 }
 
-func (LiteralBool) isNode() {}
+func (*LiteralBool) isNode() {}
+
+func (*LiteralBool) isConstantValue() {}
 
-func (LiteralBool) isConstantValue() {}
+func (*LiteralBool) isValue() {}
 
-func (LiteralBool) isValue() {}
+type LiteralBytes struct {
+	Value []uint8
+
+	LiteralBytesMetadata
+}
+
+func (*LiteralBytes) isNode() {}
+
+func (*LiteralBytes) isConstantValue() {}
+
+func (*LiteralBytes) isValue() {}
+
+type LiteralFloat64 struct {
+	Value float64
+
+	LiteralFloat64Metadata
+}
+
+func (*LiteralFloat64) isNode() {}
+
+func (*LiteralFloat64) isConstantValue() {}
+
+func (*LiteralFloat64) isValue() {}
+
+type LiteralInt32 struct {
+	Value int32
+
+	LiteralInt32Metadata
+}
+
+func (*LiteralInt32) isNode() {}
+
+func (*LiteralInt32) isConstantValue() {}
+
+func (*LiteralInt32) isValue() {}
 
 type LiteralInt64 struct {
 	Value int64
@@ -279,11 +407,11 @@ type LiteralInt64 struct {
 	LiteralInt64Metadata
 }
 
-func (LiteralInt64) isNode() {}
+func (*LiteralInt64) isNode() {}
 
-func (LiteralInt64) isConstantValue() {}
+func (*LiteralInt64) isConstantValue() {}
 
-func (LiteralInt64) isValue() {}
+func (*LiteralInt64) isValue() {}
 
 type LiteralList struct {
 	Values []Value
@@ -291,11 +419,11 @@ type LiteralList struct {
 	LiteralListMetadata
 }
 
-func (LiteralList) isNode() {}
+func (*LiteralList) isNode() {}
 
-func (LiteralList) isConstantValue() {}
+func (*LiteralList) isConstantValue() {}
 
-func (LiteralList) isValue() {}
+func (*LiteralList) isValue() {}
 
 type LiteralMap struct {
 	Values []*KeyValue
@@ -303,11 +431,11 @@ type LiteralMap struct {
 	LiteralMapMetadata
 }
 
-func (LiteralMap) isNode() {}
+func (*LiteralMap) isNode() {}
 
-func (LiteralMap) isConstantValue() {}
+func (*LiteralMap) isConstantValue() {}
 
-func (LiteralMap) isValue() {}
+func (*LiteralMap) isValue() {}
 
 type LiteralRune struct {
 	Value rune
@@ -315,11 +443,11 @@ type LiteralRune struct {
 	LiteralRuneMetadata
 }
 
-func (LiteralRune) isNode() {}
+func (*LiteralRune) isNode() {}
 
-func (LiteralRune) isConstantValue() {}
+func (*LiteralRune) isConstantValue() {}
 
-func (LiteralRune) isValue() {}
+func (*LiteralRune) isValue() {}
 
 type LiteralSet struct {
 	Values []Value
@@ -327,11 +455,11 @@ type LiteralSet struct {
 	LiteralSetMetadata
 }
 
-func (LiteralSet) isNode() {}
+func (*LiteralSet) isNode() {}
 
-func (LiteralSet) isConstantValue() {}
+func (*LiteralSet) isConstantValue() {}
 
-func (LiteralSet) isValue() {}
+func (*LiteralSet) isValue() {}
 
 type LiteralString struct {
 	Value string
@@ -339,11 +467,23 @@ type LiteralString struct {
 	LiteralStringMetadata
 }
 
-func (LiteralString) isNode() {}
+func (*LiteralString) isNode() {}
+
+func (*LiteralString) isConstantValue() {}
+
+func (*LiteralString) isValue() {}
+
+type LiteralUint8 struct {
+	Value uint8
+
+	LiteralUint8Metadata
+}
+
+func (*LiteralUint8) isNode() {}
 
-func (LiteralString) isConstantValue() {}
+func (*LiteralUint8) isConstantValue() {}
 
-func (LiteralString) isValue() {}
+func (*LiteralUint8) isValue() {}
 
 type Lookup struct {
 	From Value
@@ -353,9 +493,9 @@ type Lookup struct {
 	LookupMetadata
 }
 
-func (Lookup) isNode() {}
+func (*Lookup) isNode() {}
 
-func (Lookup) isValue() {}
+func (*Lookup) isValue() {}
 
 type Map struct {
 	Key Type
@@ -365,19 +505,37 @@ type Map struct {
 	MapMetadata
 }
 
-func (Map) isNode() {}
+func (*Map) isNode() {}
+
+func (*Map) isType() {}
+
+type MethodCall struct {
+	Arguments []Value
+
+	Name string
+
+	Receiver Value
+
+	MethodCallMetadata
+}
+
+func (*MethodCall) isNode() {}
+
+func (*MethodCall) isStatement() {}
 
-func (Map) isType() {}
+func (*MethodCall) isValue() {}
 
 type Model struct {
+	Module string
+
 	Name string
 
 	ModelMetadata
 }
 
-func (Model) isNode() {}
+func (*Model) isNode() {}
 
-func (Model) isType() {}
+func (*Model) isType() {}
 
 type ModelDef struct {
 	EqualOverride *EqualOverride
@@ -390,16 +548,20 @@ type ModelDef struct {
 
 	Name string
 
+	Visibility Visibility
+
 	ModelDefMetadata
 }
 
-func (ModelDef) isNode() {}
+func (*ModelDef) isNode() {}
 
 type Module struct {
 	Constants []*ConstantDef
 
 	Functions []*FunctionDef
 
+	Imports []*Import
+
 	Models []*ModelDef
 
 	Name string
@@ -407,7 +569,7 @@ type Module struct {
 	ModuleMetadata
 }
 
-func (Module) isNode() {}
+func (*Module) isNode() {}
 
 type New struct {
 	Model *Model
@@ -415,9 +577,9 @@ type New struct {
 	NewMetadata
 }
 
-func (New) isNode() {}
+func (*New) isNode() {}
 
-func (New) isValue() {}
+func (*New) isValue() {}
 
 type Nil struct {
 	Type Type
@@ -425,11 +587,11 @@ type Nil struct {
 	NilMetadata
 }
 
-func (Nil) isNode() {}
+func (*Nil) isNode() {}
 
-func (Nil) isConstantValue() {}
+func (*Nil) isConstantValue() {}
 
-func (Nil) isValue() {}
+func (*Nil) isValue() {}
 
 type Pop struct {
 	List Value
@@ -437,11 +599,11 @@ type Pop struct {
 	PopMetadata
 }
 
-func (Pop) isNode() {}
+func (*Pop) isNode() {}
 
-func (Pop) isStatement() {}
+func (*Pop) isStatement() {}
 
-func (Pop) isValue() {}
+func (*Pop) isValue() {}
 
 type Property struct {
 	Name string
@@ -451,11 +613,11 @@ type Property struct {
 	PropertyMetadata
 }
 
-func (Property) isNode() {}
+func (*Property) isNode() {}
 
-func (Property) isAssignable() {}
+func (*Property) isAssignable() {}
 
-func (Property) isValue() {}
+func (*Property) isValue() {}
 
 type Push struct {
 	List Value
@@ -465,9 +627,9 @@ type Push struct {
 	PushMetadata
 }
 
-func (Push) isNode() {}
+func (*Push) isNode() {}
 
-func (Push) isStatement() {}
+func (*Push) isStatement() {}
 
 type Return struct {
 	Value Value
@@ -475,9 +637,9 @@ type Return struct {
 	ReturnMetadata
 }
 
-func (Return) isNode() {}
+func (*Return) isNode() {}
 
-func (Return) isStatement() {}
+func (*Return) isStatement() {}
 
 type Root struct {
 	Modules []*Module
@@ -485,23 +647,23 @@ type Root struct {
 	RootMetadata
 }
 
-func (Root) isNode() {}
+func (*Root) isNode() {}
 
 type Rune struct {
 	RuneMetadata
 }
 
-func (Rune) isNode() {}
+func (*Rune) isNode() {}
 
-func (Rune) isType() {}
+func (*Rune) isType() {}
 
 type Self struct {
 	SelfMetadata
 }
 
-func (Self) isNode() {}
+func (*Self) isNode() {}
 
-func (Self) isValue() {}
+func (*Self) isValue() {}
 
 type Set struct {
 	Item Type
@@ -509,9 +671,9 @@ type Set struct {
 	SetMetadata
 }
 
-func (Set) isNode() {}
+func (*Set) isNode() {}
 
-func (Set) isType() {}
+func (*Set) isType() {}
 
 type SetContains struct {
 	Set Value
@@ -521,34 +683,56 @@ type SetContains struct {
 	SetContainsMetadata
 }
 
-func (SetContains) isNode() {}
+func (*SetContains) isNode() {}
 
-func (SetContains) isValue() {}
+func (*SetContains) isValue() {}
 
 type String struct {
 	StringMetadata
 }
 
-func (String) isNode() {}
+func (*String) isNode() {}
 
-func (String) isType() {}
+func (*String) isType() {}
+
+type Uint8 struct {
+	Uint8Metadata
+}
+
+func (*Uint8) isNode() {}
+
+func (*Uint8) isType() {}
+
+type Unary struct {
+	Operator UnaryOperator
+
+	Value Value
+
+	UnaryMetadata
+}
+
+func (*Unary) isNode() {}
+
+func (*Unary) isValue() {}
 
 type Variable struct {
+	Module string
+
 	Name string
 
 	VariableMetadata
 }
 
-func (Variable) isNode() {}
+func (*Variable) isNode() {}
 
-func (Variable) isAssignable() {}
+func (*Variable) isAssignable() {}
 
-func (Variable) isValue() {}
+func (*Variable) isValue() {}
 
 type Void struct {
 	VoidMetadata
 }
 
-func (Void) isNode() {}
+func (*Void) isNode() {}
 
-func (Void) isType() {}
+func (*Void) isType() {}
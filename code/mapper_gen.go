@@ -4,253 +4,328 @@
 package code
 
 type NodeMapper[T any] interface {
-	MapAddToSet(value AddToSet) (T, error)
+	MapAddToSet(value *AddToSet) (T, error)
 
-	MapArgumentDef(value ArgumentDef) (T, error)
+	MapArgumentDef(value *ArgumentDef) (T, error)
 
-	MapAssignment(value Assignment) (T, error)
+	MapAssignment(value *Assignment) (T, error)
 
-	MapBlock(value Block) (T, error)
+	MapBinary(value *Binary) (T, error)
 
-	MapBool(value Bool) (T, error)
+	MapBlock(value *Block) (T, error)
 
-	MapBreak(value Break) (T, error)
+	MapBool(value *Bool) (T, error)
 
-	MapCall(value Call) (T, error)
+	MapBreak(value *Break) (T, error)
 
-	MapConditional(value Conditional) (T, error)
+	MapBytes(value *Bytes) (T, error)
 
-	MapConstantDef(value ConstantDef) (T, error)
+	MapCall(value *Call) (T, error)
 
-	MapContinue(value Continue) (T, error)
+	MapComparison(value *Comparison) (T, error)
 
-	MapDeclare(value Declare) (T, error)
+	MapConditional(value *Conditional) (T, error)
 
-	MapEmptyList(value EmptyList) (T, error)
+	MapConstantDef(value *ConstantDef) (T, error)
 
-	MapEqualOverride(value EqualOverride) (T, error)
+	MapContinue(value *Continue) (T, error)
 
-	MapFieldDef(value FieldDef) (T, error)
+	MapConversion(value *Conversion) (T, error)
 
-	MapFor(value For) (T, error)
+	MapDeclare(value *Declare) (T, error)
 
-	MapForEach(value ForEach) (T, error)
+	MapEmptyList(value *EmptyList) (T, error)
 
-	MapFunctionDef(value FunctionDef) (T, error)
+	MapEqualOverride(value *EqualOverride) (T, error)
 
-	MapHashOverride(value HashOverride) (T, error)
+	MapFieldDef(value *FieldDef) (T, error)
 
-	MapIf(value If) (T, error)
+	MapFloat64(value *Float64) (T, error)
 
-	MapInt64(value Int64) (T, error)
+	MapFor(value *For) (T, error)
 
-	MapKeyValue(value KeyValue) (T, error)
+	MapForEach(value *ForEach) (T, error)
 
-	MapLength(value Length) (T, error)
+	MapFunctionDef(value *FunctionDef) (T, error)
 
-	MapList(value List) (T, error)
+	MapFunctionRef(value *FunctionRef) (T, error)
 
-	MapLiteralBool(value LiteralBool) (T, error)
+	MapHashOverride(value *HashOverride) (T, error)
 
-	MapLiteralInt64(value LiteralInt64) (T, error)
+	MapIf(value *If) (T, error)
 
-	MapLiteralList(value LiteralList) (T, error)
+	MapImport(value *Import) (T, error)
 
-	MapLiteralMap(value LiteralMap) (T, error)
+	MapInt32(value *Int32) (T, error)
 
-	MapLiteralRune(value LiteralRune) (T, error)
+	MapInt64(value *Int64) (T, error)
 
-	MapLiteralSet(value LiteralSet) (T, error)
+	MapKeyValue(value *KeyValue) (T, error)
 
-	MapLiteralString(value LiteralString) (T, error)
+	MapLength(value *Length) (T, error)
 
-	MapLookup(value Lookup) (T, error)
+	MapList(value *List) (T, error)
 
-	MapMap(value Map) (T, error)
+	MapLiteralBool(value *LiteralBool) (T, error)
 
-	MapModel(value Model) (T, error)
+	MapLiteralBytes(value *LiteralBytes) (T, error)
 
-	MapModelDef(value ModelDef) (T, error)
+	MapLiteralFloat64(value *LiteralFloat64) (T, error)
 
-	MapModule(value Module) (T, error)
+	MapLiteralInt32(value *LiteralInt32) (T, error)
 
-	MapNew(value New) (T, error)
+	MapLiteralInt64(value *LiteralInt64) (T, error)
 
-	MapNil(value Nil) (T, error)
+	MapLiteralList(value *LiteralList) (T, error)
 
-	MapPop(value Pop) (T, error)
+	MapLiteralMap(value *LiteralMap) (T, error)
 
-	MapProperty(value Property) (T, error)
+	MapLiteralRune(value *LiteralRune) (T, error)
 
-	MapPush(value Push) (T, error)
+	MapLiteralSet(value *LiteralSet) (T, error)
 
-	MapReturn(value Return) (T, error)
+	MapLiteralString(value *LiteralString) (T, error)
 
-	MapRoot(value Root) (T, error)
+	MapLiteralUint8(value *LiteralUint8) (T, error)
 
-	MapRune(value Rune) (T, error)
+	MapLookup(value *Lookup) (T, error)
 
-	MapSelf(value Self) (T, error)
+	MapMap(value *Map) (T, error)
 
-	MapSet(value Set) (T, error)
+	MapMethodCall(value *MethodCall) (T, error)
 
-	MapSetContains(value SetContains) (T, error)
+	MapModel(value *Model) (T, error)
 
-	MapString(value String) (T, error)
+	MapModelDef(value *ModelDef) (T, error)
 
-	MapVariable(value Variable) (T, error)
+	MapModule(value *Module) (T, error)
 
-	MapVoid(value Void) (T, error)
+	MapNew(value *New) (T, error)
+
+	MapNil(value *Nil) (T, error)
+
+	MapPop(value *Pop) (T, error)
+
+	MapProperty(value *Property) (T, error)
+
+	MapPush(value *Push) (T, error)
+
+	MapReturn(value *Return) (T, error)
+
+	MapRoot(value *Root) (T, error)
+
+	MapRune(value *Rune) (T, error)
+
+	MapSelf(value *Self) (T, error)
+
+	MapSet(value *Set) (T, error)
+
+	MapSetContains(value *SetContains) (T, error)
+
+	MapString(value *String) (T, error)
+
+	MapUint8(value *Uint8) (T, error)
+
+	MapUnary(value *Unary) (T, error)
+
+	MapVariable(value *Variable) (T, error)
+
+	MapVoid(value *Void) (T, error)
 }
 
 func MapNode[T any](node Node, mapper NodeMapper[T]) (T, error) {
 	switch value := node.(type) {
 
-	case AddToSet:
+	case *AddToSet:
 		return mapper.MapAddToSet(value)
 
-	case ArgumentDef:
+	case *ArgumentDef:
 		return mapper.MapArgumentDef(value)
 
-	case Assignment:
+	case *Assignment:
 		return mapper.MapAssignment(value)
 
-	case Block:
+	case *Binary:
+		return mapper.MapBinary(value)
+
+	case *Block:
 		return mapper.MapBlock(value)
 
-	case Bool:
+	case *Bool:
 		return mapper.MapBool(value)
 
-	case Break:
+	case *Break:
 		return mapper.MapBreak(value)
 
-	case Call:
+	case *Bytes:
+		return mapper.MapBytes(value)
+
+	case *Call:
 		return mapper.MapCall(value)
 
-	case Conditional:
+	case *Comparison:
+		return mapper.MapComparison(value)
+
+	case *Conditional:
 		return mapper.MapConditional(value)
 
-	case ConstantDef:
+	case *ConstantDef:
 		return mapper.MapConstantDef(value)
 
-	case Continue:
+	case *Continue:
 		return mapper.MapContinue(value)
 
-	case Declare:
+	case *Conversion:
+		return mapper.MapConversion(value)
+
+	case *Declare:
 		return mapper.MapDeclare(value)
 
-	case EmptyList:
+	case *EmptyList:
 		return mapper.MapEmptyList(value)
 
-	case EqualOverride:
+	case *EqualOverride:
 		return mapper.MapEqualOverride(value)
 
-	case FieldDef:
+	case *FieldDef:
 		return mapper.MapFieldDef(value)
 
-	case For:
+	case *Float64:
+		return mapper.MapFloat64(value)
+
+	case *For:
 		return mapper.MapFor(value)
 
-	case ForEach:
+	case *ForEach:
 		return mapper.MapForEach(value)
 
-	case FunctionDef:
+	case *FunctionDef:
 		return mapper.MapFunctionDef(value)
 
-	case HashOverride:
+	case *FunctionRef:
+		return mapper.MapFunctionRef(value)
+
+	case *HashOverride:
 		return mapper.MapHashOverride(value)
 
-	case If:
+	case *If:
 		return mapper.MapIf(value)
 
-	case Int64:
+	case *Import:
+		return mapper.MapImport(value)
+
+	case *Int32:
+		return mapper.MapInt32(value)
+
+	case *Int64:
 		return mapper.MapInt64(value)
 
-	case KeyValue:
+	case *KeyValue:
 		return mapper.MapKeyValue(value)
 
-	case Length:
+	case *Length:
 		return mapper.MapLength(value)
 
-	case List:
+	case *List:
 		return mapper.MapList(value)
 
-	case LiteralBool:
+	case *LiteralBool:
 		return mapper.MapLiteralBool(value)
 
-	case LiteralInt64:
+	case *LiteralBytes:
+		return mapper.MapLiteralBytes(value)
+
+	case *LiteralFloat64:
+		return mapper.MapLiteralFloat64(value)
+
+	case *LiteralInt32:
+		return mapper.MapLiteralInt32(value)
+
+	case *LiteralInt64:
 		return mapper.MapLiteralInt64(value)
 
-	case LiteralList:
+	case *LiteralList:
 		return mapper.MapLiteralList(value)
 
-	case LiteralMap:
+	case *LiteralMap:
 		return mapper.MapLiteralMap(value)
 
-	case LiteralRune:
+	case *LiteralRune:
 		return mapper.MapLiteralRune(value)
 
-	case LiteralSet:
+	case *LiteralSet:
 		return mapper.MapLiteralSet(value)
 
-	case LiteralString:
+	case *LiteralString:
 		return mapper.MapLiteralString(value)
 
-	case Lookup:
+	case *LiteralUint8:
+		return mapper.MapLiteralUint8(value)
+
+	case *Lookup:
 		return mapper.MapLookup(value)
 
-	case Map:
+	case *Map:
 		return mapper.MapMap(value)
 
-	case Model:
+	case *MethodCall:
+		return mapper.MapMethodCall(value)
+
+	case *Model:
 		return mapper.MapModel(value)
 
-	case ModelDef:
+	case *ModelDef:
 		return mapper.MapModelDef(value)
 
-	case Module:
+	case *Module:
 		return mapper.MapModule(value)
 
-	case New:
+	case *New:
 		return mapper.MapNew(value)
 
-	case Nil:
+	case *Nil:
 		return mapper.MapNil(value)
 
-	case Pop:
+	case *Pop:
 		return mapper.MapPop(value)
 
-	case Property:
+	case *Property:
 		return mapper.MapProperty(value)
 
-	case Push:
+	case *Push:
 		return mapper.MapPush(value)
 
-	case Return:
+	case *Return:
 		return mapper.MapReturn(value)
 
-	case Root:
+	case *Root:
 		return mapper.MapRoot(value)
 
-	case Rune:
+	case *Rune:
 		return mapper.MapRune(value)
 
-	case Self:
+	case *Self:
 		return mapper.MapSelf(value)
 
-	case Set:
+	case *Set:
 		return mapper.MapSet(value)
 
-	case SetContains:
+	case *SetContains:
 		return mapper.MapSetContains(value)
 
-	case String:
+	case *String:
 		return mapper.MapString(value)
 
-	case Variable:
+	case *Uint8:
+		return mapper.MapUint8(value)
+
+	case *Unary:
+		return mapper.MapUnary(value)
+
+	case *Variable:
 		return mapper.MapVariable(value)
 
-	case Void:
+	case *Void:
 		return mapper.MapVoid(value)
 
 	default:
@@ -273,253 +348,328 @@ func MapEachNode[T any](nodes []Node, mapper NodeMapper[T]) ([]T, error) {
 }
 
 type NodeMapperNoError[T any] interface {
-	MapAddToSet(value AddToSet) T
+	MapAddToSet(value *AddToSet) T
+
+	MapArgumentDef(value *ArgumentDef) T
+
+	MapAssignment(value *Assignment) T
+
+	MapBinary(value *Binary) T
+
+	MapBlock(value *Block) T
+
+	MapBool(value *Bool) T
+
+	MapBreak(value *Break) T
+
+	MapBytes(value *Bytes) T
+
+	MapCall(value *Call) T
+
+	MapComparison(value *Comparison) T
+
+	MapConditional(value *Conditional) T
+
+	MapConstantDef(value *ConstantDef) T
 
-	MapArgumentDef(value ArgumentDef) T
+	MapContinue(value *Continue) T
 
-	MapAssignment(value Assignment) T
+	MapConversion(value *Conversion) T
 
-	MapBlock(value Block) T
+	MapDeclare(value *Declare) T
 
-	MapBool(value Bool) T
+	MapEmptyList(value *EmptyList) T
 
-	MapBreak(value Break) T
+	MapEqualOverride(value *EqualOverride) T
 
-	MapCall(value Call) T
+	MapFieldDef(value *FieldDef) T
 
-	MapConditional(value Conditional) T
+	MapFloat64(value *Float64) T
 
-	MapConstantDef(value ConstantDef) T
+	MapFor(value *For) T
 
-	MapContinue(value Continue) T
+	MapForEach(value *ForEach) T
 
-	MapDeclare(value Declare) T
+	MapFunctionDef(value *FunctionDef) T
 
-	MapEmptyList(value EmptyList) T
+	MapFunctionRef(value *FunctionRef) T
 
-	MapEqualOverride(value EqualOverride) T
+	MapHashOverride(value *HashOverride) T
 
-	MapFieldDef(value FieldDef) T
+	MapIf(value *If) T
 
-	MapFor(value For) T
+	MapImport(value *Import) T
 
-	MapForEach(value ForEach) T
+	MapInt32(value *Int32) T
 
-	MapFunctionDef(value FunctionDef) T
+	MapInt64(value *Int64) T
 
-	MapHashOverride(value HashOverride) T
+	MapKeyValue(value *KeyValue) T
 
-	MapIf(value If) T
+	MapLength(value *Length) T
 
-	MapInt64(value Int64) T
+	MapList(value *List) T
 
-	MapKeyValue(value KeyValue) T
+	MapLiteralBool(value *LiteralBool) T
 
-	MapLength(value Length) T
+	MapLiteralBytes(value *LiteralBytes) T
 
-	MapList(value List) T
+	MapLiteralFloat64(value *LiteralFloat64) T
 
-	MapLiteralBool(value LiteralBool) T
+	MapLiteralInt32(value *LiteralInt32) T
 
-	MapLiteralInt64(value LiteralInt64) T
+	MapLiteralInt64(value *LiteralInt64) T
 
-	MapLiteralList(value LiteralList) T
+	MapLiteralList(value *LiteralList) T
 
-	MapLiteralMap(value LiteralMap) T
+	MapLiteralMap(value *LiteralMap) T
 
-	MapLiteralRune(value LiteralRune) T
+	MapLiteralRune(value *LiteralRune) T
 
-	MapLiteralSet(value LiteralSet) T
+	MapLiteralSet(value *LiteralSet) T
 
-	MapLiteralString(value LiteralString) T
+	MapLiteralString(value *LiteralString) T
 
-	MapLookup(value Lookup) T
+	MapLiteralUint8(value *LiteralUint8) T
 
-	MapMap(value Map) T
+	MapLookup(value *Lookup) T
 
-	MapModel(value Model) T
+	MapMap(value *Map) T
 
-	MapModelDef(value ModelDef) T
+	MapMethodCall(value *MethodCall) T
 
-	MapModule(value Module) T
+	MapModel(value *Model) T
 
-	MapNew(value New) T
+	MapModelDef(value *ModelDef) T
 
-	MapNil(value Nil) T
+	MapModule(value *Module) T
 
-	MapPop(value Pop) T
+	MapNew(value *New) T
 
-	MapProperty(value Property) T
+	MapNil(value *Nil) T
 
-	MapPush(value Push) T
+	MapPop(value *Pop) T
 
-	MapReturn(value Return) T
+	MapProperty(value *Property) T
 
-	MapRoot(value Root) T
+	MapPush(value *Push) T
 
-	MapRune(value Rune) T
+	MapReturn(value *Return) T
 
-	MapSelf(value Self) T
+	MapRoot(value *Root) T
 
-	MapSet(value Set) T
+	MapRune(value *Rune) T
 
-	MapSetContains(value SetContains) T
+	MapSelf(value *Self) T
 
-	MapString(value String) T
+	MapSet(value *Set) T
 
-	MapVariable(value Variable) T
+	MapSetContains(value *SetContains) T
 
-	MapVoid(value Void) T
+	MapString(value *String) T
+
+	MapUint8(value *Uint8) T
+
+	MapUnary(value *Unary) T
+
+	MapVariable(value *Variable) T
+
+	MapVoid(value *Void) T
 }
 
 func MapNodeNoError[T any](node Node, mapper NodeMapperNoError[T]) T {
 	switch value := node.(type) {
 
-	case AddToSet:
+	case *AddToSet:
 		return mapper.MapAddToSet(value)
 
-	case ArgumentDef:
+	case *ArgumentDef:
 		return mapper.MapArgumentDef(value)
 
-	case Assignment:
+	case *Assignment:
 		return mapper.MapAssignment(value)
 
-	case Block:
+	case *Binary:
+		return mapper.MapBinary(value)
+
+	case *Block:
 		return mapper.MapBlock(value)
 
-	case Bool:
+	case *Bool:
 		return mapper.MapBool(value)
 
-	case Break:
+	case *Break:
 		return mapper.MapBreak(value)
 
-	case Call:
+	case *Bytes:
+		return mapper.MapBytes(value)
+
+	case *Call:
 		return mapper.MapCall(value)
 
-	case Conditional:
+	case *Comparison:
+		return mapper.MapComparison(value)
+
+	case *Conditional:
 		return mapper.MapConditional(value)
 
-	case ConstantDef:
+	case *ConstantDef:
 		return mapper.MapConstantDef(value)
 
-	case Continue:
+	case *Continue:
 		return mapper.MapContinue(value)
 
-	case Declare:
+	case *Conversion:
+		return mapper.MapConversion(value)
+
+	case *Declare:
 		return mapper.MapDeclare(value)
 
-	case EmptyList:
+	case *EmptyList:
 		return mapper.MapEmptyList(value)
 
-	case EqualOverride:
+	case *EqualOverride:
 		return mapper.MapEqualOverride(value)
 
-	case FieldDef:
+	case *FieldDef:
 		return mapper.MapFieldDef(value)
 
-	case For:
+	case *Float64:
+		return mapper.MapFloat64(value)
+
+	case *For:
 		return mapper.MapFor(value)
 
-	case ForEach:
+	case *ForEach:
 		return mapper.MapForEach(value)
 
-	case FunctionDef:
+	case *FunctionDef:
 		return mapper.MapFunctionDef(value)
 
-	case HashOverride:
+	case *FunctionRef:
+		return mapper.MapFunctionRef(value)
+
+	case *HashOverride:
 		return mapper.MapHashOverride(value)
 
-	case If:
+	case *If:
 		return mapper.MapIf(value)
 
-	case Int64:
+	case *Import:
+		return mapper.MapImport(value)
+
+	case *Int32:
+		return mapper.MapInt32(value)
+
+	case *Int64:
 		return mapper.MapInt64(value)
 
-	case KeyValue:
+	case *KeyValue:
 		return mapper.MapKeyValue(value)
 
-	case Length:
+	case *Length:
 		return mapper.MapLength(value)
 
-	case List:
+	case *List:
 		return mapper.MapList(value)
 
-	case LiteralBool:
+	case *LiteralBool:
 		return mapper.MapLiteralBool(value)
 
-	case LiteralInt64:
+	case *LiteralBytes:
+		return mapper.MapLiteralBytes(value)
+
+	case *LiteralFloat64:
+		return mapper.MapLiteralFloat64(value)
+
+	case *LiteralInt32:
+		return mapper.MapLiteralInt32(value)
+
+	case *LiteralInt64:
 		return mapper.MapLiteralInt64(value)
 
-	case LiteralList:
+	case *LiteralList:
 		return mapper.MapLiteralList(value)
 
-	case LiteralMap:
+	case *LiteralMap:
 		return mapper.MapLiteralMap(value)
 
-	case LiteralRune:
+	case *LiteralRune:
 		return mapper.MapLiteralRune(value)
 
-	case LiteralSet:
+	case *LiteralSet:
 		return mapper.MapLiteralSet(value)
 
-	case LiteralString:
+	case *LiteralString:
 		return mapper.MapLiteralString(value)
 
-	case Lookup:
+	case *LiteralUint8:
+		return mapper.MapLiteralUint8(value)
+
+	case *Lookup:
 		return mapper.MapLookup(value)
 
-	case Map:
+	case *Map:
 		return mapper.MapMap(value)
 
-	case Model:
+	case *MethodCall:
+		return mapper.MapMethodCall(value)
+
+	case *Model:
 		return mapper.MapModel(value)
 
-	case ModelDef:
+	case *ModelDef:
 		return mapper.MapModelDef(value)
 
-	case Module:
+	case *Module:
 		return mapper.MapModule(value)
 
-	case New:
+	case *New:
 		return mapper.MapNew(value)
 
-	case Nil:
+	case *Nil:
 		return mapper.MapNil(value)
 
-	case Pop:
+	case *Pop:
 		return mapper.MapPop(value)
 
-	case Property:
+	case *Property:
 		return mapper.MapProperty(value)
 
-	case Push:
+	case *Push:
 		return mapper.MapPush(value)
 
-	case Return:
+	case *Return:
 		return mapper.MapReturn(value)
 
-	case Root:
+	case *Root:
 		return mapper.MapRoot(value)
 
-	case Rune:
+	case *Rune:
 		return mapper.MapRune(value)
 
-	case Self:
+	case *Self:
 		return mapper.MapSelf(value)
 
-	case Set:
+	case *Set:
 		return mapper.MapSet(value)
 
-	case SetContains:
+	case *SetContains:
 		return mapper.MapSetContains(value)
 
-	case String:
+	case *String:
 		return mapper.MapString(value)
 
-	case Variable:
+	case *Uint8:
+		return mapper.MapUint8(value)
+
+	case *Unary:
+		return mapper.MapUnary(value)
+
+	case *Variable:
 		return mapper.MapVariable(value)
 
-	case Void:
+	case *Void:
 		return mapper.MapVoid(value)
 
 	default:
@@ -538,253 +688,328 @@ func MapEachNodeNoError[T any](nodes []Node, mapper NodeMapperNoError[T]) []T {
 }
 
 type NodeMapperOnlyError interface {
-	MapAddToSet(value AddToSet) error
+	MapAddToSet(value *AddToSet) error
+
+	MapArgumentDef(value *ArgumentDef) error
+
+	MapAssignment(value *Assignment) error
+
+	MapBinary(value *Binary) error
+
+	MapBlock(value *Block) error
+
+	MapBool(value *Bool) error
+
+	MapBreak(value *Break) error
+
+	MapBytes(value *Bytes) error
+
+	MapCall(value *Call) error
 
-	MapArgumentDef(value ArgumentDef) error
+	MapComparison(value *Comparison) error
 
-	MapAssignment(value Assignment) error
+	MapConditional(value *Conditional) error
 
-	MapBlock(value Block) error
+	MapConstantDef(value *ConstantDef) error
 
-	MapBool(value Bool) error
+	MapContinue(value *Continue) error
 
-	MapBreak(value Break) error
+	MapConversion(value *Conversion) error
 
-	MapCall(value Call) error
+	MapDeclare(value *Declare) error
 
-	MapConditional(value Conditional) error
+	MapEmptyList(value *EmptyList) error
 
-	MapConstantDef(value ConstantDef) error
+	MapEqualOverride(value *EqualOverride) error
 
-	MapContinue(value Continue) error
+	MapFieldDef(value *FieldDef) error
 
-	MapDeclare(value Declare) error
+	MapFloat64(value *Float64) error
 
-	MapEmptyList(value EmptyList) error
+	MapFor(value *For) error
 
-	MapEqualOverride(value EqualOverride) error
+	MapForEach(value *ForEach) error
 
-	MapFieldDef(value FieldDef) error
+	MapFunctionDef(value *FunctionDef) error
 
-	MapFor(value For) error
+	MapFunctionRef(value *FunctionRef) error
 
-	MapForEach(value ForEach) error
+	MapHashOverride(value *HashOverride) error
 
-	MapFunctionDef(value FunctionDef) error
+	MapIf(value *If) error
 
-	MapHashOverride(value HashOverride) error
+	MapImport(value *Import) error
 
-	MapIf(value If) error
+	MapInt32(value *Int32) error
 
-	MapInt64(value Int64) error
+	MapInt64(value *Int64) error
 
-	MapKeyValue(value KeyValue) error
+	MapKeyValue(value *KeyValue) error
 
-	MapLength(value Length) error
+	MapLength(value *Length) error
 
-	MapList(value List) error
+	MapList(value *List) error
 
-	MapLiteralBool(value LiteralBool) error
+	MapLiteralBool(value *LiteralBool) error
 
-	MapLiteralInt64(value LiteralInt64) error
+	MapLiteralBytes(value *LiteralBytes) error
 
-	MapLiteralList(value LiteralList) error
+	MapLiteralFloat64(value *LiteralFloat64) error
 
-	MapLiteralMap(value LiteralMap) error
+	MapLiteralInt32(value *LiteralInt32) error
 
-	MapLiteralRune(value LiteralRune) error
+	MapLiteralInt64(value *LiteralInt64) error
 
-	MapLiteralSet(value LiteralSet) error
+	MapLiteralList(value *LiteralList) error
 
-	MapLiteralString(value LiteralString) error
+	MapLiteralMap(value *LiteralMap) error
 
-	MapLookup(value Lookup) error
+	MapLiteralRune(value *LiteralRune) error
 
-	MapMap(value Map) error
+	MapLiteralSet(value *LiteralSet) error
 
-	MapModel(value Model) error
+	MapLiteralString(value *LiteralString) error
 
-	MapModelDef(value ModelDef) error
+	MapLiteralUint8(value *LiteralUint8) error
 
-	MapModule(value Module) error
+	MapLookup(value *Lookup) error
 
-	MapNew(value New) error
+	MapMap(value *Map) error
 
-	MapNil(value Nil) error
+	MapMethodCall(value *MethodCall) error
 
-	MapPop(value Pop) error
+	MapModel(value *Model) error
 
-	MapProperty(value Property) error
+	MapModelDef(value *ModelDef) error
 
-	MapPush(value Push) error
+	MapModule(value *Module) error
 
-	MapReturn(value Return) error
+	MapNew(value *New) error
 
-	MapRoot(value Root) error
+	MapNil(value *Nil) error
 
-	MapRune(value Rune) error
+	MapPop(value *Pop) error
 
-	MapSelf(value Self) error
+	MapProperty(value *Property) error
 
-	MapSet(value Set) error
+	MapPush(value *Push) error
 
-	MapSetContains(value SetContains) error
+	MapReturn(value *Return) error
 
-	MapString(value String) error
+	MapRoot(value *Root) error
 
-	MapVariable(value Variable) error
+	MapRune(value *Rune) error
 
-	MapVoid(value Void) error
+	MapSelf(value *Self) error
+
+	MapSet(value *Set) error
+
+	MapSetContains(value *SetContains) error
+
+	MapString(value *String) error
+
+	MapUint8(value *Uint8) error
+
+	MapUnary(value *Unary) error
+
+	MapVariable(value *Variable) error
+
+	MapVoid(value *Void) error
 }
 
 func MapNodeOnlyError(node Node, mapper NodeMapperOnlyError) error {
 	switch value := node.(type) {
 
-	case AddToSet:
+	case *AddToSet:
 		return mapper.MapAddToSet(value)
 
-	case ArgumentDef:
+	case *ArgumentDef:
 		return mapper.MapArgumentDef(value)
 
-	case Assignment:
+	case *Assignment:
 		return mapper.MapAssignment(value)
 
-	case Block:
+	case *Binary:
+		return mapper.MapBinary(value)
+
+	case *Block:
 		return mapper.MapBlock(value)
 
-	case Bool:
+	case *Bool:
 		return mapper.MapBool(value)
 
-	case Break:
+	case *Break:
 		return mapper.MapBreak(value)
 
-	case Call:
+	case *Bytes:
+		return mapper.MapBytes(value)
+
+	case *Call:
 		return mapper.MapCall(value)
 
-	case Conditional:
+	case *Comparison:
+		return mapper.MapComparison(value)
+
+	case *Conditional:
 		return mapper.MapConditional(value)
 
-	case ConstantDef:
+	case *ConstantDef:
 		return mapper.MapConstantDef(value)
 
-	case Continue:
+	case *Continue:
 		return mapper.MapContinue(value)
 
-	case Declare:
+	case *Conversion:
+		return mapper.MapConversion(value)
+
+	case *Declare:
 		return mapper.MapDeclare(value)
 
-	case EmptyList:
+	case *EmptyList:
 		return mapper.MapEmptyList(value)
 
-	case EqualOverride:
+	case *EqualOverride:
 		return mapper.MapEqualOverride(value)
 
-	case FieldDef:
+	case *FieldDef:
 		return mapper.MapFieldDef(value)
 
-	case For:
+	case *Float64:
+		return mapper.MapFloat64(value)
+
+	case *For:
 		return mapper.MapFor(value)
 
-	case ForEach:
+	case *ForEach:
 		return mapper.MapForEach(value)
 
-	case FunctionDef:
+	case *FunctionDef:
 		return mapper.MapFunctionDef(value)
 
-	case HashOverride:
+	case *FunctionRef:
+		return mapper.MapFunctionRef(value)
+
+	case *HashOverride:
 		return mapper.MapHashOverride(value)
 
-	case If:
+	case *If:
 		return mapper.MapIf(value)
 
-	case Int64:
+	case *Import:
+		return mapper.MapImport(value)
+
+	case *Int32:
+		return mapper.MapInt32(value)
+
+	case *Int64:
 		return mapper.MapInt64(value)
 
-	case KeyValue:
+	case *KeyValue:
 		return mapper.MapKeyValue(value)
 
-	case Length:
+	case *Length:
 		return mapper.MapLength(value)
 
-	case List:
+	case *List:
 		return mapper.MapList(value)
 
-	case LiteralBool:
+	case *LiteralBool:
 		return mapper.MapLiteralBool(value)
 
-	case LiteralInt64:
+	case *LiteralBytes:
+		return mapper.MapLiteralBytes(value)
+
+	case *LiteralFloat64:
+		return mapper.MapLiteralFloat64(value)
+
+	case *LiteralInt32:
+		return mapper.MapLiteralInt32(value)
+
+	case *LiteralInt64:
 		return mapper.MapLiteralInt64(value)
 
-	case LiteralList:
+	case *LiteralList:
 		return mapper.MapLiteralList(value)
 
-	case LiteralMap:
+	case *LiteralMap:
 		return mapper.MapLiteralMap(value)
 
-	case LiteralRune:
+	case *LiteralRune:
 		return mapper.MapLiteralRune(value)
 
-	case LiteralSet:
+	case *LiteralSet:
 		return mapper.MapLiteralSet(value)
 
-	case LiteralString:
+	case *LiteralString:
 		return mapper.MapLiteralString(value)
 
-	case Lookup:
+	case *LiteralUint8:
+		return mapper.MapLiteralUint8(value)
+
+	case *Lookup:
 		return mapper.MapLookup(value)
 
-	case Map:
+	case *Map:
 		return mapper.MapMap(value)
 
-	case Model:
+	case *MethodCall:
+		return mapper.MapMethodCall(value)
+
+	case *Model:
 		return mapper.MapModel(value)
 
-	case ModelDef:
+	case *ModelDef:
 		return mapper.MapModelDef(value)
 
-	case Module:
+	case *Module:
 		return mapper.MapModule(value)
 
-	case New:
+	case *New:
 		return mapper.MapNew(value)
 
-	case Nil:
+	case *Nil:
 		return mapper.MapNil(value)
 
-	case Pop:
+	case *Pop:
 		return mapper.MapPop(value)
 
-	case Property:
+	case *Property:
 		return mapper.MapProperty(value)
 
-	case Push:
+	case *Push:
 		return mapper.MapPush(value)
 
-	case Return:
+	case *Return:
 		return mapper.MapReturn(value)
 
-	case Root:
+	case *Root:
 		return mapper.MapRoot(value)
 
-	case Rune:
+	case *Rune:
 		return mapper.MapRune(value)
 
-	case Self:
+	case *Self:
 		return mapper.MapSelf(value)
 
-	case Set:
+	case *Set:
 		return mapper.MapSet(value)
 
-	case SetContains:
+	case *SetContains:
 		return mapper.MapSetContains(value)
 
-	case String:
+	case *String:
 		return mapper.MapString(value)
 
-	case Variable:
+	case *Uint8:
+		return mapper.MapUint8(value)
+
+	case *Unary:
+		return mapper.MapUnary(value)
+
+	case *Variable:
 		return mapper.MapVariable(value)
 
-	case Void:
+	case *Void:
 		return mapper.MapVoid(value)
 
 	default:
@@ -794,7 +1019,7 @@ func MapNodeOnlyError(node Node, mapper NodeMapperOnlyError) error {
 
 func MapEachNodeOnlyError(nodes []Node, mapper NodeMapperOnlyError) error {
 	for _, node := range nodes {
-		err := MapNodeNoError(node, mapper)
+		err := MapNodeOnlyError(node, mapper)
 		if err != nil {
 			return err
 		}
@@ -804,18 +1029,18 @@ func MapEachNodeOnlyError(nodes []Node, mapper NodeMapperOnlyError) error {
 }
 
 type AssignableMapper[T any] interface {
-	MapProperty(value Property) (T, error)
+	MapProperty(value *Property) (T, error)
 
-	MapVariable(value Variable) (T, error)
+	MapVariable(value *Variable) (T, error)
 }
 
 func MapAssignable[T any](node Assignable, mapper AssignableMapper[T]) (T, error) {
 	switch value := node.(type) {
 
-	case Property:
+	case *Property:
 		return mapper.MapProperty(value)
 
-	case Variable:
+	case *Variable:
 		return mapper.MapVariable(value)
 
 	default:
@@ -838,18 +1063,18 @@ func MapEachAssignable[T any](nodes []Assignable, mapper AssignableMapper[T]) ([
 }
 
 type AssignableMapperNoError[T any] interface {
-	MapProperty(value Property) T
+	MapProperty(value *Property) T
 
-	MapVariable(value Variable) T
+	MapVariable(value *Variable) T
 }
 
 func MapAssignableNoError[T any](node Assignable, mapper AssignableMapperNoError[T]) T {
 	switch value := node.(type) {
 
-	case Property:
+	case *Property:
 		return mapper.MapProperty(value)
 
-	case Variable:
+	case *Variable:
 		return mapper.MapVariable(value)
 
 	default:
@@ -868,18 +1093,18 @@ func MapEachAssignableNoError[T any](nodes []Assignable, mapper AssignableMapper
 }
 
 type AssignableMapperOnlyError interface {
-	MapProperty(value Property) error
+	MapProperty(value *Property) error
 
-	MapVariable(value Variable) error
+	MapVariable(value *Variable) error
 }
 
 func MapAssignableOnlyError(node Assignable, mapper AssignableMapperOnlyError) error {
 	switch value := node.(type) {
 
-	case Property:
+	case *Property:
 		return mapper.MapProperty(value)
 
-	case Variable:
+	case *Variable:
 		return mapper.MapVariable(value)
 
 	default:
@@ -889,7 +1114,7 @@ func MapAssignableOnlyError(node Assignable, mapper AssignableMapperOnlyError) e
 
 func MapEachAssignableOnlyError(nodes []Assignable, mapper AssignableMapperOnlyError) error {
 	for _, node := range nodes {
-		err := MapAssignableNoError(node, mapper)
+		err := MapAssignableOnlyError(node, mapper)
 		if err != nil {
 			return err
 		}
@@ -899,15 +1124,20 @@ func MapEachAssignableOnlyError(nodes []Assignable, mapper AssignableMapperOnlyE
 }
 
 type CallableMapper[T any] interface {
-	MapFunctionDef(value FunctionDef) (T, error)
+	MapFunctionDef(value *FunctionDef) (T, error)
+
+	MapFunctionRef(value *FunctionRef) (T, error)
 }
 
 func MapCallable[T any](node Callable, mapper CallableMapper[T]) (T, error) {
 	switch value := node.(type) {
 
-	case FunctionDef:
+	case *FunctionDef:
 		return mapper.MapFunctionDef(value)
 
+	case *FunctionRef:
+		return mapper.MapFunctionRef(value)
+
 	default:
 		panic("unreachable")
 	}
@@ -928,15 +1158,20 @@ func MapEachCallable[T any](nodes []Callable, mapper CallableMapper[T]) ([]T, er
 }
 
 type CallableMapperNoError[T any] interface {
-	MapFunctionDef(value FunctionDef) T
+	MapFunctionDef(value *FunctionDef) T
+
+	MapFunctionRef(value *FunctionRef) T
 }
 
 func MapCallableNoError[T any](node Callable, mapper CallableMapperNoError[T]) T {
 	switch value := node.(type) {
 
-	case FunctionDef:
+	case *FunctionDef:
 		return mapper.MapFunctionDef(value)
 
+	case *FunctionRef:
+		return mapper.MapFunctionRef(value)
+
 	default:
 		panic("unreachable")
 	}
@@ -953,15 +1188,20 @@ func MapEachCallableNoError[T any](nodes []Callable, mapper CallableMapperNoErro
 }
 
 type CallableMapperOnlyError interface {
-	MapFunctionDef(value FunctionDef) error
+	MapFunctionDef(value *FunctionDef) error
+
+	MapFunctionRef(value *FunctionRef) error
 }
 
 func MapCallableOnlyError(node Callable, mapper CallableMapperOnlyError) error {
 	switch value := node.(type) {
 
-	case FunctionDef:
+	case *FunctionDef:
 		return mapper.MapFunctionDef(value)
 
+	case *FunctionRef:
+		return mapper.MapFunctionRef(value)
+
 	default:
 		panic("unreachable")
 	}
@@ -969,7 +1209,7 @@ func MapCallableOnlyError(node Callable, mapper CallableMapperOnlyError) error {
 
 func MapEachCallableOnlyError(nodes []Callable, mapper CallableMapperOnlyError) error {
 	for _, node := range nodes {
-		err := MapCallableNoError(node, mapper)
+		err := MapCallableOnlyError(node, mapper)
 		if err != nil {
 			return err
 		}
@@ -979,53 +1219,73 @@ func MapEachCallableOnlyError(nodes []Callable, mapper CallableMapperOnlyError)
 }
 
 type ConstantValueMapper[T any] interface {
-	MapEmptyList(value EmptyList) (T, error)
+	MapEmptyList(value *EmptyList) (T, error)
+
+	MapLiteralBool(value *LiteralBool) (T, error)
+
+	MapLiteralBytes(value *LiteralBytes) (T, error)
 
-	MapLiteralBool(value LiteralBool) (T, error)
+	MapLiteralFloat64(value *LiteralFloat64) (T, error)
 
-	MapLiteralInt64(value LiteralInt64) (T, error)
+	MapLiteralInt32(value *LiteralInt32) (T, error)
 
-	MapLiteralList(value LiteralList) (T, error)
+	MapLiteralInt64(value *LiteralInt64) (T, error)
 
-	MapLiteralMap(value LiteralMap) (T, error)
+	MapLiteralList(value *LiteralList) (T, error)
 
-	MapLiteralRune(value LiteralRune) (T, error)
+	MapLiteralMap(value *LiteralMap) (T, error)
 
-	MapLiteralSet(value LiteralSet) (T, error)
+	MapLiteralRune(value *LiteralRune) (T, error)
 
-	MapLiteralString(value LiteralString) (T, error)
+	MapLiteralSet(value *LiteralSet) (T, error)
 
-	MapNil(value Nil) (T, error)
+	MapLiteralString(value *LiteralString) (T, error)
+
+	MapLiteralUint8(value *LiteralUint8) (T, error)
+
+	MapNil(value *Nil) (T, error)
 }
 
 func MapConstantValue[T any](node ConstantValue, mapper ConstantValueMapper[T]) (T, error) {
 	switch value := node.(type) {
 
-	case EmptyList:
+	case *EmptyList:
 		return mapper.MapEmptyList(value)
 
-	case LiteralBool:
+	case *LiteralBool:
 		return mapper.MapLiteralBool(value)
 
-	case LiteralInt64:
+	case *LiteralBytes:
+		return mapper.MapLiteralBytes(value)
+
+	case *LiteralFloat64:
+		return mapper.MapLiteralFloat64(value)
+
+	case *LiteralInt32:
+		return mapper.MapLiteralInt32(value)
+
+	case *LiteralInt64:
 		return mapper.MapLiteralInt64(value)
 
-	case LiteralList:
+	case *LiteralList:
 		return mapper.MapLiteralList(value)
 
-	case LiteralMap:
+	case *LiteralMap:
 		return mapper.MapLiteralMap(value)
 
-	case LiteralRune:
+	case *LiteralRune:
 		return mapper.MapLiteralRune(value)
 
-	case LiteralSet:
+	case *LiteralSet:
 		return mapper.MapLiteralSet(value)
 
-	case LiteralString:
+	case *LiteralString:
 		return mapper.MapLiteralString(value)
 
-	case Nil:
+	case *LiteralUint8:
+		return mapper.MapLiteralUint8(value)
+
+	case *Nil:
 		return mapper.MapNil(value)
 
 	default:
@@ -1048,53 +1308,73 @@ func MapEachConstantValue[T any](nodes []ConstantValue, mapper ConstantValueMapp
 }
 
 type ConstantValueMapperNoError[T any] interface {
-	MapEmptyList(value EmptyList) T
+	MapEmptyList(value *EmptyList) T
+
+	MapLiteralBool(value *LiteralBool) T
+
+	MapLiteralBytes(value *LiteralBytes) T
+
+	MapLiteralFloat64(value *LiteralFloat64) T
 
-	MapLiteralBool(value LiteralBool) T
+	MapLiteralInt32(value *LiteralInt32) T
 
-	MapLiteralInt64(value LiteralInt64) T
+	MapLiteralInt64(value *LiteralInt64) T
 
-	MapLiteralList(value LiteralList) T
+	MapLiteralList(value *LiteralList) T
 
-	MapLiteralMap(value LiteralMap) T
+	MapLiteralMap(value *LiteralMap) T
 
-	MapLiteralRune(value LiteralRune) T
+	MapLiteralRune(value *LiteralRune) T
 
-	MapLiteralSet(value LiteralSet) T
+	MapLiteralSet(value *LiteralSet) T
 
-	MapLiteralString(value LiteralString) T
+	MapLiteralString(value *LiteralString) T
 
-	MapNil(value Nil) T
+	MapLiteralUint8(value *LiteralUint8) T
+
+	MapNil(value *Nil) T
 }
 
 func MapConstantValueNoError[T any](node ConstantValue, mapper ConstantValueMapperNoError[T]) T {
 	switch value := node.(type) {
 
-	case EmptyList:
+	case *EmptyList:
 		return mapper.MapEmptyList(value)
 
-	case LiteralBool:
+	case *LiteralBool:
 		return mapper.MapLiteralBool(value)
 
-	case LiteralInt64:
+	case *LiteralBytes:
+		return mapper.MapLiteralBytes(value)
+
+	case *LiteralFloat64:
+		return mapper.MapLiteralFloat64(value)
+
+	case *LiteralInt32:
+		return mapper.MapLiteralInt32(value)
+
+	case *LiteralInt64:
 		return mapper.MapLiteralInt64(value)
 
-	case LiteralList:
+	case *LiteralList:
 		return mapper.MapLiteralList(value)
 
-	case LiteralMap:
+	case *LiteralMap:
 		return mapper.MapLiteralMap(value)
 
-	case LiteralRune:
+	case *LiteralRune:
 		return mapper.MapLiteralRune(value)
 
-	case LiteralSet:
+	case *LiteralSet:
 		return mapper.MapLiteralSet(value)
 
-	case LiteralString:
+	case *LiteralString:
 		return mapper.MapLiteralString(value)
 
-	case Nil:
+	case *LiteralUint8:
+		return mapper.MapLiteralUint8(value)
+
+	case *Nil:
 		return mapper.MapNil(value)
 
 	default:
@@ -1113,53 +1393,73 @@ func MapEachConstantValueNoError[T any](nodes []ConstantValue, mapper ConstantVa
 }
 
 type ConstantValueMapperOnlyError interface {
-	MapEmptyList(value EmptyList) error
+	MapEmptyList(value *EmptyList) error
+
+	MapLiteralBool(value *LiteralBool) error
+
+	MapLiteralBytes(value *LiteralBytes) error
 
-	MapLiteralBool(value LiteralBool) error
+	MapLiteralFloat64(value *LiteralFloat64) error
 
-	MapLiteralInt64(value LiteralInt64) error
+	MapLiteralInt32(value *LiteralInt32) error
 
-	MapLiteralList(value LiteralList) error
+	MapLiteralInt64(value *LiteralInt64) error
 
-	MapLiteralMap(value LiteralMap) error
+	MapLiteralList(value *LiteralList) error
 
-	MapLiteralRune(value LiteralRune) error
+	MapLiteralMap(value *LiteralMap) error
 
-	MapLiteralSet(value LiteralSet) error
+	MapLiteralRune(value *LiteralRune) error
 
-	MapLiteralString(value LiteralString) error
+	MapLiteralSet(value *LiteralSet) error
 
-	MapNil(value Nil) error
+	MapLiteralString(value *LiteralString) error
+
+	MapLiteralUint8(value *LiteralUint8) error
+
+	MapNil(value *Nil) error
 }
 
 func MapConstantValueOnlyError(node ConstantValue, mapper ConstantValueMapperOnlyError) error {
 	switch value := node.(type) {
 
-	case EmptyList:
+	case *EmptyList:
 		return mapper.MapEmptyList(value)
 
-	case LiteralBool:
+	case *LiteralBool:
 		return mapper.MapLiteralBool(value)
 
-	case LiteralInt64:
+	case *LiteralBytes:
+		return mapper.MapLiteralBytes(value)
+
+	case *LiteralFloat64:
+		return mapper.MapLiteralFloat64(value)
+
+	case *LiteralInt32:
+		return mapper.MapLiteralInt32(value)
+
+	case *LiteralInt64:
 		return mapper.MapLiteralInt64(value)
 
-	case LiteralList:
+	case *LiteralList:
 		return mapper.MapLiteralList(value)
 
-	case LiteralMap:
+	case *LiteralMap:
 		return mapper.MapLiteralMap(value)
 
-	case LiteralRune:
+	case *LiteralRune:
 		return mapper.MapLiteralRune(value)
 
-	case LiteralSet:
+	case *LiteralSet:
 		return mapper.MapLiteralSet(value)
 
-	case LiteralString:
+	case *LiteralString:
 		return mapper.MapLiteralString(value)
 
-	case Nil:
+	case *LiteralUint8:
+		return mapper.MapLiteralUint8(value)
+
+	case *Nil:
 		return mapper.MapNil(value)
 
 	default:
@@ -1169,7 +1469,7 @@ func MapConstantValueOnlyError(node ConstantValue, mapper ConstantValueMapperOnl
 
 func MapEachConstantValueOnlyError(nodes []ConstantValue, mapper ConstantValueMapperOnlyError) error {
 	for _, node := range nodes {
-		err := MapConstantValueNoError(node, mapper)
+		err := MapConstantValueOnlyError(node, mapper)
 		if err != nil {
 			return err
 		}
@@ -1179,33 +1479,38 @@ func MapEachConstantValueOnlyError(nodes []ConstantValue, mapper ConstantValueMa
 }
 
 type DefinitionMapper[T any] interface {
-	MapArgumentDef(value ArgumentDef) (T, error)
+	MapArgumentDef(value *ArgumentDef) (T, error)
+
+	MapConstantDef(value *ConstantDef) (T, error)
 
-	MapConstantDef(value ConstantDef) (T, error)
+	MapDeclare(value *Declare) (T, error)
 
-	MapDeclare(value Declare) (T, error)
+	MapEqualOverride(value *EqualOverride) (T, error)
 
-	MapFieldDef(value FieldDef) (T, error)
+	MapFieldDef(value *FieldDef) (T, error)
 
-	MapForEach(value ForEach) (T, error)
+	MapForEach(value *ForEach) (T, error)
 }
 
 func MapDefinition[T any](node Definition, mapper DefinitionMapper[T]) (T, error) {
 	switch value := node.(type) {
 
-	case ArgumentDef:
+	case *ArgumentDef:
 		return mapper.MapArgumentDef(value)
 
-	case ConstantDef:
+	case *ConstantDef:
 		return mapper.MapConstantDef(value)
 
-	case Declare:
+	case *Declare:
 		return mapper.MapDeclare(value)
 
-	case FieldDef:
+	case *EqualOverride:
+		return mapper.MapEqualOverride(value)
+
+	case *FieldDef:
 		return mapper.MapFieldDef(value)
 
-	case ForEach:
+	case *ForEach:
 		return mapper.MapForEach(value)
 
 	default:
@@ -1228,33 +1533,38 @@ func MapEachDefinition[T any](nodes []Definition, mapper DefinitionMapper[T]) ([
 }
 
 type DefinitionMapperNoError[T any] interface {
-	MapArgumentDef(value ArgumentDef) T
+	MapArgumentDef(value *ArgumentDef) T
 
-	MapConstantDef(value ConstantDef) T
+	MapConstantDef(value *ConstantDef) T
 
-	MapDeclare(value Declare) T
+	MapDeclare(value *Declare) T
 
-	MapFieldDef(value FieldDef) T
+	MapEqualOverride(value *EqualOverride) T
 
-	MapForEach(value ForEach) T
+	MapFieldDef(value *FieldDef) T
+
+	MapForEach(value *ForEach) T
 }
 
 func MapDefinitionNoError[T any](node Definition, mapper DefinitionMapperNoError[T]) T {
 	switch value := node.(type) {
 
-	case ArgumentDef:
+	case *ArgumentDef:
 		return mapper.MapArgumentDef(value)
 
-	case ConstantDef:
+	case *ConstantDef:
 		return mapper.MapConstantDef(value)
 
-	case Declare:
+	case *Declare:
 		return mapper.MapDeclare(value)
 
-	case FieldDef:
+	case *EqualOverride:
+		return mapper.MapEqualOverride(value)
+
+	case *FieldDef:
 		return mapper.MapFieldDef(value)
 
-	case ForEach:
+	case *ForEach:
 		return mapper.MapForEach(value)
 
 	default:
@@ -1273,33 +1583,38 @@ func MapEachDefinitionNoError[T any](nodes []Definition, mapper DefinitionMapper
 }
 
 type DefinitionMapperOnlyError interface {
-	MapArgumentDef(value ArgumentDef) error
+	MapArgumentDef(value *ArgumentDef) error
+
+	MapConstantDef(value *ConstantDef) error
 
-	MapConstantDef(value ConstantDef) error
+	MapDeclare(value *Declare) error
 
-	MapDeclare(value Declare) error
+	MapEqualOverride(value *EqualOverride) error
 
-	MapFieldDef(value FieldDef) error
+	MapFieldDef(value *FieldDef) error
 
-	MapForEach(value ForEach) error
+	MapForEach(value *ForEach) error
 }
 
 func MapDefinitionOnlyError(node Definition, mapper DefinitionMapperOnlyError) error {
 	switch value := node.(type) {
 
-	case ArgumentDef:
+	case *ArgumentDef:
 		return mapper.MapArgumentDef(value)
 
-	case ConstantDef:
+	case *ConstantDef:
 		return mapper.MapConstantDef(value)
 
-	case Declare:
+	case *Declare:
 		return mapper.MapDeclare(value)
 
-	case FieldDef:
+	case *EqualOverride:
+		return mapper.MapEqualOverride(value)
+
+	case *FieldDef:
 		return mapper.MapFieldDef(value)
 
-	case ForEach:
+	case *ForEach:
 		return mapper.MapForEach(value)
 
 	default:
@@ -1309,7 +1624,7 @@ func MapDefinitionOnlyError(node Definition, mapper DefinitionMapperOnlyError) e
 
 func MapEachDefinitionOnlyError(nodes []Definition, mapper DefinitionMapperOnlyError) error {
 	for _, node := range nodes {
-		err := MapDefinitionNoError(node, mapper)
+		err := MapDefinitionOnlyError(node, mapper)
 		if err != nil {
 			return err
 		}
@@ -1319,68 +1634,73 @@ func MapEachDefinitionOnlyError(nodes []Definition, mapper DefinitionMapperOnlyE
 }
 
 type StatementMapper[T any] interface {
-	MapAddToSet(value AddToSet) (T, error)
+	MapAddToSet(value *AddToSet) (T, error)
+
+	MapAssignment(value *Assignment) (T, error)
 
-	MapAssignment(value Assignment) (T, error)
+	MapBreak(value *Break) (T, error)
 
-	MapBreak(value Break) (T, error)
+	MapCall(value *Call) (T, error)
 
-	MapCall(value Call) (T, error)
+	MapConditional(value *Conditional) (T, error)
 
-	MapConditional(value Conditional) (T, error)
+	MapContinue(value *Continue) (T, error)
 
-	MapContinue(value Continue) (T, error)
+	MapDeclare(value *Declare) (T, error)
 
-	MapDeclare(value Declare) (T, error)
+	MapFor(value *For) (T, error)
 
-	MapFor(value For) (T, error)
+	MapForEach(value *ForEach) (T, error)
 
-	MapForEach(value ForEach) (T, error)
+	MapMethodCall(value *MethodCall) (T, error)
 
-	MapPop(value Pop) (T, error)
+	MapPop(value *Pop) (T, error)
 
-	MapPush(value Push) (T, error)
+	MapPush(value *Push) (T, error)
 
-	MapReturn(value Return) (T, error)
+	MapReturn(value *Return) (T, error)
 }
 
 func MapStatement[T any](node Statement, mapper StatementMapper[T]) (T, error) {
 	switch value := node.(type) {
 
-	case AddToSet:
+	case *AddToSet:
 		return mapper.MapAddToSet(value)
 
-	case Assignment:
+	case *Assignment:
 		return mapper.MapAssignment(value)
 
-	case Break:
+	case *Break:
 		return mapper.MapBreak(value)
 
-	case Call:
+	case *Call:
 		return mapper.MapCall(value)
 
-	case Conditional:
+	case *Conditional:
 		return mapper.MapConditional(value)
 
-	case Continue:
+	case *Continue:
 		return mapper.MapContinue(value)
 
-	case Declare:
+	case *Declare:
 		return mapper.MapDeclare(value)
 
-	case For:
+	case *For:
 		return mapper.MapFor(value)
 
-	case ForEach:
+	case *ForEach:
 		return mapper.MapForEach(value)
 
-	case Pop:
+	case *MethodCall:
+		return mapper.MapMethodCall(value)
+
+	case *Pop:
 		return mapper.MapPop(value)
 
-	case Push:
+	case *Push:
 		return mapper.MapPush(value)
 
-	case Return:
+	case *Return:
 		return mapper.MapReturn(value)
 
 	default:
@@ -1403,68 +1723,73 @@ func MapEachStatement[T any](nodes []Statement, mapper StatementMapper[T]) ([]T,
 }
 
 type StatementMapperNoError[T any] interface {
-	MapAddToSet(value AddToSet) T
+	MapAddToSet(value *AddToSet) T
 
-	MapAssignment(value Assignment) T
+	MapAssignment(value *Assignment) T
 
-	MapBreak(value Break) T
+	MapBreak(value *Break) T
 
-	MapCall(value Call) T
+	MapCall(value *Call) T
 
-	MapConditional(value Conditional) T
+	MapConditional(value *Conditional) T
 
-	MapContinue(value Continue) T
+	MapContinue(value *Continue) T
 
-	MapDeclare(value Declare) T
+	MapDeclare(value *Declare) T
 
-	MapFor(value For) T
+	MapFor(value *For) T
 
-	MapForEach(value ForEach) T
+	MapForEach(value *ForEach) T
 
-	MapPop(value Pop) T
+	MapMethodCall(value *MethodCall) T
 
-	MapPush(value Push) T
+	MapPop(value *Pop) T
 
-	MapReturn(value Return) T
+	MapPush(value *Push) T
+
+	MapReturn(value *Return) T
 }
 
 func MapStatementNoError[T any](node Statement, mapper StatementMapperNoError[T]) T {
 	switch value := node.(type) {
 
-	case AddToSet:
+	case *AddToSet:
 		return mapper.MapAddToSet(value)
 
-	case Assignment:
+	case *Assignment:
 		return mapper.MapAssignment(value)
 
-	case Break:
+	case *Break:
 		return mapper.MapBreak(value)
 
-	case Call:
+	case *Call:
 		return mapper.MapCall(value)
 
-	case Conditional:
+	case *Conditional:
 		return mapper.MapConditional(value)
 
-	case Continue:
+	case *Continue:
 		return mapper.MapContinue(value)
 
-	case Declare:
+	case *Declare:
 		return mapper.MapDeclare(value)
 
-	case For:
+	case *For:
 		return mapper.MapFor(value)
 
-	case ForEach:
+	case *ForEach:
 		return mapper.MapForEach(value)
 
-	case Pop:
+	case *MethodCall:
+		return mapper.MapMethodCall(value)
+
+	case *Pop:
 		return mapper.MapPop(value)
 
-	case Push:
+	case *Push:
 		return mapper.MapPush(value)
 
-	case Return:
+	case *Return:
 		return mapper.MapReturn(value)
 
 	default:
@@ -1483,68 +1808,73 @@ func MapEachStatementNoError[T any](nodes []Statement, mapper StatementMapperNoE
 }
 
 type StatementMapperOnlyError interface {
-	MapAddToSet(value AddToSet) error
+	MapAddToSet(value *AddToSet) error
+
+	MapAssignment(value *Assignment) error
 
-	MapAssignment(value Assignment) error
+	MapBreak(value *Break) error
 
-	MapBreak(value Break) error
+	MapCall(value *Call) error
 
-	MapCall(value Call) error
+	MapConditional(value *Conditional) error
 
-	MapConditional(value Conditional) error
+	MapContinue(value *Continue) error
 
-	MapContinue(value Continue) error
+	MapDeclare(value *Declare) error
 
-	MapDeclare(value Declare) error
+	MapFor(value *For) error
 
-	MapFor(value For) error
+	MapForEach(value *ForEach) error
 
-	MapForEach(value ForEach) error
+	MapMethodCall(value *MethodCall) error
 
-	MapPop(value Pop) error
+	MapPop(value *Pop) error
 
-	MapPush(value Push) error
+	MapPush(value *Push) error
 
-	MapReturn(value Return) error
+	MapReturn(value *Return) error
 }
 
 func MapStatementOnlyError(node Statement, mapper StatementMapperOnlyError) error {
 	switch value := node.(type) {
 
-	case AddToSet:
+	case *AddToSet:
 		return mapper.MapAddToSet(value)
 
-	case Assignment:
+	case *Assignment:
 		return mapper.MapAssignment(value)
 
-	case Break:
+	case *Break:
 		return mapper.MapBreak(value)
 
-	case Call:
+	case *Call:
 		return mapper.MapCall(value)
 
-	case Conditional:
+	case *Conditional:
 		return mapper.MapConditional(value)
 
-	case Continue:
+	case *Continue:
 		return mapper.MapContinue(value)
 
-	case Declare:
+	case *Declare:
 		return mapper.MapDeclare(value)
 
-	case For:
+	case *For:
 		return mapper.MapFor(value)
 
-	case ForEach:
+	case *ForEach:
 		return mapper.MapForEach(value)
 
-	case Pop:
+	case *MethodCall:
+		return mapper.MapMethodCall(value)
+
+	case *Pop:
 		return mapper.MapPop(value)
 
-	case Push:
+	case *Push:
 		return mapper.MapPush(value)
 
-	case Return:
+	case *Return:
 		return mapper.MapReturn(value)
 
 	default:
@@ -1554,7 +1884,7 @@ func MapStatementOnlyError(node Statement, mapper StatementMapperOnlyError) erro
 
 func MapEachStatementOnlyError(nodes []Statement, mapper StatementMapperOnlyError) error {
 	for _, node := range nodes {
-		err := MapStatementNoError(node, mapper)
+		err := MapStatementOnlyError(node, mapper)
 		if err != nil {
 			return err
 		}
@@ -1564,53 +1894,73 @@ func MapEachStatementOnlyError(nodes []Statement, mapper StatementMapperOnlyErro
 }
 
 type TypeMapper[T any] interface {
-	MapBool(value Bool) (T, error)
+	MapBool(value *Bool) (T, error)
+
+	MapBytes(value *Bytes) (T, error)
+
+	MapFloat64(value *Float64) (T, error)
+
+	MapInt32(value *Int32) (T, error)
 
-	MapInt64(value Int64) (T, error)
+	MapInt64(value *Int64) (T, error)
 
-	MapList(value List) (T, error)
+	MapList(value *List) (T, error)
 
-	MapMap(value Map) (T, error)
+	MapMap(value *Map) (T, error)
 
-	MapModel(value Model) (T, error)
+	MapModel(value *Model) (T, error)
 
-	MapRune(value Rune) (T, error)
+	MapRune(value *Rune) (T, error)
 
-	MapSet(value Set) (T, error)
+	MapSet(value *Set) (T, error)
 
-	MapString(value String) (T, error)
+	MapString(value *String) (T, error)
 
-	MapVoid(value Void) (T, error)
+	MapUint8(value *Uint8) (T, error)
+
+	MapVoid(value *Void) (T, error)
 }
 
 func MapType[T any](node Type, mapper TypeMapper[T]) (T, error) {
 	switch value := node.(type) {
 
-	case Bool:
+	case *Bool:
 		return mapper.MapBool(value)
 
-	case Int64:
+	case *Bytes:
+		return mapper.MapBytes(value)
+
+	case *Float64:
+		return mapper.MapFloat64(value)
+
+	case *Int32:
+		return mapper.MapInt32(value)
+
+	case *Int64:
 		return mapper.MapInt64(value)
 
-	case List:
+	case *List:
 		return mapper.MapList(value)
 
-	case Map:
+	case *Map:
 		return mapper.MapMap(value)
 
-	case Model:
+	case *Model:
 		return mapper.MapModel(value)
 
-	case Rune:
+	case *Rune:
 		return mapper.MapRune(value)
 
-	case Set:
+	case *Set:
 		return mapper.MapSet(value)
 
-	case String:
+	case *String:
 		return mapper.MapString(value)
 
-	case Void:
+	case *Uint8:
+		return mapper.MapUint8(value)
+
+	case *Void:
 		return mapper.MapVoid(value)
 
 	default:
@@ -1633,53 +1983,73 @@ func MapEachType[T any](nodes []Type, mapper TypeMapper[T]) ([]T, error) {
 }
 
 type TypeMapperNoError[T any] interface {
-	MapBool(value Bool) T
+	MapBool(value *Bool) T
+
+	MapBytes(value *Bytes) T
+
+	MapFloat64(value *Float64) T
+
+	MapInt32(value *Int32) T
+
+	MapInt64(value *Int64) T
 
-	MapInt64(value Int64) T
+	MapList(value *List) T
 
-	MapList(value List) T
+	MapMap(value *Map) T
 
-	MapMap(value Map) T
+	MapModel(value *Model) T
 
-	MapModel(value Model) T
+	MapRune(value *Rune) T
 
-	MapRune(value Rune) T
+	MapSet(value *Set) T
 
-	MapSet(value Set) T
+	MapString(value *String) T
 
-	MapString(value String) T
+	MapUint8(value *Uint8) T
 
-	MapVoid(value Void) T
+	MapVoid(value *Void) T
 }
 
 func MapTypeNoError[T any](node Type, mapper TypeMapperNoError[T]) T {
 	switch value := node.(type) {
 
-	case Bool:
+	case *Bool:
 		return mapper.MapBool(value)
 
-	case Int64:
+	case *Bytes:
+		return mapper.MapBytes(value)
+
+	case *Float64:
+		return mapper.MapFloat64(value)
+
+	case *Int32:
+		return mapper.MapInt32(value)
+
+	case *Int64:
 		return mapper.MapInt64(value)
 
-	case List:
+	case *List:
 		return mapper.MapList(value)
 
-	case Map:
+	case *Map:
 		return mapper.MapMap(value)
 
-	case Model:
+	case *Model:
 		return mapper.MapModel(value)
 
-	case Rune:
+	case *Rune:
 		return mapper.MapRune(value)
 
-	case Set:
+	case *Set:
 		return mapper.MapSet(value)
 
-	case String:
+	case *String:
 		return mapper.MapString(value)
 
-	case Void:
+	case *Uint8:
+		return mapper.MapUint8(value)
+
+	case *Void:
 		return mapper.MapVoid(value)
 
 	default:
@@ -1698,53 +2068,73 @@ func MapEachTypeNoError[T any](nodes []Type, mapper TypeMapperNoError[T]) []T {
 }
 
 type TypeMapperOnlyError interface {
-	MapBool(value Bool) error
+	MapBool(value *Bool) error
+
+	MapBytes(value *Bytes) error
+
+	MapFloat64(value *Float64) error
+
+	MapInt32(value *Int32) error
 
-	MapInt64(value Int64) error
+	MapInt64(value *Int64) error
 
-	MapList(value List) error
+	MapList(value *List) error
 
-	MapMap(value Map) error
+	MapMap(value *Map) error
 
-	MapModel(value Model) error
+	MapModel(value *Model) error
 
-	MapRune(value Rune) error
+	MapRune(value *Rune) error
 
-	MapSet(value Set) error
+	MapSet(value *Set) error
 
-	MapString(value String) error
+	MapString(value *String) error
 
-	MapVoid(value Void) error
+	MapUint8(value *Uint8) error
+
+	MapVoid(value *Void) error
 }
 
 func MapTypeOnlyError(node Type, mapper TypeMapperOnlyError) error {
 	switch value := node.(type) {
 
-	case Bool:
+	case *Bool:
 		return mapper.MapBool(value)
 
-	case Int64:
+	case *Bytes:
+		return mapper.MapBytes(value)
+
+	case *Float64:
+		return mapper.MapFloat64(value)
+
+	case *Int32:
+		return mapper.MapInt32(value)
+
+	case *Int64:
 		return mapper.MapInt64(value)
 
-	case List:
+	case *List:
 		return mapper.MapList(value)
 
-	case Map:
+	case *Map:
 		return mapper.MapMap(value)
 
-	case Model:
+	case *Model:
 		return mapper.MapModel(value)
 
-	case Rune:
+	case *Rune:
 		return mapper.MapRune(value)
 
-	case Set:
+	case *Set:
 		return mapper.MapSet(value)
 
-	case String:
+	case *String:
 		return mapper.MapString(value)
 
-	case Void:
+	case *Uint8:
+		return mapper.MapUint8(value)
+
+	case *Void:
 		return mapper.MapVoid(value)
 
 	default:
@@ -1754,7 +2144,7 @@ func MapTypeOnlyError(node Type, mapper TypeMapperOnlyError) error {
 
 func MapEachTypeOnlyError(nodes []Type, mapper TypeMapperOnlyError) error {
 	for _, node := range nodes {
-		err := MapTypeNoError(node, mapper)
+		err := MapTypeOnlyError(node, mapper)
 		if err != nil {
 			return err
 		}
@@ -1764,98 +2154,143 @@ func MapEachTypeOnlyError(nodes []Type, mapper TypeMapperOnlyError) error {
 }
 
 type ValueMapper[T any] interface {
-	MapCall(value Call) (T, error)
+	MapBinary(value *Binary) (T, error)
+
+	MapCall(value *Call) (T, error)
 
-	MapEmptyList(value EmptyList) (T, error)
+	MapComparison(value *Comparison) (T, error)
 
-	MapLength(value Length) (T, error)
+	MapConversion(value *Conversion) (T, error)
 
-	MapLiteralBool(value LiteralBool) (T, error)
+	MapEmptyList(value *EmptyList) (T, error)
 
-	MapLiteralInt64(value LiteralInt64) (T, error)
+	MapLength(value *Length) (T, error)
 
-	MapLiteralList(value LiteralList) (T, error)
+	MapLiteralBool(value *LiteralBool) (T, error)
 
-	MapLiteralMap(value LiteralMap) (T, error)
+	MapLiteralBytes(value *LiteralBytes) (T, error)
 
-	MapLiteralRune(value LiteralRune) (T, error)
+	MapLiteralFloat64(value *LiteralFloat64) (T, error)
 
-	MapLiteralSet(value LiteralSet) (T, error)
+	MapLiteralInt32(value *LiteralInt32) (T, error)
 
-	MapLiteralString(value LiteralString) (T, error)
+	MapLiteralInt64(value *LiteralInt64) (T, error)
 
-	MapLookup(value Lookup) (T, error)
+	MapLiteralList(value *LiteralList) (T, error)
 
-	MapNew(value New) (T, error)
+	MapLiteralMap(value *LiteralMap) (T, error)
 
-	MapNil(value Nil) (T, error)
+	MapLiteralRune(value *LiteralRune) (T, error)
 
-	MapPop(value Pop) (T, error)
+	MapLiteralSet(value *LiteralSet) (T, error)
 
-	MapProperty(value Property) (T, error)
+	MapLiteralString(value *LiteralString) (T, error)
 
-	MapSelf(value Self) (T, error)
+	MapLiteralUint8(value *LiteralUint8) (T, error)
 
-	MapSetContains(value SetContains) (T, error)
+	MapLookup(value *Lookup) (T, error)
 
-	MapVariable(value Variable) (T, error)
+	MapMethodCall(value *MethodCall) (T, error)
+
+	MapNew(value *New) (T, error)
+
+	MapNil(value *Nil) (T, error)
+
+	MapPop(value *Pop) (T, error)
+
+	MapProperty(value *Property) (T, error)
+
+	MapSelf(value *Self) (T, error)
+
+	MapSetContains(value *SetContains) (T, error)
+
+	MapUnary(value *Unary) (T, error)
+
+	MapVariable(value *Variable) (T, error)
 }
 
 func MapValue[T any](node Value, mapper ValueMapper[T]) (T, error) {
 	switch value := node.(type) {
 
-	case Call:
+	case *Binary:
+		return mapper.MapBinary(value)
+
+	case *Call:
 		return mapper.MapCall(value)
 
-	case EmptyList:
+	case *Comparison:
+		return mapper.MapComparison(value)
+
+	case *Conversion:
+		return mapper.MapConversion(value)
+
+	case *EmptyList:
 		return mapper.MapEmptyList(value)
 
-	case Length:
+	case *Length:
 		return mapper.MapLength(value)
 
-	case LiteralBool:
+	case *LiteralBool:
 		return mapper.MapLiteralBool(value)
 
-	case LiteralInt64:
+	case *LiteralBytes:
+		return mapper.MapLiteralBytes(value)
+
+	case *LiteralFloat64:
+		return mapper.MapLiteralFloat64(value)
+
+	case *LiteralInt32:
+		return mapper.MapLiteralInt32(value)
+
+	case *LiteralInt64:
 		return mapper.MapLiteralInt64(value)
 
-	case LiteralList:
+	case *LiteralList:
 		return mapper.MapLiteralList(value)
 
-	case LiteralMap:
+	case *LiteralMap:
 		return mapper.MapLiteralMap(value)
 
-	case LiteralRune:
+	case *LiteralRune:
 		return mapper.MapLiteralRune(value)
 
-	case LiteralSet:
+	case *LiteralSet:
 		return mapper.MapLiteralSet(value)
 
-	case LiteralString:
+	case *LiteralString:
 		return mapper.MapLiteralString(value)
 
-	case Lookup:
+	case *LiteralUint8:
+		return mapper.MapLiteralUint8(value)
+
+	case *Lookup:
 		return mapper.MapLookup(value)
 
-	case New:
+	case *MethodCall:
+		return mapper.MapMethodCall(value)
+
+	case *New:
 		return mapper.MapNew(value)
 
-	case Nil:
+	case *Nil:
 		return mapper.MapNil(value)
 
-	case Pop:
+	case *Pop:
 		return mapper.MapPop(value)
 
-	case Property:
+	case *Property:
 		return mapper.MapProperty(value)
 
-	case Self:
+	case *Self:
 		return mapper.MapSelf(value)
 
-	case SetContains:
+	case *SetContains:
 		return mapper.MapSetContains(value)
 
-	case Variable:
+	case *Unary:
+		return mapper.MapUnary(value)
+
+	case *Variable:
 		return mapper.MapVariable(value)
 
 	default:
@@ -1878,98 +2313,143 @@ func MapEachValue[T any](nodes []Value, mapper ValueMapper[T]) ([]T, error) {
 }
 
 type ValueMapperNoError[T any] interface {
-	MapCall(value Call) T
+	MapBinary(value *Binary) T
+
+	MapCall(value *Call) T
+
+	MapComparison(value *Comparison) T
+
+	MapConversion(value *Conversion) T
+
+	MapEmptyList(value *EmptyList) T
 
-	MapEmptyList(value EmptyList) T
+	MapLength(value *Length) T
 
-	MapLength(value Length) T
+	MapLiteralBool(value *LiteralBool) T
 
-	MapLiteralBool(value LiteralBool) T
+	MapLiteralBytes(value *LiteralBytes) T
 
-	MapLiteralInt64(value LiteralInt64) T
+	MapLiteralFloat64(value *LiteralFloat64) T
 
-	MapLiteralList(value LiteralList) T
+	MapLiteralInt32(value *LiteralInt32) T
 
-	MapLiteralMap(value LiteralMap) T
+	MapLiteralInt64(value *LiteralInt64) T
 
-	MapLiteralRune(value LiteralRune) T
+	MapLiteralList(value *LiteralList) T
 
-	MapLiteralSet(value LiteralSet) T
+	MapLiteralMap(value *LiteralMap) T
 
-	MapLiteralString(value LiteralString) T
+	MapLiteralRune(value *LiteralRune) T
 
-	MapLookup(value Lookup) T
+	MapLiteralSet(value *LiteralSet) T
 
-	MapNew(value New) T
+	MapLiteralString(value *LiteralString) T
 
-	MapNil(value Nil) T
+	MapLiteralUint8(value *LiteralUint8) T
 
-	MapPop(value Pop) T
+	MapLookup(value *Lookup) T
 
-	MapProperty(value Property) T
+	MapMethodCall(value *MethodCall) T
 
-	MapSelf(value Self) T
+	MapNew(value *New) T
 
-	MapSetContains(value SetContains) T
+	MapNil(value *Nil) T
 
-	MapVariable(value Variable) T
+	MapPop(value *Pop) T
+
+	MapProperty(value *Property) T
+
+	MapSelf(value *Self) T
+
+	MapSetContains(value *SetContains) T
+
+	MapUnary(value *Unary) T
+
+	MapVariable(value *Variable) T
 }
 
 func MapValueNoError[T any](node Value, mapper ValueMapperNoError[T]) T {
 	switch value := node.(type) {
 
-	case Call:
+	case *Binary:
+		return mapper.MapBinary(value)
+
+	case *Call:
 		return mapper.MapCall(value)
 
-	case EmptyList:
+	case *Comparison:
+		return mapper.MapComparison(value)
+
+	case *Conversion:
+		return mapper.MapConversion(value)
+
+	case *EmptyList:
 		return mapper.MapEmptyList(value)
 
-	case Length:
+	case *Length:
 		return mapper.MapLength(value)
 
-	case LiteralBool:
+	case *LiteralBool:
 		return mapper.MapLiteralBool(value)
 
-	case LiteralInt64:
+	case *LiteralBytes:
+		return mapper.MapLiteralBytes(value)
+
+	case *LiteralFloat64:
+		return mapper.MapLiteralFloat64(value)
+
+	case *LiteralInt32:
+		return mapper.MapLiteralInt32(value)
+
+	case *LiteralInt64:
 		return mapper.MapLiteralInt64(value)
 
-	case LiteralList:
+	case *LiteralList:
 		return mapper.MapLiteralList(value)
 
-	case LiteralMap:
+	case *LiteralMap:
 		return mapper.MapLiteralMap(value)
 
-	case LiteralRune:
+	case *LiteralRune:
 		return mapper.MapLiteralRune(value)
 
-	case LiteralSet:
+	case *LiteralSet:
 		return mapper.MapLiteralSet(value)
 
-	case LiteralString:
+	case *LiteralString:
 		return mapper.MapLiteralString(value)
 
-	case Lookup:
+	case *LiteralUint8:
+		return mapper.MapLiteralUint8(value)
+
+	case *Lookup:
 		return mapper.MapLookup(value)
 
-	case New:
+	case *MethodCall:
+		return mapper.MapMethodCall(value)
+
+	case *New:
 		return mapper.MapNew(value)
 
-	case Nil:
+	case *Nil:
 		return mapper.MapNil(value)
 
-	case Pop:
+	case *Pop:
 		return mapper.MapPop(value)
 
-	case Property:
+	case *Property:
 		return mapper.MapProperty(value)
 
-	case Self:
+	case *Self:
 		return mapper.MapSelf(value)
 
-	case SetContains:
+	case *SetContains:
 		return mapper.MapSetContains(value)
 
-	case Variable:
+	case *Unary:
+		return mapper.MapUnary(value)
+
+	case *Variable:
 		return mapper.MapVariable(value)
 
 	default:
@@ -1988,98 +2468,143 @@ func MapEachValueNoError[T any](nodes []Value, mapper ValueMapperNoError[T]) []T
 }
 
 type ValueMapperOnlyError interface {
-	MapCall(value Call) error
+	MapBinary(value *Binary) error
+
+	MapCall(value *Call) error
+
+	MapComparison(value *Comparison) error
+
+	MapConversion(value *Conversion) error
+
+	MapEmptyList(value *EmptyList) error
+
+	MapLength(value *Length) error
 
-	MapEmptyList(value EmptyList) error
+	MapLiteralBool(value *LiteralBool) error
 
-	MapLength(value Length) error
+	MapLiteralBytes(value *LiteralBytes) error
 
-	MapLiteralBool(value LiteralBool) error
+	MapLiteralFloat64(value *LiteralFloat64) error
 
-	MapLiteralInt64(value LiteralInt64) error
+	MapLiteralInt32(value *LiteralInt32) error
 
-	MapLiteralList(value LiteralList) error
+	MapLiteralInt64(value *LiteralInt64) error
 
-	MapLiteralMap(value LiteralMap) error
+	MapLiteralList(value *LiteralList) error
 
-	MapLiteralRune(value LiteralRune) error
+	MapLiteralMap(value *LiteralMap) error
 
-	MapLiteralSet(value LiteralSet) error
+	MapLiteralRune(value *LiteralRune) error
 
-	MapLiteralString(value LiteralString) error
+	MapLiteralSet(value *LiteralSet) error
 
-	MapLookup(value Lookup) error
+	MapLiteralString(value *LiteralString) error
 
-	MapNew(value New) error
+	MapLiteralUint8(value *LiteralUint8) error
 
-	MapNil(value Nil) error
+	MapLookup(value *Lookup) error
 
-	MapPop(value Pop) error
+	MapMethodCall(value *MethodCall) error
 
-	MapProperty(value Property) error
+	MapNew(value *New) error
 
-	MapSelf(value Self) error
+	MapNil(value *Nil) error
 
-	MapSetContains(value SetContains) error
+	MapPop(value *Pop) error
 
-	MapVariable(value Variable) error
+	MapProperty(value *Property) error
+
+	MapSelf(value *Self) error
+
+	MapSetContains(value *SetContains) error
+
+	MapUnary(value *Unary) error
+
+	MapVariable(value *Variable) error
 }
 
 func MapValueOnlyError(node Value, mapper ValueMapperOnlyError) error {
 	switch value := node.(type) {
 
-	case Call:
+	case *Binary:
+		return mapper.MapBinary(value)
+
+	case *Call:
 		return mapper.MapCall(value)
 
-	case EmptyList:
+	case *Comparison:
+		return mapper.MapComparison(value)
+
+	case *Conversion:
+		return mapper.MapConversion(value)
+
+	case *EmptyList:
 		return mapper.MapEmptyList(value)
 
-	case Length:
+	case *Length:
 		return mapper.MapLength(value)
 
-	case LiteralBool:
+	case *LiteralBool:
 		return mapper.MapLiteralBool(value)
 
-	case LiteralInt64:
+	case *LiteralBytes:
+		return mapper.MapLiteralBytes(value)
+
+	case *LiteralFloat64:
+		return mapper.MapLiteralFloat64(value)
+
+	case *LiteralInt32:
+		return mapper.MapLiteralInt32(value)
+
+	case *LiteralInt64:
 		return mapper.MapLiteralInt64(value)
 
-	case LiteralList:
+	case *LiteralList:
 		return mapper.MapLiteralList(value)
 
-	case LiteralMap:
+	case *LiteralMap:
 		return mapper.MapLiteralMap(value)
 
-	case LiteralRune:
+	case *LiteralRune:
 		return mapper.MapLiteralRune(value)
 
-	case LiteralSet:
+	case *LiteralSet:
 		return mapper.MapLiteralSet(value)
 
-	case LiteralString:
+	case *LiteralString:
 		return mapper.MapLiteralString(value)
 
-	case Lookup:
+	case *LiteralUint8:
+		return mapper.MapLiteralUint8(value)
+
+	case *Lookup:
 		return mapper.MapLookup(value)
 
-	case New:
+	case *MethodCall:
+		return mapper.MapMethodCall(value)
+
+	case *New:
 		return mapper.MapNew(value)
 
-	case Nil:
+	case *Nil:
 		return mapper.MapNil(value)
 
-	case Pop:
+	case *Pop:
 		return mapper.MapPop(value)
 
-	case Property:
+	case *Property:
 		return mapper.MapProperty(value)
 
-	case Self:
+	case *Self:
 		return mapper.MapSelf(value)
 
-	case SetContains:
+	case *SetContains:
 		return mapper.MapSetContains(value)
 
-	case Variable:
+	case *Unary:
+		return mapper.MapUnary(value)
+
+	case *Variable:
 		return mapper.MapVariable(value)
 
 	default:
@@ -2089,7 +2614,7 @@ func MapValueOnlyError(node Value, mapper ValueMapperOnlyError) error {
 
 func MapEachValueOnlyError(nodes []Value, mapper ValueMapperOnlyError) error {
 	for _, node := range nodes {
-		err := MapValueNoError(node, mapper)
+		err := MapValueOnlyError(node, mapper)
 		if err != nil {
 			return err
 		}
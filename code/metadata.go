@@ -1,99 +1,341 @@
 package code
 
-type AddToSetMetadata struct{}
-
-type ArgumentDefMetadata struct{}
-
-type AssignmentMetadata struct{}
-
-type BlockMetadata struct{}
-
-type BoolMetadata struct{}
-
-type BreakMetadata struct{}
-
-type CallMetadata struct{}
-
-type ConditionalMetadata struct{}
-
-type ConstantDefMetadata struct{}
-
-type ContinueMetadata struct{}
-
-type DeclareMetadata struct{}
-
-type EmptyListMetadata struct{}
-
-type EqualOverrideMetadata struct{}
-
-type FieldDefMetadata struct{}
-
-type ForMetadata struct{}
-
-type ForEachMetadata struct{}
-
-type FunctionDefMetadata struct{}
-
-type HashOverrideMetadata struct{}
-
-type IfMetadata struct{}
-
-type Int64Metadata struct{}
-
-type KeyValueMetadata struct{}
-
-type LengthMetadata struct{}
-
-type ListMetadata struct{}
-
-type LiteralBoolMetadata struct{}
-
-type LiteralInt64Metadata struct{}
-
-type LiteralListMetadata struct{}
-
-type LiteralMapMetadata struct{}
-
-type LiteralRuneMetadata struct{}
-
-type LiteralSetMetadata struct{}
-
-type LiteralStringMetadata struct{}
-
-type LookupMetadata struct{}
-
-type MapMetadata struct{}
-
-type ModelMetadata struct{}
-
-type ModelDefMetadata struct{}
-
-type ModuleMetadata struct{}
-
-type NewMetadata struct{}
-
-type NilMetadata struct{}
-
-type PopMetadata struct{}
-
-type PropertyMetadata struct{}
-
-type PushMetadata struct{}
-
-type ReturnMetadata struct{}
-
-type RootMetadata struct{}
-
-type RuneMetadata struct{}
-
-type SelfMetadata struct{}
-
-type SetMetadata struct{}
-
-type SetContainsMetadata struct{}
-
-type StringMetadata struct{}
-
-type VariableMetadata struct{}
-
-type VoidMetadata struct{}
+// NodeMetadata is the metadata shared by every node.
+type NodeMetadata struct {
+	// Where the node was parsed from. This is the zero Position if the AST was built by hand.
+	Position Position
+}
+
+func (m *NodeMetadata) nodeMetadata() *NodeMetadata {
+	return m
+}
+
+// PositionOf returns where the node was parsed from.
+func PositionOf(node Node) Position {
+	// Every node embeds NodeMetadata in its own metadata.
+	return node.(interface{ nodeMetadata() *NodeMetadata }).nodeMetadata().Position
+}
+
+// ValueMetadata is the metadata shared by every value.
+type ValueMetadata struct {
+	// The static type of the value.
+	Type Type
+}
+
+type AddToSetMetadata struct {
+	NodeMetadata
+}
+
+type ArgumentDefMetadata struct {
+	NodeMetadata
+	// Whether the function assigns to the argument or changes its contents.
+	Modified bool
+}
+
+type AssignmentMetadata struct {
+	NodeMetadata
+}
+
+type BinaryMetadata struct {
+	NodeMetadata
+	ValueMetadata
+	// What happens when the result doesn't fit in its type.
+	Overflow Overflow
+}
+
+type BlockMetadata struct {
+	NodeMetadata
+}
+
+type BoolMetadata struct {
+	NodeMetadata
+}
+
+type BreakMetadata struct {
+	NodeMetadata
+}
+
+type BytesMetadata struct {
+	NodeMetadata
+}
+
+type CallMetadata struct {
+	NodeMetadata
+	ValueMetadata
+}
+
+type ComparisonMetadata struct {
+	NodeMetadata
+	ValueMetadata
+}
+
+type ConditionalMetadata struct {
+	NodeMetadata
+}
+
+type ConstantDefMetadata struct {
+	NodeMetadata
+	// The module that the constant is defined in.
+	Module *Module
+}
+
+type ContinueMetadata struct {
+	NodeMetadata
+}
+
+type ConversionMetadata struct {
+	NodeMetadata
+	ValueMetadata
+	// What happens when the value doesn't fit in the type that it's converted to.
+	Overflow Overflow
+}
+
+type DeclareMetadata struct {
+	NodeMetadata
+}
+
+type EmptyListMetadata struct {
+	NodeMetadata
+	ValueMetadata
+}
+
+type EqualOverrideMetadata struct {
+	NodeMetadata
+}
+
+type FieldDefMetadata struct {
+	NodeMetadata
+}
+
+type Float64Metadata struct {
+	NodeMetadata
+}
+
+type ForMetadata struct {
+	NodeMetadata
+}
+
+type ForEachMetadata struct {
+	NodeMetadata
+}
+
+type FunctionDefMetadata struct {
+	NodeMetadata
+	// Whether the function changes the contents of the model that it's defined on.
+	ModifiesSelf bool
+	// The module that the function, or the model of the method, is defined in.
+	Module *Module
+}
+
+type FunctionRefMetadata struct {
+	NodeMetadata
+	// The function that is referred to, or nil if the reference couldn't be resolved. Calls refer to this function
+	// directly instead of to the reference.
+	Definition *FunctionDef
+}
+
+type HashOverrideMetadata struct {
+	NodeMetadata
+}
+
+type IfMetadata struct {
+	NodeMetadata
+}
+
+type ImportMetadata struct {
+	NodeMetadata
+	// The module that is imported, or nil if there is no module with the name. Together the imports of every module
+	// form the dependency graph of the root, which never has a cycle.
+	Definition *Module
+}
+
+type Int32Metadata struct {
+	NodeMetadata
+}
+
+type Int64Metadata struct {
+	NodeMetadata
+}
+
+type KeyValueMetadata struct {
+	NodeMetadata
+}
+
+type LengthMetadata struct {
+	NodeMetadata
+	ValueMetadata
+}
+
+type ListMetadata struct {
+	NodeMetadata
+}
+
+type LiteralBoolMetadata struct {
+	NodeMetadata
+	ValueMetadata
+}
+
+type LiteralBytesMetadata struct {
+	NodeMetadata
+	ValueMetadata
+}
+
+type LiteralFloat64Metadata struct {
+	NodeMetadata
+	ValueMetadata
+}
+
+type LiteralInt32Metadata struct {
+	NodeMetadata
+	ValueMetadata
+}
+
+type LiteralInt64Metadata struct {
+	NodeMetadata
+	ValueMetadata
+}
+
+type LiteralListMetadata struct {
+	NodeMetadata
+	ValueMetadata
+}
+
+type LiteralMapMetadata struct {
+	NodeMetadata
+	ValueMetadata
+}
+
+type LiteralRuneMetadata struct {
+	NodeMetadata
+	ValueMetadata
+}
+
+type LiteralSetMetadata struct {
+	NodeMetadata
+	ValueMetadata
+}
+
+type LiteralStringMetadata struct {
+	NodeMetadata
+	ValueMetadata
+}
+
+type LiteralUint8Metadata struct {
+	NodeMetadata
+	ValueMetadata
+}
+
+type LookupMetadata struct {
+	NodeMetadata
+	ValueMetadata
+
+	Usage Usage
+}
+
+type MapMetadata struct {
+	NodeMetadata
+}
+
+type ModelMetadata struct {
+	NodeMetadata
+}
+
+type MethodCallMetadata struct {
+	NodeMetadata
+	ValueMetadata
+
+	// The method of the receiver's model that is called.
+	Method *FunctionDef
+}
+
+type ModelDefMetadata struct {
+	NodeMetadata
+	// The module that the model is defined in.
+	Module *Module
+}
+
+type ModuleMetadata struct {
+	NodeMetadata
+}
+
+type NewMetadata struct {
+	NodeMetadata
+	ValueMetadata
+}
+
+type NilMetadata struct {
+	NodeMetadata
+	ValueMetadata
+}
+
+type PopMetadata struct {
+	NodeMetadata
+	ValueMetadata
+}
+
+type PropertyMetadata struct {
+	NodeMetadata
+	ValueMetadata
+
+	Usage Usage
+	// The field of the model that is accessed.
+	Field *FieldDef
+}
+
+type PushMetadata struct {
+	NodeMetadata
+}
+
+type ReturnMetadata struct {
+	NodeMetadata
+}
+
+type RootMetadata struct {
+	NodeMetadata
+}
+
+type RuneMetadata struct {
+	NodeMetadata
+}
+
+type SelfMetadata struct {
+	NodeMetadata
+	ValueMetadata
+}
+
+type SetMetadata struct {
+	NodeMetadata
+}
+
+type SetContainsMetadata struct {
+	NodeMetadata
+	ValueMetadata
+}
+
+type StringMetadata struct {
+	NodeMetadata
+}
+
+type Uint8Metadata struct {
+	NodeMetadata
+}
+
+type UnaryMetadata struct {
+	NodeMetadata
+	ValueMetadata
+	// What happens when the result doesn't fit in its type.
+	Overflow Overflow
+}
+
+type VariableMetadata struct {
+	NodeMetadata
+	ValueMetadata
+
+	Usage Usage
+	// The node that declares the variable. This is a *Declare, *ArgumentDef, *ForEach, *EqualOverride, or *ConstantDef.
+	Definition Definition
+}
+
+type VoidMetadata struct {
+	NodeMetadata
+}
@@ -8,51 +8,51 @@ type Node interface {
 	isNode()
 }
 
-type Definition interface {
+type Assignable interface {
 	Node
 
-	// isDefinition is just a interface guard to restrict what can be used as a Definition.
-	isDefinition()
+	// isAssignable is just a interface guard to restrict what can be used as a Assignable.
+	isAssignable()
 }
 
-type Type interface {
+type Callable interface {
 	Node
 
-	// isType is just a interface guard to restrict what can be used as a Type.
-	isType()
+	// isCallable is just a interface guard to restrict what can be used as a Callable.
+	isCallable()
 }
 
-type Value interface {
+type ConstantValue interface {
 	Node
 
-	// isValue is just a interface guard to restrict what can be used as a Value.
-	isValue()
+	// isConstantValue is just a interface guard to restrict what can be used as a ConstantValue.
+	isConstantValue()
 }
 
-type ConstantValue interface {
+type Definition interface {
 	Node
 
-	// isConstantValue is just a interface guard to restrict what can be used as a ConstantValue.
-	isConstantValue()
+	// isDefinition is just a interface guard to restrict what can be used as a Definition.
+	isDefinition()
 }
 
-type Callable interface {
+type Statement interface {
 	Node
 
-	// isCallable is just a interface guard to restrict what can be used as a Callable.
-	isCallable()
+	// isStatement is just a interface guard to restrict what can be used as a Statement.
+	isStatement()
 }
 
-type Assignable interface {
+type Type interface {
 	Node
 
-	// isAssignable is just a interface guard to restrict what can be used as a Assignable.
-	isAssignable()
+	// isType is just a interface guard to restrict what can be used as a Type.
+	isType()
 }
 
-type Statement interface {
+type Value interface {
 	Node
 
-	// isStatement is just a interface guard to restrict what can be used as a Statement.
-	isStatement()
+	// isValue is just a interface guard to restrict what can be used as a Value.
+	isValue()
 }
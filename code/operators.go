@@ -0,0 +1,60 @@
+package code
+
+// Symbol returns the operator as it's written in AgnosticScript.
+func (o BinaryOperator) Symbol() string {
+	switch o {
+	case BinaryOperatorAdd:
+		return "+"
+	case BinaryOperatorSubtract:
+		return "-"
+	case BinaryOperatorMultiply:
+		return "*"
+	case BinaryOperatorDivide:
+		return "/"
+	case BinaryOperatorModulo:
+		return "%"
+	case BinaryOperatorAnd:
+		return "&&"
+	case BinaryOperatorOr:
+		return "||"
+	default:
+		return o.String()
+	}
+}
+
+// Symbol returns the operator as it's written in AgnosticScript.
+func (o ComparisonOperator) Symbol() string {
+	switch o {
+	case ComparisonOperatorEqual:
+		return "=="
+	case ComparisonOperatorNotEqual:
+		return "!="
+	case ComparisonOperatorLessThan:
+		return "<"
+	case ComparisonOperatorLessThanOrEqual:
+		return "<="
+	case ComparisonOperatorGreaterThan:
+		return ">"
+	case ComparisonOperatorGreaterThanOrEqual:
+		return ">="
+	default:
+		return o.String()
+	}
+}
+
+// Symbol returns the operator as it's written in AgnosticScript.
+func (o UnaryOperator) Symbol() string {
+	switch o {
+	case UnaryOperatorNegate:
+		return "-"
+	case UnaryOperatorNot:
+		return "!"
+	default:
+		return o.String()
+	}
+}
+
+// IsOrdering returns whether the operator compares the order of its operands rather than their equality.
+func (o ComparisonOperator) IsOrdering() bool {
+	return o != ComparisonOperatorEqual && o != ComparisonOperatorNotEqual
+}
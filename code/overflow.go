@@ -0,0 +1,107 @@
+package code
+
+import "math"
+
+// Overflow is what happens when the result of an operation doesn't fit in the type of the result. The rules are the
+// same for every language, so each backend has to emit whatever is needed to follow them.
+type Overflow int
+
+const (
+	// OverflowNone means that the result always fits. This is the case for operations on booleans and strings and for
+	// conversions into a type that can hold every value of the original type.
+	OverflowNone Overflow = iota
+	// OverflowWrap means that only the low bits of the two's complement result are kept, so the result wraps around to
+	// the other end of the range of the type. Integer arithmetic and conversions between integer types wrap.
+	OverflowWrap
+	// OverflowRound means that the result is rounded to the nearest float64 as specified by IEEE 754. Results that are
+	// too large become infinities. Float arithmetic and conversions from int64 to float64 round.
+	OverflowRound
+	// OverflowSaturate means that the result is truncated toward zero and then clamped to the range of the type, with NaN
+	// becoming zero. Conversions from float64 to an integer type saturate.
+	OverflowSaturate
+)
+
+func (o Overflow) String() string {
+	switch o {
+	case OverflowNone:
+		return "none"
+	case OverflowWrap:
+		return "wrap"
+	case OverflowRound:
+		return "round"
+	case OverflowSaturate:
+		return "saturate"
+	default:
+		return "unknown"
+	}
+}
+
+// IsInteger returns whether the type is one of the fixed-width integer types.
+func IsInteger(typ Type) bool {
+	switch typ.(type) {
+	case *Int32, *Int64, *Uint8:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsNumeric returns whether the type is an integer or float type.
+func IsNumeric(typ Type) bool {
+	_, isFloat := typ.(*Float64)
+	return isFloat || IsInteger(typ)
+}
+
+// IntegerRange returns the smallest and largest values of an integer type.
+func IntegerRange(typ Type) (min, max int64) {
+	switch typ.(type) {
+	case *Int32:
+		return math.MinInt32, math.MaxInt32
+	case *Uint8:
+		return 0, math.MaxUint8
+	default:
+		return math.MinInt64, math.MaxInt64
+	}
+}
+
+// ArithmeticOverflow returns what happens when the result of arithmetic on the type overflows. Integer arithmetic
+// wraps, and float arithmetic rounds.
+func ArithmeticOverflow(typ Type) Overflow {
+	switch {
+	case IsInteger(typ):
+		return OverflowWrap
+	case IsNumeric(typ):
+		return OverflowRound
+	default:
+		return OverflowNone
+	}
+}
+
+// ConversionOverflow returns what happens when a value of one numeric type is converted into another one that can't hold
+// it.
+func ConversionOverflow(from, to Type) Overflow {
+	_, fromFloat := from.(*Float64)
+	_, toFloat := to.(*Float64)
+
+	switch {
+	case fromFloat && toFloat:
+		return OverflowNone
+	case fromFloat:
+		return OverflowSaturate
+	case toFloat:
+		// Only integers with more than 53 bits can lose precision.
+		if _, isInt64 := from.(*Int64); isInt64 {
+			return OverflowRound
+		}
+
+		return OverflowNone
+	}
+
+	fromMin, fromMax := IntegerRange(from)
+	toMin, toMax := IntegerRange(to)
+	if toMin <= fromMin && fromMax <= toMax {
+		return OverflowNone
+	}
+
+	return OverflowWrap
+}
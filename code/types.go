@@ -0,0 +1,216 @@
+package code
+
+// TypeOf returns the static type of a value.
+func TypeOf(value Value) Type {
+	return MapValueNoError[Type](value, typeOfMapper{})
+}
+
+// SameType returns whether the two types are identical.
+func SameType(a, b Type) bool {
+	switch a := a.(type) {
+	case *List:
+		b, ok := b.(*List)
+		return ok && SameType(a.Item, b.Item)
+	case *Map:
+		b, ok := b.(*Map)
+		return ok && SameType(a.Key, b.Key) && SameType(a.Value, b.Value)
+	case *Model:
+		b, ok := b.(*Model)
+		// Models of different modules can share a name.
+		return ok && a.Name == b.Name && a.Module == b.Module
+	case *Set:
+		b, ok := b.(*Set)
+		return ok && SameType(a.Item, b.Item)
+	default:
+		return TypeName(a) == TypeName(b)
+	}
+}
+
+// ItemType returns the type of the items produced when iterating over the given type, or nil if the type can't be
+// iterated over. Iterating over a map produces its keys, iterating over a string produces its runes, and iterating over
+// bytes produces uint8s.
+func ItemType(iterable Type) Type {
+	switch iterable := iterable.(type) {
+	case *List:
+		return iterable.Item
+	case *Map:
+		return iterable.Key
+	case *Set:
+		return iterable.Item
+	case *String:
+		return &Rune{}
+	case *Bytes:
+		return &Uint8{}
+	default:
+		return nil
+	}
+}
+
+// TypeName returns the name of a type as it's written in AgnosticScript.
+func TypeName(typ Type) string {
+	return MapTypeNoError[string](typ, typeNameMapper{})
+}
+
+type typeOfMapper struct{}
+
+func (typeOfMapper) MapBinary(value *Binary) Type {
+	return value.ValueMetadata.Type
+}
+
+func (typeOfMapper) MapCall(value *Call) Type {
+	return value.ValueMetadata.Type
+}
+
+func (typeOfMapper) MapComparison(value *Comparison) Type {
+	return value.ValueMetadata.Type
+}
+
+func (typeOfMapper) MapConversion(value *Conversion) Type {
+	return value.ValueMetadata.Type
+}
+
+func (typeOfMapper) MapEmptyList(value *EmptyList) Type {
+	return value.ValueMetadata.Type
+}
+
+func (typeOfMapper) MapLength(value *Length) Type {
+	return value.ValueMetadata.Type
+}
+
+func (typeOfMapper) MapLiteralBool(value *LiteralBool) Type {
+	return value.ValueMetadata.Type
+}
+
+func (typeOfMapper) MapLiteralBytes(value *LiteralBytes) Type {
+	return value.ValueMetadata.Type
+}
+
+func (typeOfMapper) MapLiteralFloat64(value *LiteralFloat64) Type {
+	return value.ValueMetadata.Type
+}
+
+func (typeOfMapper) MapLiteralInt32(value *LiteralInt32) Type {
+	return value.ValueMetadata.Type
+}
+
+func (typeOfMapper) MapLiteralInt64(value *LiteralInt64) Type {
+	return value.ValueMetadata.Type
+}
+
+func (typeOfMapper) MapLiteralList(value *LiteralList) Type {
+	return value.ValueMetadata.Type
+}
+
+func (typeOfMapper) MapLiteralMap(value *LiteralMap) Type {
+	return value.ValueMetadata.Type
+}
+
+func (typeOfMapper) MapLiteralRune(value *LiteralRune) Type {
+	return value.ValueMetadata.Type
+}
+
+func (typeOfMapper) MapLiteralSet(value *LiteralSet) Type {
+	return value.ValueMetadata.Type
+}
+
+func (typeOfMapper) MapLiteralString(value *LiteralString) Type {
+	return value.ValueMetadata.Type
+}
+
+func (typeOfMapper) MapLiteralUint8(value *LiteralUint8) Type {
+	return value.ValueMetadata.Type
+}
+
+func (typeOfMapper) MapLookup(value *Lookup) Type {
+	return value.ValueMetadata.Type
+}
+
+func (typeOfMapper) MapNew(value *New) Type {
+	return value.ValueMetadata.Type
+}
+
+func (typeOfMapper) MapNil(value *Nil) Type {
+	return value.ValueMetadata.Type
+}
+
+func (typeOfMapper) MapPop(value *Pop) Type {
+	return value.ValueMetadata.Type
+}
+
+func (typeOfMapper) MapMethodCall(value *MethodCall) Type {
+	return value.ValueMetadata.Type
+}
+
+func (typeOfMapper) MapProperty(value *Property) Type {
+	return value.ValueMetadata.Type
+}
+
+func (typeOfMapper) MapSelf(value *Self) Type {
+	return value.ValueMetadata.Type
+}
+
+func (typeOfMapper) MapSetContains(value *SetContains) Type {
+	return value.ValueMetadata.Type
+}
+
+func (typeOfMapper) MapUnary(value *Unary) Type {
+	return value.ValueMetadata.Type
+}
+
+func (typeOfMapper) MapVariable(value *Variable) Type {
+	return value.ValueMetadata.Type
+}
+
+type typeNameMapper struct{}
+
+func (typeNameMapper) MapBool(value *Bool) string {
+	return "bool"
+}
+
+func (typeNameMapper) MapBytes(value *Bytes) string {
+	return "bytes"
+}
+
+func (typeNameMapper) MapFloat64(value *Float64) string {
+	return "float64"
+}
+
+func (typeNameMapper) MapInt32(value *Int32) string {
+	return "int32"
+}
+
+func (typeNameMapper) MapInt64(value *Int64) string {
+	return "int64"
+}
+
+func (typeNameMapper) MapModel(value *Model) string {
+	return value.Name
+}
+
+func (typeNameMapper) MapRune(value *Rune) string {
+	return "rune"
+}
+
+func (typeNameMapper) MapString(value *String) string {
+	return "string"
+}
+
+func (typeNameMapper) MapUint8(value *Uint8) string {
+	return "uint8"
+}
+
+func (typeNameMapper) MapVoid(value *Void) string {
+	return "void"
+}
+
+func (typeNameMapper) MapList(value *List) string {
+	return "list[" + TypeName(value.Item) + "]"
+}
+
+func (typeNameMapper) MapMap(value *Map) string {
+	return "map[" + TypeName(value.Key) + ", " + TypeName(value.Value) + "]"
+}
+
+func (typeNameMapper) MapSet(value *Set) string {
+	return "set[" + TypeName(value.Item) + "]"
+}
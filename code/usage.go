@@ -0,0 +1,30 @@
+package code
+
+// Usage describes what the surrounding code does with a value. Backends for languages with ownership rules use it to
+// decide whether a value can be borrowed or has to be copied.
+type Usage int
+
+const (
+	// UsageRead means that the value is only inspected.
+	UsageRead Usage = iota
+	// UsageOwned means that the value is stored somewhere that outlives the expression, such as in a variable, a
+	// collection, or a return value.
+	UsageOwned
+	// UsageArgument means that the value is passed to a function. Whether it's borrowed or copied depends on the
+	// ArgumentDef that it's passed to.
+	UsageArgument
+	// UsageMutate means that the contents of the value are changed in place.
+	UsageMutate
+	// UsageAssign means that the value is replaced by an assignment.
+	UsageAssign
+)
+
+// Access returns the usage of a value that a property or lookup with this usage is applied to.
+func (u Usage) Access() Usage {
+	switch u {
+	case UsageMutate, UsageAssign:
+		return UsageMutate
+	default:
+		return UsageRead
+	}
+}
@@ -0,0 +1,72 @@
+// Package agnosticscript parses AgnosticScript, the textual syntax for the AST, into an ast.Root. Print does the
+// reverse, rendering an ast.Root as AgnosticScript in a canonical format.
+//
+// The grammar, in EBNF:
+//
+//	File       = { Module } .
+//	Module     = "module" ident "{" { Import } { [ "private" ] ( Constant | Model | Function ) } "}" .
+//	Import     = "import" ident .
+//	Name       = [ ident "." ] ident .
+//	Constant   = "const" ident "=" Value .
+//	Model      = "model" ident "{" { [ "private" ] ( Field | Function ) | Equals | Hash } "}" .
+//	Field      = ident Type .
+//	Equals     = "equals" "(" ident ")" Block .
+//	Hash       = "hash" Block .
+//	Function   = "func" ident "(" [ ident Type { "," ident Type } [ "," ] ] ")" [ Type ] Block .
+//	Type       = "bool" | "bytes" | "float64" | "int32" | "int64" | "rune" | "string" | "uint8" | Name
+//	           | "list" "[" Type "]" | "set" "[" Type "]" | "map" "[" Type "," Type "]" .
+//	Block      = "{" { Statement } "}" .
+//	Statement  = Simple | If | For | "break" | "continue" | "return" Value .
+//	Simple     = "var" ident "=" Value
+//	           | "push" "(" Value "," Value ")"
+//	           | "insert" "(" Value "," Value ")"
+//	           | Value [ "=" Value ] .
+//	If         = "if" Value Block [ "else" ( If | Block ) ] .
+//	For        = "for" ident "in" Value Block
+//	           | "for" [ Simple ] ";" Value ";" [ Simple ] Block
+//	           | "for" Value Block .
+//	Value      = And { "||" And } .
+//	And        = Comparison { "&&" Comparison } .
+//	Comparison = Sum [ ( "==" | "!=" | "<" | "<=" | ">" | ">=" ) Sum ] .
+//	Sum        = Product { ( "+" | "-" ) Product } .
+//	Product    = Unary { ( "*" | "/" | "%" ) Unary } .
+//	Unary      = ( "-" | "!" ) Unary | Postfix .
+//	Postfix    = Primary { "." ident [ "(" [ Values ] ")" ] | "[" Value "]" } .
+//	Primary    = int | "-" int | float | "-" float | string | rune | "true" | "false" | "self" | Name
+//	           | "(" Value ")"
+//	           | ( "float64" | "int32" | "int64" | "uint8" ) "(" Value ")"
+//	           | "bytes" "{" [ int { "," int } [ "," ] ] "}"
+//	           | Name "(" [ Values ] ")"
+//	           | "[" Values "]"
+//	           | "list" "[" Type "]" "{" "}"
+//	           | "set" "{" [ Values ] "}"
+//	           | "map" "{" [ Value ":" Value { "," Value ":" Value } [ "," ] ] "}"
+//	           | "nil" "(" Type ")"
+//	           | "new" "(" Name ")"
+//	           | "len" "(" Value ")"
+//	           | "pop" "(" Value ")"
+//	           | "contains" "(" Value "," Value ")" .
+//	Values     = Value { "," Value } [ "," ] .
+//
+// Strings and runes are quoted like Go literals. A function without a return type returns void. Comments start with
+// "//" and run to the end of the line. A model type is named by an identifier, which is why "equals" and "hash" are
+// only treated as overrides when followed by "(" and "{" respectively.
+//
+// Binary operators are left-associative, while comparisons can't be chained. A minus sign directly before a number is
+// part of the literal rather than a negation.
+//
+// A float has a fraction, an exponent, or both, such as "1.5" or "2e10". An integer without a conversion is an int64.
+// Converting an integer literal to a numeric type produces a literal of that type, so "uint8(255)" is a uint8 literal
+// while "uint8(x)" converts x at runtime.
+//
+// A statement that is a bare value must be a call, a method call, or a pop, and the target of an assignment must be a
+// variable, a property, or a lookup. Calls are resolved to the function of the same name in the enclosing module, while
+// method calls are resolved against the model of their receiver when the AST is mapped to code.
+//
+// A module can refer to the models, functions, and constants of the modules that it imports by qualifying their names
+// with the name of the module, such as "geometry.Point" or "geometry.area(p)". A name is only a qualifier if it's the
+// name of an imported module, so a module's imports can't be shadowed by its variables.
+//
+// Constants, models, fields, and functions are public unless they're marked as private. Only the module that defines a
+// private definition can refer to it, and the types of its public definitions can't use its private models.
+package agnosticscript
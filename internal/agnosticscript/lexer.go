@@ -0,0 +1,214 @@
+package agnosticscript
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const punctuation = "{}()[],.:;=-+*/%<>!"
+
+// The punctuation that is two characters long. These are matched before single characters so that "<=" isn't split
+// into "<" and "=".
+var longPunctuation = []string{"==", "!=", "<=", ">=", "&&", "||"}
+
+// lexer splits AgnosticScript source into tokens.
+type lexer struct {
+	source   string
+	filename string
+	// The byte offset of the next rune.
+	offset int
+	line   int
+	column int
+}
+
+// tokenize returns all of the tokens in the source. The last token is always tokenEOF.
+func tokenize(filename, source string) ([]token, error) {
+	l := &lexer{
+		source:   source,
+		filename: filename,
+		line:     1,
+		column:   1,
+	}
+
+	var tokens []token
+	for {
+		t, err := l.next()
+		if err != nil {
+			return nil, err
+		}
+
+		tokens = append(tokens, t)
+		if t.kind == tokenEOF {
+			return tokens, nil
+		}
+	}
+}
+
+func (l *lexer) position() Position {
+	return Position{
+		Filename: l.filename,
+		Line:     l.line,
+		Column:   l.column,
+		Offset:   l.offset,
+	}
+}
+
+// peek returns the next rune without consuming it. It returns utf8.RuneError at the end of the source.
+func (l *lexer) peek() rune {
+	if l.offset >= len(l.source) {
+		return utf8.RuneError
+	}
+
+	r, _ := utf8.DecodeRuneInString(l.source[l.offset:])
+	return r
+}
+
+// advance consumes the next rune.
+func (l *lexer) advance() rune {
+	r, size := utf8.DecodeRuneInString(l.source[l.offset:])
+	l.offset += size
+	if r == '\n' {
+		l.line++
+		l.column = 1
+	} else {
+		l.column += size
+	}
+
+	return r
+}
+
+func (l *lexer) atEnd() bool {
+	return l.offset >= len(l.source)
+}
+
+// skipSpaceAndComments consumes whitespace and line comments.
+func (l *lexer) skipSpaceAndComments() {
+	for !l.atEnd() {
+		switch {
+		case unicode.IsSpace(l.peek()):
+			l.advance()
+		case strings.HasPrefix(l.source[l.offset:], "//"):
+			for !l.atEnd() && l.peek() != '\n' {
+				l.advance()
+			}
+		default:
+			return
+		}
+	}
+}
+
+func (l *lexer) next() (token, error) {
+	l.skipSpaceAndComments()
+
+	start := l.offset
+	position := l.position()
+	if l.atEnd() {
+		return token{kind: tokenEOF, position: position}, nil
+	}
+
+	r := l.peek()
+	switch {
+	case r == '_' || unicode.IsLetter(r):
+		for !l.atEnd() && (l.peek() == '_' || unicode.IsLetter(l.peek()) || unicode.IsDigit(l.peek())) {
+			l.advance()
+		}
+
+		text := l.source[start:l.offset]
+		if _, isKeyword := keywords[text]; isKeyword {
+			return token{kind: tokenKeyword, text: text, position: position}, nil
+		}
+
+		return token{kind: tokenIdentifier, text: text, position: position}, nil
+	case '0' <= r && r <= '9':
+		return l.number()
+	case r == '"' || r == '\'':
+		return l.quoted(r)
+	}
+
+	for _, text := range longPunctuation {
+		if strings.HasPrefix(l.source[l.offset:], text) {
+			for range text {
+				l.advance()
+			}
+
+			return token{kind: tokenPunctuation, text: text, position: position}, nil
+		}
+	}
+
+	switch {
+	case strings.ContainsRune(punctuation, r):
+		l.advance()
+		return token{kind: tokenPunctuation, text: string(r), position: position}, nil
+	default:
+		return token{}, errorAt(position, "unexpected character %q", r)
+	}
+}
+
+// quoted consumes a string or rune literal. The text of the token includes the quotes and is unquoted by the parser.
+func (l *lexer) quoted(quote rune) (token, error) {
+	start := l.offset
+	position := l.position()
+
+	kind := tokenString
+	if quote == '\'' {
+		kind = tokenRune
+	}
+
+	l.advance()
+	for {
+		if l.atEnd() || l.peek() == '\n' {
+			return token{}, errorAt(position, "unterminated %s literal", kind)
+		}
+
+		r := l.advance()
+		if r == '\\' && !l.atEnd() {
+			l.advance()
+			continue
+		}
+
+		if r == quote {
+			return token{kind: kind, text: l.source[start:l.offset], position: position}, nil
+		}
+	}
+}
+
+// number consumes an integer or float literal. A float has a fraction, an exponent, or both. The fraction must start
+// with a digit so that a dot after an integer is still punctuation.
+func (l *lexer) number() (token, error) {
+	start := l.offset
+	position := l.position()
+
+	kind := tokenInt
+	l.digits()
+	if l.peek() == '.' && l.offset+1 < len(l.source) && isDigit(rune(l.source[l.offset+1])) {
+		kind = tokenFloat
+		l.advance()
+		l.digits()
+	}
+
+	if l.peek() == 'e' || l.peek() == 'E' {
+		kind = tokenFloat
+		l.advance()
+		if l.peek() == '+' || l.peek() == '-' {
+			l.advance()
+		}
+
+		if !isDigit(l.peek()) {
+			return token{}, errorAt(position, "missing exponent in float literal %s", l.source[start:l.offset])
+		}
+		l.digits()
+	}
+
+	return token{kind: kind, text: l.source[start:l.offset], position: position}, nil
+}
+
+func (l *lexer) digits() {
+	for !l.atEnd() && isDigit(l.peek()) {
+		l.advance()
+	}
+}
+
+func isDigit(r rune) bool {
+	return '0' <= r && r <= '9'
+}
@@ -0,0 +1,1403 @@
+package agnosticscript
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/JosephNaberhaus/agnostic/ast"
+)
+
+// Parse parses the AgnosticScript source of a single file into a root that contains each of the file's modules.
+func Parse(filename, source string) (ast.Root, error) {
+	tokens, err := tokenize(filename, source)
+	if err != nil {
+		return ast.Root{}, err
+	}
+
+	p := &parser{tokens: tokens}
+
+	var root ast.Root
+	names := map[string]struct{}{}
+	for !p.at(tokenEOF) {
+		position := p.peek().position
+		module, err := p.module()
+		if err != nil {
+			return ast.Root{}, err
+		}
+
+		if _, exists := names[module.Name]; exists {
+			return ast.Root{}, errorAt(position, "module %q is already defined", module.Name)
+		}
+		names[module.Name] = struct{}{}
+
+		root.Modules = append(root.Modules, module)
+	}
+
+	return root, nil
+}
+
+// parser is a recursive-descent parser over the tokens of a file. Each method parses a single production of the
+// grammar, starting at the current token.
+type parser struct {
+	tokens []token
+	// The index of the current token.
+	index int
+	// The names of the functions called by the module being parsed, which are checked once the whole module is parsed.
+	calls []token
+	// The names of the modules imported by the module being parsed. An imported module's name followed by a dot
+	// qualifies the name that comes after it.
+	imports map[string]struct{}
+	// The name of the module being parsed, which can also qualify the names of its own definitions.
+	moduleName string
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.index]
+}
+
+// peekAhead returns the token that is the given number of tokens after the current one.
+func (p *parser) peekAhead(n int) token {
+	if p.index+n >= len(p.tokens) {
+		return p.tokens[len(p.tokens)-1]
+	}
+
+	return p.tokens[p.index+n]
+}
+
+func (p *parser) advance() token {
+	t := p.tokens[p.index]
+	if t.kind != tokenEOF {
+		p.index++
+	}
+
+	return t
+}
+
+// span returns the position of the source from the token at the index first up to the end of the last consumed token.
+func (p *parser) span(first int) ast.Position {
+	start := p.tokens[first].position
+	end := start.Offset
+	if p.index > first {
+		last := p.tokens[p.index-1]
+		end = last.position.Offset + len(last.text)
+	}
+
+	return ast.Position{
+		Filename:  start.Filename,
+		Line:      start.Line,
+		Column:    start.Column,
+		Offset:    start.Offset,
+		EndOffset: end,
+	}
+}
+
+func (p *parser) at(kind tokenKind) bool {
+	return p.peek().kind == kind
+}
+
+func (p *parser) atText(text string) bool {
+	return p.peek().is(text)
+}
+
+// accept consumes the current token if it's the given keyword or punctuation.
+func (p *parser) accept(text string) bool {
+	if p.atText(text) {
+		p.advance()
+		return true
+	}
+
+	return false
+}
+
+func (p *parser) unexpected(expected string) error {
+	t := p.peek()
+	return errorAt(t.position, "expected %s but found %s", expected, t)
+}
+
+// expect consumes the current token if it's the given keyword or punctuation and returns an error otherwise.
+func (p *parser) expect(text string) error {
+	if !p.accept(text) {
+		return p.unexpected(strconv.Quote(text))
+	}
+
+	return nil
+}
+
+func (p *parser) identifier() (string, error) {
+	if !p.at(tokenIdentifier) {
+		return "", p.unexpected("identifier")
+	}
+
+	return p.advance().text, nil
+}
+
+// list parses a comma separated list of items that ends with the closing punctuation. The opening punctuation must
+// already be consumed. A trailing comma is allowed.
+func (p *parser) list(closing string, item func() error) error {
+	for !p.accept(closing) {
+		if err := item(); err != nil {
+			return err
+		}
+
+		if !p.accept(",") {
+			return p.expect(closing)
+		}
+	}
+
+	return nil
+}
+
+// module parses a module. Calls can refer to functions that are defined later in the module, so they're checked once
+// the whole module is parsed.
+func (p *parser) module() (ast.Module, error) {
+	first := p.index
+	if err := p.expect("module"); err != nil {
+		return ast.Module{}, err
+	}
+
+	name, err := p.identifier()
+	if err != nil {
+		return ast.Module{}, err
+	}
+
+	p.calls = nil
+	p.imports = map[string]struct{}{}
+	p.moduleName = name
+	module, err := p.moduleBody(name)
+	if err != nil {
+		return ast.Module{}, err
+	}
+
+	functions := map[string]struct{}{}
+	for _, function := range module.Functions {
+		functions[function.Name] = struct{}{}
+	}
+
+	for _, call := range p.calls {
+		if _, ok := functions[call.text]; !ok {
+			return ast.Module{}, errorAt(call.position, "undefined function %q", call.text)
+		}
+	}
+
+	module.Position = p.span(first)
+	return module, nil
+}
+
+func (p *parser) moduleBody(name string) (ast.Module, error) {
+	module := ast.Module{Name: name}
+	if err := p.expect("{"); err != nil {
+		return ast.Module{}, err
+	}
+
+	definitions := map[string]struct{}{}
+	define := func(position Position, kind, name string) error {
+		if _, exists := definitions[name]; exists {
+			return errorAt(position, "%s %q is already defined in module %q", kind, name, module.Name)
+		}
+
+		definitions[name] = struct{}{}
+		return nil
+	}
+
+	// Imports come first so that every qualified name in the module is known to be qualified when it's parsed.
+	for p.atText("import") {
+		position := p.peek().position
+		imported, err := p.importDeclaration()
+		if err != nil {
+			return ast.Module{}, err
+		}
+
+		if _, exists := p.imports[imported.Module]; exists {
+			return ast.Module{}, errorAt(position, "module %q is already imported", imported.Module)
+		}
+		p.imports[imported.Module] = struct{}{}
+
+		module.Imports = append(module.Imports, imported)
+	}
+
+	for !p.accept("}") {
+		position := p.peek().position
+		switch definition := p.definition(); {
+		case definition.is("const"):
+			constant, err := p.constant()
+			if err != nil {
+				return ast.Module{}, err
+			}
+
+			if err := define(position, "constant", constant.Name); err != nil {
+				return ast.Module{}, err
+			}
+
+			module.Constants = append(module.Constants, constant)
+		case definition.is("model"):
+			model, err := p.model()
+			if err != nil {
+				return ast.Module{}, err
+			}
+
+			if err := define(position, "model", model.Name); err != nil {
+				return ast.Module{}, err
+			}
+
+			module.Models = append(module.Models, model)
+		case definition.is("func"):
+			function, err := p.function()
+			if err != nil {
+				return ast.Module{}, err
+			}
+
+			if err := define(position, "function", function.Name); err != nil {
+				return ast.Module{}, err
+			}
+
+			module.Functions = append(module.Functions, function)
+		case definition.is("import"):
+			return ast.Module{}, errorAt(position, "imports must come before the constants, models, and functions of the module")
+		default:
+			p.visibility()
+			return ast.Module{}, p.unexpected("constant, model, or function")
+		}
+	}
+
+	return module, nil
+}
+
+func (p *parser) importDeclaration() (ast.Import, error) {
+	first := p.index
+	if err := p.expect("import"); err != nil {
+		return ast.Import{}, err
+	}
+
+	name, err := p.identifier()
+	if err != nil {
+		return ast.Import{}, err
+	}
+
+	return ast.Import{Module: name, Position: p.span(first)}, nil
+}
+
+// definition returns the token that starts the definition at the current token, skipping over its visibility.
+func (p *parser) definition() token {
+	if p.atText("private") {
+		return p.peekAhead(1)
+	}
+
+	return p.peek()
+}
+
+// visibility consumes the visibility of a definition. Definitions are public unless they're marked as private.
+func (p *parser) visibility() ast.Visibility {
+	if p.accept("private") {
+		return ast.VisibilityPrivate
+	}
+
+	return ast.VisibilityPublic
+}
+
+func (p *parser) constant() (ast.ConstantDef, error) {
+	first := p.index
+	visibility := p.visibility()
+	if err := p.expect("const"); err != nil {
+		return ast.ConstantDef{}, err
+	}
+
+	name, err := p.identifier()
+	if err != nil {
+		return ast.ConstantDef{}, err
+	}
+
+	if err := p.expect("="); err != nil {
+		return ast.ConstantDef{}, err
+	}
+
+	position := p.peek().position
+	value, err := p.value()
+	if err != nil {
+		return ast.ConstantDef{}, err
+	}
+
+	constant, ok := value.(ast.ConstantValue)
+	if !ok {
+		return ast.ConstantDef{}, errorAt(position, "the value of constant %q must be a literal", name)
+	}
+
+	return ast.ConstantDef{Name: name, Visibility: visibility, Value: constant, Position: p.span(first)}, nil
+}
+
+func (p *parser) model() (ast.ModelDef, error) {
+	first := p.index
+	visibility := p.visibility()
+	if err := p.expect("model"); err != nil {
+		return ast.ModelDef{}, err
+	}
+
+	name, err := p.identifier()
+	if err != nil {
+		return ast.ModelDef{}, err
+	}
+
+	if err := p.expect("{"); err != nil {
+		return ast.ModelDef{}, err
+	}
+
+	model := ast.ModelDef{Name: name, Visibility: visibility}
+	members := map[string]struct{}{}
+	define := func(position Position, kind, member string) error {
+		if _, exists := members[member]; exists {
+			return errorAt(position, "%s %q is already defined in model %q", kind, member, name)
+		}
+
+		members[member] = struct{}{}
+		return nil
+	}
+
+	for !p.accept("}") {
+		position := p.peek().position
+		switch member := p.definition(); {
+		case member.is("func"):
+			method, err := p.function()
+			if err != nil {
+				return ast.ModelDef{}, err
+			}
+
+			if err := define(position, "method", method.Name); err != nil {
+				return ast.ModelDef{}, err
+			}
+
+			model.Methods = append(model.Methods, method)
+		case p.peek().text == "equals" && p.peekAhead(1).is("("):
+			if model.EqualOverride.IsSet() {
+				return ast.ModelDef{}, errorAt(position, "model %q already overrides equals", name)
+			}
+
+			override, err := p.equalOverride()
+			if err != nil {
+				return ast.ModelDef{}, err
+			}
+
+			model.EqualOverride = ast.OptionalWithValue(override)
+		case p.peek().text == "hash" && p.peekAhead(1).is("{"):
+			if model.HashOverride.IsSet() {
+				return ast.ModelDef{}, errorAt(position, "model %q already overrides hash", name)
+			}
+
+			override, err := p.hashOverride()
+			if err != nil {
+				return ast.ModelDef{}, err
+			}
+
+			model.HashOverride = ast.OptionalWithValue(override)
+		case member.kind == tokenIdentifier:
+			field, err := p.field()
+			if err != nil {
+				return ast.ModelDef{}, err
+			}
+
+			if err := define(position, "field", field.Name); err != nil {
+				return ast.ModelDef{}, err
+			}
+
+			model.Fields = append(model.Fields, field)
+		default:
+			p.visibility()
+			return ast.ModelDef{}, p.unexpected("field, method, equals, or hash")
+		}
+	}
+
+	model.Position = p.span(first)
+	return model, nil
+}
+
+func (p *parser) field() (ast.FieldDef, error) {
+	first := p.index
+	visibility := p.visibility()
+	name, err := p.identifier()
+	if err != nil {
+		return ast.FieldDef{}, err
+	}
+
+	typ, err := p.typ()
+	if err != nil {
+		return ast.FieldDef{}, err
+	}
+
+	return ast.FieldDef{Name: name, Visibility: visibility, Type: typ, Position: p.span(first)}, nil
+}
+
+func (p *parser) equalOverride() (ast.EqualOverride, error) {
+	first := p.index
+	p.advance()
+	if err := p.expect("("); err != nil {
+		return ast.EqualOverride{}, err
+	}
+
+	otherName, err := p.identifier()
+	if err != nil {
+		return ast.EqualOverride{}, err
+	}
+
+	if err := p.expect(")"); err != nil {
+		return ast.EqualOverride{}, err
+	}
+
+	block, err := p.block()
+	if err != nil {
+		return ast.EqualOverride{}, err
+	}
+
+	return ast.EqualOverride{OtherName: otherName, Block: block, Position: p.span(first)}, nil
+}
+
+func (p *parser) hashOverride() (ast.HashOverride, error) {
+	first := p.index
+	p.advance()
+
+	block, err := p.block()
+	if err != nil {
+		return ast.HashOverride{}, err
+	}
+
+	return ast.HashOverride{Block: block, Position: p.span(first)}, nil
+}
+
+func (p *parser) function() (ast.FunctionDef, error) {
+	first := p.index
+	visibility := p.visibility()
+	if err := p.expect("func"); err != nil {
+		return ast.FunctionDef{}, err
+	}
+
+	name, err := p.identifier()
+	if err != nil {
+		return ast.FunctionDef{}, err
+	}
+
+	if err := p.expect("("); err != nil {
+		return ast.FunctionDef{}, err
+	}
+
+	function := ast.FunctionDef{Name: name, Visibility: visibility}
+	err = p.list(")", func() error {
+		first := p.index
+		argumentName, err := p.identifier()
+		if err != nil {
+			return err
+		}
+
+		typ, err := p.typ()
+		if err != nil {
+			return err
+		}
+
+		function.Arguments = append(function.Arguments, ast.ArgumentDef{Name: argumentName, Type: typ, Position: p.span(first)})
+		return nil
+	})
+	if err != nil {
+		return ast.FunctionDef{}, err
+	}
+
+	// Functions without a return type return void.
+	function.ReturnType = ast.Void{}
+	if !p.atText("{") {
+		function.ReturnType, err = p.typ()
+		if err != nil {
+			return ast.FunctionDef{}, err
+		}
+	}
+
+	function.Block, err = p.block()
+	if err != nil {
+		return ast.FunctionDef{}, err
+	}
+
+	function.Position = p.span(first)
+	return function, nil
+}
+
+func (p *parser) typ() (ast.Type, error) {
+	first := p.index
+	t := p.peek()
+	if t.kind == tokenIdentifier {
+		return p.modelType()
+	}
+
+	if t.kind != tokenKeyword {
+		return nil, p.unexpected("type")
+	}
+
+	switch t.text {
+	case "bool":
+		p.advance()
+		return ast.Bool{Position: p.span(first)}, nil
+	case "bytes":
+		p.advance()
+		return ast.Bytes{Position: p.span(first)}, nil
+	case "float64":
+		p.advance()
+		return ast.Float64{Position: p.span(first)}, nil
+	case "int32":
+		p.advance()
+		return ast.Int32{Position: p.span(first)}, nil
+	case "int64":
+		p.advance()
+		return ast.Int64{Position: p.span(first)}, nil
+	case "rune":
+		p.advance()
+		return ast.Rune{Position: p.span(first)}, nil
+	case "string":
+		p.advance()
+		return ast.String{Position: p.span(first)}, nil
+	case "uint8":
+		p.advance()
+		return ast.Uint8{Position: p.span(first)}, nil
+	case "list", "set":
+		p.advance()
+		item, err := p.typeArgument()
+		if err != nil {
+			return nil, err
+		}
+
+		if t.text == "list" {
+			return ast.List{Item: item, Position: p.span(first)}, nil
+		}
+
+		return ast.Set{Item: item, Position: p.span(first)}, nil
+	case "map":
+		p.advance()
+		if err := p.expect("["); err != nil {
+			return nil, err
+		}
+
+		key, err := p.typ()
+		if err != nil {
+			return nil, err
+		}
+
+		if err := p.expect(","); err != nil {
+			return nil, err
+		}
+
+		value, err := p.typ()
+		if err != nil {
+			return nil, err
+		}
+
+		if err := p.expect("]"); err != nil {
+			return nil, err
+		}
+
+		return ast.Map{Key: key, Value: value, Position: p.span(first)}, nil
+	default:
+		return nil, p.unexpected("type")
+	}
+}
+
+// modelType parses the name of a model, which is qualified by the name of its module if the model is imported.
+func (p *parser) modelType() (ast.Model, error) {
+	first := p.index
+	module := p.qualifier()
+	name, err := p.identifier()
+	if err != nil {
+		return ast.Model{}, err
+	}
+
+	return ast.Model{Name: name, Module: module, Position: p.span(first)}, nil
+}
+
+// qualifier consumes the name of an imported module or of the module being parsed and the dot that follows it, and
+// returns the name of the module. Any other name isn't a qualifier, in which case nothing is consumed.
+func (p *parser) qualifier() string {
+	t := p.peek()
+	if t.kind != tokenIdentifier || !p.peekAhead(1).is(".") {
+		return ""
+	}
+
+	if _, imported := p.imports[t.text]; !imported && t.text != p.moduleName {
+		return ""
+	}
+
+	p.advance()
+	p.advance()
+	return t.text
+}
+
+// typeArgument parses a type wrapped in square brackets.
+func (p *parser) typeArgument() (ast.Type, error) {
+	if err := p.expect("["); err != nil {
+		return nil, err
+	}
+
+	typ, err := p.typ()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.expect("]"); err != nil {
+		return nil, err
+	}
+
+	return typ, nil
+}
+
+func (p *parser) block() (ast.Block, error) {
+	first := p.index
+	if err := p.expect("{"); err != nil {
+		return ast.Block{}, err
+	}
+
+	var block ast.Block
+	for !p.accept("}") {
+		statement, err := p.statement()
+		if err != nil {
+			return ast.Block{}, err
+		}
+
+		block.Statements = append(block.Statements, statement)
+	}
+
+	block.Position = p.span(first)
+	return block, nil
+}
+
+func (p *parser) statement() (ast.Statement, error) {
+	first := p.index
+	switch {
+	case p.atText("if"):
+		return p.conditional()
+	case p.atText("for"):
+		return p.loop()
+	case p.accept("break"):
+		return ast.Break{Position: p.span(first)}, nil
+	case p.accept("continue"):
+		return ast.Continue{Position: p.span(first)}, nil
+	case p.accept("return"):
+		value, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+
+		return ast.Return{Value: value, Position: p.span(first)}, nil
+	default:
+		return p.simpleStatement()
+	}
+}
+
+// simpleStatement parses a statement that can be used in the header of a for loop.
+func (p *parser) simpleStatement() (ast.Statement, error) {
+	first := p.index
+	position := p.peek().position
+	switch {
+	case p.accept("var"):
+		name, err := p.identifier()
+		if err != nil {
+			return nil, err
+		}
+
+		if err := p.expect("="); err != nil {
+			return nil, err
+		}
+
+		value, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+
+		return ast.Declare{Name: name, Value: value, Position: p.span(first)}, nil
+	case p.atText("push"), p.atText("insert"):
+		builtin := p.advance().text
+		arguments, err := p.builtinArguments(builtin, 2)
+		if err != nil {
+			return nil, err
+		}
+
+		if builtin == "push" {
+			return ast.Push{List: arguments[0], Value: arguments[1], Position: p.span(first)}, nil
+		}
+
+		return ast.AddToSet{Set: arguments[0], Value: arguments[1], Position: p.span(first)}, nil
+	}
+
+	value, err := p.value()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.accept("=") {
+		switch value.(type) {
+		case ast.Lookup, ast.Property, ast.Variable:
+		default:
+			return nil, errorAt(position, "cannot assign to this value")
+		}
+
+		from, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+
+		return ast.Assignment{To: value, From: from, Position: p.span(first)}, nil
+	}
+
+	statement, ok := value.(ast.Statement)
+	if !ok {
+		return nil, errorAt(position, "value is not used")
+	}
+
+	return statement, nil
+}
+
+func (p *parser) conditional() (ast.Statement, error) {
+	first := p.index
+	var conditional ast.Conditional
+	for {
+		ifFirst := p.index
+		if err := p.expect("if"); err != nil {
+			return nil, err
+		}
+
+		condition, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+
+		block, err := p.block()
+		if err != nil {
+			return nil, err
+		}
+
+		conditional.Ifs = append(conditional.Ifs, ast.If{Condition: condition, Block: block, Position: p.span(ifFirst)})
+
+		if !p.accept("else") {
+			conditional.Position = p.span(first)
+			return conditional, nil
+		}
+
+		if !p.atText("if") {
+			elseBlock, err := p.block()
+			if err != nil {
+				return nil, err
+			}
+
+			conditional.Else = ast.OptionalWithValue(elseBlock)
+			conditional.Position = p.span(first)
+			return conditional, nil
+		}
+	}
+}
+
+// loop parses one of the three forms of for loop: "for item in iterable", "for init; condition; afterEach", and
+// "for condition".
+func (p *parser) loop() (ast.Statement, error) {
+	first := p.index
+	if err := p.expect("for"); err != nil {
+		return nil, err
+	}
+
+	if p.at(tokenIdentifier) && p.peekAhead(1).is("in") {
+		itemName := p.advance().text
+		p.advance()
+
+		iterable, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+
+		block, err := p.block()
+		if err != nil {
+			return nil, err
+		}
+
+		return ast.ForEach{ItemName: itemName, Iterable: iterable, Block: block, Position: p.span(first)}, nil
+	}
+
+	var loop ast.For
+	if !p.atText(";") {
+		// This is either the initialization or the condition of a loop without a header.
+		position := p.peek().position
+		start := p.index
+		condition, err := p.value()
+		if err == nil && p.atText("{") {
+			loop.Condition = condition
+			loop.Block, err = p.block()
+			if err != nil {
+				return nil, err
+			}
+
+			loop.Position = p.span(first)
+			return loop, nil
+		}
+
+		p.index = start
+		initialization, err := p.simpleStatement()
+		if err != nil {
+			return nil, err
+		}
+
+		if !p.atText(";") {
+			return nil, errorAt(position, "expected a for loop header")
+		}
+
+		loop.Initialization = ast.OptionalWithValue(initialization)
+	}
+
+	if err := p.expect(";"); err != nil {
+		return nil, err
+	}
+
+	condition, err := p.value()
+	if err != nil {
+		return nil, err
+	}
+	loop.Condition = condition
+
+	if err := p.expect(";"); err != nil {
+		return nil, err
+	}
+
+	if !p.atText("{") {
+		afterEach, err := p.simpleStatement()
+		if err != nil {
+			return nil, err
+		}
+
+		loop.AfterEach = ast.OptionalWithValue(afterEach)
+	}
+
+	loop.Block, err = p.block()
+	if err != nil {
+		return nil, err
+	}
+
+	loop.Position = p.span(first)
+	return loop, nil
+}
+
+// builtinArguments parses the parenthesized arguments of a builtin that takes a fixed number of arguments.
+func (p *parser) builtinArguments(builtin string, count int) ([]ast.Value, error) {
+	position := p.peek().position
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+
+	arguments, err := p.arguments()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(arguments) != count {
+		return nil, errorAt(position, "%s takes %d arguments but %d were given", builtin, count, len(arguments))
+	}
+
+	return arguments, nil
+}
+
+// arguments parses the values of a call. The opening parenthesis must already be consumed.
+func (p *parser) arguments() ([]ast.Value, error) {
+	var arguments []ast.Value
+	err := p.list(")", func() error {
+		argument, err := p.value()
+		if err != nil {
+			return err
+		}
+
+		arguments = append(arguments, argument)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return arguments, nil
+}
+
+var (
+	orOperators = map[string]ast.BinaryOperator{
+		"||": ast.BinaryOperatorOr,
+	}
+	andOperators = map[string]ast.BinaryOperator{
+		"&&": ast.BinaryOperatorAnd,
+	}
+	additiveOperators = map[string]ast.BinaryOperator{
+		"+": ast.BinaryOperatorAdd,
+		"-": ast.BinaryOperatorSubtract,
+	}
+	multiplicativeOperators = map[string]ast.BinaryOperator{
+		"*": ast.BinaryOperatorMultiply,
+		"/": ast.BinaryOperatorDivide,
+		"%": ast.BinaryOperatorModulo,
+	}
+	comparisonOperators = map[string]ast.ComparisonOperator{
+		"==": ast.ComparisonOperatorEqual,
+		"!=": ast.ComparisonOperatorNotEqual,
+		"<":  ast.ComparisonOperatorLessThan,
+		"<=": ast.ComparisonOperatorLessThanOrEqual,
+		">":  ast.ComparisonOperatorGreaterThan,
+		">=": ast.ComparisonOperatorGreaterThanOrEqual,
+	}
+)
+
+// value parses a value, including any operators. From the lowest precedence to the highest, the operators are ||, &&,
+// the comparisons, + and -, and then *, / and %. Unary operators bind tighter than all of them.
+func (p *parser) value() (ast.Value, error) {
+	return p.binary(orOperators, func() (ast.Value, error) {
+		return p.binary(andOperators, p.comparison)
+	})
+}
+
+// binary parses a left-associative chain of operands that are separated by any of the operators.
+func (p *parser) binary(operators map[string]ast.BinaryOperator, operand func() (ast.Value, error)) (ast.Value, error) {
+	first := p.index
+	left, err := operand()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		operator, ok := operators[p.peek().text]
+		if !ok || !p.at(tokenPunctuation) {
+			return left, nil
+		}
+		p.advance()
+
+		right, err := operand()
+		if err != nil {
+			return nil, err
+		}
+
+		left = ast.Binary{Left: left, Operator: operator, Right: right, Position: p.span(first)}
+	}
+}
+
+// comparison parses a single comparison. Comparisons don't chain, since the result of one can't be ordered.
+func (p *parser) comparison() (ast.Value, error) {
+	first := p.index
+	left, err := p.additive()
+	if err != nil {
+		return nil, err
+	}
+
+	operator, ok := comparisonOperators[p.peek().text]
+	if !ok || !p.at(tokenPunctuation) {
+		return left, nil
+	}
+	p.advance()
+
+	right, err := p.additive()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, chained := comparisonOperators[p.peek().text]; chained && p.at(tokenPunctuation) {
+		return nil, errorAt(p.peek().position, "comparisons can't be chained; use && to combine them")
+	}
+
+	return ast.Comparison{Left: left, Operator: operator, Right: right, Position: p.span(first)}, nil
+}
+
+func (p *parser) additive() (ast.Value, error) {
+	return p.binary(additiveOperators, func() (ast.Value, error) {
+		return p.binary(multiplicativeOperators, p.unary)
+	})
+}
+
+// unary parses a value that may be negated or inverted. A minus sign directly before a number is part of the literal
+// instead.
+func (p *parser) unary() (ast.Value, error) {
+	first := p.index
+
+	var operator ast.UnaryOperator
+	switch {
+	case p.atText("-") && p.peekAhead(1).kind != tokenInt && p.peekAhead(1).kind != tokenFloat:
+		operator = ast.UnaryOperatorNegate
+	case p.atText("!"):
+		operator = ast.UnaryOperatorNot
+	default:
+		return p.postfix()
+	}
+	p.advance()
+
+	value, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+
+	return ast.Unary{Operator: operator, Value: value, Position: p.span(first)}, nil
+}
+
+// postfix parses a primary value followed by any number of property accesses, method calls, and lookups.
+func (p *parser) postfix() (ast.Value, error) {
+	first := p.index
+	value, err := p.primary()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		switch {
+		case p.accept("."):
+			name, err := p.identifier()
+			if err != nil {
+				return nil, err
+			}
+
+			if p.accept("(") {
+				arguments, err := p.arguments()
+				if err != nil {
+					return nil, err
+				}
+
+				value = ast.MethodCall{Receiver: value, Name: name, Arguments: arguments, Position: p.span(first)}
+				continue
+			}
+
+			value = ast.Property{Of: value, Name: name, Position: p.span(first)}
+		case p.accept("["):
+			key, err := p.value()
+			if err != nil {
+				return nil, err
+			}
+
+			if err := p.expect("]"); err != nil {
+				return nil, err
+			}
+
+			value = ast.Lookup{From: value, Key: key, Position: p.span(first)}
+		default:
+			return value, nil
+		}
+	}
+}
+
+func (p *parser) primary() (ast.Value, error) {
+	first := p.index
+	t := p.peek()
+	switch t.kind {
+	case tokenInt:
+		return p.integer("", first)
+	case tokenFloat:
+		return p.float("", first)
+	case tokenString:
+		p.advance()
+		value, err := strconv.Unquote(t.text)
+		if err != nil {
+			return nil, errorAt(t.position, "invalid string literal %s", t.text)
+		}
+
+		return ast.LiteralString{Value: value, Position: p.span(first)}, nil
+	case tokenRune:
+		p.advance()
+		value, _, tail, err := strconv.UnquoteChar(t.text[1:len(t.text)-1], '\'')
+		if err != nil || tail != "" {
+			return nil, errorAt(t.position, "invalid rune literal %s", t.text)
+		}
+
+		return ast.LiteralRune{Value: value, Position: p.span(first)}, nil
+	case tokenIdentifier:
+		module := p.qualifier()
+		t = p.peek()
+		if !p.at(tokenIdentifier) {
+			return nil, p.unexpected("identifier")
+		}
+
+		p.advance()
+		name := p.span(first)
+		if !p.accept("(") {
+			return ast.Variable{Name: t.text, Module: module, Position: name}, nil
+		}
+
+		arguments, err := p.arguments()
+		if err != nil {
+			return nil, err
+		}
+
+		// Functions of other modules are checked when the AST is mapped, since the module might be in another file.
+		if module == "" || module == p.moduleName {
+			p.calls = append(p.calls, t)
+		}
+
+		function := ast.FunctionRef{Name: t.text, Module: module, Position: name}
+		return ast.Call{Function: function, Arguments: arguments, Position: p.span(first)}, nil
+	}
+
+	switch {
+	case p.accept("-"):
+		if p.at(tokenFloat) {
+			return p.float("-", first)
+		}
+
+		if !p.at(tokenInt) {
+			return nil, p.unexpected("number")
+		}
+
+		return p.integer("-", first)
+	case p.atText("float64"), p.atText("int32"), p.atText("int64"), p.atText("uint8"):
+		return p.conversion()
+	case p.accept("bytes"):
+		return p.literalBytes(first)
+	case p.accept("("):
+		value, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+
+		if err := p.expect(")"); err != nil {
+			return nil, err
+		}
+
+		return value, nil
+	case p.accept("true"):
+		return ast.LiteralBool{Value: true, Position: p.span(first)}, nil
+	case p.accept("false"):
+		return ast.LiteralBool{Value: false, Position: p.span(first)}, nil
+	case p.accept("self"):
+		return ast.Self{Position: p.span(first)}, nil
+	case p.accept("["):
+		values, err := p.values("]")
+		if err != nil {
+			return nil, err
+		}
+
+		if len(values) == 0 {
+			return nil, errorAt(t.position, "an empty list needs a type; use list[T]{} instead")
+		}
+
+		return ast.LiteralList{Values: values, Position: p.span(first)}, nil
+	case p.accept("list"):
+		item, err := p.typeArgument()
+		if err != nil {
+			return nil, err
+		}
+
+		if err := p.expect("{"); err != nil {
+			return nil, err
+		}
+
+		if err := p.expect("}"); err != nil {
+			return nil, err
+		}
+
+		return ast.EmptyList{Type: item, Position: p.span(first)}, nil
+	case p.accept("set"):
+		if err := p.expect("{"); err != nil {
+			return nil, err
+		}
+
+		values, err := p.values("}")
+		if err != nil {
+			return nil, err
+		}
+
+		return ast.LiteralSet{Values: values, Position: p.span(first)}, nil
+	case p.accept("map"):
+		return p.literalMap(first)
+	case p.accept("nil"):
+		if err := p.expect("("); err != nil {
+			return nil, err
+		}
+
+		typ, err := p.typ()
+		if err != nil {
+			return nil, err
+		}
+
+		if err := p.expect(")"); err != nil {
+			return nil, err
+		}
+
+		return ast.Nil{Type: typ, Position: p.span(first)}, nil
+	case p.accept("new"):
+		if err := p.expect("("); err != nil {
+			return nil, err
+		}
+
+		model, err := p.modelType()
+		if err != nil {
+			return nil, err
+		}
+
+		if err := p.expect(")"); err != nil {
+			return nil, err
+		}
+
+		return ast.New{Model: model, Position: p.span(first)}, nil
+	case p.accept("len"):
+		arguments, err := p.builtinArguments("len", 1)
+		if err != nil {
+			return nil, err
+		}
+
+		return ast.Length{Of: arguments[0], Position: p.span(first)}, nil
+	case p.accept("pop"):
+		arguments, err := p.builtinArguments("pop", 1)
+		if err != nil {
+			return nil, err
+		}
+
+		return ast.Pop{List: arguments[0], Position: p.span(first)}, nil
+	case p.accept("contains"):
+		arguments, err := p.builtinArguments("contains", 2)
+		if err != nil {
+			return nil, err
+		}
+
+		return ast.SetContains{Set: arguments[0], Value: arguments[1], Position: p.span(first)}, nil
+	default:
+		return nil, p.unexpected("value")
+	}
+}
+
+// integer parses an integer literal with the given sign. The literal starts at the token at the index first, which is
+// the sign if there is one.
+func (p *parser) integer(sign string, first int) (ast.Value, error) {
+	t := p.advance()
+	value, err := strconv.ParseInt(sign+t.text, 10, 64)
+	if err != nil {
+		return nil, errorAt(t.position, "integer literal %s%s is out of range", sign, t.text)
+	}
+
+	return ast.LiteralInt64{Value: value, Position: p.span(first)}, nil
+}
+
+// float parses a float literal with the given sign. The literal starts at the token at the index first, which is the
+// sign if there is one.
+func (p *parser) float(sign string, first int) (ast.Value, error) {
+	t := p.advance()
+	value, err := strconv.ParseFloat(sign+t.text, 64)
+	if err != nil {
+		return nil, errorAt(t.position, "float literal %s%s is out of range", sign, t.text)
+	}
+
+	return ast.LiteralFloat64{Value: value, Position: p.span(first)}, nil
+}
+
+// conversion parses a conversion to a numeric type, such as "int32(x)". Converting an integer literal instead produces
+// a literal of that type, which is how literals of types other than int64 are written.
+func (p *parser) conversion() (ast.Value, error) {
+	first := p.index
+	to, err := p.typ()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+
+	position := p.peek().position
+	value, err := p.value()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.expect(")"); err != nil {
+		return nil, err
+	}
+
+	literal, isLiteral := value.(ast.LiteralInt64)
+	if !isLiteral {
+		return ast.Conversion{Value: value, To: to, Position: p.span(first)}, nil
+	}
+
+	switch to.(type) {
+	case ast.Float64:
+		return ast.LiteralFloat64{Value: float64(literal.Value), Position: p.span(first)}, nil
+	case ast.Int32:
+		if literal.Value < math.MinInt32 || literal.Value > math.MaxInt32 {
+			return nil, errorAt(position, "integer literal %d is out of range for int32", literal.Value)
+		}
+
+		return ast.LiteralInt32{Value: int32(literal.Value), Position: p.span(first)}, nil
+	case ast.Uint8:
+		if literal.Value < 0 || literal.Value > math.MaxUint8 {
+			return nil, errorAt(position, "integer literal %d is out of range for uint8", literal.Value)
+		}
+
+		return ast.LiteralUint8{Value: uint8(literal.Value), Position: p.span(first)}, nil
+	default:
+		literal.Position = p.span(first)
+		return literal, nil
+	}
+}
+
+// literalBytes parses a literal byte array. The "bytes" keyword, which is the token at the index first, must already be
+// consumed.
+func (p *parser) literalBytes(first int) (ast.Value, error) {
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+
+	literal := ast.LiteralBytes{Value: []uint8{}}
+	err := p.list("}", func() error {
+		t := p.peek()
+		if !p.at(tokenInt) {
+			return p.unexpected("integer")
+		}
+		p.advance()
+
+		value, err := strconv.ParseUint(t.text, 10, 8)
+		if err != nil {
+			return errorAt(t.position, "integer literal %s is out of range for uint8", t.text)
+		}
+
+		literal.Value = append(literal.Value, uint8(value))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	literal.Position = p.span(first)
+	return literal, nil
+}
+
+// values parses a comma separated list of values. The opening punctuation must already be consumed.
+func (p *parser) values(closing string) ([]ast.Value, error) {
+	var values []ast.Value
+	err := p.list(closing, func() error {
+		value, err := p.value()
+		if err != nil {
+			return err
+		}
+
+		values = append(values, value)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return values, nil
+}
+
+// literalMap parses a literal map. The "map" keyword, which is the token at the index first, must already be consumed.
+func (p *parser) literalMap(first int) (ast.Value, error) {
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+
+	var literal ast.LiteralMap
+	err := p.list("}", func() error {
+		keyValueFirst := p.index
+		key, err := p.value()
+		if err != nil {
+			return err
+		}
+
+		if err := p.expect(":"); err != nil {
+			return err
+		}
+
+		value, err := p.value()
+		if err != nil {
+			return err
+		}
+
+		literal.Values = append(literal.Values, ast.KeyValue{Key: key, Value: value, Position: p.span(keyValueFirst)})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	literal.Position = p.span(first)
+	return literal, nil
+}
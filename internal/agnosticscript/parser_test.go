@@ -0,0 +1,648 @@
+package agnosticscript
+
+import (
+	"reflect"
+	"testing"
+	"unsafe"
+
+	"github.com/JosephNaberhaus/agnostic/ast"
+	"github.com/JosephNaberhaus/agnostic/internal/mappers/ast_to_code_mapper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const example = `
+// Models, constants, and functions.
+module example {
+	const greeting = "hello"
+	const minimum = -9223372036854775808
+
+	model Point {
+		x int64
+		next Point
+
+		equals(other) {
+			return contains(set{other.x}, self.x)
+		}
+
+		hash {
+			return self.x
+		}
+
+		func move(dx int64) {
+			self.x = dx
+		}
+	}
+
+	func main() int64 {
+		return count(1)
+	}
+
+	func count(n int64) int64 {
+		return count(n)
+	}
+}
+`
+
+func TestParse(t *testing.T) {
+	root, err := Parse("example.as", example)
+	require.NoError(t, err)
+
+	count := ast.FunctionRef{Name: "count"}
+
+	expected := ast.Root{Modules: []ast.Module{{
+		Name: "example",
+		Constants: []ast.ConstantDef{
+			{Name: "greeting", Value: ast.LiteralString{Value: "hello"}},
+			{Name: "minimum", Value: ast.LiteralInt64{Value: -9223372036854775808}},
+		},
+		Models: []ast.ModelDef{{
+			Name: "Point",
+			Fields: []ast.FieldDef{
+				{Name: "x", Type: ast.Int64{}},
+				{Name: "next", Type: ast.Model{Name: "Point"}},
+			},
+			Methods: []ast.FunctionDef{{
+				Name:       "move",
+				Arguments:  []ast.ArgumentDef{{Name: "dx", Type: ast.Int64{}}},
+				ReturnType: ast.Void{},
+				Block: ast.Block{Statements: []ast.Statement{
+					ast.Assignment{To: ast.Property{Of: ast.Self{}, Name: "x"}, From: ast.Variable{Name: "dx"}},
+				}},
+			}},
+			EqualOverride: ast.OptionalWithValue(ast.EqualOverride{
+				OtherName: "other",
+				Block: ast.Block{Statements: []ast.Statement{
+					ast.Return{Value: ast.SetContains{
+						Set:   ast.LiteralSet{Values: []ast.Value{ast.Property{Of: ast.Variable{Name: "other"}, Name: "x"}}},
+						Value: ast.Property{Of: ast.Self{}, Name: "x"},
+					}},
+				}},
+			}),
+			HashOverride: ast.OptionalWithValue(ast.HashOverride{
+				Block: ast.Block{Statements: []ast.Statement{
+					ast.Return{Value: ast.Property{Of: ast.Self{}, Name: "x"}},
+				}},
+			}),
+		}},
+		Functions: []ast.FunctionDef{
+			{
+				Name:       "main",
+				ReturnType: ast.Int64{},
+				Block: ast.Block{Statements: []ast.Statement{
+					ast.Return{Value: ast.Call{Function: count, Arguments: []ast.Value{ast.LiteralInt64{Value: 1}}}},
+				}},
+			},
+			{
+				Name:       "count",
+				Arguments:  []ast.ArgumentDef{{Name: "n", Type: ast.Int64{}}},
+				ReturnType: ast.Int64{},
+				Block: ast.Block{Statements: []ast.Statement{
+					ast.Return{Value: ast.Call{Function: count, Arguments: []ast.Value{ast.Variable{Name: "n"}}}},
+				}},
+			},
+		},
+	}}}
+
+	assert.Equal(t, expected, withoutPositions(root))
+}
+
+func TestParse_Statements(t *testing.T) {
+	list := ast.Variable{Name: "l"}
+	set := ast.Variable{Name: "s"}
+	one := ast.LiteralInt64{Value: 1}
+	body := ast.Block{Statements: []ast.Statement{ast.Break{}}}
+
+	tests := []struct {
+		name     string
+		source   string
+		expected ast.Statement
+	}{
+		{
+			name:     "Declare",
+			source:   `var x = "é"`,
+			expected: ast.Declare{Name: "x", Value: ast.LiteralString{Value: "é"}},
+		},
+		{
+			name:   "LiteralList",
+			source: `var x = [1, 2,]`,
+			expected: ast.Declare{Name: "x", Value: ast.LiteralList{Values: []ast.Value{
+				one, ast.LiteralInt64{Value: 2},
+			}}},
+		},
+		{
+			name:     "EmptyList",
+			source:   `var x = list[map[string, set[rune]]]{}`,
+			expected: ast.Declare{Name: "x", Value: ast.EmptyList{Type: ast.Map{Key: ast.String{}, Value: ast.Set{Item: ast.Rune{}}}}},
+		},
+		{
+			name:   "LiteralSet",
+			source: `var x = set{'a', '\n'}`,
+			expected: ast.Declare{Name: "x", Value: ast.LiteralSet{Values: []ast.Value{
+				ast.LiteralRune{Value: 'a'}, ast.LiteralRune{Value: '\n'},
+			}}},
+		},
+		{
+			name:   "LiteralMap",
+			source: `var x = map{"a": true, "b": false}`,
+			expected: ast.Declare{Name: "x", Value: ast.LiteralMap{Values: []ast.KeyValue{
+				{Key: ast.LiteralString{Value: "a"}, Value: ast.LiteralBool{Value: true}},
+				{Key: ast.LiteralString{Value: "b"}, Value: ast.LiteralBool{Value: false}},
+			}}},
+		},
+		{
+			name:     "New",
+			source:   `var x = new(Point)`,
+			expected: ast.Declare{Name: "x", Value: ast.New{Model: ast.Model{Name: "Point"}}},
+		},
+		{
+			name:     "Nil",
+			source:   `var x = nil(Point)`,
+			expected: ast.Declare{Name: "x", Value: ast.Nil{Type: ast.Model{Name: "Point"}}},
+		},
+		{
+			name:     "Length",
+			source:   `return len(l)`,
+			expected: ast.Return{Value: ast.Length{Of: list}},
+		},
+		{
+			name:     "Push",
+			source:   `push(l, 1)`,
+			expected: ast.Push{List: list, Value: one},
+		},
+		{
+			name:     "Pop",
+			source:   `pop(l)`,
+			expected: ast.Pop{List: list},
+		},
+		{
+			name:     "AddToSet",
+			source:   `insert(s, 1)`,
+			expected: ast.AddToSet{Set: set, Value: one},
+		},
+		{
+			name:   "Assignment",
+			source: `l[0].next = contains(s, 1)`,
+			expected: ast.Assignment{
+				To:   ast.Property{Of: ast.Lookup{From: list, Key: ast.LiteralInt64{Value: 0}}, Name: "next"},
+				From: ast.SetContains{Set: set, Value: one},
+			},
+		},
+		{
+			name:   "MethodCall",
+			source: `l[0].next.move(1, self.get(),)`,
+			expected: ast.MethodCall{
+				Receiver:  ast.Property{Of: ast.Lookup{From: list, Key: ast.LiteralInt64{Value: 0}}, Name: "next"},
+				Name:      "move",
+				Arguments: []ast.Value{one, ast.MethodCall{Receiver: ast.Self{}, Name: "get"}},
+			},
+		},
+		{
+			name:   "Conditional",
+			source: `if a { break } else if b { continue } else { break }`,
+			expected: ast.Conditional{
+				Ifs: []ast.If{
+					{Condition: ast.Variable{Name: "a"}, Block: body},
+					{Condition: ast.Variable{Name: "b"}, Block: ast.Block{Statements: []ast.Statement{ast.Continue{}}}},
+				},
+				Else: ast.OptionalWithValue(body),
+			},
+		},
+		{
+			name:     "ForEach",
+			source:   `for item in l { break }`,
+			expected: ast.ForEach{ItemName: "item", Iterable: list, Block: body},
+		},
+		{
+			name:   "For",
+			source: `for var i = 0; true; i = 1 { break }`,
+			expected: ast.For{
+				Initialization: ast.OptionalWithValue[ast.Statement](ast.Declare{Name: "i", Value: ast.LiteralInt64{Value: 0}}),
+				Condition:      ast.LiteralBool{Value: true},
+				AfterEach:      ast.OptionalWithValue[ast.Statement](ast.Assignment{To: ast.Variable{Name: "i"}, From: one}),
+				Block:          body,
+			},
+		},
+		{
+			name:     "For without a header",
+			source:   `for ; true; { break }`,
+			expected: ast.For{Condition: ast.LiteralBool{Value: true}, Block: body},
+		},
+		{
+			name:     "For with only a condition",
+			source:   `for contains(s, 1) { break }`,
+			expected: ast.For{Condition: ast.SetContains{Set: set, Value: one}, Block: body},
+		},
+		{
+			name:   "Binary",
+			source: `var x = a + b * 2 - -1 % c`,
+			expected: ast.Declare{Name: "x", Value: ast.Binary{
+				Left: ast.Binary{
+					Left:     ast.Variable{Name: "a"},
+					Operator: ast.BinaryOperatorAdd,
+					Right:    ast.Binary{Left: ast.Variable{Name: "b"}, Operator: ast.BinaryOperatorMultiply, Right: ast.LiteralInt64{Value: 2}},
+				},
+				Operator: ast.BinaryOperatorSubtract,
+				Right:    ast.Binary{Left: ast.LiteralInt64{Value: -1}, Operator: ast.BinaryOperatorModulo, Right: ast.Variable{Name: "c"}},
+			}},
+		},
+		{
+			name:   "Parentheses",
+			source: `var x = (a + b) / len(l)`,
+			expected: ast.Declare{Name: "x", Value: ast.Binary{
+				Left:     ast.Binary{Left: ast.Variable{Name: "a"}, Operator: ast.BinaryOperatorAdd, Right: ast.Variable{Name: "b"}},
+				Operator: ast.BinaryOperatorDivide,
+				Right:    ast.Length{Of: list},
+			}},
+		},
+		{
+			name:   "Comparison",
+			source: `if a < 1 || b != c && !d { break }`,
+			expected: ast.Conditional{Ifs: []ast.If{{
+				Condition: ast.Binary{
+					Left:     ast.Comparison{Left: ast.Variable{Name: "a"}, Operator: ast.ComparisonOperatorLessThan, Right: one},
+					Operator: ast.BinaryOperatorOr,
+					Right: ast.Binary{
+						Left:     ast.Comparison{Left: ast.Variable{Name: "b"}, Operator: ast.ComparisonOperatorNotEqual, Right: ast.Variable{Name: "c"}},
+						Operator: ast.BinaryOperatorAnd,
+						Right:    ast.Unary{Operator: ast.UnaryOperatorNot, Value: ast.Variable{Name: "d"}},
+					},
+				},
+				Block: body,
+			}}},
+		},
+		{
+			name:   "Numbers",
+			source: `var x = [1.5, -2e3, 0.25E-1, int32(-7), uint8(255), float64(3), int64(4)]`,
+			expected: ast.Declare{Name: "x", Value: ast.LiteralList{Values: []ast.Value{
+				ast.LiteralFloat64{Value: 1.5},
+				ast.LiteralFloat64{Value: -2000},
+				ast.LiteralFloat64{Value: 0.025},
+				ast.LiteralInt32{Value: -7},
+				ast.LiteralUint8{Value: 255},
+				ast.LiteralFloat64{Value: 3},
+				ast.LiteralInt64{Value: 4},
+			}}},
+		},
+		{
+			name:   "Conversion",
+			source: `return float64(int32(l[0]))`,
+			expected: ast.Return{Value: ast.Conversion{
+				Value: ast.Conversion{Value: ast.Lookup{From: list, Key: ast.LiteralInt64{Value: 0}}, To: ast.Int32{}},
+				To:    ast.Float64{},
+			}},
+		},
+		{
+			name:     "LiteralBytes",
+			source:   `var x = bytes{0, 128, 255,}`,
+			expected: ast.Declare{Name: "x", Value: ast.LiteralBytes{Value: []uint8{0, 128, 255}}},
+		},
+		{
+			name:     "Empty LiteralBytes",
+			source:   `var x = bytes{}`,
+			expected: ast.Declare{Name: "x", Value: ast.LiteralBytes{Value: []uint8{}}},
+		},
+		{
+			name:   "Unary",
+			source: `return -l[0] >= -(1)`,
+			expected: ast.Return{Value: ast.Comparison{
+				Left:     ast.Unary{Operator: ast.UnaryOperatorNegate, Value: ast.Lookup{From: list, Key: ast.LiteralInt64{Value: 0}}},
+				Operator: ast.ComparisonOperatorGreaterThanOrEqual,
+				Right:    ast.Unary{Operator: ast.UnaryOperatorNegate, Value: one},
+			}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root, err := Parse("test.as", "module test { func f() {\n"+tt.source+"\n} }")
+			require.NoError(t, err)
+			assert.Equal(t, []ast.Statement{tt.expected}, withoutPositions(root.Modules[0].Functions[0].Block.Statements))
+		})
+	}
+}
+
+func TestParse_Imports(t *testing.T) {
+	root, err := Parse("test.as", `
+module test {
+	import geometry
+
+	func f(p geometry.Point) geometry.Point {
+		var q = new(geometry.Point)
+		q.x = geometry.area(p) + geometry.scale
+		return q
+	}
+}
+`)
+	require.NoError(t, err)
+
+	point := ast.Model{Name: "Point", Module: "geometry"}
+	area := ast.Call{
+		Function:  ast.FunctionRef{Name: "area", Module: "geometry"},
+		Arguments: []ast.Value{ast.Variable{Name: "p"}},
+	}
+
+	expected := ast.Module{
+		Name:    "test",
+		Imports: []ast.Import{{Module: "geometry"}},
+		Functions: []ast.FunctionDef{{
+			Name:       "f",
+			Arguments:  []ast.ArgumentDef{{Name: "p", Type: point}},
+			ReturnType: point,
+			Block: ast.Block{Statements: []ast.Statement{
+				ast.Declare{Name: "q", Value: ast.New{Model: point}},
+				ast.Assignment{
+					To:   ast.Property{Of: ast.Variable{Name: "q"}, Name: "x"},
+					From: ast.Binary{Left: area, Operator: ast.BinaryOperatorAdd, Right: ast.Variable{Name: "scale", Module: "geometry"}},
+				},
+				ast.Return{Value: ast.Variable{Name: "q"}},
+			}},
+		}},
+	}
+
+	assert.Equal(t, expected, withoutPositions(root.Modules[0]))
+}
+
+func TestParse_QualifiedByOwnModule(t *testing.T) {
+	root, err := Parse("test.as", `
+module test {
+	func f(p test.Point) int64 {
+		return test.g(p) + test.scale
+	}
+
+	func g(p Point) int64 {
+		return p.x
+	}
+}
+`)
+	require.NoError(t, err)
+
+	point := ast.Model{Name: "Point", Module: "test"}
+	g := ast.Call{
+		Function:  ast.FunctionRef{Name: "g", Module: "test"},
+		Arguments: []ast.Value{ast.Variable{Name: "p"}},
+	}
+
+	expected := ast.FunctionDef{
+		Name:       "f",
+		Arguments:  []ast.ArgumentDef{{Name: "p", Type: point}},
+		ReturnType: ast.Int64{},
+		Block: ast.Block{Statements: []ast.Statement{
+			ast.Return{Value: ast.Binary{Left: g, Operator: ast.BinaryOperatorAdd, Right: ast.Variable{Name: "scale", Module: "test"}}},
+		}},
+	}
+
+	assert.Equal(t, expected, withoutPositions(root.Modules[0]).Functions[0])
+}
+
+func TestParse_QualifiedByOwnModuleErrors(t *testing.T) {
+	_, err := Parse("test.as", "module test {\n\tfunc f() {\n\t\ttest.g()\n\t}\n}")
+	assert.EqualError(t, err, `test.as:3:8: undefined function "g"`)
+}
+
+func TestParse_Visibility(t *testing.T) {
+	root, err := Parse("test.as", `
+module test {
+	private const limit = 10
+
+	model Counter {
+		count int64
+		private step int64
+
+		private func advance() {
+			self.count = self.count + self.step
+		}
+	}
+
+	private model Cache {}
+
+	private func helper() {}
+}
+`)
+	require.NoError(t, err)
+
+	self := func(name string) ast.Property {
+		return ast.Property{Of: ast.Self{}, Name: name}
+	}
+
+	expected := ast.Module{
+		Name:      "test",
+		Constants: []ast.ConstantDef{{Name: "limit", Visibility: ast.VisibilityPrivate, Value: ast.LiteralInt64{Value: 10}}},
+		Models: []ast.ModelDef{
+			{
+				Name: "Counter",
+				Fields: []ast.FieldDef{
+					{Name: "count", Type: ast.Int64{}},
+					{Name: "step", Visibility: ast.VisibilityPrivate, Type: ast.Int64{}},
+				},
+				Methods: []ast.FunctionDef{{
+					Name:       "advance",
+					Visibility: ast.VisibilityPrivate,
+					ReturnType: ast.Void{},
+					Block: ast.Block{Statements: []ast.Statement{
+						ast.Assignment{
+							To:   self("count"),
+							From: ast.Binary{Left: self("count"), Operator: ast.BinaryOperatorAdd, Right: self("step")},
+						},
+					}},
+				}},
+			},
+			{Name: "Cache", Visibility: ast.VisibilityPrivate},
+		},
+		Functions: []ast.FunctionDef{{Name: "helper", Visibility: ast.VisibilityPrivate, ReturnType: ast.Void{}}},
+	}
+
+	assert.Equal(t, expected, withoutPositions(root.Modules[0]))
+	// The position of a definition includes its visibility.
+	assert.Equal(t, 2, root.Modules[0].Constants[0].Position.Column)
+}
+
+func TestParse_Positions(t *testing.T) {
+	source := "module m {\n\tfunc f(x int64) int64 {\n\t\treturn x.y[0]\n\t}\n}"
+	root, err := Parse("m.as", source)
+	require.NoError(t, err)
+
+	text := func(position ast.Position) string {
+		return source[position.Offset:position.EndOffset]
+	}
+
+	module := root.Modules[0]
+	function := module.Functions[0]
+	statement := function.Block.Statements[0].(ast.Return)
+	lookup := statement.Value.(ast.Lookup)
+
+	assert.Equal(t, ast.Position{Filename: "m.as", Line: 1, Column: 1, Offset: 0, EndOffset: len(source)}, module.Position)
+	assert.Equal(t, "func f(x int64) int64 {\n\t\treturn x.y[0]\n\t}", text(function.Position))
+	assert.Equal(t, "x int64", text(function.Arguments[0].Position))
+	assert.Equal(t, "int64", text(function.ReturnType.(ast.Int64).Position))
+	assert.Equal(t, "return x.y[0]", text(statement.Position))
+	assert.Equal(t, ast.Position{Filename: "m.as", Line: 3, Column: 10, Offset: 45, EndOffset: 51}, lookup.Position)
+	assert.Equal(t, "x.y", text(lookup.From.(ast.Property).Position))
+	assert.Equal(t, "0", text(lookup.Key.(ast.LiteralInt64).Position))
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		source   string
+		expected string
+	}{
+		{
+			name:     "unexpected character",
+			source:   "module test {\n\t#\n}",
+			expected: "test.as:2:2: unexpected character '#'",
+		},
+		{
+			name:     "unterminated string",
+			source:   `module test { const x = "abc }`,
+			expected: "test.as:1:25: unterminated string literal",
+		},
+		{
+			name:     "missing brace",
+			source:   "module test {\n\tfunc f() {\n}",
+			expected: "test.as:3:2: expected constant, model, or function but found end of file",
+		},
+		{
+			name:     "undefined function",
+			source:   "module test {\n\tfunc f() {\n\t\tg()\n\t}\n}",
+			expected: "test.as:3:3: undefined function \"g\"",
+		},
+		{
+			name:     "duplicate function",
+			source:   "module test {\n\tfunc f() {}\n\tfunc f() {}\n}",
+			expected: "test.as:3:2: function \"f\" is already defined in module \"test\"",
+		},
+		{
+			name:     "duplicate import",
+			source:   "module test {\n\timport other\n\timport other\n}",
+			expected: "test.as:3:2: module \"other\" is already imported",
+		},
+		{
+			name:     "import after a definition",
+			source:   "module test {\n\tfunc f() {}\n\timport other\n}",
+			expected: "test.as:3:2: imports must come before the constants, models, and functions of the module",
+		},
+		{
+			name:     "private import",
+			source:   "module test {\n\tprivate import other\n}",
+			expected: "test.as:2:2: imports must come before the constants, models, and functions of the module",
+		},
+		{
+			name:     "private without a definition",
+			source:   "module test {\n\tprivate private func f() {}\n}",
+			expected: "test.as:2:10: expected constant, model, or function but found \"private\"",
+		},
+		{
+			name:     "non-literal constant",
+			source:   "module test {\n\tconst x = y\n}",
+			expected: "test.as:2:12: the value of constant \"x\" must be a literal",
+		},
+		{
+			name:     "unused value",
+			source:   "module test {\n\tfunc f() {\n\t\tx.y\n\t}\n}",
+			expected: "test.as:3:3: value is not used",
+		},
+		{
+			name:     "invalid assignment",
+			source:   "module test {\n\tfunc f() {\n\t\tlen(x) = 1\n\t}\n}",
+			expected: "test.as:3:3: cannot assign to this value",
+		},
+		{
+			name:     "integer out of range",
+			source:   "module test {\n\tconst x = 9223372036854775808\n}",
+			expected: "test.as:2:12: integer literal 9223372036854775808 is out of range",
+		},
+		{
+			name:     "float missing exponent",
+			source:   "module test {\n\tconst x = 1e\n}",
+			expected: "test.as:2:12: missing exponent in float literal 1e",
+		},
+		{
+			name:     "float out of range",
+			source:   "module test {\n\tconst x = 1e400\n}",
+			expected: "test.as:2:12: float literal 1e400 is out of range",
+		},
+		{
+			name:     "int32 out of range",
+			source:   "module test {\n\tconst x = int32(2147483648)\n}",
+			expected: "test.as:2:18: integer literal 2147483648 is out of range for int32",
+		},
+		{
+			name:     "byte out of range",
+			source:   "module test {\n\tconst x = bytes{1, 256}\n}",
+			expected: "test.as:2:21: integer literal 256 is out of range for uint8",
+		},
+		{
+			name:     "chained comparison",
+			source:   "module test {\n\tfunc f() {\n\t\treturn a < b < c\n\t}\n}",
+			expected: "test.as:3:16: comparisons can't be chained; use && to combine them",
+		},
+		{
+			name:     "wrong number of builtin arguments",
+			source:   "module test {\n\tfunc f() {\n\t\tpush(x)\n\t}\n}",
+			expected: "test.as:3:7: push takes 2 arguments but 1 were given",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse("test.as", tt.source)
+
+			var parseErr *Error
+			require.ErrorAs(t, err, &parseErr)
+			assert.Equal(t, tt.expected, err.Error())
+		})
+	}
+}
+
+func TestParse_MapsToCode(t *testing.T) {
+	root, err := Parse("example.as", example)
+	require.NoError(t, err)
+
+	mapper := &ast_to_code_mapper.Mapper{}
+	_, err = mapper.MapRoot(root)
+	require.NoError(t, err)
+}
+
+// withoutPositions returns a copy of the AST with all of its positions cleared, so that it can be compared to an AST
+// that's built by hand.
+func withoutPositions[T any](value T) T {
+	return clearPositions(reflect.ValueOf(value)).Interface().(T)
+}
+
+func clearPositions(value reflect.Value) reflect.Value {
+	switch value.Kind() {
+	case reflect.Interface:
+		if value.IsNil() {
+			return value
+		}
+
+		result := reflect.New(value.Type()).Elem()
+		result.Set(clearPositions(value.Elem()))
+		return result
+	case reflect.Slice:
+		if value.IsNil() {
+			return value
+		}
+
+		result := reflect.MakeSlice(value.Type(), value.Len(), value.Len())
+		for i := 0; i < value.Len(); i++ {
+			result.Index(i).Set(clearPositions(value.Index(i)))
+		}
+		return result
+	case reflect.Struct:
+		if value.Type() == reflect.TypeOf(ast.Position{}) {
+			return reflect.Zero(value.Type())
+		}
+
+		result := reflect.New(value.Type()).Elem()
+		result.Set(value)
+		for i := 0; i < value.NumField(); i++ {
+			// Optionals have unexported fields, which can only be set through their address.
+			field := result.Field(i)
+			field = reflect.NewAt(field.Type(), unsafe.Pointer(field.UnsafeAddr())).Elem()
+			field.Set(clearPositions(field))
+		}
+		return result
+	default:
+		return value
+	}
+}
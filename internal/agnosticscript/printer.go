@@ -0,0 +1,541 @@
+package agnosticscript
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/JosephNaberhaus/agnostic/ast"
+	"github.com/JosephNaberhaus/agnostic/internal/languages"
+)
+
+var _ ast.NodeMapperNoError[string] = printer{}
+
+// indent is the indentation of each level of nested blocks.
+const indent = "\t"
+
+// The precedence of each level of values, from the lowest to the highest.
+const (
+	precedenceOr = iota + 1
+	precedenceAnd
+	precedenceComparison
+	precedenceSum
+	precedenceProduct
+	precedenceUnary
+	precedencePostfix
+)
+
+var binaryPrecedences = map[ast.BinaryOperator]int{
+	ast.BinaryOperatorOr:       precedenceOr,
+	ast.BinaryOperatorAnd:      precedenceAnd,
+	ast.BinaryOperatorAdd:      precedenceSum,
+	ast.BinaryOperatorSubtract: precedenceSum,
+	ast.BinaryOperatorMultiply: precedenceProduct,
+	ast.BinaryOperatorDivide:   precedenceProduct,
+	ast.BinaryOperatorModulo:   precedenceProduct,
+}
+
+var (
+	binarySymbols     = symbols(orOperators, andOperators, additiveOperators, multiplicativeOperators)
+	comparisonSymbols = symbols(comparisonOperators)
+)
+
+// symbols inverts the tables of operators that the parser uses, so that an operator is printed the way it's parsed.
+func symbols[T comparable](tables ...map[string]T) map[T]string {
+	result := map[T]string{}
+	for _, table := range tables {
+		for symbol, operator := range table {
+			result[operator] = symbol
+		}
+	}
+
+	return result
+}
+
+// Print renders the root as AgnosticScript in its canonical format: blocks are indented with tabs, binary operators are
+// surrounded by spaces, and values are only parenthesized where the order of their operators requires it. Parsing the
+// printed source produces the same AST, apart from the positions of its nodes.
+//
+// Some ASTs can't be written in AgnosticScript, such as an empty literal list, a conversion of an integer literal, or a
+// float that's NaN or infinite. They're printed as closely as the syntax allows, but won't parse into the same AST.
+func Print(root ast.Root) string {
+	return printer{}.MapRoot(root)
+}
+
+// printer renders nodes as AgnosticScript. Definitions and statements that span several lines don't end with a newline.
+type printer struct{}
+
+func (p printer) value(value ast.Value) string {
+	return ast.MapValueNoError[string](value, p)
+}
+
+func (p printer) values(values []ast.Value) string {
+	return strings.Join(ast.MapEachValueNoError[string](values, p), ", ")
+}
+
+func (p printer) typ(typ ast.Type) string {
+	return ast.MapTypeNoError[string](typ, p)
+}
+
+// operand renders a value that's an operand of an operator with the given precedence, parenthesizing it if its own
+// operators bind more loosely.
+func (p printer) operand(value ast.Value, minimum int) string {
+	result := p.value(value)
+	if precedence(value) < minimum {
+		return "(" + result + ")"
+	}
+
+	return result
+}
+
+// precedence returns the precedence of the operator at the top of the value. Values without an operator bind the
+// tightest.
+func precedence(value ast.Value) int {
+	switch value := value.(type) {
+	case ast.Binary:
+		return binaryPrecedences[value.Operator]
+	case ast.Comparison:
+		return precedenceComparison
+	case ast.Unary:
+		return precedenceUnary
+	default:
+		return precedencePostfix
+	}
+}
+
+// qualified renders a name that's qualified by the module it's defined in. Names in the current module have no module.
+func qualified(module, name string) string {
+	if module == "" {
+		return name
+	}
+
+	return module + "." + name
+}
+
+func visibility(value ast.Visibility) string {
+	if value == ast.VisibilityPrivate {
+		return "private "
+	}
+
+	return ""
+}
+
+func (p printer) MapAddToSet(value ast.AddToSet) string {
+	return "insert(" + p.value(value.Set) + ", " + p.value(value.Value) + ")"
+}
+
+func (p printer) MapArgumentDef(value ast.ArgumentDef) string {
+	return value.Name + " " + p.typ(value.Type)
+}
+
+func (p printer) MapAssignment(value ast.Assignment) string {
+	return p.value(value.To) + " = " + p.value(value.From)
+}
+
+// MapBinary renders a binary operation. The operators are left-associative, so the right operand is parenthesized when
+// it has the same precedence as the operator.
+func (p printer) MapBinary(value ast.Binary) string {
+	precedence := binaryPrecedences[value.Operator]
+	left := p.operand(value.Left, precedence)
+	right := p.operand(value.Right, precedence+1)
+	return left + " " + binarySymbols[value.Operator] + " " + right
+}
+
+func (p printer) MapBlock(value ast.Block) string {
+	if len(value.Statements) == 0 {
+		return "{}"
+	}
+
+	statements := ast.MapEachStatementNoError[string](value.Statements, p)
+	return "{\n" + languages.Indent(strings.Join(statements, "\n"), indent) + "\n}"
+}
+
+func (p printer) MapBool(value ast.Bool) string {
+	return "bool"
+}
+
+func (p printer) MapBreak(value ast.Break) string {
+	return "break"
+}
+
+func (p printer) MapBytes(value ast.Bytes) string {
+	return "bytes"
+}
+
+func (p printer) MapCall(value ast.Call) string {
+	var function string
+	switch callable := value.Function.(type) {
+	case ast.FunctionRef:
+		function = p.MapFunctionRef(callable)
+	case ast.FunctionDef:
+		function = callable.Name
+	}
+
+	return function + "(" + p.values(value.Arguments) + ")"
+}
+
+// MapComparison renders a comparison. Comparisons can't be chained, so an operand that's a comparison is parenthesized.
+func (p printer) MapComparison(value ast.Comparison) string {
+	left := p.operand(value.Left, precedenceSum)
+	right := p.operand(value.Right, precedenceSum)
+	return left + " " + comparisonSymbols[value.Operator] + " " + right
+}
+
+func (p printer) MapConditional(value ast.Conditional) string {
+	ifs := make([]string, 0, len(value.Ifs))
+	for _, ifNode := range value.Ifs {
+		ifs = append(ifs, p.MapIf(ifNode))
+	}
+
+	result := strings.Join(ifs, " else ")
+	if value.Else.IsSet() {
+		result += " else " + p.MapBlock(value.Else.Value())
+	}
+
+	return result
+}
+
+func (p printer) MapConstantDef(value ast.ConstantDef) string {
+	constant := ast.MapConstantValueNoError[string](value.Value, p)
+	return visibility(value.Visibility) + "const " + value.Name + " = " + constant
+}
+
+func (p printer) MapContinue(value ast.Continue) string {
+	return "continue"
+}
+
+func (p printer) MapConversion(value ast.Conversion) string {
+	return p.typ(value.To) + "(" + p.value(value.Value) + ")"
+}
+
+func (p printer) MapDeclare(value ast.Declare) string {
+	return "var " + value.Name + " = " + p.value(value.Value)
+}
+
+func (p printer) MapEmptyList(value ast.EmptyList) string {
+	return "list[" + p.typ(value.Type) + "]{}"
+}
+
+func (p printer) MapEqualOverride(value ast.EqualOverride) string {
+	return "equals(" + value.OtherName + ") " + p.MapBlock(value.Block)
+}
+
+func (p printer) MapFieldDef(value ast.FieldDef) string {
+	return visibility(value.Visibility) + value.Name + " " + p.typ(value.Type)
+}
+
+func (p printer) MapFloat64(value ast.Float64) string {
+	return "float64"
+}
+
+// MapFor renders a for loop. A loop with neither an initialization nor an after each statement has no header, since the
+// parser treats a condition on its own as that kind of loop.
+func (p printer) MapFor(value ast.For) string {
+	condition := p.value(value.Condition)
+	block := p.MapBlock(value.Block)
+	if !value.Initialization.IsSet() && !value.AfterEach.IsSet() {
+		return "for " + condition + " " + block
+	}
+
+	var initialization, afterEach string
+	if value.Initialization.IsSet() {
+		initialization = ast.MapStatementNoError[string](value.Initialization.Value(), p)
+	}
+
+	if value.AfterEach.IsSet() {
+		afterEach = " " + ast.MapStatementNoError[string](value.AfterEach.Value(), p)
+	}
+
+	return "for " + initialization + "; " + condition + ";" + afterEach + " " + block
+}
+
+func (p printer) MapForEach(value ast.ForEach) string {
+	return "for " + value.ItemName + " in " + p.value(value.Iterable) + " " + p.MapBlock(value.Block)
+}
+
+// MapFunctionDef renders a function. Functions that return void are written without a return type.
+func (p printer) MapFunctionDef(value ast.FunctionDef) string {
+	arguments := make([]string, 0, len(value.Arguments))
+	for _, argument := range value.Arguments {
+		arguments = append(arguments, p.MapArgumentDef(argument))
+	}
+
+	var returnType string
+	if _, isVoid := value.ReturnType.(ast.Void); !isVoid {
+		returnType = " " + p.typ(value.ReturnType)
+	}
+
+	signature := "func " + value.Name + "(" + strings.Join(arguments, ", ") + ")" + returnType
+	return visibility(value.Visibility) + signature + " " + p.MapBlock(value.Block)
+}
+
+func (p printer) MapFunctionRef(value ast.FunctionRef) string {
+	return qualified(value.Module, value.Name)
+}
+
+func (p printer) MapHashOverride(value ast.HashOverride) string {
+	return "hash " + p.MapBlock(value.Block)
+}
+
+func (p printer) MapIf(value ast.If) string {
+	return "if " + p.value(value.Condition) + " " + p.MapBlock(value.Block)
+}
+
+func (p printer) MapImport(value ast.Import) string {
+	return "import " + value.Module
+}
+
+func (p printer) MapInt32(value ast.Int32) string {
+	return "int32"
+}
+
+func (p printer) MapInt64(value ast.Int64) string {
+	return "int64"
+}
+
+func (p printer) MapKeyValue(value ast.KeyValue) string {
+	return p.value(value.Key) + ": " + p.value(value.Value)
+}
+
+func (p printer) MapLength(value ast.Length) string {
+	return "len(" + p.value(value.Of) + ")"
+}
+
+func (p printer) MapList(value ast.List) string {
+	return "list[" + p.typ(value.Item) + "]"
+}
+
+func (p printer) MapLiteralBool(value ast.LiteralBool) string {
+	return strconv.FormatBool(value.Value)
+}
+
+func (p printer) MapLiteralBytes(value ast.LiteralBytes) string {
+	values := make([]string, 0, len(value.Value))
+	for _, b := range value.Value {
+		values = append(values, strconv.Itoa(int(b)))
+	}
+
+	return "bytes{" + strings.Join(values, ", ") + "}"
+}
+
+// MapLiteralFloat64 renders the shortest decimal that parses back into the same float. A float literal needs a
+// fraction or an exponent to not be an integer.
+func (p printer) MapLiteralFloat64(value ast.LiteralFloat64) string {
+	result := strconv.FormatFloat(value.Value, 'g', -1, 64)
+	if !strings.ContainsAny(result, ".eIN") {
+		result += ".0"
+	}
+
+	return result
+}
+
+func (p printer) MapLiteralInt32(value ast.LiteralInt32) string {
+	return "int32(" + strconv.FormatInt(int64(value.Value), 10) + ")"
+}
+
+func (p printer) MapLiteralInt64(value ast.LiteralInt64) string {
+	return strconv.FormatInt(value.Value, 10)
+}
+
+func (p printer) MapLiteralList(value ast.LiteralList) string {
+	return "[" + p.values(value.Values) + "]"
+}
+
+func (p printer) MapLiteralMap(value ast.LiteralMap) string {
+	entries := make([]string, 0, len(value.Values))
+	for _, entry := range value.Values {
+		entries = append(entries, p.MapKeyValue(entry))
+	}
+
+	return "map{" + strings.Join(entries, ", ") + "}"
+}
+
+func (p printer) MapLiteralRune(value ast.LiteralRune) string {
+	return strconv.QuoteRune(value.Value)
+}
+
+func (p printer) MapLiteralSet(value ast.LiteralSet) string {
+	return "set{" + p.values(value.Values) + "}"
+}
+
+func (p printer) MapLiteralString(value ast.LiteralString) string {
+	return strconv.Quote(value.Value)
+}
+
+func (p printer) MapLiteralUint8(value ast.LiteralUint8) string {
+	return "uint8(" + strconv.Itoa(int(value.Value)) + ")"
+}
+
+func (p printer) MapLookup(value ast.Lookup) string {
+	return p.operand(value.From, precedencePostfix) + "[" + p.value(value.Key) + "]"
+}
+
+func (p printer) MapMap(value ast.Map) string {
+	return "map[" + p.typ(value.Key) + ", " + p.typ(value.Value) + "]"
+}
+
+func (p printer) MapMethodCall(value ast.MethodCall) string {
+	return p.operand(value.Receiver, precedencePostfix) + "." + value.Name + "(" + p.values(value.Arguments) + ")"
+}
+
+func (p printer) MapModel(value ast.Model) string {
+	return qualified(value.Module, value.Name)
+}
+
+// MapModelDef renders a model with its fields first, then its overrides, and then its methods, each separated from the
+// next by a blank line.
+func (p printer) MapModelDef(value ast.ModelDef) string {
+	var members []string
+	if len(value.Fields) > 0 {
+		fields := make([]string, 0, len(value.Fields))
+		for _, field := range value.Fields {
+			fields = append(fields, p.MapFieldDef(field))
+		}
+
+		members = append(members, strings.Join(fields, "\n"))
+	}
+
+	if value.EqualOverride.IsSet() {
+		members = append(members, p.MapEqualOverride(value.EqualOverride.Value()))
+	}
+
+	if value.HashOverride.IsSet() {
+		members = append(members, p.MapHashOverride(value.HashOverride.Value()))
+	}
+
+	for _, method := range value.Methods {
+		members = append(members, p.MapFunctionDef(method))
+	}
+
+	return visibility(value.Visibility) + "model " + value.Name + " " + p.body(members)
+}
+
+// MapModule renders a module with its imports first, then its constants, and then its models and functions, each
+// separated from the next by a blank line.
+func (p printer) MapModule(value ast.Module) string {
+	var definitions []string
+	if len(value.Imports) > 0 {
+		imports := make([]string, 0, len(value.Imports))
+		for _, imported := range value.Imports {
+			imports = append(imports, p.MapImport(imported))
+		}
+
+		definitions = append(definitions, strings.Join(imports, "\n"))
+	}
+
+	if len(value.Constants) > 0 {
+		constants := make([]string, 0, len(value.Constants))
+		for _, constant := range value.Constants {
+			constants = append(constants, p.MapConstantDef(constant))
+		}
+
+		definitions = append(definitions, strings.Join(constants, "\n"))
+	}
+
+	for _, model := range value.Models {
+		definitions = append(definitions, p.MapModelDef(model))
+	}
+
+	for _, function := range value.Functions {
+		definitions = append(definitions, p.MapFunctionDef(function))
+	}
+
+	return "module " + value.Name + " " + p.body(definitions)
+}
+
+// body renders the braces around the members of a module or model, which are separated by blank lines.
+func (p printer) body(members []string) string {
+	if len(members) == 0 {
+		return "{}"
+	}
+
+	return "{\n" + languages.Indent(strings.Join(members, "\n\n"), indent) + "\n}"
+}
+
+func (p printer) MapNew(value ast.New) string {
+	return "new(" + p.MapModel(value.Model) + ")"
+}
+
+func (p printer) MapNil(value ast.Nil) string {
+	return "nil(" + p.typ(value.Type) + ")"
+}
+
+func (p printer) MapPop(value ast.Pop) string {
+	return "pop(" + p.value(value.List) + ")"
+}
+
+func (p printer) MapProperty(value ast.Property) string {
+	return p.operand(value.Of, precedencePostfix) + "." + value.Name
+}
+
+func (p printer) MapPush(value ast.Push) string {
+	return "push(" + p.value(value.List) + ", " + p.value(value.Value) + ")"
+}
+
+func (p printer) MapReturn(value ast.Return) string {
+	return "return " + p.value(value.Value)
+}
+
+// MapRoot renders each module, separated by blank lines. The source ends with a newline unless it's empty.
+func (p printer) MapRoot(value ast.Root) string {
+	modules := make([]string, 0, len(value.Modules))
+	for _, module := range value.Modules {
+		modules = append(modules, p.MapModule(module))
+	}
+
+	if len(modules) == 0 {
+		return ""
+	}
+
+	return strings.Join(modules, "\n\n") + "\n"
+}
+
+func (p printer) MapRune(value ast.Rune) string {
+	return "rune"
+}
+
+func (p printer) MapSelf(value ast.Self) string {
+	return "self"
+}
+
+func (p printer) MapSet(value ast.Set) string {
+	return "set[" + p.typ(value.Item) + "]"
+}
+
+func (p printer) MapSetContains(value ast.SetContains) string {
+	return "contains(" + p.value(value.Set) + ", " + p.value(value.Value) + ")"
+}
+
+func (p printer) MapString(value ast.String) string {
+	return "string"
+}
+
+func (p printer) MapUint8(value ast.Uint8) string {
+	return "uint8"
+}
+
+// MapUnary renders a unary operation. A minus sign directly before a number would be parsed as part of the literal, so
+// a negated value that starts with a digit is parenthesized.
+func (p printer) MapUnary(value ast.Unary) string {
+	operand := p.operand(value.Value, precedenceUnary)
+	switch value.Operator {
+	case ast.UnaryOperatorNegate:
+		if operand != "" && operand[0] >= '0' && operand[0] <= '9' {
+			operand = "(" + operand + ")"
+		}
+
+		return "-" + operand
+	case ast.UnaryOperatorNot:
+		return "!" + operand
+	default:
+		return value.Operator.String() + operand
+	}
+}
+
+func (p printer) MapVariable(value ast.Variable) string {
+	return qualified(value.Module, value.Name)
+}
+
+// MapVoid renders nothing, since functions that return void don't have a return type.
+func (p printer) MapVoid(value ast.Void) string {
+	return ""
+}
@@ -0,0 +1,254 @@
+package agnosticscript
+
+import (
+	"math"
+	"testing"
+
+	"github.com/JosephNaberhaus/agnostic/ast"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrint(t *testing.T) {
+	root, err := Parse("test.as", `
+module test { import other
+	private const limit = (10)
+	const names = ["a",
+		"b\n"]
+	func main() int64 {
+		var x = ((1 + 2) * 3 - -4)
+		if x > 3 && !(x == 5) { return x } else if x < 0 {
+		return -(x) } else {}
+		for ;x<10; { x=x+1 }
+		for var i = 0; i < 10; i = i + 1 {}
+		for var i = 0; i < 10; {
+			push(l(), i)
+		}
+		for x in [1] { continue }
+		for true { break }
+		return other.f(x,)
+	}
+	func l() list[int64] { return list[int64]{} }
+	private model Point { equals(o) { return true } x float64
+		func size() { } private y map[string, set[rune]] hash { return 1 } }
+}
+module other { func f(x int64) int64 { return x } }
+`)
+	require.NoError(t, err)
+
+	expected := `module test {
+	import other
+
+	private const limit = 10
+	const names = ["a", "b\n"]
+
+	private model Point {
+		x float64
+		private y map[string, set[rune]]
+
+		equals(o) {
+			return true
+		}
+
+		hash {
+			return 1
+		}
+
+		func size() {}
+	}
+
+	func main() int64 {
+		var x = (1 + 2) * 3 - -4
+		if x > 3 && !(x == 5) {
+			return x
+		} else if x < 0 {
+			return -x
+		} else {}
+		for x < 10 {
+			x = x + 1
+		}
+		for var i = 0; i < 10; i = i + 1 {}
+		for var i = 0; i < 10; {
+			push(l(), i)
+		}
+		for x in [1] {
+			continue
+		}
+		for true {
+			break
+		}
+		return other.f(x)
+	}
+
+	func l() list[int64] {
+		return list[int64]{}
+	}
+}
+
+module other {
+	func f(x int64) int64 {
+		return x
+	}
+}
+`
+	printed := Print(root)
+	assert.Equal(t, expected, printed)
+
+	reparsed, err := Parse("test.as", printed)
+	require.NoError(t, err)
+	assert.Equal(t, withoutPositions(root), withoutPositions(reparsed))
+	assert.Equal(t, printed, Print(reparsed))
+}
+
+func TestPrint_Example(t *testing.T) {
+	root, err := Parse("example.as", example)
+	require.NoError(t, err)
+
+	reparsed, err := Parse("example.as", Print(root))
+	require.NoError(t, err)
+	assert.Equal(t, withoutPositions(root), withoutPositions(reparsed))
+}
+
+func TestPrint_Values(t *testing.T) {
+	a := ast.Variable{Name: "a"}
+	b := ast.Variable{Name: "b"}
+	five := ast.LiteralInt64{Value: 5}
+	binary := func(left ast.Value, operator ast.BinaryOperator, right ast.Value) ast.Binary {
+		return ast.Binary{Left: left, Operator: operator, Right: right}
+	}
+	negate := func(value ast.Value) ast.Unary {
+		return ast.Unary{Operator: ast.UnaryOperatorNegate, Value: value}
+	}
+
+	tests := []struct {
+		name     string
+		value    ast.Value
+		expected string
+	}{
+		{
+			name:     "Left associative",
+			value:    binary(binary(a, ast.BinaryOperatorSubtract, b), ast.BinaryOperatorSubtract, five),
+			expected: "a - b - 5",
+		},
+		{
+			name:     "Right operand of the same precedence",
+			value:    binary(a, ast.BinaryOperatorSubtract, binary(b, ast.BinaryOperatorAdd, five)),
+			expected: "a - (b + 5)",
+		},
+		{
+			name:     "Lower precedence",
+			value:    binary(binary(a, ast.BinaryOperatorOr, b), ast.BinaryOperatorAnd, a),
+			expected: "(a || b) && a",
+		},
+		{
+			name:     "Higher precedence",
+			value:    binary(a, ast.BinaryOperatorOr, binary(b, ast.BinaryOperatorAnd, a)),
+			expected: "a || b && a",
+		},
+		{
+			name: "Comparison of a comparison",
+			value: ast.Comparison{
+				Left:     ast.Comparison{Left: a, Operator: ast.ComparisonOperatorLessThan, Right: b},
+				Operator: ast.ComparisonOperatorEqual,
+				Right:    ast.LiteralBool{Value: true},
+			},
+			expected: "(a < b) == true",
+		},
+		{
+			name:     "Negated literal",
+			value:    negate(five),
+			expected: "-(5)",
+		},
+		{
+			name:     "Negative literal",
+			value:    ast.LiteralInt64{Value: -5},
+			expected: "-5",
+		},
+		{
+			name:     "Negated negative literal",
+			value:    negate(ast.LiteralInt64{Value: -5}),
+			expected: "--5",
+		},
+		{
+			name:     "Negated property of a literal",
+			value:    negate(ast.Property{Of: ast.LiteralFloat64{Value: 1.5}, Name: "x"}),
+			expected: "-(1.5.x)",
+		},
+		{
+			name:     "Negated operation",
+			value:    negate(binary(a, ast.BinaryOperatorMultiply, b)),
+			expected: "-(a * b)",
+		},
+		{
+			name:     "Receiver",
+			value:    ast.MethodCall{Receiver: negate(a), Name: "f", Arguments: []ast.Value{b, five}},
+			expected: "(-a).f(b, 5)",
+		},
+		{
+			name:     "Postfix",
+			value:    ast.Lookup{From: ast.Property{Of: ast.Self{}, Name: "items"}, Key: binary(a, ast.BinaryOperatorAdd, five)},
+			expected: "self.items[a + 5]",
+		},
+		{
+			name:     "Whole float",
+			value:    ast.LiteralFloat64{Value: 3},
+			expected: "3.0",
+		},
+		{
+			name:     "Large float",
+			value:    ast.LiteralFloat64{Value: -1e300},
+			expected: "-1e+300",
+		},
+		{
+			name:     "Sized literals",
+			value:    ast.LiteralList{Values: []ast.Value{ast.LiteralInt32{Value: math.MinInt32}, ast.LiteralUint8{Value: 255}}},
+			expected: "[int32(-2147483648), uint8(255)]",
+		},
+		{
+			name:     "Quoted literals",
+			value:    ast.LiteralMap{Values: []ast.KeyValue{{Key: ast.LiteralString{Value: "\"é\"\x00"}, Value: ast.LiteralRune{Value: '\''}}}},
+			expected: `map{"\"é\"\x00": '\''}`,
+		},
+		{
+			name:     "Bytes",
+			value:    ast.LiteralBytes{Value: []uint8{0, 255}},
+			expected: "bytes{0, 255}",
+		},
+		{
+			name:     "Builtins",
+			value:    ast.LiteralSet{Values: []ast.Value{ast.Length{Of: ast.Pop{List: a}}, ast.Nil{Type: ast.Model{Name: "Point"}}}},
+			expected: "set{len(pop(a)), nil(Point)}",
+		},
+		{
+			name:     "Conversion",
+			value:    ast.Conversion{To: ast.Float64{}, Value: a},
+			expected: "float64(a)",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			statement := ast.Declare{Name: "x", Value: test.value}
+			root := ast.Root{Modules: []ast.Module{{
+				Name: "test",
+				Functions: []ast.FunctionDef{{
+					Name:       "f",
+					ReturnType: ast.Void{},
+					Block:      ast.Block{Statements: []ast.Statement{statement}},
+				}},
+			}}}
+
+			printed := Print(root)
+			assert.Equal(t, "module test {\n\tfunc f() {\n\t\tvar x = "+test.expected+"\n\t}\n}\n", printed)
+
+			reparsed, err := Parse("test.as", printed)
+			require.NoError(t, err)
+			assert.Equal(t, root, withoutPositions(reparsed))
+		})
+	}
+}
+
+func TestPrint_Empty(t *testing.T) {
+	assert.Equal(t, "", Print(ast.Root{}))
+	assert.Equal(t, "module test {}\n", Print(ast.Root{Modules: []ast.Module{{Name: "test"}}}))
+}
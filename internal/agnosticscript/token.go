@@ -0,0 +1,129 @@
+package agnosticscript
+
+import "fmt"
+
+// Position is a location in a source file. Lines and columns start at one and columns are counted in bytes.
+type Position struct {
+	Filename string
+	Line     int
+	Column   int
+	// The byte offset from the start of the source.
+	Offset int
+}
+
+func (p Position) String() string {
+	return fmt.Sprintf("%s:%d:%d", p.Filename, p.Line, p.Column)
+}
+
+// Error is a syntax error at a specific position in a source file.
+type Error struct {
+	Position Position
+	Message  string
+}
+
+func (e *Error) Error() string {
+	return e.Position.String() + ": " + e.Message
+}
+
+func errorAt(position Position, format string, args ...any) error {
+	return &Error{
+		Position: position,
+		Message:  fmt.Sprintf(format, args...),
+	}
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdentifier
+	tokenInt
+	tokenFloat
+	tokenString
+	tokenRune
+	tokenKeyword
+	tokenPunctuation
+)
+
+func (k tokenKind) String() string {
+	switch k {
+	case tokenEOF:
+		return "end of file"
+	case tokenIdentifier:
+		return "identifier"
+	case tokenInt:
+		return "integer"
+	case tokenFloat:
+		return "float"
+	case tokenString:
+		return "string"
+	case tokenRune:
+		return "rune"
+	case tokenKeyword:
+		return "keyword"
+	case tokenPunctuation:
+		return "punctuation"
+	default:
+		return "unknown"
+	}
+}
+
+var keywords = map[string]struct{}{
+	"bool":     {},
+	"break":    {},
+	"bytes":    {},
+	"const":    {},
+	"contains": {},
+	"continue": {},
+	"else":     {},
+	"false":    {},
+	"float64":  {},
+	"for":      {},
+	"func":     {},
+	"if":       {},
+	"import":   {},
+	"in":       {},
+	"insert":   {},
+	"int32":    {},
+	"int64":    {},
+	"len":      {},
+	"list":     {},
+	"map":      {},
+	"model":    {},
+	"module":   {},
+	"new":      {},
+	"nil":      {},
+	"pop":      {},
+	"private":  {},
+	"push":     {},
+	"return":   {},
+	"rune":     {},
+	"self":     {},
+	"set":      {},
+	"string":   {},
+	"true":     {},
+	"uint8":    {},
+	"var":      {},
+}
+
+type token struct {
+	kind     tokenKind
+	text     string
+	position Position
+}
+
+// is returns whether the token is the given keyword or punctuation.
+func (t token) is(text string) bool {
+	return (t.kind == tokenKeyword || t.kind == tokenPunctuation) && t.text == text
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokenEOF:
+		return t.kind.String()
+	case tokenKeyword, tokenPunctuation:
+		return fmt.Sprintf("%q", t.text)
+	default:
+		return t.kind.String() + " " + t.text
+	}
+}
@@ -0,0 +1,472 @@
+package conformance
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/JosephNaberhaus/agnostic/code"
+	"github.com/JosephNaberhaus/agnostic/internal/languages"
+	"github.com/JosephNaberhaus/agnostic/internal/languages/cpp"
+	"github.com/JosephNaberhaus/agnostic/internal/languages/golang"
+	"github.com/JosephNaberhaus/agnostic/internal/languages/java"
+	"github.com/JosephNaberhaus/agnostic/internal/languages/python"
+	"github.com/JosephNaberhaus/agnostic/internal/languages/rust"
+	"github.com/JosephNaberhaus/agnostic/internal/languages/typescript"
+)
+
+// Backends are the backends that the corpus is run with.
+var Backends = []Backend{
+	{
+		Name:     "go",
+		Generate: golang.Generate,
+		Tools:    []string{"go"},
+		// Modules are imported by their name, which only works in GOPATH mode.
+		source: "src",
+		driver: driver(goDriver, goFormat),
+		build: func(dir string, files []languages.File) ([][]string, []string) {
+			return [][]string{{"go", "build", "-o", "driver", "./src/driver"}}, []string{filepath.Join(dir, "driver")}
+		},
+		env: func(dir string) []string {
+			return []string{"GOPATH=" + dir, "GO111MODULE=off", "GOFLAGS="}
+		},
+	},
+	{
+		Name:     "python",
+		Generate: python.Generate,
+		Tools:    []string{"python3"},
+		driver:   driver(pythonDriver, pythonFormat),
+		build: func(dir string, files []languages.File) ([][]string, []string) {
+			return nil, []string{"python3", "driver.py"}
+		},
+	},
+	{
+		Name:     "typescript",
+		Generate: typescript.Generate,
+		Tools:    []string{"tsc", "node"},
+		driver:   driver(typescriptDriver, typescriptFormat),
+		build: func(dir string, files []languages.File) ([][]string, []string) {
+			compile := []string{"tsc", "--strict", "--target", "es2020", "--module", "commonjs", "--outDir", "out"}
+			return [][]string{append(compile, paths(files, ".ts")...)}, []string{"node", filepath.Join("out", "driver.js")}
+		},
+	},
+	{
+		Name:     "java",
+		Generate: java.Generate,
+		Tools:    []string{"javac", "java"},
+		driver:   driver(javaDriver, javaFormat),
+		build: func(dir string, files []languages.File) ([][]string, []string) {
+			compile := []string{"javac", "-encoding", "UTF-8", "-d", "out"}
+			return [][]string{append(compile, paths(files, ".java")...)}, []string{"java", "-Dstdout.encoding=UTF-8", "-cp", "out", "Driver"}
+		},
+	},
+	{
+		Name:     "rust",
+		Generate: rust.Generate,
+		Tools:    []string{"rustc"},
+		driver:   driver(rustDriver, rustFormat),
+		build: func(dir string, files []languages.File) ([][]string, []string) {
+			// Building without optimizations keeps the checks for overflow, so arithmetic that doesn't wrap panics.
+			return [][]string{
+				{"rustc", "--edition", "2021", "--crate-type", "lib", "--crate-name", "generated", "--cap-lints", "allow", "--out-dir", "out", "lib.rs"},
+				{"rustc", "--edition", "2021", "--extern", "generated=" + filepath.Join("out", "libgenerated.rlib"), "-o", filepath.Join("out", "driver"), "driver.rs"},
+			}, []string{filepath.Join(dir, "out", "driver")}
+		},
+	},
+	{
+		Name:     "cpp",
+		Generate: cpp.Generate,
+		Tools:    []string{"g++"},
+		driver:   driver(cppDriver, cppFormat),
+		build: func(dir string, files []languages.File) ([][]string, []string) {
+			compile := []string{"g++", "-std=c++17", "-o", "driver"}
+			return [][]string{append(compile, paths(files, ".cpp")...)}, []string{filepath.Join(dir, "driver")}
+		},
+	},
+}
+
+// paths returns the paths of the files with the extension.
+func paths(files []languages.File, extension string) []string {
+	var result []string
+	for _, file := range files {
+		if strings.HasSuffix(file.Path, extension) {
+			result = append(result, file.Path)
+		}
+	}
+
+	return result
+}
+
+// formatter returns an expression that formats the value of the expression, which has the given type, the way that
+// interpreter.Format does. Nested lists name their items by their depth so that they don't shadow each other.
+type formatter func(expression string, typ code.Type, depth int) (string, error)
+
+// driver returns a function that creates the driver from a template, which is filled in with the expression that
+// formats the result of the entry function.
+func driver(template languages.File, format formatter) func(result code.Type) (languages.File, error) {
+	return func(result code.Type) (languages.File, error) {
+		expression, err := format("result", result, 0)
+		if err != nil {
+			return languages.File{}, err
+		}
+
+		return languages.File{
+			Path:     template.Path,
+			Contents: strings.Replace(template.Contents, "RESULT", expression, 1),
+		}, nil
+	}
+}
+
+func unsupported(typ code.Type) error {
+	return fmt.Errorf("a result of type %s can't be printed", code.TypeName(typ))
+}
+
+var goDriver = languages.File{
+	Path: "driver/main.go",
+	Contents: `package main
+
+import (
+	"example"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+func main() {
+	result := example.Run()
+	fmt.Println(RESULT)
+}
+
+func formatBytes(items []byte) string {
+	parts := make([]string, len(items))
+	for i, item := range items {
+		parts[i] = strconv.FormatUint(uint64(item), 10)
+	}
+	return "bytes{" + strings.Join(parts, ", ") + "}"
+}
+`,
+}
+
+func goFormat(expression string, typ code.Type, depth int) (string, error) {
+	switch typ := typ.(type) {
+	case *code.Bool:
+		return "strconv.FormatBool(" + expression + ")", nil
+	case *code.Int32, *code.Int64:
+		return "strconv.FormatInt(int64(" + expression + "), 10)", nil
+	case *code.Uint8:
+		return "strconv.FormatUint(uint64(" + expression + "), 10)", nil
+	case *code.Rune:
+		return "strconv.QuoteRune(" + expression + ")", nil
+	case *code.String:
+		return "strconv.Quote(" + expression + ")", nil
+	case *code.Bytes:
+		return "formatBytes(" + expression + ")", nil
+	case *code.List:
+		item := fmt.Sprintf("item%d", depth)
+		format, err := goFormat(item, typ.Item, depth+1)
+		if err != nil {
+			return "", err
+		}
+
+		return fmt.Sprintf(`func() string {
+		var parts []string
+		for _, %s := range *%s {
+			parts = append(parts, %s)
+		}
+		return "[" + strings.Join(parts, ", ") + "]"
+	}()`, item, expression, format), nil
+	default:
+		return "", unsupported(typ)
+	}
+}
+
+var pythonDriver = languages.File{
+	Path: "driver.py",
+	Contents: `import example
+
+
+def quote(text: str, delimiter: str) -> str:
+    escapes = {"\\": "\\\\", "\n": "\\n", "\r": "\\r", "\t": "\\t", delimiter: "\\" + delimiter}
+    return delimiter + "".join(escapes.get(c, c) for c in text) + delimiter
+
+
+result = example.run()
+print(RESULT)
+`,
+}
+
+func pythonFormat(expression string, typ code.Type, depth int) (string, error) {
+	switch typ := typ.(type) {
+	case *code.Bool:
+		return `("true" if ` + expression + ` else "false")`, nil
+	case *code.Int32, *code.Int64, *code.Uint8:
+		return "str(" + expression + ")", nil
+	case *code.Rune:
+		return "quote(" + expression + `, "'")`, nil
+	case *code.String:
+		return "quote(" + expression + `, '"')`, nil
+	case *code.Bytes:
+		return `("bytes{" + ", ".join(str(item) for item in ` + expression + `) + "}")`, nil
+	case *code.List:
+		item := fmt.Sprintf("item%d", depth)
+		format, err := pythonFormat(item, typ.Item, depth+1)
+		if err != nil {
+			return "", err
+		}
+
+		return fmt.Sprintf(`("[" + ", ".join(%s for %s in %s) + "]")`, format, item, expression), nil
+	default:
+		return "", unsupported(typ)
+	}
+}
+
+var typescriptDriver = languages.File{
+	Path: "driver.ts",
+	Contents: `import * as example from "./example";
+
+function quote(text: string, delimiter: string): string {
+  const escapes: Record<string, string> = { "\\": "\\\\", "\n": "\\n", "\r": "\\r", "\t": "\\t" };
+  escapes[delimiter] = "\\" + delimiter;
+  let result = delimiter;
+  for (const c of text) {
+    result += escapes[c] ?? c;
+  }
+  return result + delimiter;
+}
+
+const result = example.run();
+console.log(RESULT);
+`,
+}
+
+func typescriptFormat(expression string, typ code.Type, depth int) (string, error) {
+	switch typ := typ.(type) {
+	case *code.Bool, *code.Int32, *code.Int64, *code.Uint8:
+		return "String(" + expression + ")", nil
+	case *code.Rune:
+		return "quote(" + expression + `, "'")`, nil
+	case *code.String:
+		return "quote(" + expression + `, '"')`, nil
+	case *code.Bytes:
+		return `("bytes{" + Array.from(` + expression + `).join(", ") + "}")`, nil
+	case *code.List:
+		item := fmt.Sprintf("item%d", depth)
+		format, err := typescriptFormat(item, typ.Item, depth+1)
+		if err != nil {
+			return "", err
+		}
+
+		return fmt.Sprintf(`("[" + %s.map((%s) => %s).join(", ") + "]")`, expression, item, format), nil
+	default:
+		return "", unsupported(typ)
+	}
+}
+
+var javaDriver = languages.File{
+	Path: "Driver.java",
+	Contents: `public final class Driver {
+    public static void main(String[] args) {
+        var result = example.Example.run();
+        System.out.println(RESULT);
+    }
+
+    static String quote(String text, char delimiter) {
+        var result = new StringBuilder().append(delimiter);
+        text.codePoints().forEach(c -> {
+            if (c == '\\' || c == delimiter) {
+                result.append('\\').appendCodePoint(c);
+            } else if (c == '\n') {
+                result.append("\\n");
+            } else if (c == '\r') {
+                result.append("\\r");
+            } else if (c == '\t') {
+                result.append("\\t");
+            } else {
+                result.appendCodePoint(c);
+            }
+        });
+        return result.append(delimiter).toString();
+    }
+
+    static String formatBytes(byte[] items) {
+        var parts = new java.util.ArrayList<String>();
+        for (var item : items) {
+            parts.add(String.valueOf(item & 0xFF));
+        }
+        return "bytes{" + String.join(", ", parts) + "}";
+    }
+}
+`,
+}
+
+func javaFormat(expression string, typ code.Type, depth int) (string, error) {
+	switch typ := typ.(type) {
+	case *code.Bool, *code.Int32, *code.Int64, *code.Uint8:
+		return "String.valueOf(" + expression + ")", nil
+	case *code.Rune:
+		return "quote(new String(Character.toChars(" + expression + ")), '\\'')", nil
+	case *code.String:
+		return "quote(" + expression + ", '\"')", nil
+	case *code.Bytes:
+		return "formatBytes(" + expression + ")", nil
+	case *code.List:
+		item := fmt.Sprintf("item%d", depth)
+		format, err := javaFormat(item, typ.Item, depth+1)
+		if err != nil {
+			return "", err
+		}
+
+		return fmt.Sprintf(`("[" + %s.stream().map((%s) -> %s).collect(java.util.stream.Collectors.joining(", ")) + "]")`, expression, item, format), nil
+	default:
+		return "", unsupported(typ)
+	}
+}
+
+var rustDriver = languages.File{
+	Path: "driver.rs",
+	Contents: `fn quote(text: &str, delimiter: char) -> String {
+    let mut result = String::from(delimiter);
+    for c in text.chars() {
+        match c {
+            '\\' => result.push_str("\\\\"),
+            '\n' => result.push_str("\\n"),
+            '\r' => result.push_str("\\r"),
+            '\t' => result.push_str("\\t"),
+            c if c == delimiter => {
+                result.push('\\');
+                result.push(c);
+            }
+            c => result.push(c),
+        }
+    }
+    result.push(delimiter);
+    result
+}
+
+fn format_bytes(items: &[u8]) -> String {
+    let parts: Vec<String> = items.iter().map(|item| item.to_string()).collect();
+    format!("bytes{{{}}}", parts.join(", "))
+}
+
+fn main() {
+    let result = &generated::example::run();
+    println!("{}", RESULT);
+}
+`,
+}
+
+// rustFormat formats a reference to the value, since the copies of the items of lists are iterated over by reference.
+func rustFormat(expression string, typ code.Type, depth int) (string, error) {
+	switch typ := typ.(type) {
+	case *code.Bool, *code.Int32, *code.Int64, *code.Uint8:
+		return expression + ".to_string()", nil
+	case *code.Rune:
+		return "quote(&" + expression + ".to_string(), '\\'')", nil
+	case *code.String:
+		return "quote(" + expression + ", '\"')", nil
+	case *code.Bytes:
+		return "format_bytes(&" + expression + ".items())", nil
+	case *code.List:
+		item := fmt.Sprintf("item%d", depth)
+		format, err := rustFormat(item, typ.Item, depth+1)
+		if err != nil {
+			return "", err
+		}
+
+		return fmt.Sprintf(`format!("[{}]", %s.items().iter().map(|%s| %s).collect::<Vec<String>>().join(", "))`, expression, item, format), nil
+	default:
+		return "", unsupported(typ)
+	}
+}
+
+var cppDriver = languages.File{
+	Path: "driver.cpp",
+	Contents: `#include <iostream>
+#include <string>
+#include <vector>
+
+#include "example.h"
+
+static std::string encode(char32_t rune) {
+    std::string result;
+    if (rune < 0x80) {
+        result += static_cast<char>(rune);
+    } else if (rune < 0x800) {
+        result += static_cast<char>(0xC0 | (rune >> 6));
+        result += static_cast<char>(0x80 | (rune & 0x3F));
+    } else if (rune < 0x10000) {
+        result += static_cast<char>(0xE0 | (rune >> 12));
+        result += static_cast<char>(0x80 | ((rune >> 6) & 0x3F));
+        result += static_cast<char>(0x80 | (rune & 0x3F));
+    } else {
+        result += static_cast<char>(0xF0 | (rune >> 18));
+        result += static_cast<char>(0x80 | ((rune >> 12) & 0x3F));
+        result += static_cast<char>(0x80 | ((rune >> 6) & 0x3F));
+        result += static_cast<char>(0x80 | (rune & 0x3F));
+    }
+    return result;
+}
+
+static std::string quote(const std::string& text, char delimiter) {
+    std::string result(1, delimiter);
+    for (char c : text) {
+        if (c == '\\' || c == delimiter) {
+            result += '\\';
+            result += c;
+        } else if (c == '\n') {
+            result += "\\n";
+        } else if (c == '\r') {
+            result += "\\r";
+        } else if (c == '\t') {
+            result += "\\t";
+        } else {
+            result += c;
+        }
+    }
+    return result + delimiter;
+}
+
+template <typename T, typename F>
+static std::string formatList(const agnostic::List<T>& list, F format, const std::string& prefix, const std::string& suffix) {
+    std::vector<T> items = list.items();
+    std::string result = prefix;
+    for (std::size_t i = 0; i < items.size(); i++) {
+        if (i > 0) {
+            result += ", ";
+        }
+        result += format(items[i]);
+    }
+    return result + suffix;
+}
+
+int main() {
+    const auto result = example::run();
+    std::cout << RESULT << std::endl;
+}
+`,
+}
+
+func cppFormat(expression string, typ code.Type, depth int) (string, error) {
+	switch typ := typ.(type) {
+	case *code.Bool:
+		return `std::string(` + expression + ` ? "true" : "false")`, nil
+	case *code.Int32, *code.Int64, *code.Uint8:
+		return "std::to_string(" + expression + ")", nil
+	case *code.Rune:
+		return "quote(encode(" + expression + "), '\\'')", nil
+	case *code.String:
+		return "quote(" + expression + ", '\"')", nil
+	case *code.Bytes:
+		return "formatList(" + expression + `, [](uint8_t item) { return std::to_string(item); }, "bytes{", "}")`, nil
+	case *code.List:
+		item := fmt.Sprintf("item%d", depth)
+		format, err := cppFormat(item, typ.Item, depth+1)
+		if err != nil {
+			return "", err
+		}
+
+		return fmt.Sprintf(`formatList(%s, [](const auto& %s) { return %s; }, "[", "]")`, expression, item, format), nil
+	default:
+		return "", unsupported(typ)
+	}
+}
@@ -0,0 +1,211 @@
+// Package conformance checks that the code generated by every backend behaves the same as the interpreter, which is the
+// reference for what a program means.
+//
+// Each program of the Corpus has a module named "example" with a public function "run" that takes no arguments. A
+// backend runs a program by generating it along with a driver that calls run and prints its result. The result is
+// printed the way interpreter.Format writes it, so it must be a bool, an integer, a rune, a string, bytes, or a list of
+// those, and its strings and runes may only contain printable characters. Floats, maps, sets, and models have no
+// format that every language agrees on, so a program reduces them to one of the other types before returning.
+//
+// A program that stops with a runtime error has the outcome Failure. Languages report errors differently, so the
+// outcome only records that the program failed and not why.
+package conformance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"github.com/JosephNaberhaus/agnostic/ast"
+	"github.com/JosephNaberhaus/agnostic/code"
+	"github.com/JosephNaberhaus/agnostic/internal/agnosticscript"
+	"github.com/JosephNaberhaus/agnostic/internal/interpreter"
+	"github.com/JosephNaberhaus/agnostic/internal/languages"
+	"github.com/JosephNaberhaus/agnostic/internal/mappers/ast_to_code_mapper"
+)
+
+const (
+	// The module and function that running a program calls.
+	entryModule   = "example"
+	entryFunction = "run"
+)
+
+// Failure is the outcome of a program that stops with a runtime error.
+const Failure = "runtime error"
+
+// Timeout is how long a generated program can run before it's stopped. It doesn't include building the program.
+const Timeout = time.Minute
+
+// Program is a program of the corpus.
+type Program struct {
+	Name string
+	Root ast.Root
+	// The outcome of running the program, which is either its formatted result or Failure.
+	Expected string
+}
+
+// program parses the AgnosticScript source of a program. The corpus is fixed, so a source that doesn't parse is a bug
+// and panics.
+func program(name, expected, source string) Program {
+	root, err := agnosticscript.Parse(name+".as", source)
+	if err != nil {
+		panic(fmt.Sprintf("program %q: %v", name, err))
+	}
+
+	return Program{Name: name, Root: root, Expected: expected}
+}
+
+// compile maps the AST of the program into code and returns it along with the entry function.
+func compile(program Program) (*code.Root, *code.FunctionDef, error) {
+	mapper := &ast_to_code_mapper.Mapper{}
+	result, err := mapper.MapRoot(program.Root)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := result.(*code.Root)
+	for _, module := range root.Modules {
+		if module.Name != entryModule {
+			continue
+		}
+
+		for _, function := range module.Functions {
+			if function.Name == entryFunction && len(function.Arguments) == 0 {
+				return root, function, nil
+			}
+		}
+	}
+
+	return nil, nil, fmt.Errorf("module %q has no function %q without arguments", entryModule, entryFunction)
+}
+
+// Interpret runs the program with the interpreter and returns its outcome.
+func Interpret(program Program) (string, error) {
+	root, _, err := compile(program)
+	if err != nil {
+		return "", err
+	}
+
+	result, err := interpreter.New(root).Call(entryModule, entryFunction)
+	var runtimeError *interpreter.Error
+	if errors.As(err, &runtimeError) {
+		return Failure, nil
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	return interpreter.Format(result), nil
+}
+
+// Backend is a language that code can be generated in, along with how to run the generated code.
+type Backend struct {
+	Name     string
+	Generate func(root *code.Root) ([]languages.File, error)
+	// The programs that must be installed to build and run the generated code.
+	Tools []string
+	// driver returns the file that calls the entry function and prints its result, which has the given type.
+	driver func(result code.Type) (languages.File, error)
+	// source is the directory under the build directory that the files are written to.
+	source string
+	// build returns the commands that build the files in dir, and the command that runs the result.
+	build func(dir string, files []languages.File) (build [][]string, run []string)
+	// env returns what is added to the environment of every command that runs in dir.
+	env func(dir string) []string
+}
+
+// Missing returns the tools of the backend that aren't installed.
+func (b Backend) Missing() []string {
+	var missing []string
+	for _, tool := range b.Tools {
+		if _, err := exec.LookPath(tool); err != nil {
+			missing = append(missing, tool)
+		}
+	}
+
+	return missing
+}
+
+// Run generates the program with the backend, then builds and runs it in dir and returns its outcome. An error means
+// that the program couldn't be generated or built.
+func (b Backend) Run(program Program, dir string) (string, error) {
+	root, entry, err := compile(program)
+	if err != nil {
+		return "", err
+	}
+
+	files, err := b.Generate(root)
+	if err != nil {
+		return "", err
+	}
+
+	driver, err := b.driver(entry.ReturnType)
+	if err != nil {
+		return "", err
+	}
+
+	files = append(files, driver)
+	for _, file := range files {
+		path := filepath.Join(dir, b.source, file.Path)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return "", err
+		}
+
+		if err := os.WriteFile(path, []byte(file.Contents), 0o644); err != nil {
+			return "", err
+		}
+	}
+
+	build, run := b.build(dir, files)
+	for _, args := range build {
+		if output, err := b.command(context.Background(), dir, args).CombinedOutput(); err != nil {
+			return "", fmt.Errorf("%s: %w\n%s", args[0], err, output)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	defer cancel()
+
+	output, err := b.command(ctx, dir, run).Output()
+	if ctx.Err() != nil {
+		return "", fmt.Errorf("program didn't finish within %s", Timeout)
+	}
+
+	var exitError *exec.ExitError
+	if errors.As(err, &exitError) {
+		return Failure, nil
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	return string(trimNewline(output)), nil
+}
+
+func (b Backend) command(ctx context.Context, dir string, args []string) *exec.Cmd {
+	command := exec.CommandContext(ctx, args[0], args[1:]...)
+	command.Dir = dir
+	command.Env = os.Environ()
+	if b.env != nil {
+		command.Env = append(command.Env, b.env(dir)...)
+	}
+	return command
+}
+
+// trimNewline removes the line ending that the driver prints after the result.
+func trimNewline(output []byte) []byte {
+	if n := len(output); n > 0 && output[n-1] == '\n' {
+		output = output[:n-1]
+		if n := len(output); n > 0 && output[n-1] == '\r' {
+			output = output[:n-1]
+		}
+	}
+
+	return output
+}
@@ -0,0 +1,69 @@
+package conformance
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/JosephNaberhaus/agnostic/internal/agnosticscript"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInterpret(t *testing.T) {
+	for _, program := range Corpus {
+		t.Run(program.Name, func(t *testing.T) {
+			actual, err := Interpret(program)
+			require.NoError(t, err)
+			assert.Equal(t, program.Expected, actual)
+		})
+	}
+}
+
+// TestPrint checks that every program still has the same result after it's printed and parsed again.
+func TestPrint(t *testing.T) {
+	for _, program := range Corpus {
+		t.Run(program.Name, func(t *testing.T) {
+			printed := agnosticscript.Print(program.Root)
+			root, err := agnosticscript.Parse(program.Name+".as", printed)
+			require.NoError(t, err)
+			assert.Equal(t, printed, agnosticscript.Print(root))
+
+			actual, err := Interpret(Program{Name: program.Name, Root: root, Expected: program.Expected})
+			require.NoError(t, err)
+			assert.Equal(t, program.Expected, actual)
+		})
+	}
+}
+
+// TestBackends runs the corpus with every backend whose tools are installed. The backends that are skipped because
+// of a missing tool are listed at the end, since a skipped backend would otherwise look like a passing one.
+func TestBackends(t *testing.T) {
+	var skipped []string
+	for _, backend := range Backends {
+		t.Run(backend.Name, func(t *testing.T) {
+			if testing.Short() {
+				t.Skip("building generated code is slow")
+			}
+
+			if missing := backend.Missing(); len(missing) > 0 {
+				skipped = append(skipped, fmt.Sprintf("%s (%s missing)", backend.Name, strings.Join(missing, ", ")))
+				t.Skipf("not exercised: %s is missing", strings.Join(missing, ", "))
+			}
+
+			for _, program := range Corpus {
+				t.Run(program.Name, func(t *testing.T) {
+					t.Parallel()
+
+					actual, err := backend.Run(program, t.TempDir())
+					require.NoError(t, err)
+					assert.Equal(t, program.Expected, actual)
+				})
+			}
+		})
+	}
+
+	if len(skipped) > 0 {
+		t.Logf("not exercised: %s", strings.Join(skipped, "; "))
+	}
+}
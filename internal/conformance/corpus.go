@@ -0,0 +1,789 @@
+package conformance
+
+// Corpus is the programs that every backend must run the same way as the interpreter.
+var Corpus = []Program{
+	program("Arithmetic", "[7, -3, 10, 0, -2, 1, -1, 3]", `
+module example {
+	func run() list[int64] {
+		var a = 2
+		var b = 5
+		return [a + b, a - b, a * b, a / b, -7 / 3, 7 % 3, -7 % 3, -(-3)]
+	}
+}
+`),
+	program("Wrapping", "[-2147483648, 255, 0, -9223372036854775808, -9223372036854775808, 0, 2147483647]", `
+module example {
+	func run() list[int64] {
+		var maxInt32 = int32(2147483647)
+		var maxInt64 = 9223372036854775807
+		var minInt64 = -9223372036854775808
+		var minInt32 = int32(-2147483648)
+		return [
+			int64(maxInt32 + int32(1)),
+			int64(uint8(0) - uint8(1)),
+			int64(uint8(16) * uint8(16)),
+			maxInt64 + 1,
+			minInt64 / -1,
+			minInt64 % -1,
+			int64(minInt32 - int32(1)),
+		]
+	}
+}
+`),
+	program("Negation", "[-9223372036854775808, -2147483648]", `
+module example {
+	func run() list[int64] {
+		var minInt64 = -9223372036854775808
+		var minInt32 = int32(-2147483648)
+		return [-minInt64, int64(-minInt32)]
+	}
+}
+`),
+	program("IntegerConversions", "[44, -1, 255, 1, -56, 200]", `
+module example {
+	func run() list[int64] {
+		var big = 300
+		var negative = -1
+		var wide = -4294967295
+		var byte = uint8(200)
+		return [int64(uint8(big)), int64(int32(negative)), int64(uint8(negative)), int64(int32(wide)), int64(int32(int64(byte) - 256)), int64(byte)]
+	}
+}
+`),
+	program("FloatConversions", "[2, -2, 2147483647, -2147483648, 0, 255, 0, 9223372036854775807, -9223372036854775808]", `
+module example {
+	func run() list[int64] {
+		var zero = 0.0
+		var nan = zero / zero
+		var infinity = 1.0 / zero
+		return [
+			int64(2.9),
+			int64(-2.9),
+			int64(int32(1e10)),
+			int64(int32(-1e10)),
+			int64(uint8(-1.5)),
+			int64(uint8(300.5)),
+			int64(nan),
+			int64(infinity),
+			int64(-infinity),
+		]
+	}
+}
+`),
+	program("FloatArithmetic", `["true", "true", "true", "false", "true", "9007199254740992"]`, `
+module example {
+	func run() list[string] {
+		var zero = 0.0
+		var nan = zero / zero
+		var sum = 0.1 + 0.2
+		var results = list[bool]{}
+		push(results, sum != 0.3)
+		push(results, sum > 0.3)
+		push(results, 1.0 / zero > 1e308)
+		push(results, nan == nan)
+		push(results, nan != nan)
+		var strings = list[string]{}
+		for result in results {
+			if result {
+				push(strings, "true")
+			} else {
+				push(strings, "false")
+			}
+		}
+		var rounded = float64(9007199254740993)
+		if int64(rounded) == 9007199254740992 {
+			push(strings, "9007199254740992")
+		}
+		return strings
+	}
+}
+`),
+	program("DivisionByZero", Failure, `
+module example {
+	func divide(a int64, b int64) int64 {
+		return a / b
+	}
+
+	func run() int64 {
+		return divide(1, 0)
+	}
+}
+`),
+	program("ModuloByZero", Failure, `
+module example {
+	func run() uint8 {
+		var zero = uint8(0)
+		return uint8(7) % zero
+	}
+}
+`),
+	program("Strings", `["héllo, wörld", "ö", "5", "'\"\\"]`, `
+module example {
+	func run() list[string] {
+		var greeting = "héllo"
+		var joined = greeting + ", " + "wörld"
+		var length = len(greeting)
+		var result = [joined, "", "", "'\"\\"]
+		for r in joined {
+			if r == 'ö' {
+				result[1] = "ö"
+			}
+		}
+		if joined[7] == 'w' && length == 5 {
+			result[2] = "5"
+		}
+		return result
+	}
+}
+`),
+	program("Runes", `['a', 'é', '世', '\'']`, `
+module example {
+	func run() list[rune] {
+		var text = "aé世"
+		var result = list[rune]{}
+		for r in text {
+			push(result, r)
+		}
+		push(result, '\'')
+		return result
+	}
+}
+`),
+	program("RuneOutOfRange", Failure, `
+module example {
+	func run() rune {
+		return "é"[1]
+	}
+}
+`),
+	program("Bytes", "[bytes{0, 127, 255, 40}, bytes{}]", `
+module example {
+	func run() list[bytes] {
+		var data = bytes{0, 127, 128, 255}
+		var total = 0
+		for b in data {
+			total = total + int64(b)
+		}
+		data[2] = data[3]
+		data[3] = uint8(total % 256 - 214)
+		return [data, bytes{}]
+	}
+}
+`),
+	program("Lists", "[[1, 2, 3], [4], [], [3, 2]]", `
+module example {
+	func run() list[list[int64]] {
+		var numbers = [1, 2]
+		push(numbers, 3)
+		var popped = [4, 5]
+		pop(popped)
+		var empty = list[int64]{}
+		var reversed = list[int64]{}
+		var copy = [1, 2, 3]
+		for len(copy) > 1 {
+			push(reversed, pop(copy))
+		}
+		return [numbers, popped, empty, reversed]
+	}
+}
+`),
+	program("PopEmpty", Failure, `
+module example {
+	func run() int64 {
+		var items = [1]
+		pop(items)
+		return pop(items)
+	}
+}
+`),
+	program("IndexOutOfRange", Failure, `
+module example {
+	func run() int64 {
+		var items = [1, 2]
+		return items[2]
+	}
+}
+`),
+	program("NegativeIndex", Failure, `
+module example {
+	func run() int64 {
+		var items = [1, 2]
+		var index = -1
+		return items[index]
+	}
+}
+`),
+	program("NegativeStore", Failure, `
+module example {
+	func run() list[int64] {
+		var items = [1, 2]
+		var index = -1
+		items[index] = 3
+		return items
+	}
+}
+`),
+	program("NegativeRuneIndex", Failure, `
+module example {
+	func run() list[rune] {
+		var text = "ab"
+		var index = -1
+		return [text[index]]
+	}
+}
+`),
+	program("Maps", "[3, 10, 20, 40]", `
+module example {
+	func run() list[int64] {
+		var scores = map{"a": 1, "b": 2}
+		scores["c"] = 3
+		scores["a"] = 10
+		var total = 0
+		for key in scores {
+			total = total + scores[key]
+		}
+		return [len(scores), scores["a"], scores["b"] * 10, total + 25]
+	}
+}
+`),
+	program("ListAliasing", "[[9, 2, 3, 4, 5], [9, 2, 3, 4, 5], [0], [1]]", `
+module example {
+	func run() list[list[int64]] {
+		var a = [1, 2]
+		var b = a
+		push(b, 3)
+		b[0] = 9
+		add(a, 4)
+		var lists = list[list[int64]]{}
+		push(lists, a)
+		push(lists[0], 5)
+		var replaced = replace(a)
+		var copied = [1]
+		var other = copied
+		other = [2]
+		return [a, b, replaced, copied]
+	}
+
+	func add(items list[int64], item int64) {
+		push(items, item)
+	}
+
+	func replace(items list[int64]) list[int64] {
+		items = [0]
+		return items
+	}
+}
+`),
+	program("MapAliasing", "[1, 2, 3, 3, 4]", `
+module example {
+	func run() list[int64] {
+		var a = map{"x": 1}
+		var b = a
+		b["y"] = 2
+		put(a, "z", 3)
+		var maps = list[map[string, int64]]{}
+		push(maps, b)
+		maps[0]["w"] = 4
+		return [a["x"], a["y"], b["z"], len(maps[0]) - 1, a["w"]]
+	}
+
+	func put(values map[string, int64], key string, value int64) {
+		values[key] = value
+	}
+}
+`),
+	program("SetAliasing", "[3, 3]", `
+module example {
+	func run() list[int64] {
+		var a = set{1}
+		var b = a
+		insert(b, 2)
+		add(a, 3)
+		return [len(a), len(b)]
+	}
+
+	func add(items set[int64], item int64) {
+		insert(items, item)
+	}
+}
+`),
+	program("BytesAliasing", "[bytes{9, 8}, bytes{9, 8}]", `
+module example {
+	func run() list[bytes] {
+		var a = bytes{1, 2}
+		var b = a
+		b[0] = uint8(9)
+		store(a, 1, uint8(8))
+		return [a, b]
+	}
+
+	func store(data bytes, index int64, value uint8) {
+		data[index] = value
+	}
+}
+`),
+	program("ChangeWhileIterating", "[6, 6, 2, 2, 3, 2]", `
+module example {
+	func run() list[int64] {
+		var items = [1, 2, 3]
+		var total = 0
+		for item in items {
+			push(items, item)
+			items[2] = 0
+			total = total + item
+		}
+		var values = map{1: 1}
+		var visited = 0
+		for key in values {
+			values[key + 10] = key
+			visited = visited + 1
+		}
+		var seen = set{1}
+		for item in seen {
+			insert(seen, item + 10)
+			visited = visited + 1
+		}
+		var data = bytes{1, 2}
+		for b in data {
+			data[1] = uint8(0)
+			visited = visited + int64(b)
+		}
+		return [len(items), total, len(values), len(seen), visited - 2, int64(data[1]) + 2]
+	}
+}
+`),
+	program("ConstantCollections", "[2, 3, 1, 1, 1, 7, 4, 2, 2, 9]", `
+module example {
+	const primes = [2, 3, 5]
+	const names = map{"a": 1}
+	const digits = set{1}
+	const data = bytes{1}
+
+	func run() list[int64] {
+		var a = primes
+		a[0] = 7
+		push(a, 11)
+		var m = names
+		m["b"] = 2
+		var s = digits
+		insert(s, 2)
+		var d = data
+		d[0] = uint8(9)
+		add(primes, 13)
+		return [primes[0], len(primes), len(names), len(digits), int64(data[0]), a[0], len(a), len(m), len(s), int64(d[0])]
+	}
+
+	func add(items list[int64], item int64) {
+		push(items, item)
+	}
+}
+`),
+	program("InsertionOrder", "[[5, 0, 7, 2, 9, 4, 11, 6, 1, 8, 3, 10], [5, 0, 10, 3, 8, 1, 6, 11, 4, 9, 2, 7], [4, 1, 2]]", `
+module example {
+	func run() list[list[int64]] {
+		var values = map{5: 0}
+		var items = set{5}
+		for var i = 0; i < 12; i = i + 1 {
+			values[i * 7 % 12] = i
+			insert(items, i * 5 % 12)
+		}
+		var keys = list[int64]{}
+		for key in values {
+			push(keys, key)
+		}
+		var ordered = list[int64]{}
+		for item in items {
+			push(ordered, item)
+		}
+		var names = map{"c": 3, "a": 1}
+		names["b"] = 2
+		names["c"] = 4
+		var counts = list[int64]{}
+		for name in names {
+			push(counts, names[name])
+		}
+		return [keys, ordered, counts]
+	}
+}
+`),
+	program("MissingKey", Failure, `
+module example {
+	func run() int64 {
+		var scores = map{"a": 1}
+		return scores["b"]
+	}
+}
+`),
+	program("Sets", `["true", "false", "3", "6"]`, `
+module example {
+	func run() list[string] {
+		var items = set{1, 2}
+		insert(items, 2)
+		insert(items, 3)
+		var total = 0
+		for item in items {
+			total = total + item
+		}
+		var result = list[string]{}
+		push(result, describe(contains(items, 3)))
+		push(result, describe(contains(items, 4)))
+		push(result, count(len(items)))
+		push(result, count(total))
+		return result
+	}
+
+	func describe(value bool) string {
+		if value {
+			return "true"
+		}
+		return "false"
+	}
+
+	func count(n int64) string {
+		var digits = ["0", "1", "2", "3", "4", "5", "6", "7", "8", "9"]
+		return digits[n]
+	}
+}
+`),
+	program("Models", "[0, 7, 12]", `
+module example {
+	model Counter {
+		count int64
+		step int64
+
+		func increment() {
+			self.count = self.count + self.step
+		}
+
+		func get() int64 {
+			return self.count
+		}
+	}
+
+	func run() list[int64] {
+		var counter = new(Counter)
+		var initial = counter.get()
+		counter.step = 7
+		counter.increment()
+		var afterOne = counter.get()
+		incrementBy(counter, 5)
+		return [initial, afterOne, counter.count]
+	}
+
+	func incrementBy(counter Counter, step int64) {
+		counter.step = step
+		counter.increment()
+	}
+}
+`),
+	program("ModelAliasing", "[7, 7, 3]", `
+module example {
+	model Point {
+		x int64
+		items list[int64]
+	}
+
+	func run() list[int64] {
+		var a = new(Point)
+		var b = a
+		b.x = 5
+		var points = list[Point]{}
+		push(points, a)
+		points[0].x = 7
+		push(points[0].items, 1)
+		push(b.items, 2)
+		push(a.items, 3)
+		return [a.x, b.x, len(points[0].items)]
+	}
+}
+`),
+	program("ModelComparison", "[6, 3, 1, 1]", `
+module example {
+	model Node {
+		value int64
+		next Node
+	}
+
+	func run() list[int64] {
+		var head = new(Node)
+		head.value = 1
+		var tail = head
+		for var i = 2; i <= 4; i = i + 1 {
+			var node = new(Node)
+			node.value = i
+			tail.next = node
+			tail = node
+		}
+		var total = 0
+		var count = 0
+		var n = head
+		for n.next != nil(Node) {
+			total = total + n.value
+			count = count + 1
+			n = n.next
+		}
+		var same = 0
+		if n == tail {
+			same = 1
+		}
+		var twin = new(Node)
+		twin.value = 4
+		var different = 0
+		if twin != tail {
+			different = 1
+		}
+		return [total, count, same, different]
+	}
+}
+`),
+	program("ModelZeroValues", `["0", "0", "", "0"]`, `
+module example {
+	model Everything {
+		number int64
+		small uint8
+		text string
+		items list[int64]
+	}
+
+	func run() list[string] {
+		var value = new(Everything)
+		var result = list[string]{}
+		push(result, digit(value.number))
+		push(result, digit(int64(value.small)))
+		push(result, value.text)
+		push(result, digit(len(value.items)))
+		return result
+	}
+
+	func digit(n int64) string {
+		var digits = ["0", "1", "2", "3", "4", "5", "6", "7", "8", "9"]
+		return digits[n]
+	}
+}
+`),
+	program("NilProperty", Failure, `
+module example {
+	model Node {
+		value int64
+		next Node
+	}
+
+	func run() int64 {
+		var node = new(Node)
+		return node.next.value
+	}
+}
+`),
+	program("EqualOverride", `["true", "false", "true", "1", "1b"]`, `
+module example {
+	model Point {
+		x int64
+		y int64
+
+		equals(other) {
+			return self.x == other.x && self.y == other.y
+		}
+
+		hash {
+			return self.x * 31 + self.y
+		}
+	}
+
+	func newPoint(x int64, y int64) Point {
+		var result = new(Point)
+		result.x = x
+		result.y = y
+		return result
+	}
+
+	func run() list[string] {
+		var points = set{newPoint(1, 2), newPoint(1, 2)}
+		var names = map{newPoint(3, 4): "a"}
+		names[newPoint(3, 4)] = "b"
+		var result = list[string]{}
+		push(result, describe(newPoint(1, 2) == newPoint(1, 2)))
+		push(result, describe(newPoint(1, 2) == newPoint(2, 1)))
+		push(result, describe(contains(points, newPoint(1, 2))))
+		push(result, digit(len(points)))
+		push(result, digit(len(names)) + names[newPoint(3, 4)])
+		return result
+	}
+
+	func describe(value bool) string {
+		if value {
+			return "true"
+		}
+		return "false"
+	}
+
+	func digit(n int64) string {
+		var digits = ["0", "1", "2", "3", "4", "5", "6", "7", "8", "9"]
+		return digits[n]
+	}
+}
+`),
+	program("NilEquality", `["false", "true", "true", "false"]`, `
+module example {
+	model Point {
+		x int64
+
+		equals(other) {
+			return self.x == other.x
+		}
+	}
+
+	func run() list[string] {
+		var point = new(Point)
+		var missing = nil(Point)
+		var result = list[string]{}
+		push(result, describe(point == missing))
+		push(result, describe(missing == nil(Point)))
+		push(result, describe(point != nil(Point)))
+		push(result, describe(nil(Point) == point))
+		return result
+	}
+
+	func describe(value bool) string {
+		if value {
+			return "true"
+		}
+		return "false"
+	}
+}
+`),
+	program("ModelIdentity", `["2", "1"]`, `
+module example {
+	model Plain {
+		x int64
+	}
+
+	model Hashed {
+		x int64
+
+		hash {
+			return self.x
+		}
+	}
+
+	func run() list[string] {
+		var a = new(Plain)
+		var b = new(Plain)
+		var plains = set{a, b, a}
+		var first = new(Hashed)
+		var hashed = map{first: 1}
+		hashed[new(Hashed)] = 2
+		hashed[first] = 3
+		var result = list[string]{}
+		push(result, digit(len(plains)))
+		push(result, digit(len(hashed) - 1))
+		return result
+	}
+
+	func digit(n int64) string {
+		var digits = ["0", "1", "2", "3", "4", "5", "6", "7", "8", "9"]
+		return digits[n]
+	}
+}
+`),
+	program("ControlFlow", "[1, 3, 5, 7, 20, 55]", `
+module example {
+	func run() list[int64] {
+		var result = list[int64]{}
+		for var i = 0; i < 10; i = i + 1 {
+			if i % 2 == 0 {
+				continue
+			}
+			if i > 7 {
+				break
+			}
+			push(result, i)
+		}
+		var nested = 0
+		for var i = 0; i < 5; i = i + 1 {
+			for var j = 0; j < 5; j = j + 1 {
+				if j > i {
+					break
+				}
+				nested = nested + 1
+			}
+		}
+		push(result, nested + 5)
+		push(result, fibonacci(10))
+		return result
+	}
+
+	func fibonacci(n int64) int64 {
+		if n < 2 {
+			return n
+		} else if n == 2 {
+			return 1
+		}
+		return fibonacci(n - 1) + fibonacci(n - 2)
+	}
+}
+`),
+	program("ShortCircuit", "true", `
+module example {
+	func fail() bool {
+		var items = list[bool]{}
+		return pop(items)
+	}
+
+	func run() bool {
+		return !(false && fail()) && (true || fail())
+	}
+}
+`),
+	program("Constants", "[3, 8]", `
+module example {
+	const limit = 3
+	const base = int32(5)
+
+	func run() list[int64] {
+		return [limit, int64(base) + limit]
+	}
+}
+`),
+	program("Modules", "[30, 6]", `
+module example {
+	import bank
+
+	func run() list[int64] {
+		return [bank.balance(), bank.scaled(2)]
+	}
+}
+
+module bank {
+	const rate = 3
+
+	model Account {
+		balance int64
+		private history list[int64]
+
+		func deposit(amount int64) {
+			push(self.history, amount)
+			self.balance = self.balance + amount - fee()
+		}
+
+		func count() int64 {
+			return len(self.history)
+		}
+	}
+
+	private func fee() int64 {
+		return 1
+	}
+
+	func balance() int64 {
+		var account = new(Account)
+		account.deposit(10)
+		account.deposit(20)
+		return account.balance + account.count()
+	}
+
+	func scaled(n int64) int64 {
+		return n * rate
+	}
+}
+`),
+}
@@ -0,0 +1,32 @@
+// Package interpreter executes a code.Root directly. It's the reference for what an Agnostic program means, so the
+// code generated by each language should behave the same as the interpreter does.
+//
+// Booleans, numbers, runes, and strings are values, while bytes, lists, maps, sets, and models are references. Assigning
+// one of the latter or passing it to a function shares it, so changing its contents is visible through every reference
+// to it. A constant is evaluated each time it's used, so changing the contents of a constant's collection doesn't change
+// the constant.
+//
+// Arithmetic follows the Overflow of the operation: integers wrap and floats round. Converting a float to an integer
+// saturates. Integer division truncates toward zero and the remainder has the sign of the dividend.
+//
+// Logical operators short-circuit, and the operands of every other operator are evaluated from left to right. An
+// assignment evaluates the operands of its target before the assigned value, and then stores the value.
+//
+// Iterating over a collection iterates over the items that it had when the loop started, so changing the collection in
+// the loop doesn't change which items are visited. Maps and sets are iterated over in the order that their keys were
+// first inserted.
+//
+// A model is equal to another one if its equal override says so, or if it's the same instance when it has no equal
+// override. A nil model is only equal to nil, and the overrides are never called with nil. Maps and sets hash models
+// with their hash override. Models that only override equals all hash the same, while models without overrides hash by
+// their identity.
+//
+// The following are runtime errors, which stop the program:
+//   - Popping from an empty list.
+//   - Looking up a key that a map doesn't have.
+//   - Looking up or assigning to an index that is out of range.
+//   - Assigning to a rune of a string, since strings can't be changed.
+//   - Accessing a property of nil or calling a method on nil.
+//   - Dividing an integer by zero or taking its remainder by zero.
+//   - Calling functions more than MaxDepth deep.
+package interpreter
@@ -0,0 +1,506 @@
+package interpreter
+
+import (
+	"cmp"
+	"unicode/utf8"
+
+	"github.com/JosephNaberhaus/agnostic/code"
+)
+
+// evaluate returns the value that the node evaluates to.
+func (f *frame) evaluate(value code.Value) (Value, error) {
+	return code.MapValue[Value](value, evaluator{f})
+}
+
+// evaluateEach evaluates the values from first to last.
+func (f *frame) evaluateEach(values []code.Value) ([]Value, error) {
+	results := make([]Value, 0, len(values))
+	for _, value := range values {
+		result, err := f.evaluate(value)
+		if err != nil {
+			return nil, err
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// evaluator evaluates values.
+type evaluator struct {
+	*frame
+}
+
+var _ code.ValueMapper[Value] = evaluator{}
+
+func (e evaluator) MapBinary(value *code.Binary) (Value, error) {
+	left, err := e.evaluate(value.Left)
+	if err != nil {
+		return nil, err
+	}
+
+	// The logical operators short-circuit.
+	switch value.Operator {
+	case code.BinaryOperatorAnd:
+		if !left.(Bool) {
+			return left, nil
+		}
+
+		return e.evaluate(value.Right)
+	case code.BinaryOperatorOr:
+		if left.(Bool) {
+			return left, nil
+		}
+
+		return e.evaluate(value.Right)
+	}
+
+	right, err := e.evaluate(value.Right)
+	if err != nil {
+		return nil, err
+	}
+
+	switch left := left.(type) {
+	case Float64:
+		return floatArithmetic(value, left, right.(Float64))
+	case Int32:
+		return integerArithmetic(value, left, right.(Int32))
+	case Int64:
+		return integerArithmetic(value, left, right.(Int64))
+	case Uint8:
+		return integerArithmetic(value, left, right.(Uint8))
+	case String:
+		if value.Operator == code.BinaryOperatorAdd {
+			return left + right.(String), nil
+		}
+	}
+
+	return nil, errorf(value, "operator %s is not defined for %T", value.Operator.Symbol(), left)
+}
+
+// integerValue is one of the integer values.
+type integerValue interface {
+	Int32 | Int64 | Uint8
+	Value
+}
+
+// integerArithmetic applies the operator to integers. Go's arithmetic on integers wraps, just as integer arithmetic
+// does in Agnostic.
+func integerArithmetic[T integerValue](value *code.Binary, left, right T) (Value, error) {
+	switch value.Operator {
+	case code.BinaryOperatorAdd:
+		return left + right, nil
+	case code.BinaryOperatorSubtract:
+		return left - right, nil
+	case code.BinaryOperatorMultiply:
+		return left * right, nil
+	case code.BinaryOperatorDivide:
+		if right == 0 {
+			return nil, errorf(value, "integer division by zero")
+		}
+
+		return left / right, nil
+	case code.BinaryOperatorModulo:
+		if right == 0 {
+			return nil, errorf(value, "integer modulo by zero")
+		}
+
+		return left % right, nil
+	default:
+		return nil, errorf(value, "operator %s is not defined for integers", value.Operator.Symbol())
+	}
+}
+
+func floatArithmetic(value *code.Binary, left, right Float64) (Value, error) {
+	switch value.Operator {
+	case code.BinaryOperatorAdd:
+		return left + right, nil
+	case code.BinaryOperatorSubtract:
+		return left - right, nil
+	case code.BinaryOperatorMultiply:
+		return left * right, nil
+	case code.BinaryOperatorDivide:
+		return left / right, nil
+	default:
+		return nil, errorf(value, "operator %s is not defined for floats", value.Operator.Symbol())
+	}
+}
+
+func (e evaluator) MapCall(value *code.Call) (Value, error) {
+	var function *code.FunctionDef
+	switch callable := value.Function.(type) {
+	case *code.FunctionDef:
+		function = callable
+	case *code.FunctionRef:
+		function = callable.Definition
+	}
+
+	if function == nil {
+		return nil, errorf(value, "call of an unresolved function")
+	}
+
+	arguments, err := e.evaluateEach(value.Arguments)
+	if err != nil {
+		return nil, err
+	}
+
+	return e.interpreter.call(function, nil, arguments)
+}
+
+func (e evaluator) MapComparison(value *code.Comparison) (Value, error) {
+	left, err := e.evaluate(value.Left)
+	if err != nil {
+		return nil, err
+	}
+
+	right, err := e.evaluate(value.Right)
+	if err != nil {
+		return nil, err
+	}
+
+	switch left := left.(type) {
+	case Float64:
+		return compare(value.Operator, left, right.(Float64)), nil
+	case Int32:
+		return compare(value.Operator, left, right.(Int32)), nil
+	case Int64:
+		return compare(value.Operator, left, right.(Int64)), nil
+	case Rune:
+		return compare(value.Operator, left, right.(Rune)), nil
+	case Uint8:
+		return compare(value.Operator, left, right.(Uint8)), nil
+	}
+
+	if value.Operator.IsOrdering() {
+		return nil, errorf(value, "operator %s is not defined for %T", value.Operator.Symbol(), left)
+	}
+
+	equal, err := e.interpreter.equal(left, right)
+	if err != nil {
+		return nil, err
+	}
+
+	return Bool(equal == (value.Operator == code.ComparisonOperatorEqual)), nil
+}
+
+// compare applies the operator to the ordered values. Floats are compared as specified by IEEE 754, so NaN isn't equal
+// to anything.
+func compare[T cmp.Ordered](operator code.ComparisonOperator, left, right T) Bool {
+	switch operator {
+	case code.ComparisonOperatorEqual:
+		return left == right
+	case code.ComparisonOperatorNotEqual:
+		return left != right
+	case code.ComparisonOperatorLessThan:
+		return left < right
+	case code.ComparisonOperatorLessThanOrEqual:
+		return left <= right
+	case code.ComparisonOperatorGreaterThan:
+		return left > right
+	default:
+		return left >= right
+	}
+}
+
+func (e evaluator) MapConversion(value *code.Conversion) (Value, error) {
+	from, err := e.evaluate(value.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	var integer int64
+	switch from := from.(type) {
+	case Float64:
+		if _, ok := value.To.(*code.Float64); ok {
+			return from, nil
+		}
+
+		integer = saturate(float64(from), value.To)
+	case Int32:
+		integer = int64(from)
+	case Int64:
+		integer = int64(from)
+	case Uint8:
+		integer = int64(from)
+	default:
+		return nil, errorf(value, "cannot convert %T", from)
+	}
+
+	// Converting between integers keeps the low bits, which wraps the value into the range of the new type.
+	switch value.To.(type) {
+	case *code.Float64:
+		return Float64(integer), nil
+	case *code.Int32:
+		return Int32(integer), nil
+	case *code.Int64:
+		return Int64(integer), nil
+	case *code.Uint8:
+		return Uint8(integer), nil
+	default:
+		return nil, errorf(value, "cannot convert to %s", code.TypeName(value.To))
+	}
+}
+
+func (e evaluator) MapEmptyList(value *code.EmptyList) (Value, error) {
+	return &List{}, nil
+}
+
+func (e evaluator) MapLength(value *code.Length) (Value, error) {
+	of, err := e.evaluate(value.Of)
+	if err != nil {
+		return nil, err
+	}
+
+	switch of := of.(type) {
+	case *Bytes:
+		return Int64(len(of.Items)), nil
+	case *List:
+		return Int64(len(of.Items)), nil
+	case *Map:
+		return Int64(of.Len()), nil
+	case *Set:
+		return Int64(of.Len()), nil
+	case String:
+		return Int64(utf8.RuneCountInString(string(of))), nil
+	default:
+		return nil, errorf(value, "cannot take the length of %T", of)
+	}
+}
+
+func (e evaluator) MapLiteralBool(value *code.LiteralBool) (Value, error) {
+	return Bool(value.Value), nil
+}
+
+func (e evaluator) MapLiteralBytes(value *code.LiteralBytes) (Value, error) {
+	return &Bytes{Items: append([]uint8(nil), value.Value...)}, nil
+}
+
+func (e evaluator) MapLiteralFloat64(value *code.LiteralFloat64) (Value, error) {
+	return Float64(value.Value), nil
+}
+
+func (e evaluator) MapLiteralInt32(value *code.LiteralInt32) (Value, error) {
+	return Int32(value.Value), nil
+}
+
+func (e evaluator) MapLiteralInt64(value *code.LiteralInt64) (Value, error) {
+	return Int64(value.Value), nil
+}
+
+func (e evaluator) MapLiteralList(value *code.LiteralList) (Value, error) {
+	items, err := e.evaluateEach(value.Values)
+	if err != nil {
+		return nil, err
+	}
+
+	return &List{Items: items}, nil
+}
+
+func (e evaluator) MapLiteralMap(value *code.LiteralMap) (Value, error) {
+	result := e.interpreter.NewMap()
+	for _, keyValue := range value.Values {
+		key, err := e.evaluate(keyValue.Key)
+		if err != nil {
+			return nil, err
+		}
+
+		item, err := e.evaluate(keyValue.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := result.Put(key, item); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+func (e evaluator) MapLiteralRune(value *code.LiteralRune) (Value, error) {
+	return Rune(value.Value), nil
+}
+
+func (e evaluator) MapLiteralSet(value *code.LiteralSet) (Value, error) {
+	items, err := e.evaluateEach(value.Values)
+	if err != nil {
+		return nil, err
+	}
+
+	result := e.interpreter.NewSet()
+	for _, item := range items {
+		if err := result.Add(item); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+func (e evaluator) MapLiteralString(value *code.LiteralString) (Value, error) {
+	return String(value.Value), nil
+}
+
+func (e evaluator) MapLiteralUint8(value *code.LiteralUint8) (Value, error) {
+	return Uint8(value.Value), nil
+}
+
+func (e evaluator) MapLookup(value *code.Lookup) (Value, error) {
+	from, err := e.evaluate(value.From)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := e.evaluate(value.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	switch from := from.(type) {
+	case *Bytes:
+		index, err := checkIndex(value, key, len(from.Items))
+		if err != nil {
+			return nil, err
+		}
+
+		return Uint8(from.Items[index]), nil
+	case *List:
+		index, err := checkIndex(value, key, len(from.Items))
+		if err != nil {
+			return nil, err
+		}
+
+		return from.Items[index], nil
+	case *Map:
+		result, ok, err := from.Get(key)
+		if err != nil {
+			return nil, err
+		}
+
+		if !ok {
+			return nil, errorf(value, "map has no key %s", Format(key))
+		}
+
+		return result, nil
+	case String:
+		return runeAt(value, from, key)
+	default:
+		return nil, errorf(value, "cannot look up a value in %T", from)
+	}
+}
+
+func (e evaluator) MapMethodCall(value *code.MethodCall) (Value, error) {
+	receiver, err := e.evaluate(value.Receiver)
+	if err != nil {
+		return nil, err
+	}
+
+	arguments, err := e.evaluateEach(value.Arguments)
+	if err != nil {
+		return nil, err
+	}
+
+	instance := receiver.(*Instance)
+	if instance == nil {
+		return nil, errorf(value, "cannot call method %q on nil", value.Name)
+	}
+
+	return e.interpreter.call(value.Method, instance, arguments)
+}
+
+func (e evaluator) MapNew(value *code.New) (Value, error) {
+	model, err := e.interpreter.lookupModel(value.Model)
+	if err != nil {
+		return nil, errorf(value, "%s", err)
+	}
+
+	return e.interpreter.instantiate(model), nil
+}
+
+func (e evaluator) MapNil(value *code.Nil) (Value, error) {
+	return e.interpreter.zero(value.Type), nil
+}
+
+func (e evaluator) MapPop(value *code.Pop) (Value, error) {
+	result, err := e.evaluate(value.List)
+	if err != nil {
+		return nil, err
+	}
+
+	list := result.(*List)
+	if len(list.Items) == 0 {
+		return nil, errorf(value, "cannot pop from an empty list")
+	}
+
+	item := list.Items[len(list.Items)-1]
+	list.Items = list.Items[:len(list.Items)-1]
+	return item, nil
+}
+
+func (e evaluator) MapProperty(value *code.Property) (Value, error) {
+	of, err := e.evaluate(value.Of)
+	if err != nil {
+		return nil, err
+	}
+
+	instance := of.(*Instance)
+	if instance == nil {
+		return nil, errorf(value, "cannot access property %q of nil", value.Name)
+	}
+
+	return instance.Fields[value.Name], nil
+}
+
+func (e evaluator) MapSelf(value *code.Self) (Value, error) {
+	return e.self, nil
+}
+
+func (e evaluator) MapSetContains(value *code.SetContains) (Value, error) {
+	set, err := e.evaluate(value.Set)
+	if err != nil {
+		return nil, err
+	}
+
+	item, err := e.evaluate(value.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	contains, err := set.(*Set).Contains(item)
+	return Bool(contains), err
+}
+
+func (e evaluator) MapUnary(value *code.Unary) (Value, error) {
+	operand, err := e.evaluate(value.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	switch operand := operand.(type) {
+	case Bool:
+		return !operand, nil
+	case Float64:
+		return -operand, nil
+	case Int32:
+		return -operand, nil
+	case Int64:
+		return -operand, nil
+	default:
+		return nil, errorf(value, "operator %s is not defined for %T", value.Operator.Symbol(), operand)
+	}
+}
+
+func (e evaluator) MapVariable(value *code.Variable) (Value, error) {
+	if constant, ok := value.Definition.(*code.ConstantDef); ok {
+		// Constants are evaluated each time that they're used so that their collections are never shared.
+		return newFrame(e.interpreter, nil).evaluate(constant.Value.(code.Value))
+	}
+
+	result, ok := e.variables[value.Definition]
+	if !ok {
+		return nil, errorf(value, "variable %q is used before it's declared", value.Name)
+	}
+
+	return result, nil
+}
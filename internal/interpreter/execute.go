@@ -0,0 +1,334 @@
+package interpreter
+
+import (
+	"unicode/utf8"
+
+	"github.com/JosephNaberhaus/agnostic/code"
+)
+
+// frame is the state of a single call.
+type frame struct {
+	interpreter *Interpreter
+	// The instance that the method is called on, or nil outside of a method.
+	self *Instance
+	// The values of the variables, by the node that declares them.
+	variables map[code.Definition]Value
+	// The value that is returned once the call returns.
+	result Value
+}
+
+func newFrame(interpreter *Interpreter, self *Instance) *frame {
+	return &frame{
+		interpreter: interpreter,
+		self:        self,
+		variables:   map[code.Definition]Value{},
+	}
+}
+
+// outcome is how a statement finished, which decides what runs after it.
+type outcome int
+
+const (
+	// outcomeNext means that the next statement runs.
+	outcomeNext outcome = iota
+	// outcomeBreak means that the innermost loop stops.
+	outcomeBreak
+	// outcomeContinue means that the innermost loop skips to its next iteration.
+	outcomeContinue
+	// outcomeReturn means that the call returns the result of the frame.
+	outcomeReturn
+)
+
+// execute runs the statements of the block until one of them doesn't finish with outcomeNext.
+func (f *frame) execute(block *code.Block) (outcome, error) {
+	for _, statement := range block.Statements {
+		result, err := code.MapStatement[outcome](statement, executor{f})
+		if err != nil || result != outcomeNext {
+			return result, err
+		}
+	}
+
+	return outcomeNext, nil
+}
+
+// loop runs the block as the body of a loop. It returns whether the loop should stop, and the outcome of the loop.
+func (f *frame) loop(block *code.Block) (bool, outcome, error) {
+	result, err := f.execute(block)
+	switch {
+	case err != nil:
+		return true, outcomeNext, err
+	case result == outcomeBreak:
+		return true, outcomeNext, nil
+	case result == outcomeReturn:
+		return true, outcomeReturn, nil
+	default:
+		return false, outcomeNext, nil
+	}
+}
+
+// executor runs statements.
+type executor struct {
+	*frame
+}
+
+var _ code.StatementMapper[outcome] = executor{}
+
+func (e executor) MapAddToSet(value *code.AddToSet) (outcome, error) {
+	set, err := e.evaluate(value.Set)
+	if err != nil {
+		return outcomeNext, err
+	}
+
+	item, err := e.evaluate(value.Value)
+	if err != nil {
+		return outcomeNext, err
+	}
+
+	return outcomeNext, set.(*Set).Add(item)
+}
+
+func (e executor) MapAssignment(value *code.Assignment) (outcome, error) {
+	switch to := value.To.(type) {
+	case *code.Variable:
+		from, err := e.evaluate(value.From)
+		if err != nil {
+			return outcomeNext, err
+		}
+
+		if _, ok := to.Definition.(*code.ConstantDef); ok {
+			return outcomeNext, errorf(value, "cannot assign to constant %q", to.Name)
+		}
+
+		e.variables[to.Definition] = from
+		return outcomeNext, nil
+	case *code.Property:
+		of, err := e.evaluate(to.Of)
+		if err != nil {
+			return outcomeNext, err
+		}
+
+		from, err := e.evaluate(value.From)
+		if err != nil {
+			return outcomeNext, err
+		}
+
+		instance := of.(*Instance)
+		if instance == nil {
+			return outcomeNext, errorf(to, "cannot assign to property %q of nil", to.Name)
+		}
+
+		instance.Fields[to.Name] = from
+		return outcomeNext, nil
+	case *code.Lookup:
+		return outcomeNext, e.assignLookup(to, value.From)
+	default:
+		return outcomeNext, errorf(value, "cannot assign to %T", value.To)
+	}
+}
+
+func (e executor) assignLookup(to *code.Lookup, value code.Value) error {
+	from, err := e.evaluate(to.From)
+	if err != nil {
+		return err
+	}
+
+	key, err := e.evaluate(to.Key)
+	if err != nil {
+		return err
+	}
+
+	item, err := e.evaluate(value)
+	if err != nil {
+		return err
+	}
+
+	switch from := from.(type) {
+	case *Bytes:
+		index, err := checkIndex(to, key, len(from.Items))
+		if err != nil {
+			return err
+		}
+
+		from.Items[index] = uint8(item.(Uint8))
+		return nil
+	case *List:
+		index, err := checkIndex(to, key, len(from.Items))
+		if err != nil {
+			return err
+		}
+
+		from.Items[index] = item
+		return nil
+	case *Map:
+		return from.Put(key, item)
+	case String:
+		return errorf(to, "cannot assign to a rune of a string")
+	default:
+		return errorf(to, "cannot assign to a lookup in %T", from)
+	}
+}
+
+func (e executor) MapBreak(value *code.Break) (outcome, error) {
+	return outcomeBreak, nil
+}
+
+func (e executor) MapCall(value *code.Call) (outcome, error) {
+	_, err := e.evaluate(value)
+	return outcomeNext, err
+}
+
+func (e executor) MapConditional(value *code.Conditional) (outcome, error) {
+	for _, branch := range value.Ifs {
+		condition, err := e.evaluate(branch.Condition)
+		if err != nil {
+			return outcomeNext, err
+		}
+
+		if condition.(Bool) {
+			return e.execute(branch.Block)
+		}
+	}
+
+	if value.Else != nil {
+		return e.execute(value.Else)
+	}
+
+	return outcomeNext, nil
+}
+
+func (e executor) MapContinue(value *code.Continue) (outcome, error) {
+	return outcomeContinue, nil
+}
+
+func (e executor) MapDeclare(value *code.Declare) (outcome, error) {
+	result, err := e.evaluate(value.Value)
+	if err != nil {
+		return outcomeNext, err
+	}
+
+	e.variables[value] = result
+	return outcomeNext, nil
+}
+
+func (e executor) MapFor(value *code.For) (outcome, error) {
+	if value.Initialization != nil {
+		if _, err := code.MapStatement[outcome](value.Initialization, e); err != nil {
+			return outcomeNext, err
+		}
+	}
+
+	for {
+		condition, err := e.evaluate(value.Condition)
+		if err != nil || !condition.(Bool) {
+			return outcomeNext, err
+		}
+
+		stop, result, err := e.loop(value.Block)
+		if stop {
+			return result, err
+		}
+
+		if value.AfterEach != nil {
+			if _, err := code.MapStatement[outcome](value.AfterEach, e); err != nil {
+				return outcomeNext, err
+			}
+		}
+	}
+}
+
+func (e executor) MapForEach(value *code.ForEach) (outcome, error) {
+	iterable, err := e.evaluate(value.Iterable)
+	if err != nil {
+		return outcomeNext, err
+	}
+
+	var items []Value
+	switch iterable := iterable.(type) {
+	case *Bytes:
+		for _, item := range iterable.Items {
+			items = append(items, Uint8(item))
+		}
+	case *List:
+		items = append(items, iterable.Items...)
+	case *Map:
+		items = iterable.Keys()
+	case *Set:
+		items = iterable.Items()
+	case String:
+		for _, item := range iterable {
+			items = append(items, Rune(item))
+		}
+	default:
+		return outcomeNext, errorf(value, "cannot iterate over %T", iterable)
+	}
+
+	for _, item := range items {
+		e.variables[value] = item
+
+		stop, result, err := e.loop(value.Block)
+		if stop {
+			return result, err
+		}
+	}
+
+	return outcomeNext, nil
+}
+
+func (e executor) MapMethodCall(value *code.MethodCall) (outcome, error) {
+	_, err := e.evaluate(value)
+	return outcomeNext, err
+}
+
+func (e executor) MapPop(value *code.Pop) (outcome, error) {
+	_, err := e.evaluate(value)
+	return outcomeNext, err
+}
+
+func (e executor) MapPush(value *code.Push) (outcome, error) {
+	list, err := e.evaluate(value.List)
+	if err != nil {
+		return outcomeNext, err
+	}
+
+	item, err := e.evaluate(value.Value)
+	if err != nil {
+		return outcomeNext, err
+	}
+
+	list.(*List).Items = append(list.(*List).Items, item)
+	return outcomeNext, nil
+}
+
+func (e executor) MapReturn(value *code.Return) (outcome, error) {
+	if value.Value == nil {
+		return outcomeReturn, nil
+	}
+
+	result, err := e.evaluate(value.Value)
+	if err != nil {
+		return outcomeNext, err
+	}
+
+	e.result = result
+	return outcomeReturn, nil
+}
+
+// checkIndex returns the key as an index into a sequence of the given length, or an error if it's out of range.
+func checkIndex(lookup *code.Lookup, key Value, length int) (int, error) {
+	index := int64(key.(Int64))
+	if index < 0 || index >= int64(length) {
+		return 0, errorf(lookup, "index %d out of range for length %d", index, length)
+	}
+
+	return int(index), nil
+}
+
+// runeAt returns the rune at the index of the string, where the index counts runes rather than bytes.
+func runeAt(lookup *code.Lookup, text String, key Value) (Value, error) {
+	index, err := checkIndex(lookup, key, utf8.RuneCountInString(string(text)))
+	if err != nil {
+		return nil, err
+	}
+
+	return Rune([]rune(text)[index]), nil
+}
@@ -0,0 +1,101 @@
+package interpreter
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Format returns a human-readable form of the value, which is written like AgnosticScript where possible. Fields are
+// written in the order that the model declares them, and an instance that contains itself is written as "...".
+func Format(value Value) string {
+	var builder strings.Builder
+	format(&builder, value, map[*Instance]bool{})
+	return builder.String()
+}
+
+func format(builder *strings.Builder, value Value, visiting map[*Instance]bool) {
+	switch value := value.(type) {
+	case nil:
+		builder.WriteString("void")
+	case Bool:
+		builder.WriteString(strconv.FormatBool(bool(value)))
+	case Float64:
+		builder.WriteString(strconv.FormatFloat(float64(value), 'g', -1, 64))
+	case Int32:
+		builder.WriteString(strconv.FormatInt(int64(value), 10))
+	case Int64:
+		builder.WriteString(strconv.FormatInt(int64(value), 10))
+	case Rune:
+		builder.WriteString(strconv.QuoteRune(rune(value)))
+	case String:
+		builder.WriteString(strconv.Quote(string(value)))
+	case Uint8:
+		builder.WriteString(strconv.FormatUint(uint64(value), 10))
+	case *Bytes:
+		builder.WriteString("bytes{")
+		for index, item := range value.Items {
+			if index > 0 {
+				builder.WriteString(", ")
+			}
+
+			builder.WriteString(strconv.FormatUint(uint64(item), 10))
+		}
+		builder.WriteString("}")
+	case *List:
+		builder.WriteString("[")
+		formatEach(builder, value.Items, visiting)
+		builder.WriteString("]")
+	case *Map:
+		builder.WriteString("map{")
+		for index, key := range value.keys {
+			if index > 0 {
+				builder.WriteString(", ")
+			}
+
+			format(builder, key, visiting)
+			builder.WriteString(": ")
+			format(builder, value.values[index], visiting)
+		}
+		builder.WriteString("}")
+	case *Set:
+		builder.WriteString("set{")
+		formatEach(builder, value.keys, visiting)
+		builder.WriteString("}")
+	case *Instance:
+		if value == nil {
+			builder.WriteString("nil")
+			return
+		}
+
+		if visiting[value] {
+			builder.WriteString("...")
+			return
+		}
+
+		visiting[value] = true
+		defer delete(visiting, value)
+
+		builder.WriteString(value.Model.Name)
+		builder.WriteString("{")
+		for index, field := range value.Model.Fields {
+			if index > 0 {
+				builder.WriteString(", ")
+			}
+
+			builder.WriteString(field.Name)
+			builder.WriteString(": ")
+			format(builder, value.Fields[field.Name], visiting)
+		}
+		builder.WriteString("}")
+	}
+}
+
+func formatEach(builder *strings.Builder, values []Value, visiting map[*Instance]bool) {
+	for index, value := range values {
+		if index > 0 {
+			builder.WriteString(", ")
+		}
+
+		format(builder, value, visiting)
+	}
+}
@@ -0,0 +1,191 @@
+package interpreter
+
+import (
+	"fmt"
+
+	"github.com/JosephNaberhaus/agnostic/code"
+)
+
+// MaxDepth is how deeply functions can call each other before the program is stopped. It keeps a program that recurses
+// forever from overflowing the stack of the interpreter.
+const MaxDepth = 10_000
+
+// Interpreter executes the functions of a code.Root. The root must have been mapped from an AST without errors, since
+// the interpreter relies on its metadata. An Interpreter isn't safe for concurrent use.
+type Interpreter struct {
+	root *code.Root
+	// The number of calls that haven't returned yet.
+	depth int
+}
+
+// New returns an interpreter of the root.
+func New(root *code.Root) *Interpreter {
+	return &Interpreter{root: root}
+}
+
+// Error is a runtime error, such as popping from an empty list.
+type Error struct {
+	// Where the error happened. This is the zero Position if the AST was built by hand.
+	Position code.Position
+	Message  string
+}
+
+func (e *Error) Error() string {
+	if e.Position.IsValid() {
+		return fmt.Sprintf("%s: %s", e.Position, e.Message)
+	}
+
+	return e.Message
+}
+
+// errorf returns an Error at the position of the node.
+func errorf(node code.Node, format string, args ...any) error {
+	return &Error{
+		Position: code.PositionOf(node),
+		Message:  fmt.Sprintf(format, args...),
+	}
+}
+
+// Call calls the function of the module with the arguments, and returns its result. The result of a function without a
+// return type is nil.
+func (i *Interpreter) Call(module, function string, arguments ...Value) (Value, error) {
+	definition, err := i.lookupModule(module)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, candidate := range definition.Functions {
+		if candidate.Name != function {
+			continue
+		}
+
+		if len(arguments) != len(candidate.Arguments) {
+			return nil, fmt.Errorf("function %q takes %d arguments but got %d", function, len(candidate.Arguments), len(arguments))
+		}
+
+		for index, argument := range candidate.Arguments {
+			if !conforms(arguments[index], argument.Type) {
+				return nil, fmt.Errorf("argument %q of function %q must be a %s", argument.Name, function, code.TypeName(argument.Type))
+			}
+		}
+
+		return i.call(candidate, nil, arguments)
+	}
+
+	return nil, fmt.Errorf("module %q has no function %q", module, function)
+}
+
+// NewInstance returns an instance of the model of the module whose fields are set to their zero values.
+func (i *Interpreter) NewInstance(module, model string) (*Instance, error) {
+	definition, err := i.lookupModel(&code.Model{Module: module, Name: model})
+	if err != nil {
+		return nil, err
+	}
+
+	return i.instantiate(definition), nil
+}
+
+// NewMap returns an empty map.
+func (i *Interpreter) NewMap() *Map {
+	return &Map{table: table{interpreter: i}}
+}
+
+// NewSet returns an empty set.
+func (i *Interpreter) NewSet() *Set {
+	return &Set{table: table{interpreter: i}}
+}
+
+func (i *Interpreter) lookupModule(name string) (*code.Module, error) {
+	for _, module := range i.root.Modules {
+		if module.Name == name {
+			return module, nil
+		}
+	}
+
+	return nil, fmt.Errorf("undefined module %q", name)
+}
+
+func (i *Interpreter) lookupModel(model *code.Model) (*code.ModelDef, error) {
+	module, err := i.lookupModule(model.Module)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, definition := range module.Models {
+		if definition.Name == model.Name {
+			return definition, nil
+		}
+	}
+
+	return nil, fmt.Errorf("module %q has no model %q", model.Module, model.Name)
+}
+
+func (i *Interpreter) instantiate(model *code.ModelDef) *Instance {
+	instance := &Instance{
+		Model:  model,
+		Fields: make(map[string]Value, len(model.Fields)),
+	}
+
+	for _, field := range model.Fields {
+		instance.Fields[field.Name] = i.zero(field.Type)
+	}
+
+	return instance
+}
+
+// call calls the function, which is a method of self unless self is nil.
+func (i *Interpreter) call(function *code.FunctionDef, self *Instance, arguments []Value) (Value, error) {
+	if i.depth >= MaxDepth {
+		return nil, errorf(function, "calls are nested more than %d deep", MaxDepth)
+	}
+
+	i.depth++
+	defer func() { i.depth-- }()
+
+	f := newFrame(i, self)
+	for index, argument := range function.Arguments {
+		f.variables[argument] = arguments[index]
+	}
+
+	result, err := f.execute(function.Block)
+	if err != nil {
+		return nil, err
+	}
+
+	if result == outcomeReturn {
+		return f.result, nil
+	}
+
+	if _, ok := function.ReturnType.(*code.Void); ok || function.ReturnType == nil {
+		return nil, nil
+	}
+
+	return nil, errorf(function, "function %q ended without returning a value", function.Name)
+}
+
+// callOverride runs the block of an override of the model of self. The equal override also declares the instance that
+// self is compared with.
+func (i *Interpreter) callOverride(self *Instance, block *code.Block, other *code.EqualOverride, otherValue Value) (Value, error) {
+	if i.depth >= MaxDepth {
+		return nil, errorf(block, "calls are nested more than %d deep", MaxDepth)
+	}
+
+	i.depth++
+	defer func() { i.depth-- }()
+
+	f := newFrame(i, self)
+	if other != nil {
+		f.variables[other] = otherValue
+	}
+
+	result, err := f.execute(block)
+	if err != nil {
+		return nil, err
+	}
+
+	if result != outcomeReturn {
+		return nil, errorf(block, "override of model %q ended without returning a value", self.Model.Name)
+	}
+
+	return f.result, nil
+}
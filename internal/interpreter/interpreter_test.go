@@ -0,0 +1,455 @@
+package interpreter
+
+import (
+	"math"
+	"testing"
+
+	"github.com/JosephNaberhaus/agnostic/code"
+	"github.com/JosephNaberhaus/agnostic/internal/agnosticscript"
+	"github.com/JosephNaberhaus/agnostic/internal/mappers/ast_to_code_mapper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// interpret parses and maps the source and returns an interpreter of it.
+func interpret(t *testing.T, source string) *Interpreter {
+	t.Helper()
+
+	root, err := agnosticscript.Parse("test.as", source)
+	require.NoError(t, err)
+
+	mapper := &ast_to_code_mapper.Mapper{}
+	result, err := mapper.MapRoot(root)
+	require.NoError(t, err)
+
+	return New(result.(*code.Root))
+}
+
+func TestCall(t *testing.T) {
+	interpreter := interpret(t, `
+module test {
+	import shapes
+
+	const limit = 3
+	const primes = [2, 3, 5]
+
+	func sum(items list[int64]) int64 {
+		var total = 0
+		for item in items {
+			total = total + item
+		}
+		return total
+	}
+
+	func fibonacci(n int64) int64 {
+		if n < 2 {
+			return n
+		}
+		return fibonacci(n - 1) + fibonacci(n - 2)
+	}
+
+	func loops() list[int64] {
+		var result = list[int64]{}
+		for var i = 0; i < 10; i = i + 1 {
+			if i % 2 == 0 {
+				continue
+			}
+			if i > 7 {
+				break
+			}
+			push(result, i)
+		}
+		return result
+	}
+
+	func aliasing() int64 {
+		var a = [1]
+		var b = a
+		push(b, 2)
+		return len(a)
+	}
+
+	func constants() int64 {
+		push(primes, 7)
+		return len(primes) + limit
+	}
+
+	func shortCircuit() bool {
+		return false && pop(list[bool]{})
+	}
+
+	func text(s string) list[rune] {
+		var result = list[rune]{}
+		for r in s {
+			push(result, r)
+		}
+		push(result, s[1])
+		return result
+	}
+
+	func keys() list[string] {
+		var m = map{"b": 1, "a": 2}
+		m["c"] = 3
+		m["b"] = 4
+		var result = list[string]{}
+		for key in m {
+			push(result, key)
+		}
+		return result
+	}
+
+	func area(width int64, height int64) int64 {
+		var rectangle = new(shapes.Rectangle)
+		rectangle.width = width
+		rectangle.height = height
+		return rectangle.area()
+	}
+
+	func grow(rectangle shapes.Rectangle) {
+		rectangle.grow(1)
+	}
+}
+
+module shapes {
+	model Rectangle {
+		width int64
+		height int64
+
+		func area() int64 {
+			return self.width * self.height
+		}
+
+		func grow(amount int64) {
+			self.width = self.width + amount
+			self.height = self.height + amount
+		}
+	}
+}
+`)
+
+	tests := []struct {
+		function  string
+		arguments []Value
+		expected  string
+	}{
+		{function: "sum", arguments: []Value{&List{Items: []Value{Int64(1), Int64(2), Int64(3)}}}, expected: "6"},
+		{function: "fibonacci", arguments: []Value{Int64(10)}, expected: "55"},
+		{function: "loops", expected: "[1, 3, 5, 7]"},
+		{function: "aliasing", expected: "2"},
+		{function: "constants", expected: "6"},
+		{function: "shortCircuit", expected: "false"},
+		{function: "text", arguments: []Value{String("héllo")}, expected: "['h', 'é', 'l', 'l', 'o', 'é']"},
+		{function: "keys", expected: `["b", "a", "c"]`},
+		{function: "area", arguments: []Value{Int64(3), Int64(4)}, expected: "12"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.function, func(t *testing.T) {
+			result, err := interpreter.Call("test", test.function, test.arguments...)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, Format(result))
+		})
+	}
+
+	t.Run("self", func(t *testing.T) {
+		rectangle, err := interpreter.NewInstance("shapes", "Rectangle")
+		require.NoError(t, err)
+
+		result, err := interpreter.Call("test", "grow", rectangle)
+		require.NoError(t, err)
+		assert.Nil(t, result)
+		assert.Equal(t, "Rectangle{width: 1, height: 1}", Format(rectangle))
+	})
+}
+
+func TestCall_Numbers(t *testing.T) {
+	interpreter := interpret(t, `
+module test {
+	func wrap() list[int64] {
+		var a = int32(2147483647) + int32(1)
+		var b = uint8(0) - uint8(1)
+		var c = -9223372036854775808 / -1
+		var d = -7 % 3
+		return [int64(a), int64(b), c, d, -7 / 2]
+	}
+
+	func saturate() list[int64] {
+		var nan = 0.0 / 0.0
+		var big = 1e300
+		return [int64(int32(big)), int64(uint8(-1.5)), int64(nan), int64(-big), int64(2.9)]
+	}
+
+	func convert() list[int64] {
+		var a = 300
+		var b = -1
+		var c = -4294967295
+		return [int64(uint8(a)), int64(int32(c)), int64(uint8(b))]
+	}
+
+	func floats() list[float64] {
+		return [1.0 / 0.0, float64(9007199254740993), 0.1 + 0.2]
+	}
+
+	func nan() bool {
+		var nan = 0.0 / 0.0
+		return nan == nan
+	}
+}
+`)
+
+	tests := []struct {
+		function string
+		expected string
+	}{
+		{function: "wrap", expected: "[-2147483648, 255, -9223372036854775808, -1, -3]"},
+		{function: "saturate", expected: "[2147483647, 0, 0, -9223372036854775808, 2]"},
+		{function: "convert", expected: "[44, 1, 255]"},
+		{function: "floats", expected: "[+Inf, 9.007199254740992e+15, 0.30000000000000004]"},
+		{function: "nan", expected: "false"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.function, func(t *testing.T) {
+			result, err := interpreter.Call("test", test.function)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, Format(result))
+		})
+	}
+}
+
+func TestCall_Hashing(t *testing.T) {
+	interpreter := interpret(t, `
+module test {
+	model Hashed {
+		x int64
+		y int64
+
+		equals(other) {
+			return self.x == other.x
+		}
+
+		hash {
+			return self.x
+		}
+	}
+
+	model Equal {
+		x int64
+
+		equals(other) {
+			return self.x == other.x
+		}
+	}
+
+	model Plain {
+		x int64
+	}
+
+	func hashed() int64 {
+		var a = new(Hashed)
+		a.y = 1
+		var b = new(Hashed)
+		b.y = 2
+		var items = set{a, b}
+		var other = new(Hashed)
+		other.x = 1
+		insert(items, other)
+		return len(items)
+	}
+
+	func equal() bool {
+		var a = new(Equal)
+		var b = new(Equal)
+		var counts = map{a: 1}
+		counts[b] = 2
+		return len(counts) == 1 && counts[a] == 2 && a == b
+	}
+
+	func plain() bool {
+		var a = new(Plain)
+		var b = new(Plain)
+		var items = set{a}
+		return contains(items, a) && !contains(items, b)
+	}
+
+	func nilEqual() bool {
+		var a = new(Equal)
+		return a != nil(Equal) && nil(Equal) == nil(Equal)
+	}
+}
+`)
+
+	tests := []struct {
+		function string
+		expected string
+	}{
+		{function: "hashed", expected: "2"},
+		{function: "equal", expected: "true"},
+		{function: "plain", expected: "true"},
+		{function: "nilEqual", expected: "true"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.function, func(t *testing.T) {
+			result, err := interpreter.Call("test", test.function)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, Format(result))
+		})
+	}
+
+	t.Run("map argument", func(t *testing.T) {
+		a, err := interpreter.NewInstance("test", "Hashed")
+		require.NoError(t, err)
+		b, err := interpreter.NewInstance("test", "Hashed")
+		require.NoError(t, err)
+		b.Fields["y"] = Int64(1)
+
+		m := interpreter.NewMap()
+		require.NoError(t, m.Put(a, String("a")))
+		require.NoError(t, m.Put(b, String("b")))
+		assert.Equal(t, `map{Hashed{x: 0, y: 0}: "b"}`, Format(m))
+	})
+}
+
+func TestCall_RuntimeErrors(t *testing.T) {
+	interpreter := interpret(t, `
+module test {
+	model Node {
+		next Node
+
+		func get() Node {
+			return self.next
+		}
+	}
+
+	func popEmpty() int64 {
+		var items = [1]
+		pop(items)
+		return pop(items)
+	}
+
+	func missingKey() int64 {
+		return map{"a": 1}["b"]
+	}
+
+	func outOfRange(index int64) int64 {
+		return [1, 2][index]
+	}
+
+	func runeOutOfRange() rune {
+		return "é"[1]
+	}
+
+	func nilProperty() Node {
+		return nil(Node).next
+	}
+
+	func nilMethod() Node {
+		return new(Node).next.get()
+	}
+
+	func assignNil() {
+		var node = nil(Node)
+		node.next = new(Node)
+	}
+
+	func assignRune() {
+		var text = "abc"
+		text[0] = 'b'
+	}
+
+	func divide(a int64, b int64) int64 {
+		return a / b
+	}
+
+	func modulo(a uint8, b uint8) uint8 {
+		return a % b
+	}
+
+	func forever(n int64) int64 {
+		return forever(n + 1)
+	}
+}
+`)
+
+	tests := []struct {
+		function  string
+		arguments []Value
+		expected  string
+	}{
+		{function: "popEmpty", expected: "test.as:14:10: cannot pop from an empty list"},
+		{function: "missingKey", expected: `test.as:18:10: map has no key "b"`},
+		{function: "outOfRange", arguments: []Value{Int64(2)}, expected: "test.as:22:10: index 2 out of range for length 2"},
+		{function: "outOfRange", arguments: []Value{Int64(-1)}, expected: "test.as:22:10: index -1 out of range for length 2"},
+		{function: "runeOutOfRange", expected: "test.as:26:10: index 1 out of range for length 1"},
+		{function: "nilProperty", expected: `test.as:30:10: cannot access property "next" of nil`},
+		{function: "nilMethod", expected: `test.as:34:10: cannot call method "get" on nil`},
+		{function: "assignNil", expected: `test.as:39:3: cannot assign to property "next" of nil`},
+		{function: "assignRune", expected: "test.as:44:3: cannot assign to a rune of a string"},
+		{function: "divide", arguments: []Value{Int64(1), Int64(0)}, expected: "test.as:48:10: integer division by zero"},
+		{function: "modulo", arguments: []Value{Uint8(1), Uint8(0)}, expected: "test.as:52:10: integer modulo by zero"},
+		{function: "forever", arguments: []Value{Int64(0)}, expected: "test.as:55:2: calls are nested more than 10000 deep"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.function, func(t *testing.T) {
+			_, err := interpreter.Call("test", test.function, test.arguments...)
+			require.Error(t, err)
+			assert.IsType(t, &Error{}, err)
+			assert.Equal(t, test.expected, err.Error())
+		})
+	}
+}
+
+func TestCall_Errors(t *testing.T) {
+	interpreter := interpret(t, `
+module test {
+	func square(x float64) float64 {
+		return x * x
+	}
+}
+`)
+
+	result, err := interpreter.Call("test", "square", Float64(1.5))
+	require.NoError(t, err)
+	assert.Equal(t, Float64(2.25), result)
+
+	tests := []struct {
+		name      string
+		module    string
+		function  string
+		arguments []Value
+		expected  string
+	}{
+		{name: "undefined module", module: "other", function: "square", expected: `undefined module "other"`},
+		{name: "undefined function", module: "test", function: "cube", expected: `module "test" has no function "cube"`},
+		{name: "argument count", module: "test", function: "square", expected: `function "square" takes 1 arguments but got 0`},
+		{name: "argument type", module: "test", function: "square", arguments: []Value{Int64(1)}, expected: `argument "x" of function "square" must be a float64`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := interpreter.Call(test.module, test.function, test.arguments...)
+			assert.EqualError(t, err, test.expected)
+		})
+	}
+}
+
+func TestFormat(t *testing.T) {
+	interpreter := New(&code.Root{})
+	m := interpreter.NewMap()
+	require.NoError(t, m.Put(String("a"), &Bytes{Items: []uint8{1, 2}}))
+	set := interpreter.NewSet()
+	require.NoError(t, set.Add(Rune('x')))
+
+	model := &code.ModelDef{Name: "Node", Fields: []*code.FieldDef{{Name: "next"}}}
+	node := &Instance{Model: model, Fields: map[string]Value{}}
+	node.Fields["next"] = node
+
+	assert.Equal(t, `map{"a": bytes{1, 2}}`, Format(m))
+	assert.Equal(t, "set{'x'}", Format(set))
+	assert.Equal(t, "Node{next: ...}", Format(node))
+	assert.Equal(t, "nil", Format((*Instance)(nil)))
+	assert.Equal(t, "-Inf", Format(Float64(math.Inf(-1))))
+	assert.Equal(t, "void", Format(nil))
+}
@@ -0,0 +1,348 @@
+package interpreter
+
+import (
+	"math"
+
+	"github.com/JosephNaberhaus/agnostic/code"
+)
+
+// Value is a value at runtime. Each type of the code package has its own type of Value, and a nil model is a nil
+// *Instance.
+type Value interface {
+	isValue()
+}
+
+type Bool bool
+
+type Float64 float64
+
+type Int32 int32
+
+type Int64 int64
+
+type Rune rune
+
+type String string
+
+type Uint8 uint8
+
+// Bytes is a mutable sequence of bytes.
+type Bytes struct {
+	Items []uint8
+}
+
+// List is a mutable sequence of values.
+type List struct {
+	Items []Value
+}
+
+// Map is a hash map whose keys are kept in the order that they were first inserted. It must be created by
+// Interpreter.NewMap so that it can call the overrides of the models that it contains.
+type Map struct {
+	table
+	values []Value
+}
+
+// Set is a hash set whose items are kept in the order that they were first inserted. It must be created by
+// Interpreter.NewSet so that it can call the overrides of the models that it contains.
+type Set struct {
+	table
+}
+
+// Instance is an instance of a model.
+type Instance struct {
+	Model *code.ModelDef
+	// The values of the fields of the instance, by their name.
+	Fields map[string]Value
+}
+
+func (Bool) isValue()      {}
+func (Float64) isValue()   {}
+func (Int32) isValue()     {}
+func (Int64) isValue()     {}
+func (Rune) isValue()      {}
+func (String) isValue()    {}
+func (Uint8) isValue()     {}
+func (*Bytes) isValue()    {}
+func (*List) isValue()     {}
+func (*Map) isValue()      {}
+func (*Set) isValue()      {}
+func (*Instance) isValue() {}
+
+// Get returns the value of the key and whether the map has it.
+func (m *Map) Get(key Value) (Value, bool, error) {
+	index, _, err := m.find(key)
+	if err != nil || index < 0 {
+		return nil, false, err
+	}
+
+	return m.values[index], true, nil
+}
+
+// Put sets the value of the key, which replaces its previous value if the map already has the key.
+func (m *Map) Put(key, value Value) error {
+	index, inserted, err := m.insert(key)
+	if err != nil {
+		return err
+	}
+
+	if inserted {
+		m.values = append(m.values, value)
+	} else {
+		m.values[index] = value
+	}
+
+	return nil
+}
+
+// Keys returns the keys of the map in the order that they were first inserted.
+func (m *Map) Keys() []Value {
+	return append([]Value(nil), m.keys...)
+}
+
+// Add adds the item to the set if it doesn't already have an equal item.
+func (s *Set) Add(item Value) error {
+	_, _, err := s.insert(item)
+	return err
+}
+
+// Contains returns whether the set has an item that is equal to the given one.
+func (s *Set) Contains(item Value) (bool, error) {
+	index, _, err := s.find(item)
+	return index >= 0, err
+}
+
+// Items returns the items of the set in the order that they were first inserted.
+func (s *Set) Items() []Value {
+	return append([]Value(nil), s.keys...)
+}
+
+// table is a hash table of keys that is shared by maps and sets. Keys are grouped into buckets by their hash, and then
+// compared with the keys of the same bucket for equality.
+type table struct {
+	interpreter *Interpreter
+	// The keys in the order that they were first inserted.
+	keys []Value
+	// The indexes of the keys of each bucket.
+	buckets map[any][]int
+}
+
+// Len returns the number of keys.
+func (t *table) Len() int {
+	return len(t.keys)
+}
+
+// find returns the index of the key that is equal to the given one, or -1 if there is no such key. It also returns the
+// bucket of the key.
+func (t *table) find(key Value) (int, any, error) {
+	bucket, err := t.interpreter.hash(key)
+	if err != nil {
+		return -1, nil, err
+	}
+
+	for _, index := range t.buckets[bucket] {
+		equal, err := t.interpreter.equal(t.keys[index], key)
+		if err != nil {
+			return -1, nil, err
+		}
+
+		if equal {
+			return index, bucket, nil
+		}
+	}
+
+	return -1, bucket, nil
+}
+
+// insert adds the key if there is no key that is equal to it. It returns the index of the key, and whether it was
+// added.
+func (t *table) insert(key Value) (int, bool, error) {
+	index, bucket, err := t.find(key)
+	if err != nil || index >= 0 {
+		return index, false, err
+	}
+
+	if t.buckets == nil {
+		t.buckets = map[any][]int{}
+	}
+
+	index = len(t.keys)
+	t.keys = append(t.keys, key)
+	t.buckets[bucket] = append(t.buckets[bucket], index)
+	return index, true, nil
+}
+
+// sharedBucket is the bucket of every instance of a model that overrides equals without overriding hash.
+type sharedBucket struct{}
+
+// hash returns the bucket of the value. Values that are equal must have the same bucket.
+func (i *Interpreter) hash(value Value) (any, error) {
+	instance, ok := value.(*Instance)
+	if !ok || instance == nil {
+		// The other hashable values are comparable by Go.
+		return value, nil
+	}
+
+	switch {
+	case instance.Model.HashOverride != nil:
+		result, err := i.callOverride(instance, instance.Model.HashOverride.Block, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		return result, nil
+	case instance.Model.EqualOverride != nil:
+		return sharedBucket{}, nil
+	default:
+		return instance, nil
+	}
+}
+
+// equal returns whether the values are equal.
+func (i *Interpreter) equal(a, b Value) (bool, error) {
+	aInstance, ok := a.(*Instance)
+	if !ok {
+		return a == b, nil
+	}
+
+	bInstance, _ := b.(*Instance)
+	if aInstance == nil || bInstance == nil || aInstance.Model.EqualOverride == nil {
+		return aInstance == bInstance, nil
+	}
+
+	override := aInstance.Model.EqualOverride
+	result, err := i.callOverride(aInstance, override.Block, override, bInstance)
+	if err != nil {
+		return false, err
+	}
+
+	return bool(result.(Bool)), nil
+}
+
+// zero returns the zero value of the type.
+func (i *Interpreter) zero(typ code.Type) Value {
+	switch typ.(type) {
+	case *code.Bool:
+		return Bool(false)
+	case *code.Bytes:
+		return &Bytes{}
+	case *code.Float64:
+		return Float64(0)
+	case *code.Int32:
+		return Int32(0)
+	case *code.Int64:
+		return Int64(0)
+	case *code.Rune:
+		return Rune(0)
+	case *code.String:
+		return String("")
+	case *code.Uint8:
+		return Uint8(0)
+	case *code.List:
+		return &List{}
+	case *code.Map:
+		return i.NewMap()
+	case *code.Set:
+		return i.NewSet()
+	default:
+		// A nil model is a nil instance rather than a nil interface, so that its type is still known.
+		return (*Instance)(nil)
+	}
+}
+
+// conforms returns whether the value has the type.
+func conforms(value Value, typ code.Type) bool {
+	switch typ := typ.(type) {
+	case *code.Bool:
+		_, ok := value.(Bool)
+		return ok
+	case *code.Bytes:
+		bytes, ok := value.(*Bytes)
+		return ok && bytes != nil
+	case *code.Float64:
+		_, ok := value.(Float64)
+		return ok
+	case *code.Int32:
+		_, ok := value.(Int32)
+		return ok
+	case *code.Int64:
+		_, ok := value.(Int64)
+		return ok
+	case *code.Rune:
+		_, ok := value.(Rune)
+		return ok
+	case *code.String:
+		_, ok := value.(String)
+		return ok
+	case *code.Uint8:
+		_, ok := value.(Uint8)
+		return ok
+	case *code.List:
+		list, ok := value.(*List)
+		if !ok || list == nil {
+			return false
+		}
+
+		for _, item := range list.Items {
+			if !conforms(item, typ.Item) {
+				return false
+			}
+		}
+
+		return true
+	case *code.Map:
+		m, ok := value.(*Map)
+		if !ok || m == nil {
+			return false
+		}
+
+		for index, key := range m.keys {
+			if !conforms(key, typ.Key) || !conforms(m.values[index], typ.Value) {
+				return false
+			}
+		}
+
+		return true
+	case *code.Set:
+		set, ok := value.(*Set)
+		if !ok || set == nil {
+			return false
+		}
+
+		for _, item := range set.keys {
+			if !conforms(item, typ.Item) {
+				return false
+			}
+		}
+
+		return true
+	case *code.Model:
+		instance, ok := value.(*Instance)
+		if !ok {
+			return false
+		}
+
+		return instance == nil || (instance.Model.Name == typ.Name && instance.Model.Module.Name == typ.Module)
+	default:
+		return false
+	}
+}
+
+// saturate converts the float to an integer of the given type by truncating it toward zero and clamping it to the
+// range of the type. NaN becomes zero.
+func saturate(value float64, typ code.Type) int64 {
+	if math.IsNaN(value) {
+		return 0
+	}
+
+	min, max := code.IntegerRange(typ)
+	switch {
+	case value <= float64(min):
+		return min
+	case value >= float64(max):
+		// The largest int64 rounds up to 2^63 as a float, so anything at least that large is out of range.
+		return max
+	default:
+		return int64(value)
+	}
+}
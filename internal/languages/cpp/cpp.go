@@ -0,0 +1,1253 @@
+package cpp
+
+import (
+	_ "embed"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/JosephNaberhaus/agnostic/code"
+	"github.com/JosephNaberhaus/agnostic/internal/languages"
+	"github.com/JosephNaberhaus/agnostic/internal/utils/stack"
+)
+
+//go:embed runtime/agnostic.h
+var runtime string
+
+const (
+	runtimeFilename = "agnostic.h"
+
+	header = "// Code generated by agnostic. DO NOT EDIT.\n\n"
+	indent = "    "
+)
+
+var _ code.NodeMapper[string] = &mapper{}
+
+// Generate converts each module into a header and source file pair. The contents of each module are placed in a
+// namespace named after the module.
+//
+// Models are always handled through a std::shared_ptr so that they can be nil and can be shared the same way they are
+// in the other backends. Collections are shared as well, since the collections of the runtime only hold a
+// std::shared_ptr to their contents. Constant collections are functions that create the collection each time that
+// it's used, so that changing it doesn't change the constant.
+//
+// Only the public constants, models, and functions of a module are declared in its header. The private fields and
+// methods of a model are still public members of its class, since the functions of the module need to access them.
+func Generate(root *code.Root) ([]languages.File, error) {
+	files := make([]languages.File, 0, 2*len(root.Modules)+1)
+	for _, module := range root.Modules {
+		m := &mapper{module: module}
+
+		headerFile, err := m.header(module)
+		if err != nil {
+			return nil, fmt.Errorf("module %q: %w", module.Name, err)
+		}
+
+		sourceFile, err := m.MapModule(module)
+		if err != nil {
+			return nil, fmt.Errorf("module %q: %w", module.Name, err)
+		}
+
+		files = append(files,
+			languages.File{Path: module.Name + ".h", Contents: headerFile},
+			languages.File{Path: module.Name + ".cpp", Contents: sourceFile},
+		)
+	}
+
+	files = append(files, languages.File{
+		Path:     runtimeFilename,
+		Contents: runtime,
+	})
+
+	return files, nil
+}
+
+// mapper converts a single module into C++. The Map methods produce the contents of the source file, while the
+// declarations that go into the header are produced separately.
+type mapper struct {
+	module *code.Module
+	// The variables that are visible at the current point in the module.
+	scopes stack.Stack[scope]
+	// The model whose methods are currently being generated. Nil when outside a model.
+	self *code.ModelDef
+}
+
+// qualifier returns the prefix of the names of declarations of the module with the given name. Declarations of another
+// module are qualified by its namespace.
+func (m *mapper) qualifier(module string) string {
+	if module == m.module.Name {
+		return ""
+	}
+
+	return identifier(module) + "::"
+}
+
+func (m *mapper) mapValues(values []code.Value) ([]string, error) {
+	return code.MapEachValue[string](values, m)
+}
+
+// namespace wraps the contents of a file in a namespace.
+func namespace(name string, contents []string) string {
+	return "namespace " + name + " {\n\n" + strings.Join(contents, "\n\n") + "\n\n}  // namespace " + name
+}
+
+// braces wraps lines in an indented pair of braces.
+func braces(lines []string) string {
+	if len(lines) == 0 {
+		return "{}"
+	}
+
+	return "{\n" + languages.Indent(strings.Join(lines, "\n"), indent) + "\n}"
+}
+
+// hashes returns whether a model needs a specialization of std::hash.
+func hashes(model *code.ModelDef) bool {
+	return model.EqualOverride != nil || model.HashOverride != nil
+}
+
+// header produces the header file of the module.
+func (m *mapper) header(module *code.Module) (string, error) {
+	m.scopes.Push(scope{})
+	defer m.scopes.Pop()
+
+	namespaceName := identifier(module.Name)
+
+	includes := []string{"#include \"" + runtimeFilename + "\""}
+	for _, i := range module.Imports {
+		include, err := m.MapImport(i)
+		if err != nil {
+			return "", err
+		}
+
+		includes = append(includes, include)
+	}
+
+	sections := []string{"#pragma once", strings.Join(includes, "\n")}
+
+	if len(module.Models) > 0 {
+		declarations := make([]string, 0, len(module.Models))
+		for _, model := range module.Models {
+			declarations = append(declarations, "class "+identifier(model.Name)+";")
+		}
+
+		sections = append(sections, namespace(namespaceName, []string{strings.Join(declarations, "\n")}))
+	}
+
+	var specializations []string
+	for _, model := range module.Models {
+		if !hashes(model) {
+			continue
+		}
+
+		qualified := namespaceName + "::" + identifier(model.Name)
+		specializations = append(specializations, "template <>\nstruct hash<"+qualified+"> "+braces([]string{
+			"size_t operator()(const " + qualified + "& value) const;",
+		})+";")
+	}
+
+	if len(specializations) > 0 {
+		sections = append(sections, namespace("std", specializations))
+	}
+
+	var declarations []string
+	if len(module.Constants) > 0 {
+		constants := make([]string, 0, len(module.Constants))
+		for _, constant := range module.Constants {
+			if constant.Visibility == code.VisibilityPrivate {
+				continue
+			}
+
+			typ, err := m.constantType(constant)
+			if err != nil {
+				return "", fmt.Errorf("constant %q: %w", constant.Name, err)
+			}
+
+			if isCollection(code.TypeOf(constant.Value.(code.Value))) {
+				constants = append(constants, typ+" "+identifier(constant.Name)+"();")
+				continue
+			}
+
+			constants = append(constants, "extern const "+typ+" "+identifier(constant.Name)+";")
+		}
+
+		if len(constants) > 0 {
+			declarations = append(declarations, strings.Join(constants, "\n"))
+		}
+	}
+
+	for _, model := range module.Models {
+		if model.Visibility == code.VisibilityPrivate {
+			continue
+		}
+
+		result, err := m.class(model)
+		if err != nil {
+			return "", fmt.Errorf("model %q: %w", model.Name, err)
+		}
+
+		declarations = append(declarations, result)
+	}
+
+	if len(module.Functions) > 0 {
+		functions := make([]string, 0, len(module.Functions))
+		for _, function := range module.Functions {
+			if function.Visibility == code.VisibilityPrivate {
+				continue
+			}
+
+			signature, err := m.signature(function, "")
+			if err != nil {
+				return "", fmt.Errorf("function %q: %w", function.Name, err)
+			}
+
+			functions = append(functions, signature+";")
+		}
+
+		if len(functions) > 0 {
+			declarations = append(declarations, strings.Join(functions, "\n"))
+		}
+	}
+
+	if len(declarations) > 0 {
+		sections = append(sections, namespace(namespaceName, declarations))
+	}
+
+	return header + strings.Join(sections, "\n\n") + "\n", nil
+}
+
+// class produces the declaration of a model's class.
+func (m *mapper) class(model *code.ModelDef) (string, error) {
+	name := identifier(model.Name)
+
+	var members []string
+	if len(model.Fields) > 0 {
+		fields := make([]string, 0, len(model.Fields))
+		for _, field := range model.Fields {
+			result, err := m.MapFieldDef(field)
+			if err != nil {
+				return "", err
+			}
+
+			fields = append(fields, result)
+		}
+
+		members = append(members, strings.Join(fields, "\n"))
+	}
+
+	var methods []string
+	for _, method := range model.Methods {
+		signature, err := m.signature(method, "")
+		if err != nil {
+			return "", fmt.Errorf("method %q: %w", method.Name, err)
+		}
+
+		methods = append(methods, signature+constQualifier(method)+";")
+	}
+
+	if model.EqualOverride != nil {
+		methods = append(methods, "bool operator==(const "+name+"& "+identifier(model.EqualOverride.OtherName)+") const;")
+	}
+
+	if model.HashOverride != nil {
+		methods = append(methods, "int64_t agnosticHash() const;")
+	}
+
+	if len(methods) > 0 {
+		members = append(members, strings.Join(methods, "\n"))
+	}
+
+	// Self can be used as a value, which requires a shared_ptr to the instance.
+	declaration := "class " + name + " : public std::enable_shared_from_this<" + name + "> {\npublic:"
+	if len(members) > 0 {
+		declaration += "\n" + languages.Indent(strings.Join(members, "\n\n"), indent)
+	}
+
+	return declaration + "\n};", nil
+}
+
+// signature produces the signature of a function. The qualifier is prepended to the name of the function.
+func (m *mapper) signature(function *code.FunctionDef, qualifier string) (string, error) {
+	arguments := make([]string, 0, len(function.Arguments))
+	for _, argument := range function.Arguments {
+		result, err := m.MapArgumentDef(argument)
+		if err != nil {
+			return "", err
+		}
+
+		arguments = append(arguments, result)
+	}
+
+	returnType, err := code.MapType[string](function.ReturnType, m)
+	if err != nil {
+		return "", err
+	}
+
+	return returnType + " " + qualifier + identifier(function.Name) + "(" + strings.Join(arguments, ", ") + ")", nil
+}
+
+// constQualifier returns the qualifier of a method that marks whether it can be called on a const instance. Methods
+// that don't modify their model are const so that they can be called from the equal and hash overrides.
+func constQualifier(method *code.FunctionDef) string {
+	if method.ModifiesSelf {
+		return ""
+	}
+
+	return " const"
+}
+
+// constantType returns the C++ type of a constant.
+func (m *mapper) constantType(constant *code.ConstantDef) (string, error) {
+	typ := code.TypeOf(constant.Value.(code.Value))
+
+	return code.MapType[string](typ, m)
+}
+
+// statements converts the statements of a block.
+func (m *mapper) statements(block *code.Block) ([]string, error) {
+	m.scopes.Push(scope{})
+	defer m.scopes.Pop()
+
+	statements := make([]string, 0, len(block.Statements))
+	for _, statement := range block.Statements {
+		result, err := code.MapStatement[string](statement, m)
+		if err != nil {
+			return nil, err
+		}
+
+		switch statement.(type) {
+		case *code.Conditional, *code.For, *code.ForEach:
+		default:
+			result += ";"
+		}
+
+		statements = append(statements, result)
+	}
+
+	return statements, nil
+}
+
+// zeroValue returns the value that a variable of the given type starts out as.
+func (m *mapper) zeroValue(typ code.Type) (string, error) {
+	switch typ.(type) {
+	case *code.Bool:
+		return "false", nil
+	case *code.Float64:
+		return "0.0", nil
+	case *code.Int32, *code.Int64, *code.Uint8:
+		return "0", nil
+	case *code.Model:
+		return "nullptr", nil
+	case *code.Rune:
+		return `U'\0'`, nil
+	case *code.Bytes, *code.List, *code.Map, *code.Set, *code.String:
+		result, err := code.MapType[string](typ, m)
+		if err != nil {
+			return "", err
+		}
+
+		return result + "()", nil
+	default:
+		return "", fmt.Errorf("%T has no zero value", typ)
+	}
+}
+
+// sharedSelf returns a shared_ptr to a model that is held directly.
+func (m *mapper) sharedSelf(instance string) string {
+	return "std::const_pointer_cast<" + identifier(m.self.Name) + ">(" + instance + "shared_from_this())"
+}
+
+func (m *mapper) MapAddToSet(value *code.AddToSet) (string, error) {
+	set, err := code.MapValue[string](value.Set, m)
+	if err != nil {
+		return "", err
+	}
+
+	item, err := code.MapValue[string](value.Value, m)
+	if err != nil {
+		return "", err
+	}
+
+	return set + ".insert(" + item + ")", nil
+}
+
+func (m *mapper) MapArgumentDef(value *code.ArgumentDef) (string, error) {
+	typ, err := code.MapType[string](value.Type, m)
+	if err != nil {
+		return "", err
+	}
+
+	m.declare(value.Name, variable{})
+
+	name := identifier(value.Name)
+	switch value.Type.(type) {
+	case *code.Bytes, *code.List, *code.Map, *code.Set, *code.String:
+		// A modified argument is copied so that assigning to it doesn't change the caller's variable. Strings are
+		// immutable and collections are shared, so the copy still sees the changes to the contents of a collection.
+		if value.Modified {
+			return typ + " " + name, nil
+		}
+
+		return "const " + typ + "& " + name, nil
+	default:
+		return typ + " " + name, nil
+	}
+}
+
+func (m *mapper) MapAssignment(value *code.Assignment) (string, error) {
+	from, err := code.MapValue[string](value.From, m)
+	if err != nil {
+		return "", err
+	}
+
+	if lookup, ok := value.To.(*code.Lookup); ok {
+		fromType := code.TypeOf(lookup.From)
+
+		if _, isMap := fromType.(*code.Map); isMap {
+			// Unlike at, the subscript operator inserts missing keys.
+			into, err := code.MapValue[string](lookup.From, m)
+			if err != nil {
+				return "", err
+			}
+
+			key, err := code.MapValue[string](lookup.Key, m)
+			if err != nil {
+				return "", err
+			}
+
+			return into + "[" + key + "] = " + from, nil
+		}
+	}
+
+	to, err := code.MapValue[string](value.To, m)
+	if err != nil {
+		return "", err
+	}
+
+	return to + " = " + from, nil
+}
+
+func (m *mapper) MapBinary(value *code.Binary) (string, error) {
+	left, err := code.MapValue[string](value.Left, m)
+	if err != nil {
+		return "", err
+	}
+
+	right, err := code.MapValue[string](value.Right, m)
+	if err != nil {
+		return "", err
+	}
+
+	if value.Overflow == code.OverflowWrap {
+		// Signed overflow is undefined behavior, so integer arithmetic goes through the runtime.
+		cppType, err := code.MapType[string](value.Type, m)
+		if err != nil {
+			return "", err
+		}
+
+		return "agnostic::" + arithmeticFunctions[value.Operator] + "<" + cppType + ">(" + left + ", " + right + ")", nil
+	}
+
+	return "(" + left + " " + value.Operator.Symbol() + " " + right + ")", nil
+}
+
+func (m *mapper) MapBlock(value *code.Block) (string, error) {
+	statements, err := m.statements(value)
+	if err != nil {
+		return "", err
+	}
+
+	return braces(statements), nil
+}
+
+func (m *mapper) MapBool(value *code.Bool) (string, error) {
+	return "bool", nil
+}
+
+func (m *mapper) MapBreak(value *code.Break) (string, error) {
+	return "break", nil
+}
+
+func (m *mapper) MapBytes(value *code.Bytes) (string, error) {
+	return "agnostic::List<uint8_t>", nil
+}
+
+func (m *mapper) MapCall(value *code.Call) (string, error) {
+	function, ok := value.Function.(*code.FunctionDef)
+	if !ok {
+		return "", fmt.Errorf("unsupported callable %T", value.Function)
+	}
+
+	arguments, err := m.mapValues(value.Arguments)
+	if err != nil {
+		return "", err
+	}
+
+	return m.qualifier(function.Module.Name) + identifier(function.Name) + "(" + strings.Join(arguments, ", ") + ")", nil
+}
+
+func (m *mapper) MapComparison(value *code.Comparison) (string, error) {
+	left, err := code.MapValue[string](value.Left, m)
+	if err != nil {
+		return "", err
+	}
+
+	right, err := code.MapValue[string](value.Right, m)
+	if err != nil {
+		return "", err
+	}
+
+	if _, isModel := code.TypeOf(value.Left).(*code.Model); isModel {
+		// Comparing the shared pointers directly would compare their identity.
+		equal := "agnostic::equal(" + left + ", " + right + ")"
+		if value.Operator == code.ComparisonOperatorNotEqual {
+			return "!" + equal, nil
+		}
+
+		return equal, nil
+	}
+
+	return "(" + left + " " + value.Operator.Symbol() + " " + right + ")", nil
+}
+
+func (m *mapper) MapConditional(value *code.Conditional) (string, error) {
+	ifs := make([]string, 0, len(value.Ifs))
+	for _, ifNode := range value.Ifs {
+		result, err := m.MapIf(ifNode)
+		if err != nil {
+			return "", err
+		}
+
+		ifs = append(ifs, result)
+	}
+
+	result := strings.Join(ifs, " else ")
+	if value.Else != nil {
+		elseBlock, err := m.MapBlock(value.Else)
+		if err != nil {
+			return "", err
+		}
+
+		result += " else " + elseBlock
+	}
+
+	return result, nil
+}
+
+func (m *mapper) MapConstantDef(value *code.ConstantDef) (string, error) {
+	typ, err := m.constantType(value)
+	if err != nil {
+		return "", err
+	}
+
+	constant, err := code.MapConstantValue[string](value.Value, m)
+	if err != nil {
+		return "", err
+	}
+
+	if isCollection(code.TypeOf(value.Value.(code.Value))) {
+		// Private constants aren't declared in the header, so they're kept private to the file like a const would be.
+		var prefix string
+		if value.Visibility == code.VisibilityPrivate {
+			prefix = "[[maybe_unused]] static "
+		}
+
+		return prefix + typ + " " + identifier(value.Name) + "() " + braces([]string{"return " + constant + ";"}), nil
+	}
+
+	return "const " + typ + " " + identifier(value.Name) + " = " + constant + ";", nil
+}
+
+func (m *mapper) MapContinue(value *code.Continue) (string, error) {
+	return "continue", nil
+}
+
+func (m *mapper) MapConversion(value *code.Conversion) (string, error) {
+	operand, err := code.MapValue[string](value.Value, m)
+	if err != nil {
+		return "", err
+	}
+
+	to, err := code.MapType[string](value.To, m)
+	if err != nil {
+		return "", err
+	}
+
+	if value.Overflow == code.OverflowSaturate {
+		// Casting a float that doesn't fit into an integer is undefined behavior.
+		return "agnostic::saturate<" + to + ">(" + operand + ")", nil
+	}
+
+	// Every supported compiler wraps integers that don't fit in a signed type around, and C++20 requires it.
+	return "static_cast<" + to + ">(" + operand + ")", nil
+}
+
+func (m *mapper) MapDeclare(value *code.Declare) (string, error) {
+	typ := code.TypeOf(value.Value)
+
+	cppType, err := code.MapType[string](typ, m)
+	if err != nil {
+		return "", err
+	}
+
+	initial, err := code.MapValue[string](value.Value, m)
+	if err != nil {
+		return "", err
+	}
+
+	m.declare(value.Name, variable{})
+
+	return cppType + " " + identifier(value.Name) + " = " + initial, nil
+}
+
+func (m *mapper) MapEmptyList(value *code.EmptyList) (string, error) {
+	return m.zeroValue(&code.List{Item: value.Type})
+}
+
+func (m *mapper) MapEqualOverride(value *code.EqualOverride) (string, error) {
+	m.scopes.Push(scope{})
+	defer m.scopes.Pop()
+
+	m.declare(value.OtherName, variable{direct: true})
+
+	block, err := m.MapBlock(value.Block)
+	if err != nil {
+		return "", err
+	}
+
+	name := identifier(m.self.Name)
+	return "bool " + name + "::operator==(const " + name + "& " + identifier(value.OtherName) + ") const " + block, nil
+}
+
+func (m *mapper) MapFieldDef(value *code.FieldDef) (string, error) {
+	typ, err := code.MapType[string](value.Type, m)
+	if err != nil {
+		return "", err
+	}
+
+	switch value.Type.(type) {
+	case *code.Bool, *code.Int64, *code.Rune:
+		zero, err := m.zeroValue(value.Type)
+		if err != nil {
+			return "", err
+		}
+
+		return typ + " " + identifier(value.Name) + " = " + zero + ";", nil
+	default:
+		return typ + " " + identifier(value.Name) + ";", nil
+	}
+}
+
+func (m *mapper) MapFloat64(value *code.Float64) (string, error) {
+	return "double", nil
+}
+
+func (m *mapper) MapFor(value *code.For) (string, error) {
+	m.scopes.Push(scope{})
+	defer m.scopes.Pop()
+
+	var initialization, afterEach string
+	var err error
+	if value.Initialization != nil {
+		initialization, err = code.MapStatement[string](value.Initialization, m)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	condition, err := code.MapValue[string](value.Condition, m)
+	if err != nil {
+		return "", err
+	}
+
+	if value.AfterEach != nil {
+		afterEach, err = code.MapStatement[string](value.AfterEach, m)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	block, err := m.MapBlock(value.Block)
+	if err != nil {
+		return "", err
+	}
+
+	return "for (" + initialization + "; " + condition + "; " + afterEach + ") " + block, nil
+}
+
+func (m *mapper) MapForEach(value *code.ForEach) (string, error) {
+	iterableType := code.TypeOf(value.Iterable)
+
+	item := code.ItemType(iterableType)
+
+	itemCppType, err := code.MapType[string](item, m)
+	if err != nil {
+		return "", err
+	}
+
+	iterable, err := code.MapValue[string](value.Iterable, m)
+	if err != nil {
+		return "", err
+	}
+
+	// The collections return a copy of their items, so that the loop isn't affected by changes to the collection.
+	switch iterableType.(type) {
+	case *code.Map:
+		iterable += ".keys()"
+	case *code.String:
+		iterable = "agnostic::runes(" + iterable + ")"
+	default:
+		iterable += ".items()"
+	}
+
+	m.scopes.Push(scope{})
+	defer m.scopes.Pop()
+
+	m.declare(value.ItemName, variable{})
+
+	block, err := m.MapBlock(value.Block)
+	if err != nil {
+		return "", err
+	}
+
+	return "for (" + itemCppType + " " + identifier(value.ItemName) + " : " + iterable + ") " + block, nil
+}
+
+func (m *mapper) MapFunctionDef(value *code.FunctionDef) (string, error) {
+	m.scopes.Push(scope{})
+	defer m.scopes.Pop()
+
+	var qualifier string
+	if m.self != nil {
+		qualifier = identifier(m.self.Name) + "::"
+	}
+
+	signature, err := m.signature(value, qualifier)
+	if err != nil {
+		return "", err
+	}
+
+	if m.self != nil {
+		signature += constQualifier(value)
+	}
+
+	block, err := m.MapBlock(value.Block)
+	if err != nil {
+		return "", err
+	}
+
+	return signature + " " + block, nil
+}
+
+func (m *mapper) MapFunctionRef(value *code.FunctionRef) (string, error) {
+	// Calls refer to the definition of the function once the AST is mapped to code.
+	return "", fmt.Errorf("unresolved reference to function %q", value.Name)
+}
+
+func (m *mapper) MapHashOverride(value *code.HashOverride) (string, error) {
+	block, err := m.MapBlock(value.Block)
+	if err != nil {
+		return "", err
+	}
+
+	return "int64_t " + identifier(m.self.Name) + "::agnosticHash() const " + block, nil
+}
+
+func (m *mapper) MapIf(value *code.If) (string, error) {
+	condition, err := code.MapValue[string](value.Condition, m)
+	if err != nil {
+		return "", err
+	}
+
+	block, err := m.MapBlock(value.Block)
+	if err != nil {
+		return "", err
+	}
+
+	return "if (" + condition + ") " + block, nil
+}
+
+// MapImport includes the header of the module, which declares everything that the module defines.
+func (m *mapper) MapImport(value *code.Import) (string, error) {
+	return "#include \"" + value.Module + ".h\"", nil
+}
+
+func (m *mapper) MapInt32(value *code.Int32) (string, error) {
+	return "int32_t", nil
+}
+
+func (m *mapper) MapInt64(value *code.Int64) (string, error) {
+	return "int64_t", nil
+}
+
+func (m *mapper) MapKeyValue(value *code.KeyValue) (string, error) {
+	key, err := code.MapValue[string](value.Key, m)
+	if err != nil {
+		return "", err
+	}
+
+	mapValue, err := code.MapValue[string](value.Value, m)
+	if err != nil {
+		return "", err
+	}
+
+	return "{" + key + ", " + mapValue + "}", nil
+}
+
+func (m *mapper) MapLength(value *code.Length) (string, error) {
+	of, err := code.MapValue[string](value.Of, m)
+	if err != nil {
+		return "", err
+	}
+
+	return "agnostic::length(" + of + ")", nil
+}
+
+func (m *mapper) MapList(value *code.List) (string, error) {
+	item, err := code.MapType[string](value.Item, m)
+	if err != nil {
+		return "", err
+	}
+
+	return "agnostic::List<" + item + ">", nil
+}
+
+func (m *mapper) MapLiteralBool(value *code.LiteralBool) (string, error) {
+	return strconv.FormatBool(value.Value), nil
+}
+
+func (m *mapper) MapLiteralBytes(value *code.LiteralBytes) (string, error) {
+	values := make([]string, 0, len(value.Value))
+	for _, b := range value.Value {
+		values = append(values, strconv.FormatUint(uint64(b), 10))
+	}
+
+	return "agnostic::List<uint8_t>{" + strings.Join(values, ", ") + "}", nil
+}
+
+func (m *mapper) MapLiteralFloat64(value *code.LiteralFloat64) (string, error) {
+	return languages.FormatFloat(value.Value), nil
+}
+
+func (m *mapper) MapLiteralInt32(value *code.LiteralInt32) (string, error) {
+	if value.Value == math.MinInt32 {
+		// The literal would be the negation of a value that's too large for an int32_t.
+		return "INT32_MIN", nil
+	}
+
+	return strconv.FormatInt(int64(value.Value), 10), nil
+}
+
+func (m *mapper) MapLiteralInt64(value *code.LiteralInt64) (string, error) {
+	if value.Value == math.MinInt64 {
+		// The literal would be the negation of a value that's too large for an int64_t.
+		return "INT64_MIN", nil
+	}
+
+	return strconv.FormatInt(value.Value, 10), nil
+}
+
+// literal converts a literal collection into a braced initializer of the collection's type.
+func (m *mapper) literal(value code.Value, elements []string) (string, error) {
+	typ := code.TypeOf(value)
+
+	cppType, err := code.MapType[string](typ, m)
+	if err != nil {
+		return "", err
+	}
+
+	return cppType + "{" + strings.Join(elements, ", ") + "}", nil
+}
+
+func (m *mapper) MapLiteralList(value *code.LiteralList) (string, error) {
+	values, err := m.mapValues(value.Values)
+	if err != nil {
+		return "", err
+	}
+
+	return m.literal(value, values)
+}
+
+func (m *mapper) MapLiteralMap(value *code.LiteralMap) (string, error) {
+	entries := make([]string, 0, len(value.Values))
+	for _, keyValue := range value.Values {
+		result, err := m.MapKeyValue(keyValue)
+		if err != nil {
+			return "", err
+		}
+
+		entries = append(entries, result)
+	}
+
+	return m.literal(value, entries)
+}
+
+func (m *mapper) MapLiteralRune(value *code.LiteralRune) (string, error) {
+	return quoteChar(value.Value), nil
+}
+
+func (m *mapper) MapLiteralSet(value *code.LiteralSet) (string, error) {
+	values, err := m.mapValues(value.Values)
+	if err != nil {
+		return "", err
+	}
+
+	return m.literal(value, values)
+}
+
+func (m *mapper) MapLiteralString(value *code.LiteralString) (string, error) {
+	return quote(value.Value), nil
+}
+
+func (m *mapper) MapLiteralUint8(value *code.LiteralUint8) (string, error) {
+	return strconv.FormatUint(uint64(value.Value), 10), nil
+}
+
+func (m *mapper) MapLookup(value *code.Lookup) (string, error) {
+	fromType := code.TypeOf(value.From)
+
+	from, err := code.MapValue[string](value.From, m)
+	if err != nil {
+		return "", err
+	}
+
+	key, err := code.MapValue[string](value.Key, m)
+	if err != nil {
+		return "", err
+	}
+
+	if _, isString := fromType.(*code.String); isString {
+		return "agnostic::lookup(" + from + ", " + key + ")", nil
+	}
+
+	// Unlike the subscript operator, at throws if the key is missing.
+	return from + ".at(" + key + ")", nil
+}
+
+func (m *mapper) MapMap(value *code.Map) (string, error) {
+	key, err := code.MapType[string](value.Key, m)
+	if err != nil {
+		return "", err
+	}
+
+	mapValue, err := code.MapType[string](value.Value, m)
+	if err != nil {
+		return "", err
+	}
+
+	return "agnostic::Map<" + key + ", " + mapValue + ">", nil
+}
+
+func (m *mapper) MapMethodCall(value *code.MethodCall) (string, error) {
+	arguments, err := m.mapValues(value.Arguments)
+	if err != nil {
+		return "", err
+	}
+
+	call := identifier(value.Name) + "(" + strings.Join(arguments, ", ") + ")"
+	switch of := value.Receiver.(type) {
+	case *code.Self:
+		return "this->" + call, nil
+	case *code.Variable:
+		if v, ok := m.lookupVariable(of.Name); ok && v.direct {
+			return identifier(of.Name) + "." + call, nil
+		}
+	}
+
+	receiver, err := code.MapValue[string](value.Receiver, m)
+	if err != nil {
+		return "", err
+	}
+
+	return receiver + "->" + call, nil
+}
+
+func (m *mapper) MapModel(value *code.Model) (string, error) {
+	return "std::shared_ptr<" + m.qualifier(value.Module) + identifier(value.Name) + ">", nil
+}
+
+func (m *mapper) MapModelDef(value *code.ModelDef) (string, error) {
+	m.self = value
+	defer func() { m.self = nil }()
+
+	definitions := make([]string, 0, len(value.Methods)+2)
+	for _, method := range value.Methods {
+		result, err := m.MapFunctionDef(method)
+		if err != nil {
+			return "", fmt.Errorf("method %q: %w", method.Name, err)
+		}
+
+		definitions = append(definitions, result)
+	}
+
+	if value.EqualOverride != nil {
+		result, err := m.MapEqualOverride(value.EqualOverride)
+		if err != nil {
+			return "", err
+		}
+
+		definitions = append(definitions, result)
+	}
+
+	if value.HashOverride != nil {
+		m.scopes.Push(scope{})
+		result, err := m.MapHashOverride(value.HashOverride)
+		m.scopes.Pop()
+		if err != nil {
+			return "", err
+		}
+
+		definitions = append(definitions, result)
+	}
+
+	return strings.Join(definitions, "\n\n"), nil
+}
+
+// hashSpecialization produces the definition of the std::hash specialization of a model.
+func (m *mapper) hashSpecialization(model *code.ModelDef) string {
+	qualified := identifier(m.module.Name) + "::" + identifier(model.Name)
+
+	if model.HashOverride == nil {
+		// Without a hash override there's no way to know which instances are equal, so every instance needs to hash
+		// the same.
+		return "size_t std::hash<" + qualified + ">::operator()(const " + qualified + "&) const " + braces([]string{"return 0;"})
+	}
+
+	body := "return std::hash<int64_t>{}(value.agnosticHash());"
+	return "size_t std::hash<" + qualified + ">::operator()(const " + qualified + "& value) const " + braces([]string{body})
+}
+
+func (m *mapper) MapModule(value *code.Module) (string, error) {
+	m.scopes.Push(scope{})
+	defer m.scopes.Pop()
+
+	sections := []string{"#include \"" + value.Name + ".h\""}
+
+	for _, model := range value.Models {
+		if hashes(model) {
+			sections = append(sections, m.hashSpecialization(model))
+		}
+	}
+
+	// Constants are grouped together, while constant collections are functions and are separated like the other
+	// functions.
+	definitions := []string{"using namespace std::string_literals;"}
+	var constants, constantFunctions []string
+	for _, constant := range value.Constants {
+		result, err := m.MapConstantDef(constant)
+		if err != nil {
+			return "", fmt.Errorf("constant %q: %w", constant.Name, err)
+		}
+
+		if isCollection(code.TypeOf(constant.Value.(code.Value))) {
+			constantFunctions = append(constantFunctions, result)
+		} else {
+			constants = append(constants, result)
+		}
+	}
+
+	if len(constants) > 0 {
+		definitions = append(definitions, strings.Join(constants, "\n"))
+	}
+
+	definitions = append(definitions, constantFunctions...)
+
+	// Private models and functions are left out of the header, so they're declared at the top of the source file
+	// instead. The constants don't need to be, since a const at namespace scope is already private to its file.
+	var functions []string
+	for _, model := range value.Models {
+		if model.Visibility == code.VisibilityPrivate {
+			result, err := m.class(model)
+			if err != nil {
+				return "", fmt.Errorf("model %q: %w", model.Name, err)
+			}
+
+			definitions = append(definitions, result)
+		}
+	}
+
+	for _, function := range value.Functions {
+		if function.Visibility == code.VisibilityPrivate {
+			signature, err := m.signature(function, "")
+			if err != nil {
+				return "", fmt.Errorf("function %q: %w", function.Name, err)
+			}
+
+			// Private functions that the rest of the module doesn't use would otherwise be a warning.
+			functions = append(functions, "[[maybe_unused]] static "+signature+";")
+		}
+	}
+
+	if len(functions) > 0 {
+		definitions = append(definitions, strings.Join(functions, "\n"))
+	}
+
+	for _, model := range value.Models {
+		result, err := m.MapModelDef(model)
+		if err != nil {
+			return "", fmt.Errorf("model %q: %w", model.Name, err)
+		}
+
+		if result != "" {
+			definitions = append(definitions, result)
+		}
+	}
+
+	for _, function := range value.Functions {
+		result, err := m.MapFunctionDef(function)
+		if err != nil {
+			return "", fmt.Errorf("function %q: %w", function.Name, err)
+		}
+
+		definitions = append(definitions, result)
+	}
+
+	sections = append(sections, namespace(identifier(value.Name), definitions))
+
+	return header + strings.Join(sections, "\n\n") + "\n", nil
+}
+
+func (m *mapper) MapNew(value *code.New) (string, error) {
+	return "std::make_shared<" + m.qualifier(value.Model.Module) + identifier(value.Model.Name) + ">()", nil
+}
+
+func (m *mapper) MapNil(value *code.Nil) (string, error) {
+	return m.zeroValue(value.Type)
+}
+
+func (m *mapper) MapPop(value *code.Pop) (string, error) {
+	list, err := code.MapValue[string](value.List, m)
+	if err != nil {
+		return "", err
+	}
+
+	return list + ".pop()", nil
+}
+
+func (m *mapper) MapProperty(value *code.Property) (string, error) {
+	switch of := value.Of.(type) {
+	case *code.Self:
+		return "this->" + identifier(value.Name), nil
+	case *code.Variable:
+		if v, ok := m.lookupVariable(of.Name); ok && v.direct {
+			return identifier(of.Name) + "." + identifier(value.Name), nil
+		}
+	}
+
+	of, err := code.MapValue[string](value.Of, m)
+	if err != nil {
+		return "", err
+	}
+
+	return of + "->" + identifier(value.Name), nil
+}
+
+func (m *mapper) MapPush(value *code.Push) (string, error) {
+	list, err := code.MapValue[string](value.List, m)
+	if err != nil {
+		return "", err
+	}
+
+	item, err := code.MapValue[string](value.Value, m)
+	if err != nil {
+		return "", err
+	}
+
+	return list + ".push_back(" + item + ")", nil
+}
+
+func (m *mapper) MapReturn(value *code.Return) (string, error) {
+	result, err := code.MapValue[string](value.Value, m)
+	if err != nil {
+		return "", err
+	}
+
+	return "return " + result, nil
+}
+
+func (m *mapper) MapRoot(value *code.Root) (string, error) {
+	return "", fmt.Errorf("a root can't be generated as a single C++ file")
+}
+
+func (m *mapper) MapRune(value *code.Rune) (string, error) {
+	return "char32_t", nil
+}
+
+func (m *mapper) MapSelf(value *code.Self) (string, error) {
+	// Properties of self are handled by MapProperty, so this is only reached when self is used as a value.
+	return m.sharedSelf(""), nil
+}
+
+func (m *mapper) MapSet(value *code.Set) (string, error) {
+	item, err := code.MapType[string](value.Item, m)
+	if err != nil {
+		return "", err
+	}
+
+	return "agnostic::Set<" + item + ">", nil
+}
+
+func (m *mapper) MapSetContains(value *code.SetContains) (string, error) {
+	set, err := code.MapValue[string](value.Set, m)
+	if err != nil {
+		return "", err
+	}
+
+	item, err := code.MapValue[string](value.Value, m)
+	if err != nil {
+		return "", err
+	}
+
+	return set + ".contains(" + item + ")", nil
+}
+
+func (m *mapper) MapString(value *code.String) (string, error) {
+	return "std::string", nil
+}
+
+func (m *mapper) MapUint8(value *code.Uint8) (string, error) {
+	return "uint8_t", nil
+}
+
+func (m *mapper) MapUnary(value *code.Unary) (string, error) {
+	operand, err := code.MapValue[string](value.Value, m)
+	if err != nil {
+		return "", err
+	}
+
+	if value.Overflow == code.OverflowWrap {
+		cppType, err := code.MapType[string](value.Type, m)
+		if err != nil {
+			return "", err
+		}
+
+		return "agnostic::negate<" + cppType + ">(" + operand + ")", nil
+	}
+
+	// Negating a negative literal would otherwise produce the decrement operator.
+	if strings.HasPrefix(operand, "-") {
+		operand = "(" + operand + ")"
+	}
+
+	return "(" + value.Operator.Symbol() + operand + ")", nil
+}
+
+func (m *mapper) MapVariable(value *code.Variable) (string, error) {
+	if constant, ok := value.Definition.(*code.ConstantDef); ok {
+		if isCollection(code.TypeOf(constant.Value.(code.Value))) {
+			return m.qualifier(constant.Module.Name) + identifier(constant.Name) + "()", nil
+		}
+
+		return m.qualifier(constant.Module.Name) + identifier(constant.Name), nil
+	}
+
+	if v, ok := m.lookupVariable(value.Name); ok && v.direct {
+		return m.sharedSelf(identifier(value.Name) + "."), nil
+	}
+
+	return identifier(value.Name), nil
+}
+
+func (m *mapper) MapVoid(value *code.Void) (string, error) {
+	return "void", nil
+}
@@ -0,0 +1,67 @@
+package cpp
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/JosephNaberhaus/agnostic/code"
+	"github.com/JosephNaberhaus/agnostic/internal/languages"
+	"github.com/JosephNaberhaus/agnostic/internal/languages/languagetest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate(t *testing.T) {
+	languagetest.RunGolden(t, generateWithoutRuntime, compile)
+}
+
+// generateWithoutRuntime leaves out the runtime so that it isn't repeated in every golden file.
+func generateWithoutRuntime(root *code.Root) ([]languages.File, error) {
+	files, err := Generate(root)
+	if err != nil {
+		return nil, err
+	}
+
+	return slices.DeleteFunc(files, func(file languages.File) bool {
+		return file.Path == runtimeFilename
+	}), nil
+}
+
+// compile checks each generated source file with g++. It's skipped if g++ isn't installed.
+func compile(t *testing.T, files []languages.File) {
+	compiler, err := exec.LookPath("g++")
+	if err != nil {
+		t.Skip("g++ is not installed")
+	}
+
+	dir := t.TempDir()
+	files = append(files, languages.File{Path: runtimeFilename, Contents: runtime})
+	for _, file := range files {
+		path := filepath.Join(dir, file.Path)
+		require.NoError(t, os.WriteFile(path, []byte(file.Contents), 0o644))
+	}
+
+	for _, file := range files {
+		if !strings.HasSuffix(file.Path, ".cpp") {
+			continue
+		}
+
+		output, err := exec.Command(
+			compiler,
+			"-std=c++17",
+			"-Wall",
+			"-Wextra",
+			"-Werror",
+			// Whether arguments and variables are used depends on the test case rather than the generator.
+			"-Wno-unused-parameter",
+			"-Wno-unused-variable",
+			"-Wno-unused-but-set-variable",
+			"-fsyntax-only",
+			filepath.Join(dir, file.Path),
+		).CombinedOutput()
+		require.NoError(t, err, string(output))
+	}
+}
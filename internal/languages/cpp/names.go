@@ -0,0 +1,152 @@
+package cpp
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+var keywords = map[string]struct{}{
+	"alignas":          {},
+	"alignof":          {},
+	"and":              {},
+	"and_eq":           {},
+	"asm":              {},
+	"auto":             {},
+	"bitand":           {},
+	"bitor":            {},
+	"bool":             {},
+	"break":            {},
+	"case":             {},
+	"catch":            {},
+	"char":             {},
+	"char16_t":         {},
+	"char32_t":         {},
+	"class":            {},
+	"compl":            {},
+	"const":            {},
+	"const_cast":       {},
+	"constexpr":        {},
+	"continue":         {},
+	"decltype":         {},
+	"default":          {},
+	"delete":           {},
+	"do":               {},
+	"double":           {},
+	"dynamic_cast":     {},
+	"else":             {},
+	"enum":             {},
+	"explicit":         {},
+	"export":           {},
+	"extern":           {},
+	"false":            {},
+	"float":            {},
+	"for":              {},
+	"friend":           {},
+	"goto":             {},
+	"if":               {},
+	"inline":           {},
+	"int":              {},
+	"long":             {},
+	"mutable":          {},
+	"namespace":        {},
+	"new":              {},
+	"noexcept":         {},
+	"not":              {},
+	"not_eq":           {},
+	"nullptr":          {},
+	"operator":         {},
+	"or":               {},
+	"or_eq":            {},
+	"private":          {},
+	"protected":        {},
+	"public":           {},
+	"register":         {},
+	"reinterpret_cast": {},
+	"return":           {},
+	"short":            {},
+	"signed":           {},
+	"sizeof":           {},
+	"static":           {},
+	"static_assert":    {},
+	"static_cast":      {},
+	"struct":           {},
+	"switch":           {},
+	"template":         {},
+	"this":             {},
+	"thread_local":     {},
+	"throw":            {},
+	"true":             {},
+	"try":              {},
+	"typedef":          {},
+	"typeid":           {},
+	"typename":         {},
+	"union":            {},
+	"unsigned":         {},
+	"using":            {},
+	"virtual":          {},
+	"void":             {},
+	"volatile":         {},
+	"wchar_t":          {},
+	"while":            {},
+	"xor":              {},
+	"xor_eq":           {},
+
+	// Names that the generated code relies on.
+	"agnostic":         {},
+	"std":              {},
+	"shared_from_this": {},
+}
+
+// identifier converts an Agnostic name into a valid C++ identifier.
+func identifier(name string) string {
+	if _, isKeyword := keywords[name]; isKeyword {
+		return name + "_"
+	}
+
+	return name
+}
+
+// escape converts a rune into its form inside of a C++ string or character literal.
+func escape(r rune, quote rune) string {
+	switch r {
+	case quote:
+		return `\` + string(r)
+	case '\\':
+		return `\\`
+	case '\n':
+		return `\n`
+	case '\r':
+		return `\r`
+	case '\t':
+		return `\t`
+	default:
+		if r < 0x80 && !unicode.IsPrint(r) {
+			// Octal escapes are used because they can't absorb the characters that follow them.
+			return fmt.Sprintf(`\%03o`, r)
+		}
+
+		return string(r)
+	}
+}
+
+// quote converts a string into a C++ std::string literal. The generated source is expected to be compiled as UTF-8.
+func quote(str string) string {
+	var sb strings.Builder
+	sb.WriteByte('"')
+	for _, r := range str {
+		sb.WriteString(escape(r, '"'))
+	}
+	sb.WriteString(`"s`)
+
+	return sb.String()
+}
+
+// quoteChar converts a rune into a C++ char32_t literal.
+func quoteChar(r rune) string {
+	if r >= 0x80 {
+		return fmt.Sprintf(`U'\U%08X'`, r)
+	}
+
+	return "U'" + escape(r, '\'') + "'"
+}
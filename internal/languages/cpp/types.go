@@ -0,0 +1,48 @@
+package cpp
+
+import "github.com/JosephNaberhaus/agnostic/code"
+
+// variable is a name that is visible at some point in the module.
+type variable struct {
+	// Whether the variable holds a model directly rather than a pointer to it. This is the case for the other value of
+	// an equal override.
+	direct bool
+}
+
+// scope maps the names that are visible in a block to their variables.
+type scope map[string]variable
+
+// declare makes a name visible in the innermost scope.
+func (m *mapper) declare(name string, v variable) {
+	m.scopes.Peek()[name] = v
+}
+
+// lookupVariable finds a name by searching from the innermost scope outward.
+func (m *mapper) lookupVariable(name string) (variable, bool) {
+	for i := len(m.scopes) - 1; i >= 0; i-- {
+		if v, ok := m.scopes[i][name]; ok {
+			return v, true
+		}
+	}
+
+	return variable{}, false
+}
+
+// isCollection returns whether values of the type are bytes, lists, maps, or sets, which are shared like models.
+func isCollection(typ code.Type) bool {
+	switch typ.(type) {
+	case *code.Bytes, *code.List, *code.Map, *code.Set:
+		return true
+	default:
+		return false
+	}
+}
+
+// arithmeticFunctions are the functions of the runtime that perform each arithmetic operator on integers.
+var arithmeticFunctions = map[code.BinaryOperator]string{
+	code.BinaryOperatorAdd:      "add",
+	code.BinaryOperatorSubtract: "subtract",
+	code.BinaryOperatorMultiply: "multiply",
+	code.BinaryOperatorDivide:   "divide",
+	code.BinaryOperatorModulo:   "remainder",
+}
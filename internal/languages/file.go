@@ -0,0 +1,9 @@
+package languages
+
+// File is a single source file that was generated by a language backend.
+type File struct {
+	// Path of the file relative to the output directory.
+	Path string
+	// Contents is the full text of the file.
+	Contents string
+}
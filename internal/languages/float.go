@@ -0,0 +1,17 @@
+package languages
+
+import (
+	"strconv"
+	"strings"
+)
+
+// FormatFloat formats a finite float as the shortest literal that round-trips. The literal always has a decimal point or
+// an exponent so that languages treat it as a float rather than as an integer.
+func FormatFloat(value float64) string {
+	result := strconv.FormatFloat(value, 'g', -1, 64)
+	if !strings.ContainsAny(result, ".e") {
+		result += ".0"
+	}
+
+	return result
+}
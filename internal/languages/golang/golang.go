@@ -0,0 +1,1111 @@
+package golang
+
+import (
+	"fmt"
+	"go/format"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/JosephNaberhaus/agnostic/code"
+	"github.com/JosephNaberhaus/agnostic/internal/languages"
+)
+
+var _ code.NodeMapper[string] = &mapper{}
+
+// Generate converts each module into a single gofmt-formatted Go file. Every module becomes its own package, whose
+// import path is the name of the module, and the public declarations of a module are exported. Lists are pointers to
+// slices so that pushing to a list is visible through every reference to it. Maps and sets are generated as hash tables
+// that keep their keys in insertion order and use the overrides of models, since Go maps are iterated over in a random
+// order and compare pointers.
+func Generate(root *code.Root) ([]languages.File, error) {
+	files := make([]languages.File, 0, len(root.Modules))
+	for _, module := range root.Modules {
+		source, err := newMapper(module).MapModule(module)
+		if err != nil {
+			return nil, fmt.Errorf("module %q: %w", module.Name, err)
+		}
+
+		formatted, err := format.Source([]byte(source))
+		if err != nil {
+			return nil, fmt.Errorf("module %q: generated invalid Go: %w", module.Name, err)
+		}
+
+		files = append(files, languages.File{
+			Path:     path.Join(module.Name, module.Name+".go"),
+			Contents: string(formatted),
+		})
+	}
+
+	return files, nil
+}
+
+// mapper converts a single module into Go source. The output of each method is a fragment of Go that still needs to be
+// passed through gofmt.
+type mapper struct {
+	// The module being generated.
+	module *code.Module
+	// The model whose methods are currently being generated. Nil when outside a model.
+	self *code.ModelDef
+	// The helpers that the generated code depends on.
+	helpers map[string]struct{}
+	// The packages that the generated code imports.
+	imports map[string]struct{}
+	// The definitions of the variables that the generated code reads, since Go doesn't allow a variable that is never
+	// read.
+	used map[code.Definition]bool
+}
+
+func newMapper(module *code.Module) *mapper {
+	return &mapper{
+		module:  module,
+		helpers: map[string]struct{}{},
+		imports: map[string]struct{}{},
+		used:    map[code.Definition]bool{},
+	}
+}
+
+func (m *mapper) useHelper(name string) {
+	m.helpers[name] = struct{}{}
+	for _, dependency := range helperDependencies[name] {
+		m.useHelper(dependency)
+	}
+}
+
+func (m *mapper) useImport(name string) {
+	m.imports[name] = struct{}{}
+}
+
+func (m *mapper) mapValues(values []code.Value) ([]string, error) {
+	return code.MapEachValue[string](values, m)
+}
+
+func (m *mapper) MapAddToSet(value *code.AddToSet) (string, error) {
+	set, err := code.MapValue[string](value.Set, m)
+	if err != nil {
+		return "", err
+	}
+
+	item, err := code.MapValue[string](value.Value, m)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s.insert(%s)", set, item), nil
+}
+
+func (m *mapper) MapArgumentDef(value *code.ArgumentDef) (string, error) {
+	typ, err := code.MapType[string](value.Type, m)
+	if err != nil {
+		return "", err
+	}
+
+	return identifier(value.Name) + " " + typ, nil
+}
+
+func (m *mapper) MapAssignment(value *code.Assignment) (string, error) {
+	from, err := code.MapValue[string](value.From, m)
+	if err != nil {
+		return "", err
+	}
+
+	// Assigning to a key of a map adds the key, rather than looking it up.
+	if lookup, ok := value.To.(*code.Lookup); ok {
+		if _, isMap := code.TypeOf(lookup.From).(*code.Map); isMap {
+			return m.assignKey(lookup, from)
+		}
+	}
+
+	to, err := code.MapValue[string](value.To, m)
+	if err != nil {
+		return "", err
+	}
+
+	return to + " = " + from, nil
+}
+
+// assignKey sets the value of the key of the lookup.
+func (m *mapper) assignKey(lookup *code.Lookup, value string) (string, error) {
+	from, err := code.MapValue[string](lookup.From, m)
+	if err != nil {
+		return "", err
+	}
+
+	key, err := code.MapValue[string](lookup.Key, m)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s.put(%s, %s)", from, key, value), nil
+}
+
+func (m *mapper) MapBinary(value *code.Binary) (string, error) {
+	left, err := code.MapValue[string](value.Left, m)
+	if err != nil {
+		return "", err
+	}
+
+	right, err := code.MapValue[string](value.Right, m)
+	if err != nil {
+		return "", err
+	}
+
+	if value.Overflow != code.OverflowNone {
+		// Constant arithmetic is evaluated when compiling, where overflow and division by zero are errors.
+		if isConstant(value.Left) && isConstant(value.Right) {
+			left, err = m.dynamic(value.Left, left)
+			if err != nil {
+				return "", err
+			}
+		}
+
+		isDivision := value.Operator == code.BinaryOperatorDivide || value.Operator == code.BinaryOperatorModulo
+		if isDivision && isConstant(value.Right) && !isNonZeroLiteral(value.Right) {
+			right, err = m.dynamic(value.Right, right)
+			if err != nil {
+				return "", err
+			}
+		}
+	}
+
+	return "(" + left + " " + value.Operator.Symbol() + " " + right + ")", nil
+}
+
+func (m *mapper) MapBlock(value *code.Block) (string, error) {
+	statements, err := code.MapEachStatement[string](value.Statements, m)
+	if err != nil {
+		return "", err
+	}
+
+	// The variables of the block can only be read by the statements of the block, which have all been mapped.
+	for i, statement := range value.Statements {
+		if declare, ok := statement.(*code.Declare); ok && !m.used[declare] {
+			statements[i] += "\n_ = " + identifier(declare.Name)
+		}
+	}
+
+	if len(statements) == 0 {
+		return "{}", nil
+	}
+
+	return "{\n" + strings.Join(statements, "\n") + "\n}", nil
+}
+
+func (m *mapper) MapBool(value *code.Bool) (string, error) {
+	return "bool", nil
+}
+
+func (m *mapper) MapBreak(value *code.Break) (string, error) {
+	return "break", nil
+}
+
+func (m *mapper) MapBytes(value *code.Bytes) (string, error) {
+	return "[]byte", nil
+}
+
+func (m *mapper) MapCall(value *code.Call) (string, error) {
+	function, ok := value.Function.(*code.FunctionDef)
+	if !ok {
+		return "", fmt.Errorf("unsupported callable %T", value.Function)
+	}
+
+	arguments, err := m.mapValues(value.Arguments)
+	if err != nil {
+		return "", err
+	}
+
+	return m.qualifier(function.Module) + declared(function.Name, function.Visibility) + "(" + strings.Join(arguments, ", ") + ")", nil
+}
+
+func (m *mapper) MapComparison(value *code.Comparison) (string, error) {
+	left, err := code.MapValue[string](value.Left, m)
+	if err != nil {
+		return "", err
+	}
+
+	right, err := code.MapValue[string](value.Right, m)
+	if err != nil {
+		return "", err
+	}
+
+	// Models are pointers, so models with an equal override are compared with it instead of by identity.
+	if model, isModel := code.TypeOf(value.Left).(*code.Model); isModel {
+		modelDef, err := m.lookupModel(model)
+		if err != nil {
+			return "", err
+		}
+
+		if modelDef.EqualOverride == nil {
+			return "(" + left + " " + value.Operator.Symbol() + " " + right + ")", nil
+		}
+
+		m.useHelper(equalHelper)
+
+		if value.Operator == code.ComparisonOperatorNotEqual {
+			return "!agnosticEqual(" + left + ", " + right + ")", nil
+		}
+
+		return "agnosticEqual(" + left + ", " + right + ")", nil
+	}
+
+	return "(" + left + " " + value.Operator.Symbol() + " " + right + ")", nil
+}
+
+func (m *mapper) MapConditional(value *code.Conditional) (string, error) {
+	ifs := make([]string, 0, len(value.Ifs))
+	for _, ifNode := range value.Ifs {
+		result, err := m.MapIf(ifNode)
+		if err != nil {
+			return "", err
+		}
+
+		ifs = append(ifs, result)
+	}
+
+	result := strings.Join(ifs, " else ")
+	if value.Else != nil {
+		elseBlock, err := m.MapBlock(value.Else)
+		if err != nil {
+			return "", err
+		}
+
+		result += " else " + elseBlock
+	}
+
+	return result, nil
+}
+
+func (m *mapper) MapConstantDef(value *code.ConstantDef) (string, error) {
+	constant, err := code.MapConstantValue[string](value.Value, m)
+	if err != nil {
+		return "", err
+	}
+
+	switch value.Value.(type) {
+	case *code.LiteralBool, *code.LiteralFloat64, *code.LiteralInt32, *code.LiteralInt64, *code.LiteralRune, *code.LiteralString, *code.LiteralUint8:
+		// Give the constant an explicit type so that it isn't treated as an untyped number.
+		typ := code.TypeOf(value.Value.(code.Value))
+
+		goType, err := code.MapType[string](typ, m)
+		if err != nil {
+			return "", err
+		}
+
+		return fmt.Sprintf("const %s %s = %s", declared(value.Name, value.Visibility), goType, constant), nil
+	case *code.Nil:
+		goType, err := code.MapType[string](value.Value.(*code.Nil).Type, m)
+		if err != nil {
+			return "", err
+		}
+
+		return fmt.Sprintf("var %s %s", declared(value.Name, value.Visibility), goType), nil
+	default:
+		// Collections can't be Go constants. They're created each time that they're used instead, so that changing the
+		// collection doesn't change the constant.
+		goType, err := code.MapType[string](code.TypeOf(value.Value.(code.Value)), m)
+		if err != nil {
+			return "", err
+		}
+
+		return fmt.Sprintf("func %s() %s {\nreturn %s\n}", declared(value.Name, value.Visibility), goType, constant), nil
+	}
+}
+
+func (m *mapper) MapContinue(value *code.Continue) (string, error) {
+	return "continue", nil
+}
+
+func (m *mapper) MapConversion(value *code.Conversion) (string, error) {
+	operand, err := code.MapValue[string](value.Value, m)
+	if err != nil {
+		return "", err
+	}
+
+	to, err := code.MapType[string](value.To, m)
+	if err != nil {
+		return "", err
+	}
+
+	switch value.Overflow {
+	case code.OverflowSaturate:
+		// Go leaves the result of converting an out of range float to an integer up to the implementation.
+		m.useHelper(saturateHelper)
+		minimum, maximum := code.IntegerRange(value.To)
+		return fmt.Sprintf("agnosticSaturate[%s](%s, %d, %d)", to, operand, minimum, maximum), nil
+	case code.OverflowWrap:
+		if isConstant(value.Value) {
+			// Converting a constant that doesn't fit is an error when compiling.
+			operand, err = m.dynamic(value.Value, operand)
+			if err != nil {
+				return "", err
+			}
+		}
+	}
+
+	return to + "(" + operand + ")", nil
+}
+
+func (m *mapper) MapDeclare(value *code.Declare) (string, error) {
+	typ := code.TypeOf(value.Value)
+
+	goType, err := code.MapType[string](typ, m)
+	if err != nil {
+		return "", err
+	}
+
+	initial, err := code.MapValue[string](value.Value, m)
+	if err != nil {
+		return "", err
+	}
+
+	if _, isNil := value.Value.(*code.Nil); isNil {
+		// A bare nil has no type, so the variable must be declared with one.
+		return fmt.Sprintf("var %s %s", identifier(value.Name), goType), nil
+	}
+
+	if isUntypedInteger(value.Value) {
+		// Untyped integer constants would otherwise become an int.
+		return fmt.Sprintf("var %s %s = %s", identifier(value.Name), goType, initial), nil
+	}
+
+	return identifier(value.Name) + " := " + initial, nil
+}
+
+// shortDeclaration declares a variable with :=, which is the only form of declaration allowed in the clause of a for
+// loop. Values that Go can't infer the type of are converted to it.
+func (m *mapper) shortDeclaration(value *code.Declare) (string, error) {
+	_, isNil := value.Value.(*code.Nil)
+	if !isNil && !isUntypedInteger(value.Value) {
+		return m.MapDeclare(value)
+	}
+
+	goType, err := code.MapType[string](code.TypeOf(value.Value), m)
+	if err != nil {
+		return "", err
+	}
+
+	initial, err := code.MapValue[string](value.Value, m)
+	if err != nil {
+		return "", err
+	}
+
+	if strings.HasPrefix(goType, "*") {
+		goType = "(" + goType + ")"
+	}
+
+	return fmt.Sprintf("%s := %s(%s)", identifier(value.Name), goType, initial), nil
+}
+
+func (m *mapper) MapEmptyList(value *code.EmptyList) (string, error) {
+	item, err := code.MapType[string](value.Type, m)
+	if err != nil {
+		return "", err
+	}
+
+	return "&[]" + item + "{}", nil
+}
+
+func (m *mapper) MapEqualOverride(value *code.EqualOverride) (string, error) {
+	block, err := m.MapBlock(value.Block)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("func (self *%s) Equal(%s *%s) bool %s", declared(m.self.Name, m.self.Visibility), identifier(value.OtherName), declared(m.self.Name, m.self.Visibility), block), nil
+}
+
+func (m *mapper) MapFieldDef(value *code.FieldDef) (string, error) {
+	typ, err := code.MapType[string](value.Type, m)
+	if err != nil {
+		return "", err
+	}
+
+	return declared(value.Name, value.Visibility) + " " + typ, nil
+}
+
+func (m *mapper) MapFloat64(value *code.Float64) (string, error) {
+	return "float64", nil
+}
+
+func (m *mapper) MapFor(value *code.For) (string, error) {
+	var initialization, afterEach string
+	var err error
+	if declare, ok := value.Initialization.(*code.Declare); ok {
+		initialization, err = m.shortDeclaration(declare)
+		if err != nil {
+			return "", err
+		}
+	} else if value.Initialization != nil {
+		initialization, err = code.MapStatement[string](value.Initialization, m)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	condition, err := code.MapValue[string](value.Condition, m)
+	if err != nil {
+		return "", err
+	}
+
+	if value.AfterEach != nil {
+		afterEach, err = code.MapStatement[string](value.AfterEach, m)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	block, err := m.MapBlock(value.Block)
+	if err != nil {
+		return "", err
+	}
+
+	if declare, ok := value.Initialization.(*code.Declare); ok && !m.used[declare] {
+		block = "{\n_ = " + identifier(declare.Name) + "\n" + strings.TrimPrefix(block, "{")
+	}
+
+	return fmt.Sprintf("for %s; %s; %s %s", initialization, condition, afterEach, block), nil
+}
+
+func (m *mapper) MapForEach(value *code.ForEach) (string, error) {
+	iterable, err := code.MapValue[string](value.Iterable, m)
+	if err != nil {
+		return "", err
+	}
+
+	block, err := m.MapBlock(value.Block)
+	if err != nil {
+		return "", err
+	}
+
+	// Loops iterate over a copy of the collection, so that changing the collection in the loop doesn't change which
+	// items are visited. Strings can't be changed.
+	switch code.TypeOf(value.Iterable).(type) {
+	case *code.Bytes:
+		m.useImport("slices")
+		iterable = "slices.Clone(" + iterable + ")"
+	case *code.List:
+		m.useImport("slices")
+		iterable = "slices.Clone(*" + iterable + ")"
+	case *code.Map:
+		iterable += ".keysInOrder()"
+	case *code.Set:
+		iterable += ".items()"
+	}
+
+	if !m.used[value] {
+		return fmt.Sprintf("for range %s %s", iterable, block), nil
+	}
+
+	return fmt.Sprintf("for _, %s := range %s %s", identifier(value.ItemName), iterable, block), nil
+}
+
+func (m *mapper) MapFunctionDef(value *code.FunctionDef) (string, error) {
+	arguments := make([]string, 0, len(value.Arguments))
+	for _, argument := range value.Arguments {
+		result, err := m.MapArgumentDef(argument)
+		if err != nil {
+			return "", err
+		}
+
+		arguments = append(arguments, result)
+	}
+
+	returnType, err := code.MapType[string](value.ReturnType, m)
+	if err != nil {
+		return "", err
+	}
+
+	block, err := m.MapBlock(value.Block)
+	if err != nil {
+		return "", err
+	}
+
+	receiver := ""
+	if m.self != nil {
+		receiver = fmt.Sprintf("(self *%s) ", declared(m.self.Name, m.self.Visibility))
+	}
+
+	return fmt.Sprintf("func %s%s(%s) %s %s", receiver, declared(value.Name, value.Visibility), strings.Join(arguments, ", "), returnType, block), nil
+}
+
+func (m *mapper) MapFunctionRef(value *code.FunctionRef) (string, error) {
+	// Calls refer to the definition of the function once the AST is mapped to code.
+	return "", fmt.Errorf("unresolved reference to function %q", value.Name)
+}
+
+func (m *mapper) MapHashOverride(value *code.HashOverride) (string, error) {
+	block, err := m.MapBlock(value.Block)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("func (self *%s) Hash() int64 %s", declared(m.self.Name, m.self.Visibility), block), nil
+}
+
+func (m *mapper) MapIf(value *code.If) (string, error) {
+	condition, err := code.MapValue[string](value.Condition, m)
+	if err != nil {
+		return "", err
+	}
+
+	block, err := m.MapBlock(value.Block)
+	if err != nil {
+		return "", err
+	}
+
+	return "if " + condition + " " + block, nil
+}
+
+// MapImport returns the import path of the module's package. Go doesn't allow unused imports, so the packages that are
+// imported are instead the ones that the generated code refers to.
+func (m *mapper) MapImport(value *code.Import) (string, error) {
+	return strconv.Quote(value.Module), nil
+}
+
+func (m *mapper) MapInt32(value *code.Int32) (string, error) {
+	return "int32", nil
+}
+
+func (m *mapper) MapInt64(value *code.Int64) (string, error) {
+	return "int64", nil
+}
+
+func (m *mapper) MapKeyValue(value *code.KeyValue) (string, error) {
+	key, err := code.MapValue[string](value.Key, m)
+	if err != nil {
+		return "", err
+	}
+
+	mapValue, err := code.MapValue[string](value.Value, m)
+	if err != nil {
+		return "", err
+	}
+
+	return key + ": " + mapValue, nil
+}
+
+func (m *mapper) MapLength(value *code.Length) (string, error) {
+	of, err := code.MapValue[string](value.Of, m)
+	if err != nil {
+		return "", err
+	}
+
+	ofType := code.TypeOf(value.Of)
+
+	if _, ok := ofType.(*code.String); ok {
+		// The length of a string is its number of runes rather than its number of bytes.
+		m.useImport("unicode/utf8")
+		return "int64(utf8.RuneCountInString(" + of + "))", nil
+	}
+
+	switch ofType.(type) {
+	case *code.List:
+		return "int64(len(*" + of + "))", nil
+	case *code.Map, *code.Set:
+		return "int64(" + of + ".len())", nil
+	}
+
+	return "int64(len(" + of + "))", nil
+}
+
+func (m *mapper) MapList(value *code.List) (string, error) {
+	item, err := code.MapType[string](value.Item, m)
+	if err != nil {
+		return "", err
+	}
+
+	return "*[]" + item, nil
+}
+
+func (m *mapper) MapLiteralBool(value *code.LiteralBool) (string, error) {
+	return strconv.FormatBool(value.Value), nil
+}
+
+func (m *mapper) MapLiteralBytes(value *code.LiteralBytes) (string, error) {
+	values := make([]string, 0, len(value.Value))
+	for _, b := range value.Value {
+		values = append(values, strconv.FormatUint(uint64(b), 10))
+	}
+
+	return "[]byte{" + strings.Join(values, ", ") + "}", nil
+}
+
+func (m *mapper) MapLiteralFloat64(value *code.LiteralFloat64) (string, error) {
+	return languages.FormatFloat(value.Value), nil
+}
+
+func (m *mapper) MapLiteralInt32(value *code.LiteralInt32) (string, error) {
+	return strconv.FormatInt(int64(value.Value), 10), nil
+}
+
+func (m *mapper) MapLiteralInt64(value *code.LiteralInt64) (string, error) {
+	return strconv.FormatInt(value.Value, 10), nil
+}
+
+func (m *mapper) MapLiteralList(value *code.LiteralList) (string, error) {
+	typ := code.TypeOf(value)
+
+	goType, err := code.MapType[string](typ, m)
+	if err != nil {
+		return "", err
+	}
+
+	values, err := m.mapValues(value.Values)
+	if err != nil {
+		return "", err
+	}
+
+	return "&" + strings.TrimPrefix(goType, "*") + "{" + strings.Join(values, ", ") + "}", nil
+}
+
+func (m *mapper) MapLiteralMap(value *code.LiteralMap) (string, error) {
+	goType, err := code.MapType[string](code.TypeOf(value), m)
+	if err != nil {
+		return "", err
+	}
+
+	if len(value.Values) == 0 {
+		return empty(goType), nil
+	}
+
+	typeArguments := strings.TrimPrefix(goType, "*agnosticMap")
+	entries := make([]string, 0, len(value.Values))
+	for _, keyValue := range value.Values {
+		key, err := code.MapValue[string](keyValue.Key, m)
+		if err != nil {
+			return "", err
+		}
+
+		mapValue, err := code.MapValue[string](keyValue.Value, m)
+		if err != nil {
+			return "", err
+		}
+
+		entries = append(entries, "agnosticEntry"+typeArguments+"{"+key+", "+mapValue+"}")
+	}
+
+	return "agnosticMapOf" + typeArguments + "(" + strings.Join(entries, ", ") + ")", nil
+}
+
+func (m *mapper) MapLiteralRune(value *code.LiteralRune) (string, error) {
+	return strconv.QuoteRune(value.Value), nil
+}
+
+func (m *mapper) MapLiteralSet(value *code.LiteralSet) (string, error) {
+	typ := code.TypeOf(value)
+
+	goType, err := code.MapType[string](typ, m)
+	if err != nil {
+		return "", err
+	}
+
+	values, err := m.mapValues(value.Values)
+	if err != nil {
+		return "", err
+	}
+
+	return "agnosticSetOf" + strings.TrimPrefix(goType, "*agnosticSet") + "(" + strings.Join(values, ", ") + ")", nil
+}
+
+func (m *mapper) MapLiteralString(value *code.LiteralString) (string, error) {
+	return strconv.Quote(value.Value), nil
+}
+
+func (m *mapper) MapLiteralUint8(value *code.LiteralUint8) (string, error) {
+	return strconv.FormatUint(uint64(value.Value), 10), nil
+}
+
+func (m *mapper) MapLookup(value *code.Lookup) (string, error) {
+	from, err := code.MapValue[string](value.From, m)
+	if err != nil {
+		return "", err
+	}
+
+	key, err := code.MapValue[string](value.Key, m)
+	if err != nil {
+		return "", err
+	}
+
+	switch code.TypeOf(value.From).(type) {
+	case *code.List:
+		return "(*" + from + ")[" + key + "]", nil
+	case *code.Map:
+		return from + ".get(" + key + ")", nil
+	case *code.String:
+		// Strings are indexed by rune rather than by byte.
+		return "[]rune(" + from + ")[" + key + "]", nil
+	}
+
+	return from + "[" + key + "]", nil
+}
+
+func (m *mapper) MapMap(value *code.Map) (string, error) {
+	key, err := code.MapType[string](value.Key, m)
+	if err != nil {
+		return "", err
+	}
+
+	mapValue, err := code.MapType[string](value.Value, m)
+	if err != nil {
+		return "", err
+	}
+
+	m.useHelper(mapHelper)
+	return "*agnosticMap[" + key + ", " + mapValue + "]", nil
+}
+
+func (m *mapper) MapMethodCall(value *code.MethodCall) (string, error) {
+	receiver, err := code.MapValue[string](value.Receiver, m)
+	if err != nil {
+		return "", err
+	}
+
+	arguments, err := m.mapValues(value.Arguments)
+	if err != nil {
+		return "", err
+	}
+
+	return receiver + "." + declared(value.Method.Name, value.Method.Visibility) + "(" + strings.Join(arguments, ", ") + ")", nil
+}
+
+func (m *mapper) MapModel(value *code.Model) (string, error) {
+	model, err := m.lookupModel(value)
+	if err != nil {
+		return "", err
+	}
+
+	return "*" + m.qualifier(model.Module) + declared(model.Name, model.Visibility), nil
+}
+
+func (m *mapper) MapModelDef(value *code.ModelDef) (string, error) {
+	fields := make([]string, 0, len(value.Fields))
+	for _, field := range value.Fields {
+		result, err := m.MapFieldDef(field)
+		if err != nil {
+			return "", err
+		}
+
+		fields = append(fields, result)
+	}
+
+	declarations := []string{"type " + declared(value.Name, value.Visibility) + " struct {\n" + strings.Join(fields, "\n") + "\n}"}
+
+	if hasConstructor(value) {
+		literal, err := m.literal(value)
+		if err != nil {
+			return "", err
+		}
+
+		declarations = append(declarations, fmt.Sprintf("func %s() *%s {\nreturn %s\n}", constructor(value), declared(value.Name, value.Visibility), literal))
+	}
+
+	m.self = value
+	defer func() { m.self = nil }()
+
+	for _, method := range value.Methods {
+		result, err := m.MapFunctionDef(method)
+		if err != nil {
+			return "", err
+		}
+
+		declarations = append(declarations, result)
+	}
+
+	if value.EqualOverride != nil {
+		result, err := m.MapEqualOverride(value.EqualOverride)
+		if err != nil {
+			return "", err
+		}
+
+		declarations = append(declarations, result)
+	}
+
+	if value.HashOverride != nil {
+		result, err := m.MapHashOverride(value.HashOverride)
+		if err != nil {
+			return "", err
+		}
+
+		declarations = append(declarations, result)
+	}
+
+	// Maps and sets of a model that overrides equals or hash use both, so the missing one keeps its default behavior.
+	name := declared(value.Name, value.Visibility)
+	if value.EqualOverride == nil && value.HashOverride != nil {
+		declarations = append(declarations, fmt.Sprintf("func (self *%s) Equal(other *%s) bool {\nreturn self == other\n}", name, name))
+	}
+	if value.HashOverride == nil && value.EqualOverride != nil {
+		declarations = append(declarations, fmt.Sprintf("func (self *%s) Hash() int64 {\nreturn 0\n}", name))
+	}
+
+	return strings.Join(declarations, "\n\n"), nil
+}
+
+func (m *mapper) MapModule(value *code.Module) (string, error) {
+	var declarations []string
+	for _, constant := range value.Constants {
+		result, err := m.MapConstantDef(constant)
+		if err != nil {
+			return "", fmt.Errorf("constant %q: %w", constant.Name, err)
+		}
+
+		declarations = append(declarations, result)
+	}
+
+	for _, model := range value.Models {
+		result, err := m.MapModelDef(model)
+		if err != nil {
+			return "", fmt.Errorf("model %q: %w", model.Name, err)
+		}
+
+		declarations = append(declarations, result)
+	}
+
+	for _, function := range value.Functions {
+		result, err := m.MapFunctionDef(function)
+		if err != nil {
+			return "", fmt.Errorf("function %q: %w", function.Name, err)
+		}
+
+		declarations = append(declarations, result)
+	}
+
+	helperNames := sortedKeys(m.helpers)
+	for _, name := range helperNames {
+		declarations = append(declarations, strings.TrimSpace(helpers[name]))
+	}
+
+	var sb strings.Builder
+	sb.WriteString("// Code generated by agnostic. DO NOT EDIT.\n\n")
+	sb.WriteString("package " + value.Name + "\n\n")
+
+	importNames := sortedKeys(m.imports)
+	if len(importNames) > 0 {
+		sb.WriteString("import (\n")
+		for _, name := range importNames {
+			sb.WriteString(strconv.Quote(name) + "\n")
+		}
+		sb.WriteString(")\n\n")
+	}
+
+	sb.WriteString(strings.Join(declarations, "\n\n"))
+	sb.WriteString("\n")
+
+	return sb.String(), nil
+}
+
+func (m *mapper) MapNew(value *code.New) (string, error) {
+	model, err := m.lookupModel(value.Model)
+	if err != nil {
+		return "", err
+	}
+
+	if hasConstructor(model) {
+		return m.qualifier(model.Module) + constructor(model) + "()", nil
+	}
+
+	return m.literal(model)
+}
+
+func (m *mapper) MapNil(value *code.Nil) (string, error) {
+	return "nil", nil
+}
+
+func (m *mapper) MapPop(value *code.Pop) (string, error) {
+	list, err := code.MapValue[string](value.List, m)
+	if err != nil {
+		return "", err
+	}
+
+	m.useHelper(popHelper)
+
+	return "agnosticPop(" + list + ")", nil
+}
+
+func (m *mapper) MapProperty(value *code.Property) (string, error) {
+	of, err := code.MapValue[string](value.Of, m)
+	if err != nil {
+		return "", err
+	}
+
+	return of + "." + declared(value.Field.Name, value.Field.Visibility), nil
+}
+
+func (m *mapper) MapPush(value *code.Push) (string, error) {
+	list, err := code.MapValue[string](value.List, m)
+	if err != nil {
+		return "", err
+	}
+
+	item, err := code.MapValue[string](value.Value, m)
+	if err != nil {
+		return "", err
+	}
+
+	m.useHelper(pushHelper)
+
+	return "agnosticPush(" + list + ", " + item + ")", nil
+}
+
+func (m *mapper) MapReturn(value *code.Return) (string, error) {
+	result, err := code.MapValue[string](value.Value, m)
+	if err != nil {
+		return "", err
+	}
+
+	return "return " + result, nil
+}
+
+func (m *mapper) MapRoot(value *code.Root) (string, error) {
+	return "", fmt.Errorf("a root can't be generated as a single Go file")
+}
+
+func (m *mapper) MapRune(value *code.Rune) (string, error) {
+	return "rune", nil
+}
+
+func (m *mapper) MapSelf(value *code.Self) (string, error) {
+	return "self", nil
+}
+
+func (m *mapper) MapSet(value *code.Set) (string, error) {
+	item, err := code.MapType[string](value.Item, m)
+	if err != nil {
+		return "", err
+	}
+
+	m.useHelper(setHelper)
+	return "*agnosticSet[" + item + "]", nil
+}
+
+func (m *mapper) MapSetContains(value *code.SetContains) (string, error) {
+	set, err := code.MapValue[string](value.Set, m)
+	if err != nil {
+		return "", err
+	}
+
+	item, err := code.MapValue[string](value.Value, m)
+	if err != nil {
+		return "", err
+	}
+
+	return set + ".contains(" + item + ")", nil
+}
+
+func (m *mapper) MapString(value *code.String) (string, error) {
+	return "string", nil
+}
+
+func (m *mapper) MapUint8(value *code.Uint8) (string, error) {
+	return "uint8", nil
+}
+
+func (m *mapper) MapUnary(value *code.Unary) (string, error) {
+	operand, err := code.MapValue[string](value.Value, m)
+	if err != nil {
+		return "", err
+	}
+
+	if value.Overflow == code.OverflowWrap && isConstant(value.Value) && !isNegatableLiteral(value.Value) {
+		// Negating the smallest constant of a type overflows, which is an error when compiling.
+		operand, err = m.dynamic(value.Value, operand)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	// Negating a negative literal would otherwise produce the decrement operator.
+	if strings.HasPrefix(operand, "-") {
+		operand = "(" + operand + ")"
+	}
+
+	return "(" + value.Operator.Symbol() + operand + ")", nil
+}
+
+func (m *mapper) MapVariable(value *code.Variable) (string, error) {
+	if constant, ok := value.Definition.(*code.ConstantDef); ok {
+		name := m.qualifier(constant.Module) + declared(constant.Name, constant.Visibility)
+		if isCollection(code.TypeOf(constant.Value.(code.Value))) {
+			// Constant collections are created by a function.
+			return name + "()", nil
+		}
+
+		return name, nil
+	}
+
+	// Assigning to the variable doesn't count as reading it.
+	if value.Usage != code.UsageAssign {
+		m.used[value.Definition] = true
+	}
+
+	return identifier(value.Name), nil
+}
+
+func (m *mapper) MapVoid(value *code.Void) (string, error) {
+	// Go functions without a result simply omit the return type.
+	return "", nil
+}
+
+// literal returns a composite literal that creates a new instance of the model.
+func (m *mapper) literal(model *code.ModelDef) (string, error) {
+	// Lists, maps, and sets are pointers, so their fields must be initialized up front.
+	var fields []string
+	for _, field := range model.Fields {
+		switch field.Type.(type) {
+		case *code.List, *code.Map, *code.Set:
+			typ, err := code.MapType[string](field.Type, m)
+			if err != nil {
+				return "", err
+			}
+
+			fields = append(fields, declared(field.Name, field.Visibility)+": "+empty(typ))
+		}
+	}
+
+	return "&" + m.qualifier(model.Module) + declared(model.Name, model.Visibility) + "{" + strings.Join(fields, ", ") + "}", nil
+}
+
+// empty returns an empty list, map, or set of the Go type, which is a pointer.
+func empty(goType string) string {
+	return "&" + strings.TrimPrefix(goType, "*") + "{}"
+}
+
+// hasConstructor returns whether new instances of the model are created by a constructor function, since the packages of
+// other modules can't initialize the private list, map, and set fields of the model themselves.
+func hasConstructor(model *code.ModelDef) bool {
+	return slices.ContainsFunc(model.Fields, func(field *code.FieldDef) bool {
+		switch field.Type.(type) {
+		case *code.List, *code.Map, *code.Set:
+			return field.Visibility == code.VisibilityPrivate
+		default:
+			return false
+		}
+	})
+}
+
+// constructor returns the name of the function that creates new instances of the model.
+func constructor(model *code.ModelDef) string {
+	return declared("new"+declared(model.Name, code.VisibilityPublic), model.Visibility)
+}
+
+// dynamic hides a constant from the compiler so that the expression using it is evaluated at runtime instead.
+func (m *mapper) dynamic(value code.Value, result string) (string, error) {
+	typ, err := code.MapType[string](code.TypeOf(value), m)
+	if err != nil {
+		return "", err
+	}
+
+	m.useHelper(dynamicHelper)
+	return "agnosticDynamic[" + typ + "](" + result + ")", nil
+}
+
+func sortedKeys(set map[string]struct{}) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+	return keys
+}
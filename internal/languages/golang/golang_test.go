@@ -0,0 +1,73 @@
+package golang
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path"
+	"testing"
+
+	"github.com/JosephNaberhaus/agnostic/internal/languages"
+	"github.com/JosephNaberhaus/agnostic/internal/languages/languagetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate(t *testing.T) {
+	languagetest.RunGolden(t, Generate, typeCheck)
+}
+
+// typeCheck verifies that each generated file is a package that compiles. The packages of other modules are type
+// checked as they're imported.
+func typeCheck(t *testing.T, files []languages.File) {
+	fset := token.NewFileSet()
+	modules := &moduleImporter{
+		fset:     fset,
+		files:    map[string]*ast.File{},
+		checked:  map[string]*types.Package{},
+		fallback: importer.ForCompiler(fset, "source", nil),
+	}
+
+	for _, file := range files {
+		parsed, err := parser.ParseFile(fset, file.Path, file.Contents, parser.ParseComments)
+		require.NoError(t, err)
+
+		modules.files[parsed.Name.Name] = parsed
+	}
+
+	for _, file := range files {
+		_, err := modules.Import(path.Dir(file.Path))
+		assert.NoError(t, err, file.Path)
+	}
+}
+
+// moduleImporter imports the generated package of each module by the module's name, which is its import path. Any
+// other package is imported from source.
+type moduleImporter struct {
+	fset     *token.FileSet
+	files    map[string]*ast.File
+	checked  map[string]*types.Package
+	fallback types.Importer
+}
+
+func (i *moduleImporter) Import(importPath string) (*types.Package, error) {
+	if pkg, ok := i.checked[importPath]; ok {
+		return pkg, nil
+	}
+
+	file, ok := i.files[importPath]
+	if !ok {
+		return i.fallback.Import(importPath)
+	}
+
+	config := types.Config{Importer: i}
+	pkg, err := config.Check(importPath, i.fset, []*ast.File{file}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	i.checked[importPath] = pkg
+	return pkg, nil
+}
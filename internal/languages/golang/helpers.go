@@ -0,0 +1,251 @@
+package golang
+
+import (
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/JosephNaberhaus/agnostic/code"
+)
+
+const (
+	popHelper      = "pop"
+	pushHelper     = "push"
+	dynamicHelper  = "dynamic"
+	saturateHelper = "saturate"
+	equalHelper    = "equal"
+	tableHelper    = "table"
+	entryHelper    = "entry"
+	mapHelper      = "map"
+	setHelper      = "set"
+)
+
+// helperDependencies are the other helpers that each helper uses.
+var helperDependencies = map[string][]string{
+	mapHelper: {tableHelper, entryHelper},
+	setHelper: {tableHelper},
+}
+
+// helpers are small generic functions that are added to the generated file when it uses an operation that Go doesn't
+// have a builtin for.
+var helpers = map[string]string{
+	popHelper: `
+// agnosticPop removes the last item from the list and returns it.
+func agnosticPop[T any](list *[]T) T {
+	item := (*list)[len(*list)-1]
+	*list = (*list)[:len(*list)-1]
+	return item
+}
+`,
+	pushHelper: `
+// agnosticPush adds the item to the end of the list.
+func agnosticPush[T any](list *[]T, item T) {
+	*list = append(*list, item)
+}
+`,
+	dynamicHelper: `
+// agnosticDynamic returns the value unchanged. Passing a constant through it stops the expression that uses the
+// constant from being evaluated when compiling, where overflow and division by zero are errors.
+func agnosticDynamic[T any](value T) T {
+	return value
+}
+`,
+	equalHelper: `
+// agnosticEqual compares two models with their equal override. A nil model is only equal to another nil model.
+func agnosticEqual[T interface {
+	comparable
+	Equal(other T) bool
+}](a, b T) bool {
+	var zero T
+	if a == zero || b == zero {
+		return a == b
+	}
+	return a.Equal(b)
+}
+`,
+	tableHelper: `
+// agnosticTable is the hash table of a map or set. The keys are kept in the order that they were first added, since Go
+// maps are iterated over in a random order. Models that override equals or hash are grouped into buckets by their hash
+// and compared with their equal override, while every other key is its own bucket.
+type agnosticTable[K comparable] struct {
+	keys    []K
+	buckets map[any][]int
+}
+
+func (t *agnosticTable[K]) bucket(key K) any {
+	var zero K
+	if hashed, ok := any(key).(interface{ Hash() int64 }); ok && key != zero {
+		return hashed.Hash()
+	}
+	return key
+}
+
+// equal compares the keys with their equal override if they have one. A nil model is only equal to another nil model.
+func (t *agnosticTable[K]) equal(a, b K) bool {
+	var zero K
+	if overridden, ok := any(a).(interface{ Equal(other K) bool }); ok && a != zero && b != zero {
+		return overridden.Equal(b)
+	}
+	return a == b
+}
+
+// find returns the index of the key that is equal to the given one, or -1 if there isn't one.
+func (t *agnosticTable[K]) find(key K) int {
+	for _, index := range t.buckets[t.bucket(key)] {
+		if t.equal(t.keys[index], key) {
+			return index
+		}
+	}
+	return -1
+}
+
+// insert adds the key if there isn't already an equal one. It returns the index of the key and whether it was added.
+func (t *agnosticTable[K]) insert(key K) (int, bool) {
+	if index := t.find(key); index >= 0 {
+		return index, false
+	}
+	if t.buckets == nil {
+		t.buckets = map[any][]int{}
+	}
+	bucket := t.bucket(key)
+	t.buckets[bucket] = append(t.buckets[bucket], len(t.keys))
+	t.keys = append(t.keys, key)
+	return len(t.keys) - 1, true
+}
+
+func (t *agnosticTable[K]) len() int {
+	return len(t.keys)
+}
+`,
+	entryHelper: `
+// agnosticEntry is a key and its value.
+type agnosticEntry[K comparable, V any] struct {
+	key   K
+	value V
+}
+`,
+	mapHelper: `
+// agnosticMap is a map whose keys are kept in the order that they were first added.
+type agnosticMap[K comparable, V any] struct {
+	agnosticTable[K]
+	values []V
+}
+
+func agnosticMapOf[K comparable, V any](entries ...agnosticEntry[K, V]) *agnosticMap[K, V] {
+	result := &agnosticMap[K, V]{}
+	for _, entry := range entries {
+		result.put(entry.key, entry.value)
+	}
+	return result
+}
+
+// get returns the value of the key, and panics if the map doesn't have it.
+func (m *agnosticMap[K, V]) get(key K) V {
+	index := m.find(key)
+	if index < 0 {
+		panic("missing key")
+	}
+	return m.values[index]
+}
+
+func (m *agnosticMap[K, V]) put(key K, value V) {
+	index, added := m.insert(key)
+	if added {
+		m.values = append(m.values, value)
+	} else {
+		m.values[index] = value
+	}
+}
+
+// keysInOrder returns a copy of the keys in the order that they were first added.
+func (m *agnosticMap[K, V]) keysInOrder() []K {
+	return append([]K(nil), m.keys...)
+}
+`,
+	setHelper: `
+// agnosticSet is a set whose items are kept in the order that they were first added.
+type agnosticSet[T comparable] struct {
+	agnosticTable[T]
+}
+
+func agnosticSetOf[T comparable](items ...T) *agnosticSet[T] {
+	result := &agnosticSet[T]{}
+	for _, item := range items {
+		result.insert(item)
+	}
+	return result
+}
+
+func (s *agnosticSet[T]) contains(item T) bool {
+	return s.find(item) >= 0
+}
+
+// items returns a copy of the items in the order that they were first added.
+func (s *agnosticSet[T]) items() []T {
+	return append([]T(nil), s.keys...)
+}
+`,
+	saturateHelper: `
+// agnosticSaturate converts the float to an integer by truncating it toward zero and clamping it to the range of the
+// integer. NaN becomes zero.
+func agnosticSaturate[T int32 | int64 | uint8](value float64, minimum, maximum T) T {
+	switch {
+	case value != value:
+		return 0
+	case value <= float64(minimum):
+		return minimum
+	case value >= float64(maximum):
+		return maximum
+	default:
+		return T(value)
+	}
+}
+`,
+}
+
+var keywords = map[string]struct{}{
+	"break":       {},
+	"case":        {},
+	"chan":        {},
+	"const":       {},
+	"continue":    {},
+	"default":     {},
+	"defer":       {},
+	"else":        {},
+	"fallthrough": {},
+	"for":         {},
+	"func":        {},
+	"go":          {},
+	"goto":        {},
+	"if":          {},
+	"import":      {},
+	"interface":   {},
+	"map":         {},
+	"package":     {},
+	"range":       {},
+	"return":      {},
+	"select":      {},
+	"struct":      {},
+	"switch":      {},
+	"type":        {},
+	"var":         {},
+}
+
+// identifier converts an Agnostic name into a valid Go identifier.
+func identifier(name string) string {
+	if _, isKeyword := keywords[name]; isKeyword {
+		return name + "_"
+	}
+
+	return name
+}
+
+// declared converts the name of a declaration into a Go identifier. Only public declarations are exported, so that
+// the packages of other modules can refer to them.
+func declared(name string, visibility code.Visibility) string {
+	first, size := utf8.DecodeRuneInString(name)
+	if visibility == code.VisibilityPrivate {
+		return identifier(string(unicode.ToLower(first)) + name[size:])
+	}
+
+	return string(unicode.ToUpper(first)) + name[size:]
+}
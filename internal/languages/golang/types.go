@@ -0,0 +1,115 @@
+package golang
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/JosephNaberhaus/agnostic/code"
+)
+
+// lookupModel finds the definition of a model in the module that is being generated or in one of its imports.
+func (m *mapper) lookupModel(model *code.Model) (*code.ModelDef, error) {
+	modules := []*code.Module{m.module}
+	for _, i := range m.module.Imports {
+		modules = append(modules, i.Definition)
+	}
+
+	for _, module := range modules {
+		if module.Name != model.Module {
+			continue
+		}
+
+		for _, modelDef := range module.Models {
+			if modelDef.Name == model.Name {
+				return modelDef, nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("undefined model %q", model.Name)
+}
+
+// isCollection returns whether values of the type are bytes, lists, maps, or sets, which are references.
+func isCollection(typ code.Type) bool {
+	switch typ.(type) {
+	case *code.Bytes, *code.List, *code.Map, *code.Set:
+		return true
+	default:
+		return false
+	}
+}
+
+// qualifier returns the prefix of the names of declarations of the module. Declarations of another module are
+// qualified by the name of its package, which is then imported.
+func (m *mapper) qualifier(module *code.Module) string {
+	if module == nil || module == m.module {
+		return ""
+	}
+
+	m.useImport(module.Name)
+	return identifier(module.Name) + "."
+}
+
+// isUntypedInteger returns whether Go treats the value as an untyped integer constant. That's the case for integer
+// literals and for arithmetic that only involves integer literals.
+func isUntypedInteger(value code.Value) bool {
+	switch value := value.(type) {
+	case *code.LiteralInt32, *code.LiteralInt64, *code.LiteralUint8:
+		return true
+	case *code.Binary:
+		return isUntypedInteger(value.Left) && isUntypedInteger(value.Right)
+	case *code.Unary:
+		return isUntypedInteger(value.Value)
+	default:
+		return false
+	}
+}
+
+// isConstant returns whether Go evaluates the numeric value when compiling. That's the case for literals, constants, and
+// for expressions on them that weren't hidden from the compiler.
+func isConstant(value code.Value) bool {
+	switch value := value.(type) {
+	case *code.LiteralFloat64, *code.LiteralInt32, *code.LiteralInt64, *code.LiteralUint8:
+		return true
+	case *code.Variable:
+		constant, ok := value.Definition.(*code.ConstantDef)
+		return ok && isConstant(constant.Value.(code.Value))
+	case *code.Unary:
+		return isConstant(value.Value) && (value.Overflow != code.OverflowWrap || isNegatableLiteral(value.Value))
+	case *code.Conversion:
+		return isConstant(value.Value) && (value.Overflow == code.OverflowNone || value.Overflow == code.OverflowRound)
+	default:
+		// Arithmetic on two constants is always hidden from the compiler.
+		return false
+	}
+}
+
+// isNonZeroLiteral returns whether the value is a number literal other than zero, which makes it safe to divide by.
+func isNonZeroLiteral(value code.Value) bool {
+	switch value := value.(type) {
+	case *code.LiteralFloat64:
+		return value.Value != 0
+	case *code.LiteralInt32:
+		return value.Value != 0
+	case *code.LiteralInt64:
+		return value.Value != 0
+	case *code.LiteralUint8:
+		return value.Value != 0
+	default:
+		return false
+	}
+}
+
+// isNegatableLiteral returns whether the value is a number literal that can be negated without overflowing.
+func isNegatableLiteral(value code.Value) bool {
+	switch value := value.(type) {
+	case *code.LiteralFloat64:
+		return true
+	case *code.LiteralInt32:
+		return value.Value != math.MinInt32
+	case *code.LiteralInt64:
+		return value.Value != math.MinInt64
+	default:
+		return false
+	}
+}
@@ -0,0 +1,15 @@
+package languages
+
+import "strings"
+
+// Indent adds the prefix to the start of every non-empty line of the text.
+func Indent(text, prefix string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = prefix + line
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
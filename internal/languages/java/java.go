@@ -0,0 +1,1086 @@
+package java
+
+import (
+	_ "embed"
+	"fmt"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/JosephNaberhaus/agnostic/code"
+	"github.com/JosephNaberhaus/agnostic/internal/languages"
+)
+
+//go:embed runtime/agnostic/Agnostic.java
+var runtime string
+
+const (
+	runtimePath  = "agnostic/Agnostic.java"
+	runtimeClass = "agnostic.Agnostic"
+	// The name that the runtime class is referred to by.
+	runtimeName = "Agnostic"
+
+	indent = "    "
+)
+
+var _ code.NodeMapper[string] = &mapper{}
+
+// Generate converts each module into a Java package. Each model becomes a class in the package and the module's
+// functions and constants become the static members of a class named after the module. Private declarations are
+// package-private, so only the rest of their module can use them.
+func Generate(root *code.Root) ([]languages.File, error) {
+	var files []languages.File
+	for _, module := range root.Modules {
+		moduleFiles, err := generateModule(module)
+		if err != nil {
+			return nil, fmt.Errorf("module %q: %w", module.Name, err)
+		}
+
+		files = append(files, moduleFiles...)
+	}
+
+	files = append(files, languages.File{
+		Path:     runtimePath,
+		Contents: runtime,
+	})
+
+	return files, nil
+}
+
+func generateModule(module *code.Module) ([]languages.File, error) {
+	m := &mapper{
+		module:      module,
+		moduleClass: title(module.Name),
+	}
+
+	var files []languages.File
+	for _, model := range module.Models {
+		if model.Name == m.moduleClass {
+			return nil, fmt.Errorf("model %q has the same name as the class of its module", model.Name)
+		}
+
+		source, err := m.MapModelDef(model)
+		if err != nil {
+			return nil, fmt.Errorf("model %q: %w", model.Name, err)
+		}
+
+		files = append(files, languages.File{
+			Path:     path.Join(module.Name, model.Name+".java"),
+			Contents: source,
+		})
+	}
+
+	source, err := m.MapModule(module)
+	if err != nil {
+		return nil, err
+	}
+
+	files = append(files, languages.File{
+		Path:     path.Join(module.Name, m.moduleClass+".java"),
+		Contents: source,
+	})
+
+	return files, nil
+}
+
+// mapper converts a single module into Java. Each file is generated separately, so the state that is specific to a file
+// is reset by the method that generates it.
+type mapper struct {
+	module *code.Module
+	// The name of the class that holds the module's functions and constants.
+	moduleClass string
+	// The model whose methods are currently being generated. Nil when outside a model.
+	self *code.ModelDef
+	// The classes imported by the file currently being generated.
+	imports map[string]struct{}
+}
+
+func (m *mapper) useImport(class string) {
+	m.imports[class] = struct{}{}
+}
+
+// fromRuntime returns a reference to a static method of the runtime.
+func (m *mapper) fromRuntime(name string) string {
+	m.useImport(runtimeClass)
+	return runtimeName + "." + name
+}
+
+// file wraps the body of a file with its header and imports.
+func (m *mapper) file(body string, staticImports ...string) string {
+	var sb strings.Builder
+	sb.WriteString("// Code generated by agnostic. DO NOT EDIT.\n\n")
+	sb.WriteString("package " + m.module.Name + ";\n\n")
+
+	imports := make([]string, 0, len(m.imports))
+	for class := range m.imports {
+		imports = append(imports, class)
+	}
+	slices.Sort(imports)
+
+	for _, class := range imports {
+		sb.WriteString("import " + class + ";\n")
+	}
+
+	for _, member := range staticImports {
+		sb.WriteString("import static " + member + ";\n")
+	}
+
+	if len(imports) > 0 || len(staticImports) > 0 {
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString(body + "\n")
+
+	return sb.String()
+}
+
+// member returns a reference to a static member of the class of the module. Members of another module are referred to
+// by their fully qualified name so that they can't clash with the classes of the module being generated.
+func (m *mapper) member(module *code.Module, name string) string {
+	if module == m.module {
+		return identifier(name)
+	}
+
+	return module.Name + "." + title(module.Name) + "." + identifier(name)
+}
+
+func (m *mapper) mapValues(values []code.Value) ([]string, error) {
+	return code.MapEachValue[string](values, m)
+}
+
+// boxed converts a type into the form that can be used as a type argument.
+func (m *mapper) boxed(typ code.Type) (string, error) {
+	result, err := code.MapType[string](typ, m)
+	if err != nil {
+		return "", err
+	}
+
+	switch result {
+	case "boolean":
+		return "Boolean", nil
+	case "double":
+		return "Double", nil
+	case "int":
+		return "Integer", nil
+	case "long":
+		return "Long", nil
+	default:
+		return result, nil
+	}
+}
+
+// statements converts the statements of a block.
+func (m *mapper) statements(block *code.Block) ([]string, error) {
+	statements := make([]string, 0, len(block.Statements))
+	for _, statement := range block.Statements {
+		result, err := code.MapStatement[string](statement, m)
+		if err != nil {
+			return nil, err
+		}
+
+		switch statement.(type) {
+		case *code.Conditional, *code.For, *code.ForEach:
+		default:
+			result += ";"
+		}
+
+		statements = append(statements, result)
+	}
+
+	return statements, nil
+}
+
+// braces wraps lines in an indented pair of braces.
+func braces(lines []string) string {
+	if len(lines) == 0 {
+		return "{}"
+	}
+
+	return "{\n" + languages.Indent(strings.Join(lines, "\n"), indent) + "\n}"
+}
+
+// zeroValue returns the value that a variable or field of the given type starts out as.
+func (m *mapper) zeroValue(typ code.Type) (string, error) {
+	switch typ := typ.(type) {
+	case *code.Bool:
+		return "false", nil
+	case *code.Bytes:
+		return "new byte[0]", nil
+	case *code.Float64:
+		return "0.0", nil
+	case *code.Int32, *code.Uint8:
+		return "0", nil
+	case *code.Int64:
+		return "0L", nil
+	case *code.List:
+		item, err := m.boxed(typ.Item)
+		if err != nil {
+			return "", err
+		}
+
+		return m.fromRuntime("<"+item+">listOf") + "()", nil
+	case *code.Map:
+		key, err := m.boxed(typ.Key)
+		if err != nil {
+			return "", err
+		}
+
+		value, err := m.boxed(typ.Value)
+		if err != nil {
+			return "", err
+		}
+
+		return m.fromRuntime("<"+key+", "+value+">mapOf") + "()", nil
+	case *code.Model:
+		return "null", nil
+	case *code.Rune:
+		return "0", nil
+	case *code.Set:
+		item, err := m.boxed(typ.Item)
+		if err != nil {
+			return "", err
+		}
+
+		return m.fromRuntime("<"+item+">setOf") + "()", nil
+	case *code.String:
+		return quote(""), nil
+	default:
+		return "", fmt.Errorf("%T has no zero value", typ)
+	}
+}
+
+// isBoxed returns whether the value is produced by a generic method of the runtime, which returns primitives in their
+// boxed form.
+// isCollection returns whether values of the type are bytes, lists, maps, or sets, which are references.
+func isCollection(typ code.Type) bool {
+	switch typ.(type) {
+	case *code.Bytes, *code.List, *code.Map, *code.Set:
+		return true
+	default:
+		return false
+	}
+}
+
+func isBoxed(value code.Value) bool {
+	switch value := value.(type) {
+	case *code.Lookup:
+		switch code.TypeOf(value.From).(type) {
+		case *code.Bytes, *code.String:
+			return false
+		default:
+			return true
+		}
+	case *code.Pop:
+		return true
+	default:
+		return false
+	}
+}
+
+func (m *mapper) MapAddToSet(value *code.AddToSet) (string, error) {
+	set, err := code.MapValue[string](value.Set, m)
+	if err != nil {
+		return "", err
+	}
+
+	item, err := code.MapValue[string](value.Value, m)
+	if err != nil {
+		return "", err
+	}
+
+	return set + ".add(" + item + ")", nil
+}
+
+func (m *mapper) MapArgumentDef(value *code.ArgumentDef) (string, error) {
+	typ, err := code.MapType[string](value.Type, m)
+	if err != nil {
+		return "", err
+	}
+
+	return typ + " " + identifier(value.Name), nil
+}
+
+func (m *mapper) MapAssignment(value *code.Assignment) (string, error) {
+	from, err := code.MapValue[string](value.From, m)
+	if err != nil {
+		return "", err
+	}
+
+	if lookup, ok := value.To.(*code.Lookup); ok {
+		into, err := code.MapValue[string](lookup.From, m)
+		if err != nil {
+			return "", err
+		}
+
+		key, err := code.MapValue[string](lookup.Key, m)
+		if err != nil {
+			return "", err
+		}
+
+		return m.fromRuntime("store") + "(" + into + ", " + key + ", " + from + ")", nil
+	}
+
+	to, err := code.MapValue[string](value.To, m)
+	if err != nil {
+		return "", err
+	}
+
+	return to + " = " + from, nil
+}
+
+func (m *mapper) MapBinary(value *code.Binary) (string, error) {
+	left, err := code.MapValue[string](value.Left, m)
+	if err != nil {
+		return "", err
+	}
+
+	right, err := code.MapValue[string](value.Right, m)
+	if err != nil {
+		return "", err
+	}
+
+	result := "(" + left + " " + value.Operator.Symbol() + " " + right + ")"
+	if _, isUint8 := value.Type.(*code.Uint8); isUint8 {
+		// Java's int and long arithmetic already wraps, but uint8 is represented as an int.
+		return "(" + result + " & 0xFF)", nil
+	}
+
+	return result, nil
+}
+
+func (m *mapper) MapBlock(value *code.Block) (string, error) {
+	statements, err := m.statements(value)
+	if err != nil {
+		return "", err
+	}
+
+	return braces(statements), nil
+}
+
+func (m *mapper) MapBool(value *code.Bool) (string, error) {
+	return "boolean", nil
+}
+
+func (m *mapper) MapBreak(value *code.Break) (string, error) {
+	return "break", nil
+}
+
+func (m *mapper) MapBytes(value *code.Bytes) (string, error) {
+	return "byte[]", nil
+}
+
+func (m *mapper) MapCall(value *code.Call) (string, error) {
+	function, ok := value.Function.(*code.FunctionDef)
+	if !ok {
+		return "", fmt.Errorf("unsupported callable %T", value.Function)
+	}
+
+	arguments, err := m.mapValues(value.Arguments)
+	if err != nil {
+		return "", err
+	}
+
+	// Models statically import the members of the module class, so a call within the module never needs to be
+	// qualified.
+	return m.member(function.Module, function.Name) + "(" + strings.Join(arguments, ", ") + ")", nil
+}
+
+func (m *mapper) MapComparison(value *code.Comparison) (string, error) {
+	left, err := code.MapValue[string](value.Left, m)
+	if err != nil {
+		return "", err
+	}
+
+	right, err := code.MapValue[string](value.Right, m)
+	if err != nil {
+		return "", err
+	}
+
+	switch typ := code.TypeOf(value.Left).(type) {
+	case *code.Model, *code.String:
+		// Objects are compared by identity with ==, so they're compared with equals instead.
+		m.useImport("java.util.Objects")
+		equals := "Objects.equals(" + left + ", " + right + ")"
+		if value.Operator == code.ComparisonOperatorNotEqual {
+			return "!" + equals, nil
+		}
+
+		return equals, nil
+	default:
+		if !value.Operator.IsOrdering() && isBoxed(value.Left) && isBoxed(value.Right) {
+			// At least one side must be a primitive for == to compare the values rather than the boxes.
+			primitive, err := code.MapType[string](typ, m)
+			if err != nil {
+				return "", err
+			}
+
+			left = "(" + primitive + ") " + left
+		}
+
+		return "(" + left + " " + value.Operator.Symbol() + " " + right + ")", nil
+	}
+}
+
+func (m *mapper) MapConditional(value *code.Conditional) (string, error) {
+	ifs := make([]string, 0, len(value.Ifs))
+	for _, ifNode := range value.Ifs {
+		result, err := m.MapIf(ifNode)
+		if err != nil {
+			return "", err
+		}
+
+		ifs = append(ifs, result)
+	}
+
+	result := strings.Join(ifs, " else ")
+	if value.Else != nil {
+		elseBlock, err := m.MapBlock(value.Else)
+		if err != nil {
+			return "", err
+		}
+
+		result += " else " + elseBlock
+	}
+
+	return result, nil
+}
+
+func (m *mapper) MapConstantDef(value *code.ConstantDef) (string, error) {
+	// Fields can't be declared with var, so the type of each constant has to be written out.
+	javaType, err := code.MapType[string](code.TypeOf(value.Value.(code.Value)), m)
+	if err != nil {
+		return "", err
+	}
+
+	constant, err := code.MapConstantValue[string](value.Value, m)
+	if err != nil {
+		return "", err
+	}
+
+	if isCollection(code.TypeOf(value.Value.(code.Value))) {
+		// The collection is created each time that it's used instead of shared, so that changing the collection doesn't
+		// change the constant.
+		return access(value.Visibility) + "static " + javaType + " " + identifier(value.Name) + "() {\n" + indent + "return " + constant + ";\n}", nil
+	}
+
+	return access(value.Visibility) + "static final " + javaType + " " + identifier(value.Name) + " = " + constant + ";", nil
+}
+
+func (m *mapper) MapContinue(value *code.Continue) (string, error) {
+	return "continue", nil
+}
+
+func (m *mapper) MapConversion(value *code.Conversion) (string, error) {
+	operand, err := code.MapValue[string](value.Value, m)
+	if err != nil {
+		return "", err
+	}
+
+	from := code.TypeOf(value.Value)
+	if isBoxed(value.Value) {
+		// A box can only be cast to its own primitive type.
+		primitive, err := code.MapType[string](from, m)
+		if err != nil {
+			return "", err
+		}
+
+		operand = "(" + primitive + ") " + operand
+	}
+
+	switch to := value.To.(type) {
+	case *code.Float64:
+		return "((double) " + operand + ")", nil
+	case *code.Int32:
+		// Casting a double to an int already truncates and saturates.
+		return "((int) " + operand + ")", nil
+	case *code.Int64:
+		return "((long) " + operand + ")", nil
+	case *code.Uint8:
+		switch from.(type) {
+		case *code.Float64:
+			return "((int) Math.max(0.0, Math.min(255.0, " + operand + ")))", nil
+		case *code.Int64:
+			return "((int) (" + operand + " & 0xFFL))", nil
+		default:
+			return "(" + operand + " & 0xFF)", nil
+		}
+	default:
+		return "", fmt.Errorf("cannot convert to %T", to)
+	}
+}
+
+func (m *mapper) MapDeclare(value *code.Declare) (string, error) {
+	initial, err := code.MapValue[string](value.Value, m)
+	if err != nil {
+		return "", err
+	}
+
+	if nilValue, ok := value.Value.(*code.Nil); ok {
+		// A variable can't be declared with var if it starts out as null.
+		typ, err := code.MapType[string](nilValue.Type, m)
+		if err != nil {
+			return "", err
+		}
+
+		return typ + " " + identifier(value.Name) + " = " + initial, nil
+	}
+
+	return "var " + identifier(value.Name) + " = " + initial, nil
+}
+
+func (m *mapper) MapEmptyList(value *code.EmptyList) (string, error) {
+	return m.zeroValue(&code.List{Item: value.Type})
+}
+
+func (m *mapper) MapEqualOverride(value *code.EqualOverride) (string, error) {
+	statements, err := m.statements(value.Block)
+	if err != nil {
+		return "", err
+	}
+
+	other := identifier(value.OtherName)
+	parameter := other + "Object"
+	check := fmt.Sprintf("if (!(%s instanceof %s %s)) {\n%sreturn false;\n}", parameter, m.self.Name, other, indent)
+
+	return "@Override\npublic boolean equals(Object " + parameter + ") " + braces(append([]string{check}, statements...)), nil
+}
+
+func (m *mapper) MapFieldDef(value *code.FieldDef) (string, error) {
+	typ, err := code.MapType[string](value.Type, m)
+	if err != nil {
+		return "", err
+	}
+
+	zero, err := m.zeroValue(value.Type)
+	if err != nil {
+		return "", err
+	}
+
+	return access(value.Visibility) + typ + " " + identifier(value.Name) + " = " + zero + ";", nil
+}
+
+func (m *mapper) MapFloat64(value *code.Float64) (string, error) {
+	return "double", nil
+}
+
+func (m *mapper) MapFor(value *code.For) (string, error) {
+	var initialization, afterEach string
+	var err error
+	if value.Initialization != nil {
+		initialization, err = code.MapStatement[string](value.Initialization, m)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	condition, err := code.MapValue[string](value.Condition, m)
+	if err != nil {
+		return "", err
+	}
+
+	if value.AfterEach != nil {
+		afterEach, err = code.MapStatement[string](value.AfterEach, m)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	block, err := m.MapBlock(value.Block)
+	if err != nil {
+		return "", err
+	}
+
+	return "for (" + initialization + "; " + condition + "; " + afterEach + ") " + block, nil
+}
+
+func (m *mapper) MapForEach(value *code.ForEach) (string, error) {
+	iterable, err := code.MapValue[string](value.Iterable, m)
+	if err != nil {
+		return "", err
+	}
+
+	block, err := m.MapBlock(value.Block)
+	if err != nil {
+		return "", err
+	}
+
+	return "for (var " + identifier(value.ItemName) + " : " + m.fromRuntime("items") + "(" + iterable + ")) " + block, nil
+}
+
+func (m *mapper) MapFunctionDef(value *code.FunctionDef) (string, error) {
+	arguments := make([]string, 0, len(value.Arguments))
+	for _, argument := range value.Arguments {
+		result, err := m.MapArgumentDef(argument)
+		if err != nil {
+			return "", err
+		}
+
+		arguments = append(arguments, result)
+	}
+
+	returnType, err := code.MapType[string](value.ReturnType, m)
+	if err != nil {
+		return "", err
+	}
+
+	block, err := m.MapBlock(value.Block)
+	if err != nil {
+		return "", err
+	}
+
+	modifiers := access(value.Visibility)
+	if m.self == nil {
+		modifiers += "static "
+	}
+
+	return modifiers + returnType + " " + identifier(value.Name) + "(" + strings.Join(arguments, ", ") + ") " + block, nil
+}
+
+func (m *mapper) MapFunctionRef(value *code.FunctionRef) (string, error) {
+	// Calls refer to the definition of the function once the AST is mapped to code.
+	return "", fmt.Errorf("unresolved reference to function %q", value.Name)
+}
+
+func (m *mapper) MapHashOverride(value *code.HashOverride) (string, error) {
+	block, err := m.MapBlock(value.Block)
+	if err != nil {
+		return "", err
+	}
+
+	// The hash override produces a long, which needs to be folded into the int that Java expects.
+	return "@Override\npublic int hashCode() {\n" + indent + "return Long.hashCode(agnosticHash());\n}\n\nprivate long agnosticHash() " + block, nil
+}
+
+func (m *mapper) MapIf(value *code.If) (string, error) {
+	condition, err := code.MapValue[string](value.Condition, m)
+	if err != nil {
+		return "", err
+	}
+
+	block, err := m.MapBlock(value.Block)
+	if err != nil {
+		return "", err
+	}
+
+	return "if (" + condition + ") " + block, nil
+}
+
+func (m *mapper) MapImport(value *code.Import) (string, error) {
+	// Declarations of other modules are referred to by their fully qualified names, so nothing is imported.
+	return "", nil
+}
+
+func (m *mapper) MapInt32(value *code.Int32) (string, error) {
+	return "int", nil
+}
+
+func (m *mapper) MapInt64(value *code.Int64) (string, error) {
+	return "long", nil
+}
+
+func (m *mapper) MapKeyValue(value *code.KeyValue) (string, error) {
+	key, err := code.MapValue[string](value.Key, m)
+	if err != nil {
+		return "", err
+	}
+
+	mapValue, err := code.MapValue[string](value.Value, m)
+	if err != nil {
+		return "", err
+	}
+
+	return m.fromRuntime("entry") + "(" + key + ", " + mapValue + ")", nil
+}
+
+func (m *mapper) MapLength(value *code.Length) (string, error) {
+	of, err := code.MapValue[string](value.Of, m)
+	if err != nil {
+		return "", err
+	}
+
+	return m.fromRuntime("length") + "(" + of + ")", nil
+}
+
+func (m *mapper) MapList(value *code.List) (string, error) {
+	item, err := m.boxed(value.Item)
+	if err != nil {
+		return "", err
+	}
+
+	m.useImport("java.util.List")
+	return "List<" + item + ">", nil
+}
+
+func (m *mapper) MapLiteralBool(value *code.LiteralBool) (string, error) {
+	return strconv.FormatBool(value.Value), nil
+}
+
+func (m *mapper) MapLiteralBytes(value *code.LiteralBytes) (string, error) {
+	values := make([]string, 0, len(value.Value))
+	for _, b := range value.Value {
+		if b > 127 {
+			// Bytes are signed in Java, so the larger values don't fit without a cast.
+			values = append(values, "(byte) "+strconv.FormatUint(uint64(b), 10))
+		} else {
+			values = append(values, strconv.FormatUint(uint64(b), 10))
+		}
+	}
+
+	if len(values) == 0 {
+		return "new byte[0]", nil
+	}
+
+	return "new byte[] {" + strings.Join(values, ", ") + "}", nil
+}
+
+func (m *mapper) MapLiteralFloat64(value *code.LiteralFloat64) (string, error) {
+	return languages.FormatFloat(value.Value), nil
+}
+
+func (m *mapper) MapLiteralInt32(value *code.LiteralInt32) (string, error) {
+	return strconv.FormatInt(int64(value.Value), 10), nil
+}
+
+func (m *mapper) MapLiteralInt64(value *code.LiteralInt64) (string, error) {
+	return strconv.FormatInt(value.Value, 10) + "L", nil
+}
+
+func (m *mapper) MapLiteralList(value *code.LiteralList) (string, error) {
+	values, err := m.mapValues(value.Values)
+	if err != nil {
+		return "", err
+	}
+
+	return m.fromRuntime("listOf") + "(" + strings.Join(values, ", ") + ")", nil
+}
+
+func (m *mapper) MapLiteralMap(value *code.LiteralMap) (string, error) {
+	entries := make([]string, 0, len(value.Values))
+	for _, keyValue := range value.Values {
+		result, err := m.MapKeyValue(keyValue)
+		if err != nil {
+			return "", err
+		}
+
+		entries = append(entries, result)
+	}
+
+	return m.fromRuntime("mapOf") + "(" + strings.Join(entries, ", ") + ")", nil
+}
+
+func (m *mapper) MapLiteralRune(value *code.LiteralRune) (string, error) {
+	if utf16.IsSurrogate(value.Value) || value.Value > 0xffff {
+		// Runes outside of the Basic Multilingual Plane don't fit in a char.
+		return fmt.Sprintf("0x%X", value.Value), nil
+	}
+
+	return "(int) " + quoteChar(value.Value), nil
+}
+
+func (m *mapper) MapLiteralSet(value *code.LiteralSet) (string, error) {
+	values, err := m.mapValues(value.Values)
+	if err != nil {
+		return "", err
+	}
+
+	return m.fromRuntime("setOf") + "(" + strings.Join(values, ", ") + ")", nil
+}
+
+func (m *mapper) MapLiteralString(value *code.LiteralString) (string, error) {
+	return quote(value.Value), nil
+}
+
+func (m *mapper) MapLiteralUint8(value *code.LiteralUint8) (string, error) {
+	return strconv.FormatUint(uint64(value.Value), 10), nil
+}
+
+func (m *mapper) MapLookup(value *code.Lookup) (string, error) {
+	from, err := code.MapValue[string](value.From, m)
+	if err != nil {
+		return "", err
+	}
+
+	key, err := code.MapValue[string](value.Key, m)
+	if err != nil {
+		return "", err
+	}
+
+	return m.fromRuntime("lookup") + "(" + from + ", " + key + ")", nil
+}
+
+func (m *mapper) MapMap(value *code.Map) (string, error) {
+	key, err := m.boxed(value.Key)
+	if err != nil {
+		return "", err
+	}
+
+	mapValue, err := m.boxed(value.Value)
+	if err != nil {
+		return "", err
+	}
+
+	m.useImport("java.util.Map")
+	return "Map<" + key + ", " + mapValue + ">", nil
+}
+
+func (m *mapper) MapMethodCall(value *code.MethodCall) (string, error) {
+	receiver, err := code.MapValue[string](value.Receiver, m)
+	if err != nil {
+		return "", err
+	}
+
+	arguments, err := m.mapValues(value.Arguments)
+	if err != nil {
+		return "", err
+	}
+
+	return receiver + "." + identifier(value.Name) + "(" + strings.Join(arguments, ", ") + ")", nil
+}
+
+func (m *mapper) MapModel(value *code.Model) (string, error) {
+	if value.Module != m.module.Name {
+		return value.Module + "." + value.Name, nil
+	}
+
+	return value.Name, nil
+}
+
+func (m *mapper) MapModelDef(value *code.ModelDef) (string, error) {
+	m.imports = map[string]struct{}{}
+
+	var members []string
+	if len(value.Fields) > 0 {
+		fields := make([]string, 0, len(value.Fields))
+		for _, field := range value.Fields {
+			result, err := m.MapFieldDef(field)
+			if err != nil {
+				return "", err
+			}
+
+			fields = append(fields, result)
+		}
+
+		members = append(members, strings.Join(fields, "\n"))
+	}
+
+	m.self = value
+	defer func() { m.self = nil }()
+
+	for _, method := range value.Methods {
+		result, err := m.MapFunctionDef(method)
+		if err != nil {
+			return "", err
+		}
+
+		members = append(members, result)
+	}
+
+	if value.EqualOverride != nil {
+		result, err := m.MapEqualOverride(value.EqualOverride)
+		if err != nil {
+			return "", err
+		}
+
+		members = append(members, result)
+
+		if value.HashOverride == nil {
+			// Without a hash override there's no way to know which instances are equal, so every instance needs to
+			// hash the same.
+			members = append(members, "@Override\npublic int hashCode() {\n"+indent+"return "+value.Name+".class.hashCode();\n}")
+		}
+	}
+
+	if value.HashOverride != nil {
+		result, err := m.MapHashOverride(value.HashOverride)
+		if err != nil {
+			return "", err
+		}
+
+		members = append(members, result)
+	}
+
+	body := access(value.Visibility) + "final class " + value.Name + " {}"
+	if len(members) > 0 {
+		body = access(value.Visibility) + "final class " + value.Name + " {\n" + languages.Indent(strings.Join(members, "\n\n"), indent) + "\n}"
+	}
+
+	var staticImports []string
+	if len(m.module.Functions) > 0 || len(m.module.Constants) > 0 {
+		// Methods can refer to the module's functions and constants without qualifying them.
+		staticImports = append(staticImports, m.module.Name+"."+m.moduleClass+".*")
+	}
+
+	return m.file(body, staticImports...), nil
+}
+
+func (m *mapper) MapModule(value *code.Module) (string, error) {
+	m.imports = map[string]struct{}{}
+
+	// The class can't be instantiated because it only holds static members.
+	members := []string{"private " + m.moduleClass + "() {}"}
+	// Fields are grouped together, while constant collections are methods and are separated like the other methods.
+	var fields, methods []string
+	for _, constant := range value.Constants {
+		result, err := m.MapConstantDef(constant)
+		if err != nil {
+			return "", fmt.Errorf("constant %q: %w", constant.Name, err)
+		}
+
+		if isCollection(code.TypeOf(constant.Value.(code.Value))) {
+			methods = append(methods, result)
+		} else {
+			fields = append(fields, result)
+		}
+	}
+
+	if len(fields) > 0 {
+		members = append(members, strings.Join(fields, "\n"))
+	}
+
+	members = append(members, methods...)
+
+	for _, function := range value.Functions {
+		result, err := m.MapFunctionDef(function)
+		if err != nil {
+			return "", fmt.Errorf("function %q: %w", function.Name, err)
+		}
+
+		members = append(members, result)
+	}
+
+	body := "public final class " + m.moduleClass + " {\n" + languages.Indent(strings.Join(members, "\n\n"), indent) + "\n}"
+
+	return m.file(body), nil
+}
+
+func (m *mapper) MapNew(value *code.New) (string, error) {
+	model, err := m.MapModel(value.Model)
+	if err != nil {
+		return "", err
+	}
+
+	return "new " + model + "()", nil
+}
+
+func (m *mapper) MapNil(value *code.Nil) (string, error) {
+	return m.zeroValue(value.Type)
+}
+
+func (m *mapper) MapPop(value *code.Pop) (string, error) {
+	list, err := code.MapValue[string](value.List, m)
+	if err != nil {
+		return "", err
+	}
+
+	return m.fromRuntime("pop") + "(" + list + ")", nil
+}
+
+func (m *mapper) MapProperty(value *code.Property) (string, error) {
+	of, err := code.MapValue[string](value.Of, m)
+	if err != nil {
+		return "", err
+	}
+
+	return of + "." + identifier(value.Name), nil
+}
+
+func (m *mapper) MapPush(value *code.Push) (string, error) {
+	list, err := code.MapValue[string](value.List, m)
+	if err != nil {
+		return "", err
+	}
+
+	item, err := code.MapValue[string](value.Value, m)
+	if err != nil {
+		return "", err
+	}
+
+	return list + ".add(" + item + ")", nil
+}
+
+func (m *mapper) MapReturn(value *code.Return) (string, error) {
+	result, err := code.MapValue[string](value.Value, m)
+	if err != nil {
+		return "", err
+	}
+
+	return "return " + result, nil
+}
+
+func (m *mapper) MapRoot(value *code.Root) (string, error) {
+	return "", fmt.Errorf("a root can't be generated as a single Java file")
+}
+
+func (m *mapper) MapRune(value *code.Rune) (string, error) {
+	// Runes are code points. A char can only hold characters in the Basic Multilingual Plane.
+	return "int", nil
+}
+
+func (m *mapper) MapSelf(value *code.Self) (string, error) {
+	return "this", nil
+}
+
+func (m *mapper) MapSet(value *code.Set) (string, error) {
+	item, err := m.boxed(value.Item)
+	if err != nil {
+		return "", err
+	}
+
+	m.useImport("java.util.Set")
+	return "Set<" + item + ">", nil
+}
+
+func (m *mapper) MapSetContains(value *code.SetContains) (string, error) {
+	set, err := code.MapValue[string](value.Set, m)
+	if err != nil {
+		return "", err
+	}
+
+	item, err := code.MapValue[string](value.Value, m)
+	if err != nil {
+		return "", err
+	}
+
+	return set + ".contains(" + item + ")", nil
+}
+
+func (m *mapper) MapString(value *code.String) (string, error) {
+	return "String", nil
+}
+
+func (m *mapper) MapUint8(value *code.Uint8) (string, error) {
+	// There are no unsigned types, so uint8 is represented as an int that is always between 0 and 255.
+	return "int", nil
+}
+
+func (m *mapper) MapUnary(value *code.Unary) (string, error) {
+	operand, err := code.MapValue[string](value.Value, m)
+	if err != nil {
+		return "", err
+	}
+
+	// Negating a negative literal would otherwise produce the decrement operator.
+	if strings.HasPrefix(operand, "-") {
+		operand = "(" + operand + ")"
+	}
+
+	return "(" + value.Operator.Symbol() + operand + ")", nil
+}
+
+func (m *mapper) MapVariable(value *code.Variable) (string, error) {
+	if constant, ok := value.Definition.(*code.ConstantDef); ok {
+		if isCollection(code.TypeOf(constant.Value.(code.Value))) {
+			return m.member(constant.Module, constant.Name) + "()", nil
+		}
+
+		return m.member(constant.Module, constant.Name), nil
+	}
+
+	return identifier(value.Name), nil
+}
+
+func (m *mapper) MapVoid(value *code.Void) (string, error) {
+	return "void", nil
+}
@@ -0,0 +1,51 @@
+package java
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/JosephNaberhaus/agnostic/code"
+	"github.com/JosephNaberhaus/agnostic/internal/languages"
+	"github.com/JosephNaberhaus/agnostic/internal/languages/languagetest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate(t *testing.T) {
+	languagetest.RunGolden(t, generateWithoutRuntime, compile)
+}
+
+// generateWithoutRuntime leaves out the runtime so that it isn't repeated in every golden file.
+func generateWithoutRuntime(root *code.Root) ([]languages.File, error) {
+	files, err := Generate(root)
+	if err != nil {
+		return nil, err
+	}
+
+	return slices.DeleteFunc(files, func(file languages.File) bool {
+		return file.Path == runtimePath
+	}), nil
+}
+
+// compile compiles the generated files with javac. It's skipped if javac isn't installed.
+func compile(t *testing.T, files []languages.File) {
+	javac, err := exec.LookPath("javac")
+	if err != nil {
+		t.Skip("javac is not installed")
+	}
+
+	dir := t.TempDir()
+	args := []string{"-encoding", "UTF-8", "-Xlint:all", "-Werror", "-d", filepath.Join(dir, "out")}
+	files = append(files, languages.File{Path: runtimePath, Contents: runtime})
+	for _, file := range files {
+		path := filepath.Join(dir, "src", file.Path)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(file.Contents), 0o644))
+		args = append(args, path)
+	}
+
+	output, err := exec.Command(javac, args...).CombinedOutput()
+	require.NoError(t, err, string(output))
+}
@@ -0,0 +1,141 @@
+package java
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/JosephNaberhaus/agnostic/code"
+)
+
+var reservedWords = map[string]struct{}{
+	"abstract":     {},
+	"assert":       {},
+	"boolean":      {},
+	"break":        {},
+	"byte":         {},
+	"case":         {},
+	"catch":        {},
+	"char":         {},
+	"class":        {},
+	"const":        {},
+	"continue":     {},
+	"default":      {},
+	"do":           {},
+	"double":       {},
+	"else":         {},
+	"enum":         {},
+	"extends":      {},
+	"false":        {},
+	"final":        {},
+	"finally":      {},
+	"float":        {},
+	"for":          {},
+	"goto":         {},
+	"if":           {},
+	"implements":   {},
+	"import":       {},
+	"instanceof":   {},
+	"int":          {},
+	"interface":    {},
+	"long":         {},
+	"native":       {},
+	"new":          {},
+	"null":         {},
+	"package":      {},
+	"private":      {},
+	"protected":    {},
+	"public":       {},
+	"return":       {},
+	"short":        {},
+	"static":       {},
+	"strictfp":     {},
+	"super":        {},
+	"switch":       {},
+	"synchronized": {},
+	"this":         {},
+	"throw":        {},
+	"throws":       {},
+	"transient":    {},
+	"true":         {},
+	"try":          {},
+	"var":          {},
+	"void":         {},
+	"volatile":     {},
+	"while":        {},
+}
+
+// identifier converts an Agnostic name into a valid Java identifier.
+func identifier(name string) string {
+	if _, isReserved := reservedWords[name]; isReserved {
+		return name + "_"
+	}
+
+	return name
+}
+
+// quote converts a string into a Java string literal. The generated source is expected to be compiled as UTF-8.
+func quote(str string) string {
+	var sb strings.Builder
+	sb.WriteByte('"')
+	for _, r := range str {
+		switch r {
+		case '"':
+			sb.WriteString(`\"`)
+		case '\\':
+			sb.WriteString(`\\`)
+		case '\n':
+			sb.WriteString(`\n`)
+		case '\r':
+			sb.WriteString(`\r`)
+		case '\t':
+			sb.WriteString(`\t`)
+		default:
+			if r < 0x20 || r == 0x7f {
+				// Unicode escapes can't be used because Java translates them before parsing the literal.
+				sb.WriteString(fmt.Sprintf(`\%03o`, r))
+			} else {
+				sb.WriteRune(r)
+			}
+		}
+	}
+	sb.WriteByte('"')
+
+	return sb.String()
+}
+
+// quoteChar converts a rune in the Basic Multilingual Plane into a Java char literal.
+func quoteChar(r rune) string {
+	switch r {
+	case '\'':
+		return `'\''`
+	case '\\':
+		return `'\\'`
+	case '\n':
+		return `'\n'`
+	case '\r':
+		return `'\r'`
+	case '\t':
+		return `'\t'`
+	default:
+		if r < 0x20 || r == 0x7f {
+			return fmt.Sprintf(`'\%03o'`, r)
+		}
+
+		return "'" + string(r) + "'"
+	}
+}
+
+// title capitalizes the first letter of a name.
+func title(name string) string {
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+// access returns the access modifier of a declaration with the given visibility. Each module is a package, so private
+// declarations are package-private.
+func access(visibility code.Visibility) string {
+	if visibility == code.VisibilityPrivate {
+		return ""
+	}
+
+	return "public "
+}
@@ -0,0 +1,1198 @@
+// Package languagetest contains a corpus of programs and helpers shared by the tests of each language backend.
+package languagetest
+
+import (
+	"slices"
+
+	"github.com/JosephNaberhaus/agnostic/ast"
+)
+
+// Case is a single program in the corpus.
+type Case struct {
+	// Name of the case. This is also used as the name of its golden file.
+	Name string
+	Root ast.Root
+}
+
+// The name of the single module used by most cases.
+const moduleName = "example"
+
+func block(statements ...ast.Statement) ast.Block {
+	return ast.Block{Statements: statements}
+}
+
+func function(name string, returnType ast.Type, arguments []ast.ArgumentDef, statements ...ast.Statement) ast.FunctionDef {
+	return ast.FunctionDef{
+		Name:       name,
+		Arguments:  arguments,
+		ReturnType: returnType,
+		Block:      block(statements...),
+	}
+}
+
+func argument(name string, typ ast.Type) ast.ArgumentDef {
+	return ast.ArgumentDef{Name: name, Type: typ}
+}
+
+func call(function ast.FunctionDef, arguments ...ast.Value) ast.Call {
+	return ast.Call{Function: ast.FunctionRef{Name: function.Name}, Arguments: arguments}
+}
+
+func variable(name string) ast.Variable {
+	return ast.Variable{Name: name}
+}
+
+func integer(value int64) ast.LiteralInt64 {
+	return ast.LiteralInt64{Value: value}
+}
+
+func str(value string) ast.LiteralString {
+	return ast.LiteralString{Value: value}
+}
+
+func declare(name string, value ast.Value) ast.Declare {
+	return ast.Declare{Name: name, Value: value}
+}
+
+func returns(value ast.Value) ast.Return {
+	return ast.Return{Value: value}
+}
+
+func property(of ast.Value, name string) ast.Property {
+	return ast.Property{Of: of, Name: name}
+}
+
+func binary(left ast.Value, operator ast.BinaryOperator, right ast.Value) ast.Binary {
+	return ast.Binary{Left: left, Operator: operator, Right: right}
+}
+
+func compare(left ast.Value, operator ast.ComparisonOperator, right ast.Value) ast.Comparison {
+	return ast.Comparison{Left: left, Operator: operator, Right: right}
+}
+
+func convert(value ast.Value, to ast.Type) ast.Conversion {
+	return ast.Conversion{Value: value, To: to}
+}
+
+func field(name string, typ ast.Type) ast.FieldDef {
+	return ast.FieldDef{Name: name, Type: typ}
+}
+
+func private(function ast.FunctionDef) ast.FunctionDef {
+	function.Visibility = ast.VisibilityPrivate
+	return function
+}
+
+func module(functions ...ast.FunctionDef) ast.Root {
+	return ast.Root{
+		Modules: []ast.Module{{
+			Name:      moduleName,
+			Functions: functions,
+		}},
+	}
+}
+
+func moduleWithModels(models []ast.ModelDef, functions ...ast.FunctionDef) ast.Root {
+	root := module(functions...)
+	root.Modules[0].Models = models
+	return root
+}
+
+var (
+	oneFunction      = function("one", ast.Int64{}, nil, returns(integer(1)))
+	identityFunction = function("identity", ast.Int64{}, []ast.ArgumentDef{argument("value", ast.Int64{})}, returns(variable("value")))
+)
+
+var pointModel = ast.ModelDef{
+	Name: "Point",
+	Fields: []ast.FieldDef{
+		field("x", ast.Int64{}),
+		field("y", ast.Int64{}),
+	},
+}
+
+var counterModel = ast.ModelDef{
+	Name: "Counter",
+	Fields: []ast.FieldDef{
+		field("count", ast.Int64{}),
+	},
+	Methods: []ast.FunctionDef{
+		function("get", ast.Int64{}, nil, returns(property(ast.Self{}, "count"))),
+		function(
+			"setTo",
+			ast.Void{},
+			[]ast.ArgumentDef{argument("value", ast.Int64{})},
+			ast.Assignment{To: property(ast.Self{}, "count"), From: variable("value")},
+		),
+	},
+}
+
+var boxModel = ast.ModelDef{
+	Name: "Box",
+	Fields: []ast.FieldDef{
+		field("count", ast.Int64{}),
+		field("labels", ast.Map{Key: ast.String{}, Value: ast.Int64{}}),
+		field("tags", ast.Set{Item: ast.String{}}),
+	},
+}
+
+// Cases contains at least one program for every node type in the spec. Each program is valid and should compile in
+// every target language.
+var Cases = []Case{
+	{
+		Name: "AddToSet",
+		Root: module(function(
+			"addToSet",
+			ast.Set{Item: ast.Int64{}},
+			nil,
+			declare("values", ast.LiteralSet{Values: []ast.Value{integer(1)}}),
+			ast.AddToSet{Set: variable("values"), Value: integer(2)},
+			returns(variable("values")),
+		)),
+	},
+	{
+		Name: "ArgumentDef",
+		Root: module(function(
+			"pick",
+			ast.String{},
+			[]ast.ArgumentDef{
+				argument("first", ast.String{}),
+				argument("second", ast.List{Item: ast.Int64{}}),
+			},
+			returns(variable("first")),
+		)),
+	},
+	{
+		Name: "Assignment",
+		Root: module(function(
+			"assignment",
+			ast.String{},
+			nil,
+			declare("value", str("before")),
+			ast.Assignment{To: variable("value"), From: str("after")},
+			returns(variable("value")),
+		)),
+	},
+	{
+		Name: "Binary",
+		Root: module(
+			function(
+				"arithmetic",
+				ast.Int64{},
+				[]ast.ArgumentDef{
+					argument("a", ast.Int64{}),
+					argument("b", ast.Int64{}),
+				},
+				declare("sum", binary(variable("a"), ast.BinaryOperatorAdd, variable("b"))),
+				declare("product", binary(variable("sum"), ast.BinaryOperatorMultiply, binary(variable("a"), ast.BinaryOperatorSubtract, variable("b")))),
+				returns(binary(variable("product"), ast.BinaryOperatorDivide, binary(variable("b"), ast.BinaryOperatorModulo, integer(3)))),
+			),
+			function(
+				"greet",
+				ast.String{},
+				[]ast.ArgumentDef{argument("name", ast.String{})},
+				returns(binary(binary(str("hello, "), ast.BinaryOperatorAdd, variable("name")), ast.BinaryOperatorAdd, str("!"))),
+			),
+			function(
+				"either",
+				ast.Bool{},
+				[]ast.ArgumentDef{
+					argument("a", ast.Bool{}),
+					argument("b", ast.Bool{}),
+				},
+				returns(binary(variable("a"), ast.BinaryOperatorAnd, binary(variable("b"), ast.BinaryOperatorOr, ast.LiteralBool{Value: false}))),
+			),
+		),
+	},
+	{
+		Name: "Block",
+		Root: module(function(
+			"block",
+			ast.Int64{},
+			nil,
+			declare("first", integer(1)),
+			declare("second", variable("first")),
+			returns(variable("second")),
+		)),
+	},
+	{
+		Name: "Bool",
+		Root: module(function(
+			"isEnabled",
+			ast.Bool{},
+			[]ast.ArgumentDef{argument("enabled", ast.Bool{})},
+			returns(variable("enabled")),
+		)),
+	},
+	{
+		Name: "Break",
+		Root: module(function(
+			"first",
+			ast.Int64{},
+			[]ast.ArgumentDef{argument("items", ast.List{Item: ast.Int64{}})},
+			declare("result", integer(0)),
+			ast.ForEach{
+				Iterable: variable("items"),
+				ItemName: "item",
+				Block: block(
+					ast.Assignment{To: variable("result"), From: variable("item")},
+					ast.Break{},
+				),
+			},
+			returns(variable("result")),
+		)),
+	},
+	{
+		Name: "Bytes",
+		Root: module(
+			function(
+				"checksum",
+				ast.Uint8{},
+				[]ast.ArgumentDef{argument("data", ast.Bytes{})},
+				declare("total", ast.LiteralUint8{Value: 0}),
+				ast.ForEach{
+					Iterable: variable("data"),
+					ItemName: "item",
+					Block:    block(ast.Assignment{To: variable("total"), From: binary(variable("total"), ast.BinaryOperatorAdd, variable("item"))}),
+				},
+				returns(variable("total")),
+			),
+			function(
+				"stamp",
+				ast.Int64{},
+				[]ast.ArgumentDef{argument("data", ast.Bytes{})},
+				ast.Assignment{To: ast.Lookup{From: variable("data"), Key: integer(0)}, From: ast.LiteralUint8{Value: 255}},
+				returns(ast.Length{Of: variable("data")}),
+			),
+		),
+	},
+	{
+		Name: "Call",
+		Root: module(
+			oneFunction,
+			identityFunction,
+			function("callNested", ast.Int64{}, nil, returns(call(identityFunction, call(oneFunction)))),
+			function("callStatement", ast.Void{}, nil, call(oneFunction)),
+		),
+	},
+	{
+		Name: "Comparison",
+		Root: moduleWithModels(
+			[]ast.ModelDef{{
+				Name:   pointModel.Name,
+				Fields: pointModel.Fields,
+				EqualOverride: ast.OptionalWithValue(ast.EqualOverride{
+					OtherName: "other",
+					Block: block(returns(binary(
+						compare(property(ast.Self{}, "x"), ast.ComparisonOperatorEqual, property(variable("other"), "x")),
+						ast.BinaryOperatorAnd,
+						compare(property(ast.Self{}, "y"), ast.ComparisonOperatorEqual, property(variable("other"), "y")),
+					))),
+				}),
+			}, counterModel},
+			function(
+				"ordered",
+				ast.Bool{},
+				[]ast.ArgumentDef{
+					argument("a", ast.Int64{}),
+					argument("b", ast.Int64{}),
+				},
+				declare("ascending", binary(
+					compare(variable("a"), ast.ComparisonOperatorLessThan, variable("b")),
+					ast.BinaryOperatorOr,
+					compare(variable("a"), ast.ComparisonOperatorLessThanOrEqual, integer(0)),
+				)),
+				declare("descending", binary(
+					compare(variable("a"), ast.ComparisonOperatorGreaterThan, variable("b")),
+					ast.BinaryOperatorOr,
+					compare(variable("a"), ast.ComparisonOperatorGreaterThanOrEqual, integer(0)),
+				)),
+				returns(binary(variable("ascending"), ast.BinaryOperatorAnd, variable("descending"))),
+			),
+			function(
+				"sameName",
+				ast.Bool{},
+				[]ast.ArgumentDef{
+					argument("a", ast.String{}),
+					argument("b", ast.String{}),
+				},
+				returns(binary(
+					compare(variable("a"), ast.ComparisonOperatorEqual, variable("b")),
+					ast.BinaryOperatorAnd,
+					compare(str("nobody"), ast.ComparisonOperatorNotEqual, variable("a")),
+				)),
+			),
+			function(
+				"afterA",
+				ast.Bool{},
+				[]ast.ArgumentDef{argument("letter", ast.Rune{})},
+				returns(compare(variable("letter"), ast.ComparisonOperatorGreaterThan, ast.LiteralRune{Value: 'a'})),
+			),
+			function(
+				"samePoint",
+				ast.Bool{},
+				[]ast.ArgumentDef{
+					argument("a", ast.Model{Name: pointModel.Name}),
+					argument("b", ast.Model{Name: pointModel.Name}),
+				},
+				declare("different", compare(variable("a"), ast.ComparisonOperatorNotEqual, variable("b"))),
+				returns(binary(compare(variable("a"), ast.ComparisonOperatorEqual, variable("b")), ast.BinaryOperatorOr, variable("different"))),
+			),
+			function(
+				"sameCounter",
+				ast.Bool{},
+				[]ast.ArgumentDef{
+					argument("a", ast.Model{Name: counterModel.Name}),
+					argument("b", ast.Model{Name: counterModel.Name}),
+				},
+				// Counter has no equal override, so it's compared by identity.
+				returns(binary(
+					compare(variable("a"), ast.ComparisonOperatorEqual, variable("b")),
+					ast.BinaryOperatorAnd,
+					compare(variable("a"), ast.ComparisonOperatorNotEqual, ast.Nil{Type: ast.Model{Name: counterModel.Name}}),
+				)),
+			),
+		),
+	},
+	{
+		Name: "Conditional",
+		Root: module(function(
+			"choose",
+			ast.Int64{},
+			[]ast.ArgumentDef{
+				argument("first", ast.Bool{}),
+				argument("second", ast.Bool{}),
+			},
+			ast.Conditional{
+				Ifs: []ast.If{
+					{Condition: variable("first"), Block: block(returns(integer(1)))},
+					{Condition: variable("second"), Block: block(returns(integer(2)))},
+				},
+				Else: ast.OptionalWithValue(block(returns(integer(3)))),
+			},
+		)),
+	},
+	{
+		Name: "ConstantDef",
+		Root: ast.Root{
+			Modules: []ast.Module{{
+				Name: moduleName,
+				Constants: []ast.ConstantDef{
+					{Name: "answer", Value: integer(42)},
+					{Name: "greeting", Value: str("hello")},
+					{Name: "enabled", Value: ast.LiteralBool{Value: true}},
+					{Name: "letter", Value: ast.LiteralRune{Value: 'a'}},
+					{Name: "primes", Value: ast.LiteralList{Values: []ast.Value{integer(2), integer(3), integer(5)}}},
+					{Name: "vowels", Value: ast.LiteralSet{Values: []ast.Value{ast.LiteralRune{Value: 'a'}, ast.LiteralRune{Value: 'e'}}}},
+					{Name: "scores", Value: ast.LiteralMap{Values: []ast.KeyValue{{Key: str("alice"), Value: integer(10)}}}},
+					{Name: "nothing", Value: ast.EmptyList{Type: ast.String{}}},
+					{Name: "ratio", Value: ast.LiteralFloat64{Value: 0.5}},
+					{Name: "port", Value: ast.LiteralInt32{Value: 8080}},
+					{Name: "mask", Value: ast.LiteralUint8{Value: 255}},
+					{Name: "magic", Value: ast.LiteralBytes{Value: []uint8{0xca, 0xfe}}},
+				},
+				Functions: []ast.FunctionDef{
+					function("getAnswer", ast.Int64{}, nil, returns(variable("answer"))),
+					function("getPrimes", ast.List{Item: ast.Int64{}}, nil, returns(variable("primes"))),
+				},
+			}},
+		},
+	},
+	{
+		Name: "Continue",
+		Root: module(function(
+			"skip",
+			ast.List{Item: ast.Int64{}},
+			[]ast.ArgumentDef{
+				argument("items", ast.List{Item: ast.Int64{}}),
+				argument("skipped", ast.Set{Item: ast.Int64{}}),
+			},
+			declare("result", ast.EmptyList{Type: ast.Int64{}}),
+			ast.ForEach{
+				Iterable: variable("items"),
+				ItemName: "item",
+				Block: block(
+					ast.Conditional{
+						Ifs: []ast.If{{
+							Condition: ast.SetContains{Set: variable("skipped"), Value: variable("item")},
+							Block:     block(ast.Continue{}),
+						}},
+					},
+					ast.Push{List: variable("result"), Value: variable("item")},
+				),
+			},
+			returns(variable("result")),
+		)),
+	},
+	{
+		Name: "Conversion",
+		Root: module(
+			function("widen", ast.Int64{}, []ast.ArgumentDef{argument("value", ast.Int32{})}, returns(convert(variable("value"), ast.Int64{}))),
+			function("narrow", ast.Int32{}, []ast.ArgumentDef{argument("value", ast.Int64{})}, returns(convert(variable("value"), ast.Int32{}))),
+			function("lowByte", ast.Uint8{}, []ast.ArgumentDef{argument("value", ast.Int32{})}, returns(convert(variable("value"), ast.Uint8{}))),
+			function("truncate", ast.Int64{}, []ast.ArgumentDef{argument("value", ast.Float64{})}, returns(convert(variable("value"), ast.Int64{}))),
+			function("clamp", ast.Uint8{}, []ast.ArgumentDef{argument("value", ast.Float64{})}, returns(convert(variable("value"), ast.Uint8{}))),
+			function("round", ast.Float64{}, []ast.ArgumentDef{argument("value", ast.Int64{})}, returns(convert(variable("value"), ast.Float64{}))),
+			function("wrapped", ast.Int32{}, nil, returns(convert(integer(4294967297), ast.Int32{}))),
+			function("saturated", ast.Int32{}, nil, returns(convert(ast.LiteralFloat64{Value: -1e20}, ast.Int32{}))),
+		),
+	},
+	{
+		Name: "Declare",
+		Root: module(function(
+			"declare",
+			ast.Map{Key: ast.String{}, Value: ast.Int64{}},
+			nil,
+			declare("name", str("agnostic")),
+			declare("count", integer(3)),
+			declare("result", ast.LiteralMap{Values: []ast.KeyValue{{Key: variable("name"), Value: variable("count")}}}),
+			returns(variable("result")),
+		), function(
+			"unused",
+			ast.Void{},
+			nil,
+			declare("ignored", integer(2)),
+			declare("overwritten", integer(3)),
+			ast.Assignment{To: variable("overwritten"), From: integer(4)},
+		)),
+	},
+	{
+		Name: "EmptyList",
+		Root: module(function(
+			"empty",
+			ast.List{Item: ast.String{}},
+			nil,
+			returns(ast.EmptyList{Type: ast.String{}}),
+		)),
+	},
+	{
+		Name: "EqualOverride",
+		Root: moduleWithModels([]ast.ModelDef{{
+			Name:   pointModel.Name,
+			Fields: pointModel.Fields,
+			EqualOverride: ast.OptionalWithValue(ast.EqualOverride{
+				OtherName: "other",
+				Block: block(returns(ast.SetContains{
+					Set:   ast.LiteralSet{Values: []ast.Value{property(ast.Self{}, "x")}},
+					Value: property(variable("other"), "x"),
+				})),
+			}),
+		}}),
+	},
+	{
+		Name: "FieldDef",
+		Root: moduleWithModels([]ast.ModelDef{{
+			Name: "User",
+			Fields: []ast.FieldDef{
+				field("name", ast.String{}),
+				field("age", ast.Int64{}),
+				field("tags", ast.Set{Item: ast.String{}}),
+				field("friends", ast.List{Item: ast.Model{Name: "User"}}),
+			},
+		}}),
+	},
+	{
+		Name: "Float64",
+		Root: module(
+			function(
+				"average",
+				ast.Float64{},
+				[]ast.ArgumentDef{
+					argument("a", ast.Float64{}),
+					argument("b", ast.Float64{}),
+				},
+				returns(binary(binary(variable("a"), ast.BinaryOperatorAdd, variable("b")), ast.BinaryOperatorDivide, ast.LiteralFloat64{Value: 2})),
+			),
+			function(
+				"smaller",
+				ast.Bool{},
+				[]ast.ArgumentDef{
+					argument("a", ast.Float64{}),
+					argument("b", ast.Float64{}),
+				},
+				returns(compare(ast.Unary{Operator: ast.UnaryOperatorNegate, Value: variable("a")}, ast.ComparisonOperatorLessThan, variable("b"))),
+			),
+			function("infinity", ast.Float64{}, nil, returns(binary(ast.LiteralFloat64{Value: 1}, ast.BinaryOperatorDivide, ast.LiteralFloat64{Value: 0}))),
+		),
+	},
+	{
+		Name: "For",
+		Root: module(function(
+			"repeatOnce",
+			ast.List{Item: ast.Int64{}},
+			nil,
+			declare("result", ast.EmptyList{Type: ast.Int64{}}),
+			ast.For{
+				Initialization: ast.OptionalWithValue[ast.Statement](declare("running", ast.LiteralBool{Value: true})),
+				Condition:      variable("running"),
+				AfterEach:      ast.OptionalWithValue[ast.Statement](ast.Assignment{To: variable("running"), From: ast.LiteralBool{Value: false}}),
+				Block:          block(ast.Push{List: variable("result"), Value: integer(1)}),
+			},
+			returns(variable("result")),
+		), function(
+			"sum",
+			ast.Int64{},
+			[]ast.ArgumentDef{argument("n", ast.Int64{})},
+			declare("total", integer(0)),
+			ast.For{
+				Initialization: ast.OptionalWithValue[ast.Statement](declare("i", integer(0))),
+				Condition:      compare(variable("i"), ast.ComparisonOperatorLessThan, variable("n")),
+				AfterEach:      ast.OptionalWithValue[ast.Statement](ast.Assignment{To: variable("i"), From: binary(variable("i"), ast.BinaryOperatorAdd, integer(1))}),
+				Block:          block(ast.Assignment{To: variable("total"), From: binary(variable("total"), ast.BinaryOperatorAdd, variable("i"))}),
+			},
+			returns(variable("total")),
+		)),
+	},
+	{
+		Name: "ForEach",
+		Root: module(
+			function(
+				"collect",
+				ast.List{Item: ast.Int64{}},
+				[]ast.ArgumentDef{
+					argument("items", ast.List{Item: ast.Int64{}}),
+					argument("keys", ast.Set{Item: ast.String{}}),
+					argument("lookup", ast.Map{Key: ast.String{}, Value: ast.Int64{}}),
+				},
+				declare("result", ast.EmptyList{Type: ast.Int64{}}),
+				ast.ForEach{
+					Iterable: variable("items"),
+					ItemName: "item",
+					Block:    block(ast.Push{List: variable("result"), Value: variable("item")}),
+				},
+				ast.ForEach{
+					Iterable: variable("keys"),
+					ItemName: "key",
+					Block:    block(ast.Push{List: variable("result"), Value: ast.Lookup{From: variable("lookup"), Key: variable("key")}}),
+				},
+				ast.ForEach{
+					Iterable: variable("lookup"),
+					ItemName: "key",
+					Block:    block(ast.Push{List: variable("result"), Value: ast.Lookup{From: variable("lookup"), Key: variable("key")}}),
+				},
+				returns(variable("result")),
+			),
+			function(
+				"runes",
+				ast.List{Item: ast.Rune{}},
+				[]ast.ArgumentDef{argument("text", ast.String{})},
+				declare("result", ast.EmptyList{Type: ast.Rune{}}),
+				ast.ForEach{
+					Iterable: variable("text"),
+					ItemName: "character",
+					Block:    block(ast.Push{List: variable("result"), Value: variable("character")}),
+				},
+				returns(variable("result")),
+			),
+			function(
+				"count",
+				ast.Int64{},
+				[]ast.ArgumentDef{argument("items", ast.List{Item: ast.Int64{}})},
+				declare("total", integer(0)),
+				ast.ForEach{
+					Iterable: variable("items"),
+					ItemName: "item",
+					Block:    block(ast.Assignment{To: variable("total"), From: binary(variable("total"), ast.BinaryOperatorAdd, integer(1))}),
+				},
+				returns(variable("total")),
+			),
+		),
+	},
+	{
+		Name: "FunctionDef",
+		Root: module(
+			function("greet", ast.String{}, []ast.ArgumentDef{argument("name", ast.String{})}, returns(variable("name"))),
+			function("nothing", ast.Void{}, nil),
+		),
+	},
+	{
+		Name: "HashOverride",
+		Root: moduleWithModels([]ast.ModelDef{{
+			Name:         pointModel.Name,
+			Fields:       pointModel.Fields,
+			HashOverride: ast.OptionalWithValue(ast.HashOverride{Block: block(returns(property(ast.Self{}, "x")))}),
+		}}),
+	},
+	{
+		Name: "If",
+		Root: module(function(
+			"check",
+			ast.Int64{},
+			[]ast.ArgumentDef{argument("flag", ast.Bool{})},
+			ast.Conditional{
+				Ifs: []ast.If{{Condition: variable("flag"), Block: block(returns(integer(1)))}},
+			},
+			returns(integer(0)),
+		)),
+	},
+	{
+		Name: "Import",
+		Root: ast.Root{
+			Modules: []ast.Module{
+				{
+					Name:    moduleName,
+					Imports: []ast.Import{{Module: "geometry"}},
+					Functions: []ast.FunctionDef{
+						function(
+							"origin",
+							ast.Model{Name: pointModel.Name, Module: "geometry"},
+							nil,
+							declare("point", ast.New{Model: ast.Model{Name: pointModel.Name, Module: "geometry"}}),
+							ast.Assignment{To: property(variable("point"), "x"), From: ast.Variable{Name: "start", Module: "geometry"}},
+							returns(variable("point")),
+						),
+						function(
+							"measure",
+							ast.Int64{},
+							[]ast.ArgumentDef{argument("point", ast.Model{Name: pointModel.Name, Module: "geometry"})},
+							returns(ast.Call{Function: ast.FunctionRef{Name: "area", Module: "geometry"}, Arguments: []ast.Value{variable("point")}}),
+						),
+						function(
+							"count",
+							ast.Int64{},
+							nil,
+							declare("counter", ast.New{Model: ast.Model{Name: counterModel.Name, Module: "geometry"}}),
+							ast.MethodCall{Receiver: variable("counter"), Name: "setTo", Arguments: []ast.Value{ast.Variable{Name: "start", Module: "geometry"}}},
+							returns(ast.MethodCall{Receiver: variable("counter"), Name: "get"}),
+						),
+					},
+				},
+				{
+					Name:      "geometry",
+					Constants: []ast.ConstantDef{{Name: "start", Value: integer(5)}},
+					Models:    []ast.ModelDef{counterModel, pointModel},
+					Functions: []ast.FunctionDef{
+						function(
+							"area",
+							ast.Int64{},
+							[]ast.ArgumentDef{argument("point", ast.Model{Name: pointModel.Name})},
+							returns(binary(property(variable("point"), "x"), ast.BinaryOperatorMultiply, property(variable("point"), "y"))),
+						),
+					},
+				},
+			},
+		},
+	},
+	{
+		Name: "Int32",
+		Root: module(
+			function(
+				"arithmetic",
+				ast.Int32{},
+				[]ast.ArgumentDef{
+					argument("a", ast.Int32{}),
+					argument("b", ast.Int32{}),
+				},
+				declare("product", binary(variable("a"), ast.BinaryOperatorMultiply, variable("b"))),
+				returns(binary(binary(variable("product"), ast.BinaryOperatorDivide, variable("b")), ast.BinaryOperatorModulo, variable("a"))),
+			),
+			function("overflow", ast.Int32{}, nil, returns(binary(ast.LiteralInt32{Value: 2147483647}, ast.BinaryOperatorAdd, ast.LiteralInt32{Value: 1}))),
+			function("negateSmallest", ast.Int32{}, nil, returns(ast.Unary{Operator: ast.UnaryOperatorNegate, Value: ast.LiteralInt32{Value: -2147483648}})),
+			function("divideSmallest", ast.Int32{}, nil, returns(binary(ast.LiteralInt32{Value: -2147483648}, ast.BinaryOperatorDivide, ast.LiteralInt32{Value: -1}))),
+		),
+	},
+	{
+		Name: "Int64",
+		Root: module(
+			function("smallest", ast.Int64{}, nil, returns(integer(-9223372036854775808))),
+			function("largest", ast.Int64{}, nil, returns(integer(9223372036854775807))),
+		),
+	},
+	{
+		Name: "KeyValue",
+		Root: module(function(
+			"ages",
+			ast.Map{Key: ast.String{}, Value: ast.Int64{}},
+			nil,
+			returns(ast.LiteralMap{Values: []ast.KeyValue{
+				{Key: str("alice"), Value: integer(30)},
+				{Key: str("bob"), Value: integer(25)},
+			}}),
+		)),
+	},
+	{
+		Name: "Length",
+		Root: module(function(
+			"sizes",
+			ast.List{Item: ast.Int64{}},
+			[]ast.ArgumentDef{
+				argument("items", ast.List{Item: ast.Int64{}}),
+				argument("text", ast.String{}),
+				argument("lookup", ast.Map{Key: ast.String{}, Value: ast.Int64{}}),
+				argument("values", ast.Set{Item: ast.String{}}),
+			},
+			returns(ast.LiteralList{Values: []ast.Value{
+				ast.Length{Of: variable("items")},
+				ast.Length{Of: variable("text")},
+				ast.Length{Of: variable("lookup")},
+				ast.Length{Of: variable("values")},
+			}}),
+		)),
+	},
+	{
+		Name: "List",
+		Root: module(function(
+			"nested",
+			ast.List{Item: ast.List{Item: ast.String{}}},
+			[]ast.ArgumentDef{argument("items", ast.List{Item: ast.List{Item: ast.String{}}})},
+			returns(variable("items")),
+		)),
+	},
+	{
+		Name: "LiteralBool",
+		Root: module(
+			function("yes", ast.Bool{}, nil, returns(ast.LiteralBool{Value: true})),
+			function("no", ast.Bool{}, nil, returns(ast.LiteralBool{Value: false})),
+		),
+	},
+	{
+		Name: "LiteralBytes",
+		Root: module(
+			function("header", ast.Bytes{}, nil, returns(ast.LiteralBytes{Value: []uint8{0, 127, 128, 255}})),
+			function("empty", ast.Bytes{}, nil, returns(ast.LiteralBytes{})),
+		),
+	},
+	{
+		Name: "LiteralFloat64",
+		Root: module(
+			function("half", ast.Float64{}, nil, returns(ast.LiteralFloat64{Value: 0.5})),
+			function("whole", ast.Float64{}, nil, returns(ast.LiteralFloat64{Value: -3})),
+			function("huge", ast.Float64{}, nil, returns(ast.LiteralFloat64{Value: 1.7976931348623157e308})),
+			function("tiny", ast.Float64{}, nil, returns(ast.LiteralFloat64{Value: 5e-324})),
+		),
+	},
+	{
+		Name: "LiteralInt32",
+		Root: module(
+			function("smallest", ast.Int32{}, nil, returns(ast.LiteralInt32{Value: -2147483648})),
+			function("largest", ast.Int32{}, nil, returns(ast.LiteralInt32{Value: 2147483647})),
+		),
+	},
+	{
+		Name: "LiteralInt64",
+		Root: module(function("answer", ast.Int64{}, nil, returns(integer(42)))),
+	},
+	{
+		Name: "LiteralList",
+		Root: module(function(
+			"names",
+			ast.List{Item: ast.String{}},
+			nil,
+			returns(ast.LiteralList{Values: []ast.Value{str("alice"), str("bob")}}),
+		)),
+	},
+	{
+		Name: "LiteralMap",
+		Root: module(function(
+			"numbers",
+			ast.Map{Key: ast.Int64{}, Value: ast.String{}},
+			nil,
+			returns(ast.LiteralMap{Values: []ast.KeyValue{{Key: integer(1), Value: str("one")}}}),
+		)),
+	},
+	{
+		Name: "LiteralRune",
+		Root: module(
+			function("letter", ast.Rune{}, nil, returns(ast.LiteralRune{Value: 'a'})),
+			function("newline", ast.Rune{}, nil, returns(ast.LiteralRune{Value: '\n'})),
+			function("quote", ast.Rune{}, nil, returns(ast.LiteralRune{Value: '\''})),
+			function("emoji", ast.Rune{}, nil, returns(ast.LiteralRune{Value: '😀'})),
+		),
+	},
+	{
+		Name: "LiteralSet",
+		Root: module(function(
+			"letters",
+			ast.Set{Item: ast.Rune{}},
+			nil,
+			returns(ast.LiteralSet{Values: []ast.Value{ast.LiteralRune{Value: 'a'}, ast.LiteralRune{Value: 'b'}}}),
+		)),
+	},
+	{
+		Name: "LiteralString",
+		Root: module(
+			function("greeting", ast.String{}, nil, returns(str("hello"))),
+			function("escaped", ast.String{}, nil, returns(str("\"quoted\"\t\\\n"))),
+			function("unicode", ast.String{}, nil, returns(str("héllo 😀"))),
+		),
+	},
+	{
+		Name: "LiteralUint8",
+		Root: module(
+			function("smallest", ast.Uint8{}, nil, returns(ast.LiteralUint8{Value: 0})),
+			function("largest", ast.Uint8{}, nil, returns(ast.LiteralUint8{Value: 255})),
+		),
+	},
+	{
+		Name: "Lookup",
+		Root: module(
+			function(
+				"lookups",
+				ast.List{Item: ast.Int64{}},
+				[]ast.ArgumentDef{
+					argument("items", ast.List{Item: ast.Int64{}}),
+					argument("lookup", ast.Map{Key: ast.String{}, Value: ast.Int64{}}),
+				},
+				returns(ast.LiteralList{Values: []ast.Value{
+					ast.Lookup{From: variable("items"), Key: integer(0)},
+					ast.Lookup{From: variable("lookup"), Key: str("key")},
+				}}),
+			),
+			function(
+				"runeAt",
+				ast.Rune{},
+				[]ast.ArgumentDef{
+					argument("text", ast.String{}),
+					argument("index", ast.Int64{}),
+				},
+				returns(ast.Lookup{From: variable("text"), Key: variable("index")}),
+			),
+		),
+	},
+	{
+		Name: "Map",
+		Root: module(function(
+			"groups",
+			ast.Map{Key: ast.String{}, Value: ast.List{Item: ast.Int64{}}},
+			[]ast.ArgumentDef{argument("values", ast.Map{Key: ast.String{}, Value: ast.List{Item: ast.Int64{}}})},
+			returns(variable("values")),
+		)),
+	},
+	{
+		Name: "MethodCall",
+		Root: moduleWithModels(
+			[]ast.ModelDef{{
+				Name:   counterModel.Name,
+				Fields: counterModel.Fields,
+				Methods: append(slices.Clone(counterModel.Methods), function(
+					"increment",
+					ast.Void{},
+					nil,
+					ast.MethodCall{Receiver: ast.Self{}, Name: "setTo", Arguments: []ast.Value{
+						binary(ast.MethodCall{Receiver: ast.Self{}, Name: "get"}, ast.BinaryOperatorAdd, integer(1)),
+					}},
+				)),
+				EqualOverride: ast.OptionalWithValue(ast.EqualOverride{
+					OtherName: "other",
+					Block: block(returns(compare(
+						ast.MethodCall{Receiver: ast.Self{}, Name: "get"},
+						ast.ComparisonOperatorEqual,
+						ast.MethodCall{Receiver: variable("other"), Name: "get"},
+					))),
+				}),
+			}},
+			function(
+				"countTwice",
+				ast.Int64{},
+				[]ast.ArgumentDef{argument("counter", ast.Model{Name: counterModel.Name})},
+				ast.MethodCall{Receiver: variable("counter"), Name: "increment"},
+				ast.MethodCall{Receiver: variable("counter"), Name: "increment"},
+				returns(ast.MethodCall{Receiver: variable("counter"), Name: "get"}),
+			),
+			function(
+				"first",
+				ast.Int64{},
+				[]ast.ArgumentDef{argument("counters", ast.List{Item: ast.Model{Name: counterModel.Name}})},
+				returns(ast.MethodCall{Receiver: ast.Lookup{From: variable("counters"), Key: integer(0)}, Name: "get"}),
+			),
+			function(
+				"fresh",
+				ast.Int64{},
+				nil,
+				declare("counter", ast.New{Model: ast.Model{Name: counterModel.Name}}),
+				ast.MethodCall{Receiver: variable("counter"), Name: "setTo", Arguments: []ast.Value{integer(3)}},
+				returns(ast.MethodCall{Receiver: variable("counter"), Name: "get"}),
+			),
+		),
+	},
+	{
+		Name: "Model",
+		Root: moduleWithModels(
+			[]ast.ModelDef{pointModel},
+			function("getX", ast.Int64{}, []ast.ArgumentDef{argument("point", ast.Model{Name: "Point"})}, returns(property(variable("point"), "x"))),
+		),
+	},
+	{
+		Name: "ModelDef",
+		Root: moduleWithModels([]ast.ModelDef{counterModel, pointModel}),
+	},
+	{
+		Name: "Module",
+		Root: ast.Root{
+			Modules: []ast.Module{{
+				Name:      moduleName,
+				Constants: []ast.ConstantDef{{Name: "start", Value: integer(5)}},
+				Models:    []ast.ModelDef{counterModel},
+				Functions: []ast.FunctionDef{
+					function(
+						"newCounter",
+						ast.Model{Name: "Counter"},
+						nil,
+						declare("counter", ast.New{Model: ast.Model{Name: "Counter"}}),
+						ast.Assignment{To: property(variable("counter"), "count"), From: variable("start")},
+						returns(variable("counter")),
+					),
+				},
+			}},
+		},
+	},
+	{
+		Name: "New",
+		Root: moduleWithModels(
+			[]ast.ModelDef{boxModel},
+			function(
+				"newBox",
+				ast.Model{Name: "Box"},
+				nil,
+				declare("box", ast.New{Model: ast.Model{Name: "Box"}}),
+				ast.Assignment{To: ast.Lookup{From: property(variable("box"), "labels"), Key: str("first")}, From: integer(1)},
+				ast.AddToSet{Set: property(variable("box"), "tags"), Value: str("new")},
+				returns(variable("box")),
+			),
+		),
+	},
+	{
+		Name: "Nil",
+		Root: moduleWithModels(
+			[]ast.ModelDef{pointModel},
+			function("nothing", ast.Model{Name: "Point"}, nil, returns(ast.Nil{Type: ast.Model{Name: "Point"}})),
+			function(
+				"noItems",
+				ast.List{Item: ast.Int64{}},
+				nil,
+				declare("items", ast.Nil{Type: ast.List{Item: ast.Int64{}}}),
+				returns(variable("items")),
+			),
+		),
+	},
+	{
+		Name: "Pop",
+		Root: module(function(
+			"popTwice",
+			ast.Int64{},
+			[]ast.ArgumentDef{argument("items", ast.List{Item: ast.Int64{}})},
+			ast.Pop{List: variable("items")},
+			returns(ast.Pop{List: variable("items")}),
+		)),
+	},
+	{
+		Name: "Property",
+		Root: moduleWithModels(
+			[]ast.ModelDef{pointModel},
+			function(
+				"moveTo",
+				ast.Int64{},
+				[]ast.ArgumentDef{
+					argument("point", ast.Model{Name: "Point"}),
+					argument("x", ast.Int64{}),
+				},
+				ast.Assignment{To: property(variable("point"), "x"), From: variable("x")},
+				returns(property(variable("point"), "y")),
+			),
+		),
+	},
+	{
+		Name: "Push",
+		Root: module(function(
+			"pushItem",
+			ast.List{Item: ast.String{}},
+			[]ast.ArgumentDef{argument("items", ast.List{Item: ast.String{}})},
+			ast.Push{List: variable("items"), Value: str("item")},
+			returns(variable("items")),
+		)),
+	},
+	{
+		Name: "Return",
+		Root: module(function("returnValue", ast.String{}, nil, returns(str("value")))),
+	},
+	{
+		Name: "Root",
+		Root: ast.Root{
+			Modules: []ast.Module{
+				{Name: "first", Functions: []ast.FunctionDef{oneFunction}},
+				{Name: "second", Functions: []ast.FunctionDef{identityFunction}},
+			},
+		},
+	},
+	{
+		Name: "Rune",
+		Root: module(function(
+			"sameRune",
+			ast.Rune{},
+			[]ast.ArgumentDef{argument("value", ast.Rune{})},
+			returns(variable("value")),
+		)),
+	},
+	{
+		Name: "Self",
+		Root: moduleWithModels([]ast.ModelDef{counterModel}),
+	},
+	{
+		Name: "Set",
+		Root: module(function(
+			"sameSet",
+			ast.Set{Item: ast.Rune{}},
+			[]ast.ArgumentDef{argument("values", ast.Set{Item: ast.Rune{}})},
+			returns(variable("values")),
+		)),
+	},
+	{
+		Name: "SetContains",
+		Root: module(function(
+			"contains",
+			ast.Bool{},
+			[]ast.ArgumentDef{
+				argument("values", ast.Set{Item: ast.String{}}),
+				argument("value", ast.String{}),
+			},
+			returns(ast.SetContains{Set: variable("values"), Value: variable("value")}),
+		)),
+	},
+	{
+		Name: "String",
+		Root: module(function(
+			"sameString",
+			ast.String{},
+			[]ast.ArgumentDef{argument("value", ast.String{})},
+			returns(variable("value")),
+		)),
+	},
+	{
+		Name: "Unary",
+		Root: module(
+			function(
+				"negate",
+				ast.Int64{},
+				[]ast.ArgumentDef{argument("value", ast.Int64{})},
+				declare("five", ast.Unary{Operator: ast.UnaryOperatorNegate, Value: integer(-5)}),
+				returns(binary(ast.Unary{Operator: ast.UnaryOperatorNegate, Value: variable("value")}, ast.BinaryOperatorAdd, variable("five"))),
+			),
+			function(
+				"invert",
+				ast.Bool{},
+				[]ast.ArgumentDef{argument("value", ast.Bool{})},
+				returns(ast.Unary{Operator: ast.UnaryOperatorNot, Value: variable("value")}),
+			),
+		),
+	},
+	{
+		Name: "Uint8",
+		Root: module(
+			function(
+				"scale",
+				ast.Uint8{},
+				[]ast.ArgumentDef{argument("value", ast.Uint8{})},
+				declare("limit", ast.LiteralUint8{Value: 200}),
+				returns(binary(binary(variable("value"), ast.BinaryOperatorMultiply, variable("limit")), ast.BinaryOperatorSubtract, ast.LiteralUint8{Value: 1})),
+			),
+			function("underflow", ast.Uint8{}, nil, returns(binary(ast.LiteralUint8{Value: 0}, ast.BinaryOperatorSubtract, ast.LiteralUint8{Value: 1}))),
+		),
+	},
+	{
+		Name: "Variable",
+		Root: module(function(
+			"variable",
+			ast.Int64{},
+			nil,
+			declare("value", integer(1)),
+			returns(variable("value")),
+		)),
+	},
+	{
+		Name: "Visibility",
+		Root: ast.Root{
+			Modules: []ast.Module{
+				{
+					Name:    moduleName,
+					Imports: []ast.Import{{Module: "bank"}},
+					Functions: []ast.FunctionDef{
+						function(
+							"balance",
+							ast.Int64{},
+							nil,
+							declare("account", ast.Call{Function: ast.FunctionRef{Name: "open"}}),
+							returns(binary(
+								binary(property(variable("account"), "balance"), ast.BinaryOperatorAdd, ast.MethodCall{Receiver: variable("account"), Name: "count"}),
+								ast.BinaryOperatorAdd,
+								ast.Call{Function: ast.FunctionRef{Name: "summary", Module: "bank"}, Arguments: []ast.Value{variable("account")}},
+							)),
+						),
+						private(function(
+							"open",
+							ast.Model{Name: "Account", Module: "bank"},
+							nil,
+							declare("account", ast.New{Model: ast.Model{Name: "Account", Module: "bank"}}),
+							ast.MethodCall{Receiver: variable("account"), Name: "deposit", Arguments: []ast.Value{integer(10)}},
+							returns(variable("account")),
+						)),
+					},
+				},
+				{
+					Name:      "bank",
+					Constants: []ast.ConstantDef{{Name: "fee", Visibility: ast.VisibilityPrivate, Value: integer(1)}},
+					Models: []ast.ModelDef{
+						{
+							Name: "Account",
+							Fields: []ast.FieldDef{
+								field("balance", ast.Int64{}),
+								{Name: "amounts", Visibility: ast.VisibilityPrivate, Type: ast.Set{Item: ast.Int64{}}},
+							},
+							Methods: []ast.FunctionDef{
+								function(
+									"deposit",
+									ast.Void{},
+									[]ast.ArgumentDef{argument("amount", ast.Int64{})},
+									ast.MethodCall{Receiver: ast.Self{}, Name: "record", Arguments: []ast.Value{variable("amount")}},
+									ast.Assignment{
+										To:   property(ast.Self{}, "balance"),
+										From: binary(binary(property(ast.Self{}, "balance"), ast.BinaryOperatorAdd, variable("amount")), ast.BinaryOperatorSubtract, variable("fee")),
+									},
+								),
+								function("count", ast.Int64{}, nil, returns(ast.Length{Of: property(ast.Self{}, "amounts")})),
+								private(function(
+									"record",
+									ast.Void{},
+									[]ast.ArgumentDef{argument("amount", ast.Int64{})},
+									ast.AddToSet{Set: property(ast.Self{}, "amounts"), Value: variable("amount")},
+								)),
+							},
+						},
+						{
+							Name:       "Ledger",
+							Visibility: ast.VisibilityPrivate,
+							Fields:     []ast.FieldDef{field("total", ast.Int64{})},
+						},
+					},
+					Functions: []ast.FunctionDef{
+						function(
+							"summary",
+							ast.Int64{},
+							[]ast.ArgumentDef{argument("account", ast.Model{Name: "Account"})},
+							returns(ast.Call{Function: ast.FunctionRef{Name: "tally"}, Arguments: []ast.Value{variable("account")}}),
+						),
+						private(function(
+							"tally",
+							ast.Int64{},
+							[]ast.ArgumentDef{argument("account", ast.Model{Name: "Account"})},
+							declare("ledger", ast.New{Model: ast.Model{Name: "Ledger"}}),
+							ast.Assignment{To: property(variable("ledger"), "total"), From: binary(property(variable("account"), "balance"), ast.BinaryOperatorMultiply, integer(2))},
+							returns(property(variable("ledger"), "total")),
+						)),
+					},
+				},
+			},
+		},
+	},
+	{
+		Name: "Void",
+		Root: moduleWithModels(
+			[]ast.ModelDef{counterModel},
+			function(
+				"reset",
+				ast.Void{},
+				[]ast.ArgumentDef{argument("counter", ast.Model{Name: "Counter"})},
+				ast.Assignment{To: property(variable("counter"), "count"), From: integer(0)},
+			),
+		),
+	},
+}
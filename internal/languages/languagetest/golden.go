@@ -0,0 +1,72 @@
+package languagetest
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/JosephNaberhaus/agnostic/code"
+	"github.com/JosephNaberhaus/agnostic/internal/languages"
+	"github.com/JosephNaberhaus/agnostic/internal/mappers/ast_to_code_mapper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "update the golden files instead of comparing against them")
+
+// Generator converts a code tree into the source files of a single language.
+type Generator func(root *code.Root) ([]languages.File, error)
+
+// Compile maps the AST of a case into code.
+func Compile(t *testing.T, c Case) *code.Root {
+	t.Helper()
+
+	mapper := &ast_to_code_mapper.Mapper{}
+	root, err := mapper.MapRoot(c.Root)
+	require.NoError(t, err)
+
+	return root.(*code.Root)
+}
+
+// RunGolden generates each case and compares the output against the golden file at testdata/<case name>.golden. Run
+// the test with -update to rewrite the golden files. The check function, if not nil, is called with the generated
+// files of each case so that the test can further validate them.
+func RunGolden(t *testing.T, generate Generator, check func(t *testing.T, files []languages.File)) {
+	for _, c := range Cases {
+		t.Run(c.Name, func(t *testing.T) {
+			// Checking the output can involve running a slow external compiler.
+			t.Parallel()
+
+			files, err := generate(Compile(t, c))
+			require.NoError(t, err)
+
+			actual := joinFiles(files)
+			goldenFile := filepath.Join("testdata", c.Name+".golden")
+			if *update {
+				require.NoError(t, os.MkdirAll("testdata", os.ModePerm))
+				require.NoError(t, os.WriteFile(goldenFile, []byte(actual), 0o644))
+			} else {
+				expected, err := os.ReadFile(goldenFile)
+				require.NoError(t, err)
+				assert.Equal(t, string(expected), actual)
+			}
+
+			if check != nil {
+				check(t, files)
+			}
+		})
+	}
+}
+
+// joinFiles combines the generated files into the single text of a golden file.
+func joinFiles(files []languages.File) string {
+	var sb strings.Builder
+	for _, file := range files {
+		sb.WriteString("-- " + file.Path + " --\n")
+		sb.WriteString(file.Contents)
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,84 @@
+package python
+
+const (
+	divideHelper      = "divide"
+	floatDivideHelper = "float_divide"
+	int32Helper       = "int32"
+	int64Helper       = "int64"
+	itemHelper        = "item"
+	lookupHelper      = "lookup"
+	moduloHelper      = "modulo"
+	saturateHelper    = "saturate"
+	storeHelper       = "store"
+	uint8Helper       = "uint8"
+)
+
+// helpers are small functions that are added to the generated file when it uses an operation that behaves differently
+// in Python. Python's integers never overflow and its integer division rounds toward negative infinity, while the
+// fixed-width integers wrap around and truncate toward zero. Python also counts negative indices from the end of a
+// sequence, while an index that is out of range is an error everywhere else.
+var helpers = map[string]string{
+	divideHelper: `
+def agnostic_divide(a: int, b: int) -> int:
+    """Divides a by b, truncating toward zero."""
+    quotient = abs(a) // abs(b)
+    return quotient if (a < 0) == (b < 0) else -quotient
+`,
+	floatDivideHelper: `
+def agnostic_float_divide(a: float, b: float) -> float:
+    """Divides a by b, producing an infinity or NaN when b is zero."""
+    if b != 0.0:
+        return a / b
+    if a == 0.0 or a != a:
+        return math.nan
+    return math.copysign(math.inf, a) * math.copysign(1.0, b)
+`,
+	int32Helper: `
+def agnostic_int32(value: int) -> int:
+    """Wraps the value around to the range of an int32."""
+    return (value + 2**31) % 2**32 - 2**31
+`,
+	int64Helper: `
+def agnostic_int64(value: int) -> int:
+    """Wraps the value around to the range of an int64."""
+    return (value + 2**63) % 2**64 - 2**63
+`,
+	itemHelper: `
+AgnosticItem = TypeVar("AgnosticItem")
+`,
+	lookupHelper: `
+def agnostic_lookup(sequence: Sequence[AgnosticItem], index: int) -> AgnosticItem:
+    """Returns the item at the index, which must be in range."""
+    if index < 0 or index >= len(sequence):
+        raise IndexError(f"index {index} is out of range for length {len(sequence)}")
+    return sequence[index]
+`,
+	moduloHelper: `
+def agnostic_modulo(a: int, b: int) -> int:
+    """Returns the remainder of dividing a by b, which has the same sign as a."""
+    return a - b * agnostic_divide(a, b)
+`,
+	saturateHelper: `
+def agnostic_saturate(value: float, minimum: int, maximum: int) -> int:
+    """Truncates the value toward zero and clamps it to the range. NaN becomes zero."""
+    if value != value:
+        return 0
+    if value <= minimum:
+        return minimum
+    if value >= maximum:
+        return maximum
+    return int(value)
+`,
+	storeHelper: `
+def agnostic_store(sequence: MutableSequence[AgnosticItem], index: int, value: AgnosticItem) -> None:
+    """Replaces the item at the index, which must be in range."""
+    if index < 0 or index >= len(sequence):
+        raise IndexError(f"index {index} is out of range for length {len(sequence)}")
+    sequence[index] = value
+`,
+	uint8Helper: `
+def agnostic_uint8(value: int) -> int:
+    """Wraps the value around to the range of a uint8."""
+    return value % 2**8
+`,
+}
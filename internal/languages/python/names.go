@@ -0,0 +1,114 @@
+package python
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/JosephNaberhaus/agnostic/code"
+)
+
+var reservedWords = map[string]struct{}{
+	"False":    {},
+	"None":     {},
+	"True":     {},
+	"and":      {},
+	"as":       {},
+	"assert":   {},
+	"async":    {},
+	"await":    {},
+	"break":    {},
+	"class":    {},
+	"continue": {},
+	"def":      {},
+	"del":      {},
+	"elif":     {},
+	"else":     {},
+	"except":   {},
+	"finally":  {},
+	"for":      {},
+	"from":     {},
+	"global":   {},
+	"if":       {},
+	"import":   {},
+	"in":       {},
+	"is":       {},
+	"lambda":   {},
+	"nonlocal": {},
+	"not":      {},
+	"or":       {},
+	"pass":     {},
+	"raise":    {},
+	"return":   {},
+	"try":      {},
+	"while":    {},
+	"with":     {},
+	"yield":    {},
+
+	// Builtins and imports that the generated code relies on.
+	"AgnosticItem":    {},
+	"Final":           {},
+	"IndexError":      {},
+	"MutableSequence": {},
+	"Sequence":        {},
+	"TypeVar":         {},
+	"bool":            {},
+	"cast":            {},
+	"dict":            {},
+	"int":             {},
+	"isinstance":      {},
+	"len":             {},
+	"list":            {},
+	"object":          {},
+	"self":            {},
+	"set":             {},
+	"str":             {},
+}
+
+// identifier converts an Agnostic name into a valid Python identifier that doesn't shadow anything the generated code
+// depends on.
+func identifier(name string) string {
+	if _, isReserved := reservedWords[name]; isReserved {
+		return name + "_"
+	}
+
+	return name
+}
+
+// declared converts the name of a declaration into a Python identifier. The names of private declarations start with
+// an underscore, which marks them as internal to their module.
+func declared(name string, visibility code.Visibility) string {
+	if visibility == code.VisibilityPrivate {
+		return "_" + name
+	}
+
+	return identifier(name)
+}
+
+// quote converts a string into a double-quoted Python string literal.
+func quote(str string) string {
+	var sb strings.Builder
+	sb.WriteByte('"')
+	for _, r := range str {
+		switch r {
+		case '"':
+			sb.WriteString(`\"`)
+		case '\\':
+			sb.WriteString(`\\`)
+		case '\n':
+			sb.WriteString(`\n`)
+		case '\r':
+			sb.WriteString(`\r`)
+		case '\t':
+			sb.WriteString(`\t`)
+		default:
+			if r < 0x20 || r == 0x7f {
+				sb.WriteString(fmt.Sprintf(`\x%02x`, r))
+			} else {
+				sb.WriteRune(r)
+			}
+		}
+	}
+	sb.WriteByte('"')
+
+	return sb.String()
+}
@@ -0,0 +1,1084 @@
+package python
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/JosephNaberhaus/agnostic/code"
+	"github.com/JosephNaberhaus/agnostic/internal/languages"
+	"github.com/JosephNaberhaus/agnostic/internal/utils/stack"
+)
+
+const indent = "    "
+
+var _ code.NodeMapper[string] = &mapper{}
+
+// Generate converts each module into a Python file with type hints. Sets are dicts whose values are None, since Python
+// sets aren't iterated over in the order that their items were added.
+func Generate(root *code.Root) ([]languages.File, error) {
+	files := make([]languages.File, 0, len(root.Modules))
+	for _, module := range root.Modules {
+		source, err := newMapper(module).MapModule(module)
+		if err != nil {
+			return nil, fmt.Errorf("module %q: %w", module.Name, err)
+		}
+
+		files = append(files, languages.File{
+			Path:     module.Name + ".py",
+			Contents: source,
+		})
+	}
+
+	return files, nil
+}
+
+// mapper converts a single module into Python.
+type mapper struct {
+	// The module being generated.
+	module *code.Module
+	// The model whose methods are currently being generated. Nil when outside a model.
+	self *code.ModelDef
+	// The statement that runs after each iteration of each loop that is currently being generated. ForEach loops push a
+	// nil statement.
+	afterEach stack.Stack[code.Statement]
+	// The names that need to be imported from the typing module.
+	typingImports map[string]struct{}
+	// The helpers that the generated code depends on.
+	helpers map[string]struct{}
+	// The modules that the generated code imports.
+	imports map[string]struct{}
+}
+
+func newMapper(module *code.Module) *mapper {
+	return &mapper{
+		module:        module,
+		typingImports: map[string]struct{}{},
+		helpers:       map[string]struct{}{},
+		imports:       map[string]struct{}{},
+	}
+}
+
+// fromTyping returns a reference to a name from the typing module.
+func (m *mapper) fromTyping(name string) string {
+	m.typingImports[name] = struct{}{}
+	return name
+}
+
+func (m *mapper) useHelper(name string) {
+	m.helpers[name] = struct{}{}
+}
+
+// useSequenceHelper uses a helper that indexes into a sequence of the given type from the typing module.
+func (m *mapper) useSequenceHelper(name string, sequence string) {
+	m.useHelper(name)
+	m.useHelper(itemHelper)
+	m.fromTyping(sequence)
+	m.fromTyping("TypeVar")
+}
+
+func (m *mapper) useImport(name string) {
+	m.imports[name] = struct{}{}
+}
+
+// wrap wraps the result of integer arithmetic around to the range of its type. Python integers never overflow on their
+// own.
+func (m *mapper) wrap(typ code.Type, result string) string {
+	switch typ.(type) {
+	case *code.Int32:
+		m.useHelper(int32Helper)
+		return "agnostic_int32(" + result + ")"
+	case *code.Uint8:
+		m.useHelper(uint8Helper)
+		return "agnostic_uint8(" + result + ")"
+	default:
+		m.useHelper(int64Helper)
+		return "agnostic_int64(" + result + ")"
+	}
+}
+
+// isSequence returns whether values of the type are indexed by position. Python counts a negative position from the
+// end, so indexing into them goes through a helper that checks the bounds instead.
+func isSequence(typ code.Type) bool {
+	switch typ.(type) {
+	case *code.Bytes, *code.List, *code.String:
+		return true
+	default:
+		return false
+	}
+}
+
+// isCollection returns whether values of the type are bytes, lists, maps, or sets, which are references.
+func isCollection(typ code.Type) bool {
+	switch typ.(type) {
+	case *code.Bytes, *code.List, *code.Map, *code.Set:
+		return true
+	default:
+		return false
+	}
+}
+
+// lookupModel finds the definition of a model in the module being generated or in one of its imports.
+func (m *mapper) lookupModel(model *code.Model) (*code.ModelDef, error) {
+	modules := []*code.Module{m.module}
+	for _, i := range m.module.Imports {
+		modules = append(modules, i.Definition)
+	}
+
+	for _, module := range modules {
+		if module.Name != model.Module {
+			continue
+		}
+
+		for _, modelDef := range module.Models {
+			if modelDef.Name == model.Name {
+				return modelDef, nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("undefined model %q", model.Name)
+}
+
+// qualifier returns the prefix of the names of declarations of the module with the given name. Declarations of another
+// module are accessed through the module, which is imported as a whole.
+func (m *mapper) qualifier(module string) string {
+	if module == m.module.Name {
+		return ""
+	}
+
+	return identifier(module) + "."
+}
+
+func (m *mapper) mapValues(values []code.Value) ([]string, error) {
+	return code.MapEachValue[string](values, m)
+}
+
+// body converts a block into the indented lines of a suite.
+func (m *mapper) body(block *code.Block) (string, error) {
+	result, err := m.MapBlock(block)
+	if err != nil {
+		return "", err
+	}
+
+	return languages.Indent(result, indent), nil
+}
+
+// zeroValue returns the value that a variable or field of the given type starts out as.
+func (m *mapper) zeroValue(typ code.Type) (string, error) {
+	pythonType, err := code.MapType[string](typ, m)
+	if err != nil {
+		return "", err
+	}
+
+	switch typ.(type) {
+	case *code.Bool:
+		return "False", nil
+	case *code.Bytes:
+		return "bytearray()", nil
+	case *code.Float64:
+		return "0.0", nil
+	case *code.Int32, *code.Int64, *code.Uint8:
+		return "0", nil
+	case *code.List, *code.Map, *code.Set:
+		// Calling the parameterized type creates an empty collection that mypy knows the type of.
+		return pythonType + "()", nil
+	case *code.Model:
+		// Models aren't typed as optional so that their properties can be accessed without narrowing.
+		return m.fromTyping("cast") + "(" + pythonType + ", None)", nil
+	case *code.Rune:
+		return quote("\x00"), nil
+	case *code.String:
+		return quote(""), nil
+	default:
+		return "", fmt.Errorf("%T has no zero value", typ)
+	}
+}
+
+func (m *mapper) MapAddToSet(value *code.AddToSet) (string, error) {
+	set, err := code.MapValue[string](value.Set, m)
+	if err != nil {
+		return "", err
+	}
+
+	item, err := code.MapValue[string](value.Value, m)
+	if err != nil {
+		return "", err
+	}
+
+	return set + "[" + item + "] = None", nil
+}
+
+func (m *mapper) MapArgumentDef(value *code.ArgumentDef) (string, error) {
+	typ, err := code.MapType[string](value.Type, m)
+	if err != nil {
+		return "", err
+	}
+
+	return identifier(value.Name) + ": " + typ, nil
+}
+
+func (m *mapper) MapAssignment(value *code.Assignment) (string, error) {
+	if lookup, ok := value.To.(*code.Lookup); ok && isSequence(code.TypeOf(lookup.From)) {
+		into, err := code.MapValue[string](lookup.From, m)
+		if err != nil {
+			return "", err
+		}
+
+		key, err := code.MapValue[string](lookup.Key, m)
+		if err != nil {
+			return "", err
+		}
+
+		from, err := code.MapValue[string](value.From, m)
+		if err != nil {
+			return "", err
+		}
+
+		m.useSequenceHelper(storeHelper, "MutableSequence")
+		return "agnostic_store(" + into + ", " + key + ", " + from + ")", nil
+	}
+
+	to, err := code.MapValue[string](value.To, m)
+	if err != nil {
+		return "", err
+	}
+
+	from, err := code.MapValue[string](value.From, m)
+	if err != nil {
+		return "", err
+	}
+
+	return to + " = " + from, nil
+}
+
+func (m *mapper) MapBinary(value *code.Binary) (string, error) {
+	left, err := code.MapValue[string](value.Left, m)
+	if err != nil {
+		return "", err
+	}
+
+	right, err := code.MapValue[string](value.Right, m)
+	if err != nil {
+		return "", err
+	}
+
+	if value.Overflow == code.OverflowWrap {
+		return m.wrap(value.Type, m.integerArithmetic(value.Operator, left, right)), nil
+	}
+
+	switch value.Operator {
+	case code.BinaryOperatorDivide:
+		if value.Overflow == code.OverflowRound {
+			// Python raises when a float is divided by zero instead of producing an infinity or NaN.
+			m.useHelper(floatDivideHelper)
+			m.useImport("math")
+			return "agnostic_float_divide(" + left + ", " + right + ")", nil
+		}
+	case code.BinaryOperatorAnd:
+		return "(" + left + " and " + right + ")", nil
+	case code.BinaryOperatorOr:
+		return "(" + left + " or " + right + ")", nil
+	}
+
+	return "(" + left + " " + value.Operator.Symbol() + " " + right + ")", nil
+}
+
+// integerArithmetic converts integer arithmetic whose result hasn't been wrapped yet.
+func (m *mapper) integerArithmetic(operator code.BinaryOperator, left, right string) string {
+	switch operator {
+	case code.BinaryOperatorDivide:
+		m.useHelper(divideHelper)
+		return "agnostic_divide(" + left + ", " + right + ")"
+	case code.BinaryOperatorModulo:
+		m.useHelper(divideHelper)
+		m.useHelper(moduloHelper)
+		return "agnostic_modulo(" + left + ", " + right + ")"
+	default:
+		return left + " " + operator.Symbol() + " " + right
+	}
+}
+
+func (m *mapper) MapBlock(value *code.Block) (string, error) {
+	if len(value.Statements) == 0 {
+		return "pass", nil
+	}
+
+	statements, err := code.MapEachStatement[string](value.Statements, m)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.Join(statements, "\n"), nil
+}
+
+func (m *mapper) MapBool(value *code.Bool) (string, error) {
+	return "bool", nil
+}
+
+func (m *mapper) MapBreak(value *code.Break) (string, error) {
+	return "break", nil
+}
+
+func (m *mapper) MapBytes(value *code.Bytes) (string, error) {
+	return "bytearray", nil
+}
+
+func (m *mapper) MapCall(value *code.Call) (string, error) {
+	function, ok := value.Function.(*code.FunctionDef)
+	if !ok {
+		return "", fmt.Errorf("unsupported callable %T", value.Function)
+	}
+
+	arguments, err := m.mapValues(value.Arguments)
+	if err != nil {
+		return "", err
+	}
+
+	return m.qualifier(function.Module.Name) + declared(function.Name, function.Visibility) + "(" + strings.Join(arguments, ", ") + ")", nil
+}
+
+func (m *mapper) MapComparison(value *code.Comparison) (string, error) {
+	left, err := code.MapValue[string](value.Left, m)
+	if err != nil {
+		return "", err
+	}
+
+	right, err := code.MapValue[string](value.Right, m)
+	if err != nil {
+		return "", err
+	}
+
+	// Models with an equal override define __eq__, so they can be compared like everything else.
+	return "(" + left + " " + value.Operator.Symbol() + " " + right + ")", nil
+}
+
+func (m *mapper) MapConditional(value *code.Conditional) (string, error) {
+	var sb strings.Builder
+	for i, ifNode := range value.Ifs {
+		result, err := m.MapIf(ifNode)
+		if err != nil {
+			return "", err
+		}
+
+		if i > 0 {
+			sb.WriteString("\nel")
+		}
+		sb.WriteString(result)
+	}
+
+	if value.Else != nil {
+		body, err := m.body(value.Else)
+		if err != nil {
+			return "", err
+		}
+
+		sb.WriteString("\nelse:\n" + body)
+	}
+
+	return sb.String(), nil
+}
+
+func (m *mapper) MapConstantDef(value *code.ConstantDef) (string, error) {
+	constant, err := code.MapConstantValue[string](value.Value, m)
+	if err != nil {
+		return "", err
+	}
+
+	typ := code.TypeOf(value.Value.(code.Value))
+	if isCollection(typ) {
+		// The collection is created each time that it's used instead of shared, so that changing the collection doesn't
+		// change the constant.
+		pythonType, err := code.MapType[string](typ, m)
+		if err != nil {
+			return "", err
+		}
+
+		return "def " + declared(value.Name, value.Visibility) + "() -> " + pythonType + ":\n" + indent + "return " + constant, nil
+	}
+
+	return declared(value.Name, value.Visibility) + ": " + m.fromTyping("Final") + " = " + constant, nil
+}
+
+func (m *mapper) MapContinue(value *code.Continue) (string, error) {
+	// While loops don't have a place for the statement that runs after each iteration, so it needs to run before
+	// continuing as well.
+	if len(m.afterEach) > 0 && m.afterEach.Peek() != nil {
+		afterEach, err := code.MapStatement[string](m.afterEach.Peek(), m)
+		if err != nil {
+			return "", err
+		}
+
+		return afterEach + "\ncontinue", nil
+	}
+
+	return "continue", nil
+}
+
+func (m *mapper) MapConversion(value *code.Conversion) (string, error) {
+	operand, err := code.MapValue[string](value.Value, m)
+	if err != nil {
+		return "", err
+	}
+
+	switch value.Overflow {
+	case code.OverflowWrap:
+		return m.wrap(value.To, operand), nil
+	case code.OverflowSaturate:
+		m.useHelper(saturateHelper)
+		minimum, maximum := code.IntegerRange(value.To)
+		return fmt.Sprintf("agnostic_saturate(%s, %d, %d)", operand, minimum, maximum), nil
+	}
+
+	if _, toFloat := value.To.(*code.Float64); toFloat && code.IsInteger(code.TypeOf(value.Value)) {
+		return "float(" + operand + ")", nil
+	}
+
+	// Every other conversion keeps the value as it is.
+	return operand, nil
+}
+
+func (m *mapper) MapDeclare(value *code.Declare) (string, error) {
+	initial, err := code.MapValue[string](value.Value, m)
+	if err != nil {
+		return "", err
+	}
+
+	if nilValue, ok := value.Value.(*code.Nil); ok {
+		// Give the variable a type so that it can be assigned something other than nil later.
+		typ, err := code.MapType[string](nilValue.Type, m)
+		if err != nil {
+			return "", err
+		}
+
+		return identifier(value.Name) + ": " + typ + " = " + initial, nil
+	}
+
+	return identifier(value.Name) + " = " + initial, nil
+}
+
+func (m *mapper) MapEmptyList(value *code.EmptyList) (string, error) {
+	return m.zeroValue(&code.List{Item: value.Type})
+}
+
+func (m *mapper) MapEqualOverride(value *code.EqualOverride) (string, error) {
+	body, err := m.body(value.Block)
+	if err != nil {
+		return "", err
+	}
+
+	other := identifier(value.OtherName)
+	return fmt.Sprintf(
+		"def __eq__(self, %s: object) -> bool:\n%sif not isinstance(%s, %s):\n%sreturn False\n%s",
+		other,
+		indent,
+		other,
+		declared(m.self.Name, m.self.Visibility),
+		indent+indent,
+		body,
+	), nil
+}
+
+func (m *mapper) MapFieldDef(value *code.FieldDef) (string, error) {
+	typ, err := code.MapType[string](value.Type, m)
+	if err != nil {
+		return "", err
+	}
+
+	zero, err := m.zeroValue(value.Type)
+	if err != nil {
+		return "", err
+	}
+
+	return "self." + declared(value.Name, value.Visibility) + ": " + typ + " = " + zero, nil
+}
+
+func (m *mapper) MapFloat64(value *code.Float64) (string, error) {
+	return "float", nil
+}
+
+func (m *mapper) MapFor(value *code.For) (string, error) {
+	// Python has no three-part for loop, so it's written as a while loop.
+	var lines []string
+	if value.Initialization != nil {
+		initialization, err := code.MapStatement[string](value.Initialization, m)
+		if err != nil {
+			return "", err
+		}
+
+		lines = append(lines, initialization)
+	}
+
+	condition, err := code.MapValue[string](value.Condition, m)
+	if err != nil {
+		return "", err
+	}
+
+	m.afterEach.Push(value.AfterEach)
+	body, err := m.MapBlock(value.Block)
+	m.afterEach.Pop()
+	if err != nil {
+		return "", err
+	}
+
+	if value.AfterEach != nil {
+		afterEach, err := code.MapStatement[string](value.AfterEach, m)
+		if err != nil {
+			return "", err
+		}
+
+		body += "\n" + afterEach
+	}
+
+	lines = append(lines, "while "+condition+":\n"+languages.Indent(body, indent))
+
+	return strings.Join(lines, "\n"), nil
+}
+
+func (m *mapper) MapForEach(value *code.ForEach) (string, error) {
+	iterable, err := code.MapValue[string](value.Iterable, m)
+	if err != nil {
+		return "", err
+	}
+
+	m.afterEach.Push(nil)
+	body, err := m.body(value.Block)
+	m.afterEach.Pop()
+	if err != nil {
+		return "", err
+	}
+
+	// Strings iterate over their code points and dicts iterate over their keys, which is exactly what ForEach needs. The
+	// other collections are copied, so that changing the collection in the loop doesn't change which items are visited.
+	if isCollection(code.TypeOf(value.Iterable)) {
+		iterable = "list(" + iterable + ")"
+	}
+
+	return "for " + identifier(value.ItemName) + " in " + iterable + ":\n" + body, nil
+}
+
+func (m *mapper) MapFunctionDef(value *code.FunctionDef) (string, error) {
+	var arguments []string
+	if m.self != nil {
+		arguments = append(arguments, "self")
+	}
+
+	for _, argument := range value.Arguments {
+		result, err := m.MapArgumentDef(argument)
+		if err != nil {
+			return "", err
+		}
+
+		arguments = append(arguments, result)
+	}
+
+	returnType, err := code.MapType[string](value.ReturnType, m)
+	if err != nil {
+		return "", err
+	}
+
+	body, err := m.body(value.Block)
+	if err != nil {
+		return "", err
+	}
+
+	return "def " + declared(value.Name, value.Visibility) + "(" + strings.Join(arguments, ", ") + ") -> " + returnType + ":\n" + body, nil
+}
+
+func (m *mapper) MapFunctionRef(value *code.FunctionRef) (string, error) {
+	// Calls refer to the definition of the function once the AST is mapped to code.
+	return "", fmt.Errorf("unresolved reference to function %q", value.Name)
+}
+
+func (m *mapper) MapHashOverride(value *code.HashOverride) (string, error) {
+	body, err := m.body(value.Block)
+	if err != nil {
+		return "", err
+	}
+
+	return "def __hash__(self) -> int:\n" + body, nil
+}
+
+func (m *mapper) MapIf(value *code.If) (string, error) {
+	condition, err := code.MapValue[string](value.Condition, m)
+	if err != nil {
+		return "", err
+	}
+
+	body, err := m.body(value.Block)
+	if err != nil {
+		return "", err
+	}
+
+	return "if " + condition + ":\n" + body, nil
+}
+
+func (m *mapper) MapImport(value *code.Import) (string, error) {
+	return "import " + identifier(value.Module), nil
+}
+
+func (m *mapper) MapInt32(value *code.Int32) (string, error) {
+	return "int", nil
+}
+
+func (m *mapper) MapInt64(value *code.Int64) (string, error) {
+	return "int", nil
+}
+
+func (m *mapper) MapKeyValue(value *code.KeyValue) (string, error) {
+	key, err := code.MapValue[string](value.Key, m)
+	if err != nil {
+		return "", err
+	}
+
+	mapValue, err := code.MapValue[string](value.Value, m)
+	if err != nil {
+		return "", err
+	}
+
+	return key + ": " + mapValue, nil
+}
+
+func (m *mapper) MapLength(value *code.Length) (string, error) {
+	of, err := code.MapValue[string](value.Of, m)
+	if err != nil {
+		return "", err
+	}
+
+	return "len(" + of + ")", nil
+}
+
+func (m *mapper) MapList(value *code.List) (string, error) {
+	item, err := code.MapType[string](value.Item, m)
+	if err != nil {
+		return "", err
+	}
+
+	return "list[" + item + "]", nil
+}
+
+func (m *mapper) MapLiteralBool(value *code.LiteralBool) (string, error) {
+	if value.Value {
+		return "True", nil
+	}
+
+	return "False", nil
+}
+
+func (m *mapper) MapLiteralBytes(value *code.LiteralBytes) (string, error) {
+	values := make([]string, 0, len(value.Value))
+	for _, b := range value.Value {
+		values = append(values, strconv.FormatUint(uint64(b), 10))
+	}
+
+	return "bytearray([" + strings.Join(values, ", ") + "])", nil
+}
+
+func (m *mapper) MapLiteralFloat64(value *code.LiteralFloat64) (string, error) {
+	return languages.FormatFloat(value.Value), nil
+}
+
+func (m *mapper) MapLiteralInt32(value *code.LiteralInt32) (string, error) {
+	return strconv.FormatInt(int64(value.Value), 10), nil
+}
+
+func (m *mapper) MapLiteralInt64(value *code.LiteralInt64) (string, error) {
+	return strconv.FormatInt(value.Value, 10), nil
+}
+
+func (m *mapper) MapLiteralList(value *code.LiteralList) (string, error) {
+	values, err := m.mapValues(value.Values)
+	if err != nil {
+		return "", err
+	}
+
+	return "[" + strings.Join(values, ", ") + "]", nil
+}
+
+func (m *mapper) MapLiteralMap(value *code.LiteralMap) (string, error) {
+	entries := make([]string, 0, len(value.Values))
+	for _, keyValue := range value.Values {
+		result, err := m.MapKeyValue(keyValue)
+		if err != nil {
+			return "", err
+		}
+
+		entries = append(entries, result)
+	}
+
+	return "{" + strings.Join(entries, ", ") + "}", nil
+}
+
+func (m *mapper) MapLiteralRune(value *code.LiteralRune) (string, error) {
+	// Runes are represented as strings that contain a single code point.
+	return quote(string(value.Value)), nil
+}
+
+func (m *mapper) MapLiteralSet(value *code.LiteralSet) (string, error) {
+	values, err := m.mapValues(value.Values)
+	if err != nil {
+		return "", err
+	}
+
+	if len(values) == 0 {
+		return m.zeroValue(code.TypeOf(value))
+	}
+
+	for i := range values {
+		values[i] += ": None"
+	}
+
+	return "{" + strings.Join(values, ", ") + "}", nil
+}
+
+func (m *mapper) MapLiteralString(value *code.LiteralString) (string, error) {
+	return quote(value.Value), nil
+}
+
+func (m *mapper) MapLiteralUint8(value *code.LiteralUint8) (string, error) {
+	return strconv.FormatUint(uint64(value.Value), 10), nil
+}
+
+func (m *mapper) MapLookup(value *code.Lookup) (string, error) {
+	from, err := code.MapValue[string](value.From, m)
+	if err != nil {
+		return "", err
+	}
+
+	key, err := code.MapValue[string](value.Key, m)
+	if err != nil {
+		return "", err
+	}
+
+	if isSequence(code.TypeOf(value.From)) {
+		m.useSequenceHelper(lookupHelper, "Sequence")
+		return "agnostic_lookup(" + from + ", " + key + ")", nil
+	}
+
+	return from + "[" + key + "]", nil
+}
+
+func (m *mapper) MapMap(value *code.Map) (string, error) {
+	key, err := code.MapType[string](value.Key, m)
+	if err != nil {
+		return "", err
+	}
+
+	mapValue, err := code.MapType[string](value.Value, m)
+	if err != nil {
+		return "", err
+	}
+
+	return "dict[" + key + ", " + mapValue + "]", nil
+}
+
+func (m *mapper) MapMethodCall(value *code.MethodCall) (string, error) {
+	receiver, err := code.MapValue[string](value.Receiver, m)
+	if err != nil {
+		return "", err
+	}
+
+	arguments, err := m.mapValues(value.Arguments)
+	if err != nil {
+		return "", err
+	}
+
+	return receiver + "." + declared(value.Method.Name, value.Method.Visibility) + "(" + strings.Join(arguments, ", ") + ")", nil
+}
+
+func (m *mapper) MapModel(value *code.Model) (string, error) {
+	model, err := m.lookupModel(value)
+	if err != nil {
+		return "", err
+	}
+
+	return m.qualifier(value.Module) + declared(model.Name, model.Visibility), nil
+}
+
+func (m *mapper) MapModelDef(value *code.ModelDef) (string, error) {
+	var members []string
+	if len(value.Fields) > 0 {
+		fields := make([]string, 0, len(value.Fields))
+		for _, field := range value.Fields {
+			result, err := m.MapFieldDef(field)
+			if err != nil {
+				return "", err
+			}
+
+			fields = append(fields, result)
+		}
+
+		members = append(members, "def __init__(self) -> None:\n"+languages.Indent(strings.Join(fields, "\n"), indent))
+	}
+
+	m.self = value
+	defer func() { m.self = nil }()
+
+	for _, method := range value.Methods {
+		result, err := m.MapFunctionDef(method)
+		if err != nil {
+			return "", err
+		}
+
+		members = append(members, result)
+	}
+
+	if value.EqualOverride != nil {
+		result, err := m.MapEqualOverride(value.EqualOverride)
+		if err != nil {
+			return "", err
+		}
+
+		members = append(members, result)
+
+		if value.HashOverride == nil {
+			// Defining __eq__ makes a class unhashable. Without a hash override there's no way to know which instances
+			// are equal, so every instance needs to hash the same.
+			members = append(members, "def __hash__(self) -> int:\n"+indent+"return hash("+declared(value.Name, value.Visibility)+")")
+		}
+	}
+
+	if value.HashOverride != nil {
+		result, err := m.MapHashOverride(value.HashOverride)
+		if err != nil {
+			return "", err
+		}
+
+		members = append(members, result)
+	}
+
+	if len(members) == 0 {
+		members = append(members, "pass")
+	}
+
+	return "class " + declared(value.Name, value.Visibility) + ":\n" + languages.Indent(strings.Join(members, "\n\n"), indent), nil
+}
+
+func (m *mapper) MapModule(value *code.Module) (string, error) {
+	for _, i := range value.Imports {
+		m.useImport(identifier(i.Module))
+	}
+
+	// Models come first so that constants can refer to them at import time.
+	var declarations []string
+	for _, model := range value.Models {
+		result, err := m.MapModelDef(model)
+		if err != nil {
+			return "", fmt.Errorf("model %q: %w", model.Name, err)
+		}
+
+		declarations = append(declarations, result)
+	}
+
+	// Constant collections are functions, so they're separated like functions instead of grouped with the rest.
+	var constants, factories []string
+	for _, constant := range value.Constants {
+		result, err := m.MapConstantDef(constant)
+		if err != nil {
+			return "", fmt.Errorf("constant %q: %w", constant.Name, err)
+		}
+
+		if isCollection(code.TypeOf(constant.Value.(code.Value))) {
+			factories = append(factories, result)
+		} else {
+			constants = append(constants, result)
+		}
+	}
+
+	if len(constants) > 0 {
+		declarations = append(declarations, strings.Join(constants, "\n"))
+	}
+	declarations = append(declarations, factories...)
+
+	for _, function := range value.Functions {
+		result, err := m.MapFunctionDef(function)
+		if err != nil {
+			return "", fmt.Errorf("function %q: %w", function.Name, err)
+		}
+
+		declarations = append(declarations, result)
+	}
+
+	// Helpers come before everything else so that constants can use them at import time.
+	if len(m.helpers) > 0 {
+		names := make([]string, 0, len(m.helpers))
+		for name := range m.helpers {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+
+		helperDeclarations := make([]string, 0, len(names))
+		for _, name := range names {
+			helperDeclarations = append(helperDeclarations, strings.TrimSpace(helpers[name]))
+		}
+
+		declarations = append(helperDeclarations, declarations...)
+	}
+
+	var sb strings.Builder
+	sb.WriteString("# Code generated by agnostic. DO NOT EDIT.\n\n")
+	sb.WriteString("from __future__ import annotations\n\n")
+
+	if len(m.imports) > 0 {
+		imports := make([]string, 0, len(m.imports))
+		for name := range m.imports {
+			imports = append(imports, name)
+		}
+		slices.Sort(imports)
+
+		for _, name := range imports {
+			sb.WriteString("import " + name + "\n")
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(m.typingImports) > 0 {
+		imports := make([]string, 0, len(m.typingImports))
+		for name := range m.typingImports {
+			imports = append(imports, name)
+		}
+		slices.Sort(imports)
+
+		sb.WriteString("from typing import " + strings.Join(imports, ", ") + "\n\n")
+	}
+
+	sb.WriteString("\n" + strings.Join(declarations, "\n\n\n") + "\n")
+
+	return sb.String(), nil
+}
+
+func (m *mapper) MapNew(value *code.New) (string, error) {
+	model, err := m.MapModel(value.Model)
+	if err != nil {
+		return "", err
+	}
+
+	return model + "()", nil
+}
+
+func (m *mapper) MapNil(value *code.Nil) (string, error) {
+	return m.zeroValue(value.Type)
+}
+
+func (m *mapper) MapPop(value *code.Pop) (string, error) {
+	list, err := code.MapValue[string](value.List, m)
+	if err != nil {
+		return "", err
+	}
+
+	return list + ".pop()", nil
+}
+
+func (m *mapper) MapProperty(value *code.Property) (string, error) {
+	of, err := code.MapValue[string](value.Of, m)
+	if err != nil {
+		return "", err
+	}
+
+	return of + "." + declared(value.Field.Name, value.Field.Visibility), nil
+}
+
+func (m *mapper) MapPush(value *code.Push) (string, error) {
+	list, err := code.MapValue[string](value.List, m)
+	if err != nil {
+		return "", err
+	}
+
+	item, err := code.MapValue[string](value.Value, m)
+	if err != nil {
+		return "", err
+	}
+
+	return list + ".append(" + item + ")", nil
+}
+
+func (m *mapper) MapReturn(value *code.Return) (string, error) {
+	result, err := code.MapValue[string](value.Value, m)
+	if err != nil {
+		return "", err
+	}
+
+	return "return " + result, nil
+}
+
+func (m *mapper) MapRoot(value *code.Root) (string, error) {
+	return "", fmt.Errorf("a root can't be generated as a single Python file")
+}
+
+func (m *mapper) MapRune(value *code.Rune) (string, error) {
+	return "str", nil
+}
+
+func (m *mapper) MapSelf(value *code.Self) (string, error) {
+	return "self", nil
+}
+
+func (m *mapper) MapSet(value *code.Set) (string, error) {
+	item, err := code.MapType[string](value.Item, m)
+	if err != nil {
+		return "", err
+	}
+
+	return "dict[" + item + ", None]", nil
+}
+
+func (m *mapper) MapSetContains(value *code.SetContains) (string, error) {
+	set, err := code.MapValue[string](value.Set, m)
+	if err != nil {
+		return "", err
+	}
+
+	item, err := code.MapValue[string](value.Value, m)
+	if err != nil {
+		return "", err
+	}
+
+	return item + " in " + set, nil
+}
+
+func (m *mapper) MapString(value *code.String) (string, error) {
+	return "str", nil
+}
+
+func (m *mapper) MapUint8(value *code.Uint8) (string, error) {
+	return "int", nil
+}
+
+func (m *mapper) MapUnary(value *code.Unary) (string, error) {
+	operand, err := code.MapValue[string](value.Value, m)
+	if err != nil {
+		return "", err
+	}
+
+	if value.Operator == code.UnaryOperatorNot {
+		return "(not " + operand + ")", nil
+	}
+
+	if value.Overflow == code.OverflowWrap {
+		if strings.HasPrefix(operand, "-") {
+			operand = "(" + operand + ")"
+		}
+
+		return m.wrap(value.Type, "-"+operand), nil
+	}
+
+	return "(" + value.Operator.Symbol() + operand + ")", nil
+}
+
+func (m *mapper) MapVariable(value *code.Variable) (string, error) {
+	if constant, ok := value.Definition.(*code.ConstantDef); ok {
+		name := m.qualifier(constant.Module.Name) + declared(constant.Name, constant.Visibility)
+		if isCollection(code.TypeOf(constant.Value.(code.Value))) {
+			return name + "()", nil
+		}
+
+		return name, nil
+	}
+
+	return identifier(value.Name), nil
+}
+
+func (m *mapper) MapVoid(value *code.Void) (string, error) {
+	return "None", nil
+}
@@ -0,0 +1,35 @@
+package python
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/JosephNaberhaus/agnostic/internal/languages"
+	"github.com/JosephNaberhaus/agnostic/internal/languages/languagetest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate(t *testing.T) {
+	languagetest.RunGolden(t, Generate, typeCheck)
+}
+
+// typeCheck runs mypy in strict mode on the generated files. It's skipped if mypy isn't installed.
+func typeCheck(t *testing.T, files []languages.File) {
+	mypy, err := exec.LookPath("mypy")
+	if err != nil {
+		t.Skip("mypy is not installed")
+	}
+
+	dir := t.TempDir()
+	args := []string{"--strict", "--no-incremental", "--cache-dir", filepath.Join(dir, ".mypy_cache")}
+	for _, file := range files {
+		path := filepath.Join(dir, file.Path)
+		require.NoError(t, os.WriteFile(path, []byte(file.Contents), 0o644))
+		args = append(args, path)
+	}
+
+	output, err := exec.Command(mypy, args...).CombinedOutput()
+	require.NoError(t, err, string(output))
+}
@@ -0,0 +1,189 @@
+package rust
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/JosephNaberhaus/agnostic/code"
+)
+
+var keywords = map[string]struct{}{
+	"abstract": {},
+	"as":       {},
+	"async":    {},
+	"await":    {},
+	"become":   {},
+	"box":      {},
+	"break":    {},
+	"const":    {},
+	"continue": {},
+	"crate":    {},
+	"do":       {},
+	"dyn":      {},
+	"else":     {},
+	"enum":     {},
+	"extern":   {},
+	"false":    {},
+	"final":    {},
+	"fn":       {},
+	"for":      {},
+	"gen":      {},
+	"if":       {},
+	"impl":     {},
+	"in":       {},
+	"let":      {},
+	"loop":     {},
+	"macro":    {},
+	"match":    {},
+	"mod":      {},
+	"move":     {},
+	"mut":      {},
+	"override": {},
+	"priv":     {},
+	"pub":      {},
+	"ref":      {},
+	"return":   {},
+	"self":     {},
+	"static":   {},
+	"struct":   {},
+	"super":    {},
+	"trait":    {},
+	"true":     {},
+	"try":      {},
+	"type":     {},
+	"typeof":   {},
+	"unsafe":   {},
+	"unsized":  {},
+	"use":      {},
+	"virtual":  {},
+	"where":    {},
+	"while":    {},
+	"yield":    {},
+}
+
+// reserved are the names that the generated code uses for itself.
+var reserved = map[string]struct{}{
+	runtimeModule:  {},
+	thisIdentifier: {},
+}
+
+// preludeTypes are the names that the generated code refers to without qualification. Models with these names would
+// shadow them.
+var preludeTypes = map[string]struct{}{
+	"Box":       {},
+	"Clone":     {},
+	"Debug":     {},
+	"Default":   {},
+	"Eq":        {},
+	"Hash":      {},
+	"Hasher":    {},
+	"LazyLock":  {},
+	"None":      {},
+	"Option":    {},
+	"PartialEq": {},
+	"Self":      {},
+	"Some":      {},
+	"String":    {},
+	"Vec":       {},
+}
+
+// identifier converts an Agnostic name into a snake case Rust identifier.
+func identifier(name string) string {
+	result := snakeCase(name)
+	if _, isKeyword := keywords[result]; isKeyword {
+		return result + "_"
+	}
+
+	if _, isReserved := reserved[result]; isReserved {
+		return result + "_"
+	}
+
+	return result
+}
+
+// public returns the visibility qualifier of an item with the given visibility. Items without one are private to their
+// Rust module.
+func public(visibility code.Visibility) string {
+	if visibility == code.VisibilityPrivate {
+		return ""
+	}
+
+	return "pub "
+}
+
+// constantIdentifier converts an Agnostic name into a screaming snake case Rust identifier.
+func constantIdentifier(name string) string {
+	return strings.ToUpper(snakeCase(name))
+}
+
+// typeIdentifier converts the name of a model into a Rust identifier. Model names are already expected to be in upper
+// camel case.
+func typeIdentifier(name string) string {
+	if _, isPrelude := preludeTypes[name]; isPrelude {
+		return name + "_"
+	}
+
+	return name
+}
+
+// snakeCase converts a camel case name into snake case. A run of capital letters is treated as a single word.
+func snakeCase(name string) string {
+	runes := []rune(name)
+
+	var sb strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) && i > 0 {
+			previous := runes[i-1]
+			nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(previous) || unicode.IsDigit(previous) || (unicode.IsUpper(previous) && nextIsLower) {
+				sb.WriteByte('_')
+			}
+		}
+
+		sb.WriteRune(unicode.ToLower(r))
+	}
+
+	return sb.String()
+}
+
+// escape converts a rune into its form inside of a Rust string or char literal.
+func escape(r rune, quote rune) string {
+	switch r {
+	case quote:
+		return `\` + string(r)
+	case '\\':
+		return `\\`
+	case '\n':
+		return `\n`
+	case '\r':
+		return `\r`
+	case '\t':
+		return `\t`
+	case 0:
+		return `\0`
+	default:
+		if !unicode.IsPrint(r) {
+			return fmt.Sprintf(`\u{%x}`, r)
+		}
+
+		return string(r)
+	}
+}
+
+// quote converts a string into a Rust string literal.
+func quote(str string) string {
+	var sb strings.Builder
+	sb.WriteByte('"')
+	for _, r := range str {
+		sb.WriteString(escape(r, '"'))
+	}
+	sb.WriteByte('"')
+
+	return sb.String()
+}
+
+// quoteChar converts a rune into a Rust char literal.
+func quoteChar(r rune) string {
+	return "'" + escape(r, '\'') + "'"
+}
@@ -0,0 +1,1200 @@
+package rust
+
+import (
+	_ "embed"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/JosephNaberhaus/agnostic/code"
+	"github.com/JosephNaberhaus/agnostic/internal/languages"
+	"github.com/JosephNaberhaus/agnostic/internal/utils/stack"
+)
+
+//go:embed runtime/agnostic.rs
+var runtime string
+
+const (
+	libraryPath = "lib.rs"
+
+	runtimeFilename = "agnostic.rs"
+	runtimePath     = "crate::agnostic"
+	// The name that the runtime is referred to as.
+	runtimeModule = "agnostic"
+
+	// The name of the model that a method or override is called on.
+	thisIdentifier = "this"
+
+	indent = "    "
+)
+
+var _ code.NodeMapper[string] = &mapper{}
+
+// Generate converts each module into a Rust module of a single crate. The crate root declares each of the modules,
+// along with a runtime module that the others share.
+//
+// Models and collections are shared like in the other languages: each is a reference counted type from the runtime
+// that every copy refers to. Fields are read by copying them out of the model, and changed through Model::update, so
+// that the model is never borrowed for longer than a single read or change. Collections are likewise only borrowed for
+// the duration of each of their methods. Arguments that a function doesn't modify are borrowed, while the others are
+// given their own copy, which still refers to the same model or collection as the caller's.
+func Generate(root *code.Root) ([]languages.File, error) {
+	files := make([]languages.File, 0, len(root.Modules)+2)
+	declarations := []string{"pub mod " + runtimeModule + ";"}
+	for _, module := range root.Modules {
+		m := &mapper{module: module}
+		source, err := m.MapModule(module)
+		if err != nil {
+			return nil, fmt.Errorf("module %q: %w", module.Name, err)
+		}
+
+		files = append(files, languages.File{
+			Path:     identifier(module.Name) + ".rs",
+			Contents: source,
+		})
+
+		declarations = append(declarations, "pub mod "+identifier(module.Name)+";")
+	}
+
+	files = append(files, languages.File{
+		Path:     libraryPath,
+		Contents: "// Code generated by agnostic. DO NOT EDIT.\n\n" + strings.Join(declarations, "\n") + "\n",
+	}, languages.File{
+		Path:     runtimeFilename,
+		Contents: runtime,
+	})
+
+	return files, nil
+}
+
+// mapper converts a single module into Rust.
+type mapper struct {
+	module *code.Module
+	// The variables that are visible at the current point in the module.
+	scopes stack.Stack[scope]
+	// The model whose methods are currently being generated. Nil when outside a model.
+	self *code.ModelDef
+	// The statement that runs after each iteration of each loop that is currently being generated. ForEach loops push a
+	// nil statement.
+	afterEach stack.Stack[code.Statement]
+	// The paths that the generated code uses.
+	uses map[string]struct{}
+}
+
+func (m *mapper) use(path string) {
+	m.uses[path] = struct{}{}
+}
+
+// fromRuntime returns a reference to an item of the runtime.
+func (m *mapper) fromRuntime(name string) string {
+	m.use(runtimePath)
+	return runtimeModule + "::" + name
+}
+
+func (m *mapper) mapValues(values []code.Value) ([]string, error) {
+	return code.MapEachValue[string](values, m)
+}
+
+// statements converts the statements of a block.
+func (m *mapper) statements(block *code.Block) ([]string, error) {
+	m.scopes.Push(scope{})
+	defer m.scopes.Pop()
+
+	statements := make([]string, 0, len(block.Statements))
+	for _, statement := range block.Statements {
+		result, err := code.MapStatement[string](statement, m)
+		if err != nil {
+			return nil, err
+		}
+
+		switch statement.(type) {
+		case *code.Conditional, *code.For, *code.ForEach:
+		default:
+			result += ";"
+		}
+
+		statements = append(statements, result)
+	}
+
+	return statements, nil
+}
+
+// braces wraps lines in an indented pair of braces.
+func braces(lines []string) string {
+	if len(lines) == 0 {
+		return "{}"
+	}
+
+	return "{\n" + languages.Indent(strings.Join(lines, "\n"), indent) + "\n}"
+}
+
+// owned converts a value that is about to be stored somewhere into an owned value. Places that are used as an owned
+// value already handle this themselves based on their usage.
+func (m *mapper) owned(value code.Value, result string) (string, error) {
+	typ := code.TypeOf(value)
+
+	if isPlace(value) && !isCopy(typ) {
+		return result + ".clone()", nil
+	}
+
+	return result, nil
+}
+
+// reference returns a reference to a value.
+func (m *mapper) reference(value code.Value, result string) string {
+	switch value := value.(type) {
+	case *code.Self:
+		return thisIdentifier
+	case *code.Variable:
+		if v, ok := m.lookupVariable(value.Name); ok && v.borrowed {
+			return result
+		}
+	}
+
+	return "&" + result
+}
+
+// handle returns a model without copying it, as either the model or a reference to it. Properties that are assigned to
+// still only read the model, since the model is changed through Model::update instead.
+func (m *mapper) handle(value code.Value) (string, error) {
+	switch value := value.(type) {
+	case *code.Self:
+		return thisIdentifier, nil
+	case *code.Variable:
+		if _, ok := value.Definition.(*code.ConstantDef); ok {
+			return code.MapValue[string](value, m)
+		}
+
+		return identifier(value.Name), nil
+	case *code.Property:
+		return m.MapProperty(value)
+	case *code.Lookup:
+		return m.MapLookup(value)
+	default:
+		return code.MapValue[string](value, m)
+	}
+}
+
+// assign assigns to a variable or to a property of a model. A property is changed through Model::update, which borrows
+// the model mutably for the duration of the change, so the value is evaluated before the model is borrowed.
+func (m *mapper) assign(target code.Value, value string) (string, error) {
+	property, ok := target.(*code.Property)
+	if !ok {
+		place, err := code.MapValue[string](target, m)
+		if err != nil {
+			return "", err
+		}
+
+		return place + " = " + value, nil
+	}
+
+	model, err := m.handle(property.Of)
+	if err != nil {
+		return "", err
+	}
+
+	return model + ".update(" + value + ", |model, value| model." + identifier(property.Name) + " = value)", nil
+}
+
+// arguments converts the arguments of a call to the function. Arguments that the function doesn't modify are
+// borrowed, unless they're cheap to copy.
+func (m *mapper) arguments(function *code.FunctionDef, values []code.Value) (string, error) {
+	if len(values) != len(function.Arguments) {
+		return "", fmt.Errorf("function %q takes %d arguments but %d were given", function.Name, len(function.Arguments), len(values))
+	}
+
+	arguments := make([]string, 0, len(values))
+	for i, argument := range values {
+		result, err := code.MapValue[string](argument, m)
+		if err != nil {
+			return "", err
+		}
+
+		definition := function.Arguments[i]
+		if !isCopy(definition.Type) && !definition.Modified {
+			result = m.reference(argument, result)
+		} else {
+			result, err = m.owned(argument, result)
+			if err != nil {
+				return "", err
+			}
+		}
+
+		arguments = append(arguments, result)
+	}
+
+	return strings.Join(arguments, ", "), nil
+}
+
+// zeroValue returns the value that a variable of the given type starts out as.
+func (m *mapper) zeroValue(typ code.Type) (string, error) {
+	switch typ := typ.(type) {
+	case *code.Bool:
+		return "false", nil
+	case *code.Float64:
+		return "0.0", nil
+	case *code.Int32:
+		return "0_i32", nil
+	case *code.Int64:
+		return "0", nil
+	case *code.Uint8:
+		return "0_u8", nil
+	case *code.Model:
+		rustType, err := code.MapType[string](typ, m)
+		if err != nil {
+			return "", err
+		}
+
+		// The type is spelled out since it can't always be inferred.
+		path, arguments, _ := strings.Cut(rustType, "<")
+		return path + "::<" + arguments + "::nil()", nil
+	case *code.Rune:
+		return `'\0'`, nil
+	case *code.String:
+		return "String::new()", nil
+	case *code.Bytes, *code.List, *code.Map, *code.Set:
+		result, err := code.MapType[string](typ, m)
+		if err != nil {
+			return "", err
+		}
+
+		// Turn the type into a path by adding the turbofish.
+		path, arguments, _ := strings.Cut(result, "<")
+		return path + "::<" + arguments + "::new()", nil
+	default:
+		return "", fmt.Errorf("%T has no zero value", typ)
+	}
+}
+
+func (m *mapper) MapAddToSet(value *code.AddToSet) (string, error) {
+	item, err := code.MapValue[string](value.Value, m)
+	if err != nil {
+		return "", err
+	}
+
+	set, err := code.MapValue[string](value.Set, m)
+	if err != nil {
+		return "", err
+	}
+
+	return set + ".insert(" + item + ")", nil
+}
+
+func (m *mapper) MapArgumentDef(value *code.ArgumentDef) (string, error) {
+	typ, err := code.MapType[string](value.Type, m)
+	if err != nil {
+		return "", err
+	}
+
+	// Modified arguments are owned so that the function can assign to them. Models and collections are shared, so
+	// changing their contents is still visible to the caller. Everything else is borrowed.
+	borrowed := !isCopy(value.Type) && !value.Modified
+	m.declare(value.Name, variable{borrowed: borrowed})
+
+	switch {
+	case borrowed:
+		return identifier(value.Name) + ": &" + typ, nil
+	case value.Modified:
+		return "mut " + identifier(value.Name) + ": " + typ, nil
+	default:
+		return identifier(value.Name) + ": " + typ, nil
+	}
+}
+
+func (m *mapper) MapAssignment(value *code.Assignment) (string, error) {
+	from, err := code.MapValue[string](value.From, m)
+	if err != nil {
+		return "", err
+	}
+
+	if lookup, ok := value.To.(*code.Lookup); ok {
+		into, err := code.MapValue[string](lookup.From, m)
+		if err != nil {
+			return "", err
+		}
+
+		key, err := code.MapValue[string](lookup.Key, m)
+		if err != nil {
+			return "", err
+		}
+
+		if _, isMap := code.TypeOf(lookup.From).(*code.Map); isMap {
+			return into + ".insert(" + key + ", " + from + ")", nil
+		}
+
+		return into + ".set(" + key + ", " + from + ")", nil
+	}
+
+	return m.assign(value.To, from)
+}
+
+func (m *mapper) MapBinary(value *code.Binary) (string, error) {
+	left, err := code.MapValue[string](value.Left, m)
+	if err != nil {
+		return "", err
+	}
+
+	right, err := code.MapValue[string](value.Right, m)
+	if err != nil {
+		return "", err
+	}
+
+	if _, isString := code.TypeOf(value.Left).(*code.String); isString {
+		// Adding strings requires an owned left side, so it's simpler to format both sides into a new string.
+		return "format!(\"{}{}\", " + left + ", " + right + ")", nil
+	}
+
+	if value.Overflow == code.OverflowWrap {
+		// Plain integer arithmetic panics on overflow in debug builds.
+		rustType, err := code.MapType[string](value.Type, m)
+		if err != nil {
+			return "", err
+		}
+
+		return rustType + "::" + wrappingMethods[value.Operator] + "(" + left + ", " + right + ")", nil
+	}
+
+	return "(" + left + " " + value.Operator.Symbol() + " " + right + ")", nil
+}
+
+func (m *mapper) MapBlock(value *code.Block) (string, error) {
+	statements, err := m.statements(value)
+	if err != nil {
+		return "", err
+	}
+
+	return braces(statements), nil
+}
+
+func (m *mapper) MapBool(value *code.Bool) (string, error) {
+	return "bool", nil
+}
+
+func (m *mapper) MapBreak(value *code.Break) (string, error) {
+	return "break", nil
+}
+
+func (m *mapper) MapBytes(value *code.Bytes) (string, error) {
+	return m.fromRuntime("List<u8>"), nil
+}
+
+func (m *mapper) MapCall(value *code.Call) (string, error) {
+	function, ok := value.Function.(*code.FunctionDef)
+	if !ok {
+		return "", fmt.Errorf("unsupported callable %T", value.Function)
+	}
+
+	arguments, err := m.arguments(function, value.Arguments)
+	if err != nil {
+		return "", err
+	}
+
+	return m.qualifier(function.Module.Name) + identifier(function.Name) + "(" + arguments + ")", nil
+}
+
+func (m *mapper) MapComparison(value *code.Comparison) (string, error) {
+	left, err := code.MapValue[string](value.Left, m)
+	if err != nil {
+		return "", err
+	}
+
+	right, err := code.MapValue[string](value.Right, m)
+	if err != nil {
+		return "", err
+	}
+
+	if !isCopy(code.TypeOf(value.Left)) {
+		// Either side might already be a reference, so both are compared as references.
+		left = m.reference(value.Left, left)
+		right = m.reference(value.Right, right)
+	}
+
+	return "(" + left + " " + value.Operator.Symbol() + " " + right + ")", nil
+}
+
+func (m *mapper) MapConditional(value *code.Conditional) (string, error) {
+	ifs := make([]string, 0, len(value.Ifs))
+	for _, ifNode := range value.Ifs {
+		result, err := m.MapIf(ifNode)
+		if err != nil {
+			return "", err
+		}
+
+		ifs = append(ifs, result)
+	}
+
+	result := strings.Join(ifs, " else ")
+	if value.Else != nil {
+		elseBlock, err := m.MapBlock(value.Else)
+		if err != nil {
+			return "", err
+		}
+
+		result += " else " + elseBlock
+	}
+
+	return result, nil
+}
+
+func (m *mapper) MapConstantDef(value *code.ConstantDef) (string, error) {
+	typ := code.TypeOf(value.Value.(code.Value))
+
+	rustType, err := code.MapType[string](typ, m)
+	if err != nil {
+		return "", err
+	}
+
+	constant, err := code.MapConstantValue[string](value.Value, m)
+	if err != nil {
+		return "", err
+	}
+
+	if isCollection(typ) {
+		// The collection is created each time that it's used instead of shared, so that changing the collection doesn't
+		// change the constant.
+		return public(value.Visibility) + "fn " + identifier(value.Name) + "() -> " + rustType + " {\n" + indent + constant + "\n}", nil
+	}
+
+	name := constantIdentifier(value.Name)
+	if isCopy(typ) {
+		return public(value.Visibility) + "const " + name + ": " + rustType + " = " + constant + ";", nil
+	}
+
+	// Values that need to allocate can't be created at compile time, so they're created on first use.
+	m.use("std::sync::LazyLock")
+	return public(value.Visibility) + "static " + name + ": LazyLock<" + rustType + "> = LazyLock::new(|| " + constant + ");", nil
+}
+
+func (m *mapper) MapContinue(value *code.Continue) (string, error) {
+	// While loops don't have a place for the statement that runs after each iteration, so it needs to run before
+	// continuing as well.
+	if len(m.afterEach) > 0 && m.afterEach.Peek() != nil {
+		afterEach, err := code.MapStatement[string](m.afterEach.Peek(), m)
+		if err != nil {
+			return "", err
+		}
+
+		return afterEach + ";\ncontinue", nil
+	}
+
+	return "continue", nil
+}
+
+func (m *mapper) MapConversion(value *code.Conversion) (string, error) {
+	operand, err := code.MapValue[string](value.Value, m)
+	if err != nil {
+		return "", err
+	}
+
+	if _, isLiteral := value.Value.(*code.LiteralInt64); isLiteral {
+		// The type of an unsuffixed literal that is cast defaults to i32, which might not be able to hold it.
+		operand += "_i64"
+	}
+
+	to, err := code.MapType[string](value.To, m)
+	if err != nil {
+		return "", err
+	}
+
+	// Casts between numbers wrap integers, saturate floats that are converted to integers, and round integers that are
+	// converted to floats.
+	return "(" + operand + " as " + to + ")", nil
+}
+
+func (m *mapper) MapDeclare(value *code.Declare) (string, error) {
+	initial, err := code.MapValue[string](value.Value, m)
+	if err != nil {
+		return "", err
+	}
+
+	m.declare(value.Name, variable{})
+
+	return "let mut " + identifier(value.Name) + " = " + initial, nil
+}
+
+func (m *mapper) MapEmptyList(value *code.EmptyList) (string, error) {
+	return m.zeroValue(&code.List{Item: value.Type})
+}
+
+func (m *mapper) MapEqualOverride(value *code.EqualOverride) (string, error) {
+	m.scopes.Push(scope{})
+	defer m.scopes.Pop()
+
+	m.declare(value.OtherName, variable{borrowed: true})
+
+	block, err := m.MapBlock(value.Block)
+	if err != nil {
+		return "", err
+	}
+
+	model := m.fromRuntime("Model<Self>")
+	return "fn equal(" + thisIdentifier + ": &" + model + ", " + identifier(value.OtherName) + ": &" + model + ") -> bool " + block, nil
+}
+
+func (m *mapper) MapFieldDef(value *code.FieldDef) (string, error) {
+	typ, err := code.MapType[string](value.Type, m)
+	if err != nil {
+		return "", err
+	}
+
+	return public(value.Visibility) + identifier(value.Name) + ": " + typ + ",", nil
+}
+
+func (m *mapper) MapFloat64(value *code.Float64) (string, error) {
+	return "f64", nil
+}
+
+func (m *mapper) MapFor(value *code.For) (string, error) {
+	m.scopes.Push(scope{})
+	defer m.scopes.Pop()
+
+	var lines []string
+	if value.Initialization != nil {
+		initialization, err := code.MapStatement[string](value.Initialization, m)
+		if err != nil {
+			return "", err
+		}
+
+		lines = append(lines, initialization+";")
+	}
+
+	condition, err := code.MapValue[string](value.Condition, m)
+	if err != nil {
+		return "", err
+	}
+
+	m.afterEach.Push(value.AfterEach)
+	body, err := m.statements(value.Block)
+	m.afterEach.Pop()
+	if err != nil {
+		return "", err
+	}
+
+	if value.AfterEach != nil {
+		afterEach, err := code.MapStatement[string](value.AfterEach, m)
+		if err != nil {
+			return "", err
+		}
+
+		body = append(body, afterEach+";")
+	}
+
+	loop := "while " + condition + " " + braces(body)
+	if len(lines) == 0 {
+		return loop, nil
+	}
+
+	// Keep the initialized variable scoped to the loop.
+	return braces(append(lines, loop)), nil
+}
+
+func (m *mapper) MapForEach(value *code.ForEach) (string, error) {
+	iterableType := code.TypeOf(value.Iterable)
+
+	iterable, err := code.MapValue[string](value.Iterable, m)
+	if err != nil {
+		return "", err
+	}
+
+	// The collections return a copy of their items, so that the loop isn't affected by changes to the collection.
+	switch iterableType.(type) {
+	case *code.Map:
+		iterable += ".keys()"
+	case *code.String:
+		iterable += ".chars()"
+	default:
+		iterable += ".items()"
+	}
+
+	m.scopes.Push(scope{})
+	defer m.scopes.Pop()
+
+	m.declare(value.ItemName, variable{})
+
+	m.afterEach.Push(nil)
+	block, err := m.MapBlock(value.Block)
+	m.afterEach.Pop()
+	if err != nil {
+		return "", err
+	}
+
+	return "for " + identifier(value.ItemName) + " in " + iterable + " " + block, nil
+}
+
+func (m *mapper) MapFunctionDef(value *code.FunctionDef) (string, error) {
+	m.scopes.Push(scope{})
+	defer m.scopes.Pop()
+
+	var arguments []string
+	if m.self != nil {
+		// Methods are associated functions of the model, since they're called on a reference to it.
+		arguments = append(arguments, thisIdentifier+": &"+m.fromRuntime("Model<Self>"))
+	}
+
+	for _, argument := range value.Arguments {
+		result, err := m.MapArgumentDef(argument)
+		if err != nil {
+			return "", err
+		}
+
+		arguments = append(arguments, result)
+	}
+
+	block, err := m.MapBlock(value.Block)
+	if err != nil {
+		return "", err
+	}
+
+	signature := public(value.Visibility) + "fn " + identifier(value.Name) + "(" + strings.Join(arguments, ", ") + ")"
+	if _, isVoid := value.ReturnType.(*code.Void); !isVoid {
+		returnType, err := code.MapType[string](value.ReturnType, m)
+		if err != nil {
+			return "", err
+		}
+
+		signature += " -> " + returnType
+	}
+
+	return signature + " " + block, nil
+}
+
+func (m *mapper) MapFunctionRef(value *code.FunctionRef) (string, error) {
+	// Calls refer to the definition of the function once the AST is mapped to code.
+	return "", fmt.Errorf("unresolved reference to function %q", value.Name)
+}
+
+func (m *mapper) MapHashOverride(value *code.HashOverride) (string, error) {
+	m.scopes.Push(scope{})
+	defer m.scopes.Pop()
+
+	block, err := m.MapBlock(value.Block)
+	if err != nil {
+		return "", err
+	}
+
+	return "fn hash_code(" + thisIdentifier + ": &" + m.fromRuntime("Model<Self>") + ") -> i64 " + block, nil
+}
+
+func (m *mapper) MapIf(value *code.If) (string, error) {
+	condition, err := code.MapValue[string](value.Condition, m)
+	if err != nil {
+		return "", err
+	}
+
+	block, err := m.MapBlock(value.Block)
+	if err != nil {
+		return "", err
+	}
+
+	return "if " + condition + " " + block, nil
+}
+
+// MapImport returns the use declaration that brings the module into scope. Only the modules that the generated code
+// refers to are used, since Rust warns about unused imports.
+func (m *mapper) MapImport(value *code.Import) (string, error) {
+	return "use crate::" + identifier(value.Module) + ";", nil
+}
+
+func (m *mapper) MapInt32(value *code.Int32) (string, error) {
+	return "i32", nil
+}
+
+func (m *mapper) MapInt64(value *code.Int64) (string, error) {
+	return "i64", nil
+}
+
+func (m *mapper) MapKeyValue(value *code.KeyValue) (string, error) {
+	key, err := code.MapValue[string](value.Key, m)
+	if err != nil {
+		return "", err
+	}
+
+	mapValue, err := code.MapValue[string](value.Value, m)
+	if err != nil {
+		return "", err
+	}
+
+	return "(" + key + ", " + mapValue + ")", nil
+}
+
+func (m *mapper) MapLength(value *code.Length) (string, error) {
+	ofType := code.TypeOf(value.Of)
+
+	of, err := code.MapValue[string](value.Of, m)
+	if err != nil {
+		return "", err
+	}
+
+	if _, isString := ofType.(*code.String); isString {
+		return of + ".chars().count() as i64", nil
+	}
+
+	return of + ".len()", nil
+}
+
+func (m *mapper) MapList(value *code.List) (string, error) {
+	item, err := code.MapType[string](value.Item, m)
+	if err != nil {
+		return "", err
+	}
+
+	return m.fromRuntime("List<" + item + ">"), nil
+}
+
+func (m *mapper) MapLiteralBool(value *code.LiteralBool) (string, error) {
+	return strconv.FormatBool(value.Value), nil
+}
+
+func (m *mapper) MapLiteralBytes(value *code.LiteralBytes) (string, error) {
+	if len(value.Value) == 0 {
+		return m.zeroValue(&code.Bytes{})
+	}
+
+	values := make([]string, 0, len(value.Value))
+	for _, b := range value.Value {
+		values = append(values, strconv.FormatUint(uint64(b), 10)+"_u8")
+	}
+
+	return m.fromRuntime("List::of(vec![") + strings.Join(values, ", ") + "])", nil
+}
+
+func (m *mapper) MapLiteralFloat64(value *code.LiteralFloat64) (string, error) {
+	return languages.FormatFloat(value.Value), nil
+}
+
+func (m *mapper) MapLiteralInt32(value *code.LiteralInt32) (string, error) {
+	return strconv.FormatInt(int64(value.Value), 10) + "_i32", nil
+}
+
+func (m *mapper) MapLiteralInt64(value *code.LiteralInt64) (string, error) {
+	return strconv.FormatInt(value.Value, 10), nil
+}
+
+func (m *mapper) MapLiteralList(value *code.LiteralList) (string, error) {
+	if len(value.Values) == 0 {
+		return m.zeroValue(value.Type)
+	}
+
+	values, err := m.mapValues(value.Values)
+	if err != nil {
+		return "", err
+	}
+
+	return m.fromRuntime("List::of(vec![") + strings.Join(values, ", ") + "])", nil
+}
+
+func (m *mapper) MapLiteralMap(value *code.LiteralMap) (string, error) {
+	if len(value.Values) == 0 {
+		return m.zeroValue(value.Type)
+	}
+
+	entries := make([]string, 0, len(value.Values))
+	for _, keyValue := range value.Values {
+		result, err := m.MapKeyValue(keyValue)
+		if err != nil {
+			return "", err
+		}
+
+		entries = append(entries, result)
+	}
+
+	return m.fromRuntime("Map::of([") + strings.Join(entries, ", ") + "])", nil
+}
+
+func (m *mapper) MapLiteralRune(value *code.LiteralRune) (string, error) {
+	return quoteChar(value.Value), nil
+}
+
+func (m *mapper) MapLiteralSet(value *code.LiteralSet) (string, error) {
+	if len(value.Values) == 0 {
+		return m.zeroValue(value.Type)
+	}
+
+	values, err := m.mapValues(value.Values)
+	if err != nil {
+		return "", err
+	}
+
+	return m.fromRuntime("Set::of([") + strings.Join(values, ", ") + "])", nil
+}
+
+func (m *mapper) MapLiteralString(value *code.LiteralString) (string, error) {
+	return "String::from(" + quote(value.Value) + ")", nil
+}
+
+func (m *mapper) MapLiteralUint8(value *code.LiteralUint8) (string, error) {
+	return strconv.FormatUint(uint64(value.Value), 10) + "_u8", nil
+}
+
+func (m *mapper) MapLookup(value *code.Lookup) (string, error) {
+	fromType := code.TypeOf(value.From)
+
+	from, err := code.MapValue[string](value.From, m)
+	if err != nil {
+		return "", err
+	}
+
+	key, err := code.MapValue[string](value.Key, m)
+	if err != nil {
+		return "", err
+	}
+
+	// The collections return a copy of the item, which refers to the same model or collection if the item is one.
+	switch fromType.(type) {
+	case *code.Bytes, *code.List:
+		return from + ".get(" + key + ")", nil
+	case *code.Map:
+		return from + ".get(" + m.reference(value.Key, key) + ")", nil
+	case *code.String:
+		return from + ".chars().nth(" + key + " as usize).unwrap()", nil
+	default:
+		return "", fmt.Errorf("cannot lookup a value in a %T", fromType)
+	}
+}
+
+func (m *mapper) MapMap(value *code.Map) (string, error) {
+	key, err := code.MapType[string](value.Key, m)
+	if err != nil {
+		return "", err
+	}
+
+	mapValue, err := code.MapType[string](value.Value, m)
+	if err != nil {
+		return "", err
+	}
+
+	return m.fromRuntime("Map<" + key + ", " + mapValue + ">"), nil
+}
+
+func (m *mapper) MapMethodCall(value *code.MethodCall) (string, error) {
+	model, ok := code.TypeOf(value.Receiver).(*code.Model)
+	if !ok {
+		return "", fmt.Errorf("cannot call method %q on %T", value.Name, code.TypeOf(value.Receiver))
+	}
+
+	receiver, err := m.handle(value.Receiver)
+	if err != nil {
+		return "", err
+	}
+
+	arguments, err := m.arguments(value.Method, value.Arguments)
+	if err != nil {
+		return "", err
+	}
+
+	if len(arguments) > 0 {
+		arguments = ", " + arguments
+	}
+
+	function := m.qualifier(model.Module) + typeIdentifier(model.Name) + "::" + identifier(value.Name)
+	return function + "(" + m.reference(value.Receiver, receiver) + arguments + ")", nil
+}
+
+func (m *mapper) MapModel(value *code.Model) (string, error) {
+	// Models are shared, so every reference to one is a handle to the same instance.
+	return m.fromRuntime("Model<" + m.qualifier(value.Module) + typeIdentifier(value.Name) + ">"), nil
+}
+
+func (m *mapper) MapModelDef(value *code.ModelDef) (string, error) {
+	name := typeIdentifier(value.Name)
+
+	fields := make([]string, 0, len(value.Fields))
+	for _, field := range value.Fields {
+		result, err := m.MapFieldDef(field)
+		if err != nil {
+			return "", err
+		}
+
+		fields = append(fields, result)
+	}
+
+	var attributes []string
+	if name != value.Name {
+		attributes = append(attributes, "#[allow(non_camel_case_types)]")
+	}
+	attributes = append(attributes, "#[derive(Default)]")
+
+	items := []string{strings.Join(attributes, "\n") + "\n" + public(value.Visibility) + "struct " + name + " " + braces(fields)}
+
+	m.self = value
+	defer func() { m.self = nil }()
+
+	methods := make([]string, 0, len(value.Methods)+1)
+	for _, method := range value.Methods {
+		result, err := m.MapFunctionDef(method)
+		if err != nil {
+			return "", fmt.Errorf("method %q: %w", method.Name, err)
+		}
+
+		methods = append(methods, result)
+	}
+
+	if len(methods) > 0 {
+		items = append(items, "impl "+name+" {\n"+languages.Indent(strings.Join(methods, "\n\n"), indent)+"\n}")
+	}
+
+	// Every model implements the object trait so that it can be compared and hashed, by identity unless it's
+	// overridden.
+	var overrides []string
+	if value.EqualOverride != nil {
+		result, err := m.MapEqualOverride(value.EqualOverride)
+		if err != nil {
+			return "", err
+		}
+
+		overrides = append(overrides, result)
+
+		if value.HashOverride == nil {
+			// Without a hash override there's no way to know which instances are equal, so every instance needs to
+			// hash the same.
+			overrides = append(overrides, "fn hash_code(_"+thisIdentifier+": &"+m.fromRuntime("Model<Self>")+") -> i64 {\n"+indent+"0\n}")
+		}
+	}
+
+	if value.HashOverride != nil {
+		result, err := m.MapHashOverride(value.HashOverride)
+		if err != nil {
+			return "", err
+		}
+
+		overrides = append(overrides, result)
+	}
+
+	object := "impl " + m.fromRuntime("Object") + " for " + name + " {}"
+	if len(overrides) > 0 {
+		object = "impl " + m.fromRuntime("Object") + " for " + name + " {\n" + languages.Indent(strings.Join(overrides, "\n\n"), indent) + "\n}"
+	}
+	items = append(items, object)
+
+	return strings.Join(items, "\n\n"), nil
+}
+
+func (m *mapper) MapModule(value *code.Module) (string, error) {
+	m.uses = map[string]struct{}{}
+	m.scopes.Push(scope{})
+	defer m.scopes.Pop()
+
+	// Constants are grouped together, while constant collections are functions and are separated like the other
+	// functions.
+	var items, constants, functions []string
+	for _, constant := range value.Constants {
+		result, err := m.MapConstantDef(constant)
+		if err != nil {
+			return "", fmt.Errorf("constant %q: %w", constant.Name, err)
+		}
+
+		if isCollection(code.TypeOf(constant.Value.(code.Value))) {
+			functions = append(functions, result)
+		} else {
+			constants = append(constants, result)
+		}
+	}
+
+	if len(constants) > 0 {
+		items = append(items, strings.Join(constants, "\n"))
+	}
+
+	items = append(items, functions...)
+
+	for _, model := range value.Models {
+		result, err := m.MapModelDef(model)
+		if err != nil {
+			return "", fmt.Errorf("model %q: %w", model.Name, err)
+		}
+
+		items = append(items, result)
+	}
+
+	for _, function := range value.Functions {
+		result, err := m.MapFunctionDef(function)
+		if err != nil {
+			return "", fmt.Errorf("function %q: %w", function.Name, err)
+		}
+
+		items = append(items, result)
+	}
+
+	var sb strings.Builder
+	sb.WriteString("// Code generated by agnostic. DO NOT EDIT.\n\n")
+
+	// Every local variable is declared as mutable because it isn't known up front which ones will be changed. The other
+	// lints flag code that mirrors the original program, including operators that are always parenthesized and private
+	// items that the rest of the module doesn't use.
+	sb.WriteString("#![allow(dead_code, unused_assignments, unused_mut, unused_parens, unused_variables)]\n\n")
+
+	uses := make([]string, 0, len(m.uses))
+	for path := range m.uses {
+		uses = append(uses, path)
+	}
+	slices.Sort(uses)
+
+	for _, path := range uses {
+		sb.WriteString("use " + path + ";\n")
+	}
+
+	if len(uses) > 0 {
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString(strings.Join(items, "\n\n"))
+	sb.WriteString("\n")
+
+	return sb.String(), nil
+}
+
+func (m *mapper) MapNew(value *code.New) (string, error) {
+	return m.fromRuntime("Model::new(") + m.qualifier(value.Model.Module) + typeIdentifier(value.Model.Name) + "::default())", nil
+}
+
+func (m *mapper) MapNil(value *code.Nil) (string, error) {
+	return m.zeroValue(value.Type)
+}
+
+func (m *mapper) MapPop(value *code.Pop) (string, error) {
+	list, err := code.MapValue[string](value.List, m)
+	if err != nil {
+		return "", err
+	}
+
+	return list + ".pop()", nil
+}
+
+// MapProperty reads a property of a model. The property is copied out of the model, since the model is only borrowed
+// while it's read. Properties that are assigned to are handled by assign instead.
+func (m *mapper) MapProperty(value *code.Property) (string, error) {
+	of, err := m.handle(value.Of)
+	if err != nil {
+		return "", err
+	}
+
+	field := "model." + identifier(value.Name)
+	if !isCopy(code.TypeOf(value)) {
+		field += ".clone()"
+	}
+
+	return of + ".get(|model| " + field + ")", nil
+}
+
+func (m *mapper) MapPush(value *code.Push) (string, error) {
+	item, err := code.MapValue[string](value.Value, m)
+	if err != nil {
+		return "", err
+	}
+
+	list, err := code.MapValue[string](value.List, m)
+	if err != nil {
+		return "", err
+	}
+
+	return list + ".push(" + item + ")", nil
+}
+
+func (m *mapper) MapReturn(value *code.Return) (string, error) {
+	if variable, ok := value.Value.(*code.Variable); ok {
+		if v, ok := m.lookupVariable(variable.Name); ok && !v.borrowed {
+			// Nothing can use the variable after it's returned, so it can be moved instead of cloned.
+			return "return " + identifier(variable.Name), nil
+		}
+	}
+
+	result, err := code.MapValue[string](value.Value, m)
+	if err != nil {
+		return "", err
+	}
+
+	return "return " + result, nil
+}
+
+func (m *mapper) MapRoot(value *code.Root) (string, error) {
+	return "", fmt.Errorf("a root can't be generated as a single Rust file")
+}
+
+func (m *mapper) MapRune(value *code.Rune) (string, error) {
+	return "char", nil
+}
+
+func (m *mapper) MapSelf(value *code.Self) (string, error) {
+	// Self is a reference to the model, so using it as a value creates another handle to the model.
+	return thisIdentifier + ".clone()", nil
+}
+
+func (m *mapper) MapSet(value *code.Set) (string, error) {
+	item, err := code.MapType[string](value.Item, m)
+	if err != nil {
+		return "", err
+	}
+
+	return m.fromRuntime("Set<" + item + ">"), nil
+}
+
+func (m *mapper) MapSetContains(value *code.SetContains) (string, error) {
+	set, err := code.MapValue[string](value.Set, m)
+	if err != nil {
+		return "", err
+	}
+
+	item, err := code.MapValue[string](value.Value, m)
+	if err != nil {
+		return "", err
+	}
+
+	return set + ".contains(" + m.reference(value.Value, item) + ")", nil
+}
+
+func (m *mapper) MapString(value *code.String) (string, error) {
+	return "String", nil
+}
+
+func (m *mapper) MapUint8(value *code.Uint8) (string, error) {
+	return "u8", nil
+}
+
+func (m *mapper) MapUnary(value *code.Unary) (string, error) {
+	operand, err := code.MapValue[string](value.Value, m)
+	if err != nil {
+		return "", err
+	}
+
+	if value.Overflow == code.OverflowWrap {
+		rustType, err := code.MapType[string](value.Type, m)
+		if err != nil {
+			return "", err
+		}
+
+		return rustType + "::wrapping_neg(" + operand + ")", nil
+	}
+
+	// Negating a negative literal would otherwise look like a decrement, which is linted against.
+	if strings.HasPrefix(operand, "-") {
+		operand = "(" + operand + ")"
+	}
+
+	return "(" + value.Operator.Symbol() + operand + ")", nil
+}
+
+func (m *mapper) MapVariable(value *code.Variable) (string, error) {
+	var result string
+	if constant, ok := value.Definition.(*code.ConstantDef); ok {
+		if isCollection(code.TypeOf(constant.Value.(code.Value))) {
+			// The function that creates the collection returns a new one, so it's already owned.
+			return m.qualifier(constant.Module.Name) + identifier(constant.Name) + "()", nil
+		}
+
+		result = m.qualifier(constant.Module.Name) + constantIdentifier(constant.Name)
+	} else if _, ok := m.lookupVariable(value.Name); ok {
+		result = identifier(value.Name)
+	} else {
+		return "", fmt.Errorf("undefined variable %q", value.Name)
+	}
+
+	if value.Usage == code.UsageOwned {
+		return m.owned(value, result)
+	}
+
+	return result, nil
+}
+
+func (m *mapper) MapVoid(value *code.Void) (string, error) {
+	return "()", nil
+}
@@ -0,0 +1,56 @@
+package rust
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/JosephNaberhaus/agnostic/code"
+	"github.com/JosephNaberhaus/agnostic/internal/languages"
+	"github.com/JosephNaberhaus/agnostic/internal/languages/languagetest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate(t *testing.T) {
+	languagetest.RunGolden(t, generateWithoutRuntime, compile)
+}
+
+// generateWithoutRuntime leaves out the runtime so that it isn't repeated in every golden file.
+func generateWithoutRuntime(root *code.Root) ([]languages.File, error) {
+	files, err := Generate(root)
+	if err != nil {
+		return nil, err
+	}
+
+	return slices.DeleteFunc(files, func(file languages.File) bool {
+		return file.Path == runtimeFilename
+	}), nil
+}
+
+// compile compiles the generated files as a library crate with rustc. It's skipped if rustc isn't installed.
+func compile(t *testing.T, files []languages.File) {
+	rustc, err := exec.LookPath("rustc")
+	if err != nil {
+		t.Skip("rustc is not installed")
+	}
+
+	dir := t.TempDir()
+	files = append(files, languages.File{Path: runtimeFilename, Contents: runtime})
+	for _, file := range files {
+		path := filepath.Join(dir, file.Path)
+		require.NoError(t, os.WriteFile(path, []byte(file.Contents), 0o644))
+	}
+
+	output, err := exec.Command(
+		rustc,
+		"--edition", "2021",
+		"--crate-type", "lib",
+		"--crate-name", "generated",
+		"--deny", "warnings",
+		"--out-dir", filepath.Join(dir, "out"),
+		filepath.Join(dir, libraryPath),
+	).CombinedOutput()
+	require.NoError(t, err, string(output))
+}
@@ -0,0 +1,106 @@
+package rust
+
+import (
+	"fmt"
+
+	"github.com/JosephNaberhaus/agnostic/code"
+)
+
+// variable is a name that is visible at some point in the module.
+type variable struct {
+	// Whether the variable holds a reference to its value rather than the value itself.
+	borrowed bool
+}
+
+// scope maps the names that are visible in a block to their variables.
+type scope map[string]variable
+
+// declare makes a name visible in the innermost scope.
+func (m *mapper) declare(name string, v variable) {
+	m.scopes.Peek()[name] = v
+}
+
+// lookupVariable finds a name by searching from the innermost scope outward.
+func (m *mapper) lookupVariable(name string) (variable, bool) {
+	for i := len(m.scopes) - 1; i >= 0; i-- {
+		if v, ok := m.scopes[i][name]; ok {
+			return v, true
+		}
+	}
+
+	return variable{}, false
+}
+
+// lookupModel finds the definition of a model in the module being generated or in one of its imports.
+func (m *mapper) lookupModel(model *code.Model) (*code.ModelDef, error) {
+	modules := []*code.Module{m.module}
+	for _, i := range m.module.Imports {
+		modules = append(modules, i.Definition)
+	}
+
+	for _, module := range modules {
+		if module.Name != model.Module {
+			continue
+		}
+
+		for _, modelDef := range module.Models {
+			if modelDef.Name == model.Name {
+				return modelDef, nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("undefined model %q", model.Name)
+}
+
+// qualifier returns the path prefix of the items of the module with the given name. Items of another module are
+// referred to through the module, which is brought into scope with a use declaration.
+func (m *mapper) qualifier(module string) string {
+	if module == m.module.Name {
+		return ""
+	}
+
+	m.use("crate::" + identifier(module))
+	return identifier(module) + "::"
+}
+
+// isCopy returns whether values of the type are copied implicitly rather than moved.
+func isCopy(typ code.Type) bool {
+	switch typ.(type) {
+	case *code.Bool, *code.Float64, *code.Int32, *code.Int64, *code.Rune, *code.Uint8:
+		return true
+	default:
+		return false
+	}
+}
+
+// isCollection returns whether values of the type are bytes, lists, maps, or sets, which are shared like models.
+func isCollection(typ code.Type) bool {
+	switch typ.(type) {
+	case *code.Bytes, *code.List, *code.Map, *code.Set:
+		return true
+	default:
+		return false
+	}
+}
+
+// isPlace returns whether the value refers to a location in memory. Using such a value in a place that takes ownership
+// requires an explicit clone.
+func isPlace(value code.Value) bool {
+	switch value.(type) {
+	case *code.Lookup, *code.Variable:
+		return true
+	default:
+		return false
+	}
+}
+
+// wrappingMethods are the methods of the integer types that perform each arithmetic operator, wrapping around instead
+// of panicking when the result overflows.
+var wrappingMethods = map[code.BinaryOperator]string{
+	code.BinaryOperatorAdd:      "wrapping_add",
+	code.BinaryOperatorSubtract: "wrapping_sub",
+	code.BinaryOperatorMultiply: "wrapping_mul",
+	code.BinaryOperatorDivide:   "wrapping_div",
+	code.BinaryOperatorModulo:   "wrapping_rem",
+}
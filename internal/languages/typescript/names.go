@@ -0,0 +1,130 @@
+package typescript
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/JosephNaberhaus/agnostic/code"
+)
+
+var reservedWords = map[string]struct{}{
+	// Reserved by the generated code itself.
+	runtimeNamespace: {},
+
+	"as":         {},
+	"break":      {},
+	"case":       {},
+	"catch":      {},
+	"class":      {},
+	"const":      {},
+	"continue":   {},
+	"debugger":   {},
+	"default":    {},
+	"delete":     {},
+	"do":         {},
+	"else":       {},
+	"enum":       {},
+	"export":     {},
+	"extends":    {},
+	"false":      {},
+	"finally":    {},
+	"for":        {},
+	"function":   {},
+	"if":         {},
+	"implements": {},
+	"import":     {},
+	"in":         {},
+	"instanceof": {},
+	"interface":  {},
+	"let":        {},
+	"new":        {},
+	"null":       {},
+	"package":    {},
+	"private":    {},
+	"protected":  {},
+	"public":     {},
+	"return":     {},
+	"static":     {},
+	"super":      {},
+	"switch":     {},
+	"this":       {},
+	"throw":      {},
+	"true":       {},
+	"try":        {},
+	"typeof":     {},
+	"var":        {},
+	"void":       {},
+	"while":      {},
+	"with":       {},
+	"yield":      {},
+}
+
+// identifier converts an Agnostic name into a valid TypeScript identifier.
+func identifier(name string) string {
+	if _, isReserved := reservedWords[name]; isReserved {
+		return name + "_"
+	}
+
+	return name
+}
+
+// quote converts a string into a double-quoted TypeScript string literal.
+func quote(str string) string {
+	var sb strings.Builder
+	sb.WriteByte('"')
+	for _, r := range str {
+		switch r {
+		case '"':
+			sb.WriteString(`\"`)
+		case '\\':
+			sb.WriteString(`\\`)
+		case '\n':
+			sb.WriteString(`\n`)
+		case '\r':
+			sb.WriteString(`\r`)
+		case '\t':
+			sb.WriteString(`\t`)
+		default:
+			if r < 0x20 || r == 0x7f || r == 0x2028 || r == 0x2029 {
+				sb.WriteString(fmt.Sprintf(`\u%04x`, r))
+			} else {
+				sb.WriteRune(r)
+			}
+		}
+	}
+	sb.WriteByte('"')
+
+	return sb.String()
+}
+
+// typescriptComparison returns the TypeScript form of a comparison operator. Equality is always strict.
+func typescriptComparison(operator code.ComparisonOperator) string {
+	switch operator {
+	case code.ComparisonOperatorEqual:
+		return "==="
+	case code.ComparisonOperatorNotEqual:
+		return "!=="
+	default:
+		return operator.Symbol()
+	}
+}
+
+// wrapNumber wraps an integer that's represented as a number around to the range of its type. Bitwise operators work on
+// the low 32 bits of a number, so the result is also an integer rather than a fraction or -0.
+func wrapNumber(typ code.Type, result string) string {
+	if _, isUint8 := typ.(*code.Uint8); isUint8 {
+		return "(" + result + " & 0xff)"
+	}
+
+	return "(" + result + " | 0)"
+}
+
+// exported returns the prefix of a top-level declaration with the given visibility. Only public declarations are
+// exported from the file of their module.
+func exported(visibility code.Visibility) string {
+	if visibility == code.VisibilityPrivate {
+		return ""
+	}
+
+	return "export "
+}
@@ -0,0 +1,42 @@
+// Checks the behavior of the runtime that the generated code relies on. Each check throws if it fails.
+
+import * as agnostic from "./agnostic";
+
+function check(condition: boolean, description: string): void {
+  if (!condition) {
+    throw new Error(description);
+  }
+}
+
+class Point {
+  x = 0n;
+
+  // Accessing a property of nil throws, so this also checks that the override is never called with nil.
+  equals(other: Point): boolean {
+    return this.x === other.x;
+  }
+}
+
+const point = new Point();
+check(!agnostic.equals(point, null), "a model is equal to nil");
+check(!agnostic.equals(null, point), "nil is equal to a model");
+check(agnostic.equals(null, null), "nil isn't equal to nil");
+check(agnostic.equals(point, new Point()), "models aren't compared with their equal override");
+
+const map = new agnostic.HashMap<string, bigint>([
+  ["c", 1n],
+  ["a", 2n],
+]);
+map.set("b", 3n);
+map.set("c", 4n);
+check([...map].join() === "c,a,b", "a map isn't iterated over in the order that its keys were added");
+check(map.size === 3 && map.get("c") === 4n, "setting a key that a map has adds it again");
+
+const set = new agnostic.HashSet<bigint>([3n, 1n]);
+set.add(2n);
+set.add(3n);
+check([...set].join() === "3,1,2", "a set isn't iterated over in the order that its items were added");
+check(set.size === 3, "adding an item that a set has adds it again");
+
+const points = new agnostic.HashSet<Point | null>([point, null, new Point(), null]);
+check(points.size === 2, "a set of models doesn't compare them with their equal override");
@@ -0,0 +1,986 @@
+package typescript
+
+import (
+	_ "embed"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/JosephNaberhaus/agnostic/code"
+	"github.com/JosephNaberhaus/agnostic/internal/languages"
+)
+
+//go:embed runtime/agnostic.ts
+var runtime string
+
+const (
+	runtimeFilename = "agnostic.ts"
+	runtimeImport   = "./agnostic"
+	// The name that the runtime is imported as.
+	runtimeNamespace = "agnostic"
+
+	indent = "  "
+)
+
+var _ code.NodeMapper[string] = &mapper{}
+
+// Generate converts each module into a TypeScript file. The files share a runtime file that implements the
+// collections.
+//
+// Only the public constants, models, and functions of a module are exported from its file. The private fields and
+// methods of a model are still public members of its class, since TypeScript can't limit a member to the file that
+// declares it.
+func Generate(root *code.Root) ([]languages.File, error) {
+	files := make([]languages.File, 0, len(root.Modules)+1)
+	for _, module := range root.Modules {
+		source, err := (&mapper{module: module}).MapModule(module)
+		if err != nil {
+			return nil, fmt.Errorf("module %q: %w", module.Name, err)
+		}
+
+		files = append(files, languages.File{
+			Path:     module.Name + ".ts",
+			Contents: source,
+		})
+	}
+
+	files = append(files, languages.File{
+		Path:     runtimeFilename,
+		Contents: runtime,
+	})
+
+	return files, nil
+}
+
+// mapper converts a single module into TypeScript.
+type mapper struct {
+	// The module being generated.
+	module *code.Module
+	// The model whose methods are currently being generated. Nil when outside a model.
+	self *code.ModelDef
+	// Whether the module uses anything from the runtime.
+	usesRuntime bool
+}
+
+// fromRuntime returns a reference to an export of the runtime.
+func (m *mapper) fromRuntime(name string) string {
+	m.usesRuntime = true
+	return runtimeNamespace + "." + name
+}
+
+// qualifier returns the prefix of the names of declarations of the module with the given name. Declarations of another
+// module are accessed through the namespace that the module is imported as.
+func (m *mapper) qualifier(module string) string {
+	if module == m.module.Name {
+		return ""
+	}
+
+	return identifier(module) + "."
+}
+
+func (m *mapper) mapValues(values []code.Value) ([]string, error) {
+	return code.MapEachValue[string](values, m)
+}
+
+// statement converts a statement that appears in a block.
+func (m *mapper) statement(value code.Statement) (string, error) {
+	result, err := code.MapStatement[string](value, m)
+	if err != nil {
+		return "", err
+	}
+
+	switch value.(type) {
+	case *code.Conditional, *code.For, *code.ForEach:
+		return result, nil
+	default:
+		return result + ";", nil
+	}
+}
+
+// isCollection returns whether values of the type are bytes, lists, maps, or sets, which are references.
+func isCollection(typ code.Type) bool {
+	switch typ.(type) {
+	case *code.Bytes, *code.List, *code.Map, *code.Set:
+		return true
+	default:
+		return false
+	}
+}
+
+// zeroValue returns the value that a variable or field of the given type starts out as.
+func (m *mapper) zeroValue(typ code.Type) (string, error) {
+	tsType, err := code.MapType[string](typ, m)
+	if err != nil {
+		return "", err
+	}
+
+	switch typ.(type) {
+	case *code.Bool:
+		return "false", nil
+	case *code.Bytes:
+		return "new Uint8Array()", nil
+	case *code.Float64, *code.Int32, *code.Uint8:
+		return "0", nil
+	case *code.Int64:
+		return "0n", nil
+	case *code.List:
+		return "[] as " + tsType, nil
+	case *code.Map, *code.Set:
+		return "new " + tsType + "()", nil
+	case *code.Model:
+		return "null", nil
+	case *code.Rune:
+		return quote("\x00"), nil
+	case *code.String:
+		return quote(""), nil
+	default:
+		return "", fmt.Errorf("%T has no zero value", typ)
+	}
+}
+
+func (m *mapper) MapAddToSet(value *code.AddToSet) (string, error) {
+	set, err := code.MapValue[string](value.Set, m)
+	if err != nil {
+		return "", err
+	}
+
+	item, err := code.MapValue[string](value.Value, m)
+	if err != nil {
+		return "", err
+	}
+
+	return set + ".add(" + item + ")", nil
+}
+
+func (m *mapper) MapArgumentDef(value *code.ArgumentDef) (string, error) {
+	typ, err := code.MapType[string](value.Type, m)
+	if err != nil {
+		return "", err
+	}
+
+	return identifier(value.Name) + ": " + typ, nil
+}
+
+func (m *mapper) MapAssignment(value *code.Assignment) (string, error) {
+	from, err := code.MapValue[string](value.From, m)
+	if err != nil {
+		return "", err
+	}
+
+	if lookup, ok := value.To.(*code.Lookup); ok {
+		// Lists and maps are both assigned to through the runtime so that they get the same bounds checking.
+		into, err := code.MapValue[string](lookup.From, m)
+		if err != nil {
+			return "", err
+		}
+
+		key, err := code.MapValue[string](lookup.Key, m)
+		if err != nil {
+			return "", err
+		}
+
+		return m.fromRuntime("store") + "(" + into + ", " + key + ", " + from + ")", nil
+	}
+
+	to, err := code.MapValue[string](value.To, m)
+	if err != nil {
+		return "", err
+	}
+
+	return to + " = " + from, nil
+}
+
+func (m *mapper) MapBinary(value *code.Binary) (string, error) {
+	left, err := code.MapValue[string](value.Left, m)
+	if err != nil {
+		return "", err
+	}
+
+	right, err := code.MapValue[string](value.Right, m)
+	if err != nil {
+		return "", err
+	}
+
+	if value.Overflow == code.OverflowWrap {
+		return m.integerArithmetic(value.Type, value.Operator, left, right), nil
+	}
+
+	return "(" + left + " " + value.Operator.Symbol() + " " + right + ")", nil
+}
+
+// integerArithmetic converts arithmetic on integers, wrapping the result around to the range of the type.
+func (m *mapper) integerArithmetic(typ code.Type, operator code.BinaryOperator, left, right string) string {
+	if _, isInt64 := typ.(*code.Int64); isInt64 {
+		// Division and modulo of bigints truncate toward zero, just like int64.
+		return "BigInt.asIntN(64, " + left + " " + operator.Symbol() + " " + right + ")"
+	}
+
+	switch operator {
+	case code.BinaryOperatorMultiply:
+		if _, isInt32 := typ.(*code.Int32); isInt32 {
+			// The product of two int32s can be too large for a number to hold exactly.
+			return "Math.imul(" + left + ", " + right + ")"
+		}
+	case code.BinaryOperatorDivide:
+		return wrapNumber(typ, m.fromRuntime("divide")+"("+left+", "+right+")")
+	case code.BinaryOperatorModulo:
+		return wrapNumber(typ, m.fromRuntime("remainder")+"("+left+", "+right+")")
+	}
+
+	return wrapNumber(typ, "("+left+" "+operator.Symbol()+" "+right+")")
+}
+
+func (m *mapper) MapBlock(value *code.Block) (string, error) {
+	if len(value.Statements) == 0 {
+		return "{}", nil
+	}
+
+	statements := make([]string, 0, len(value.Statements))
+	for _, statement := range value.Statements {
+		result, err := m.statement(statement)
+		if err != nil {
+			return "", err
+		}
+
+		statements = append(statements, result)
+	}
+
+	return "{\n" + languages.Indent(strings.Join(statements, "\n"), indent) + "\n}", nil
+}
+
+func (m *mapper) MapBool(value *code.Bool) (string, error) {
+	return "boolean", nil
+}
+
+func (m *mapper) MapBreak(value *code.Break) (string, error) {
+	return "break", nil
+}
+
+func (m *mapper) MapBytes(value *code.Bytes) (string, error) {
+	return "Uint8Array", nil
+}
+
+func (m *mapper) MapCall(value *code.Call) (string, error) {
+	function, ok := value.Function.(*code.FunctionDef)
+	if !ok {
+		return "", fmt.Errorf("unsupported callable %T", value.Function)
+	}
+
+	arguments, err := m.mapValues(value.Arguments)
+	if err != nil {
+		return "", err
+	}
+
+	return m.qualifier(function.Module.Name) + identifier(function.Name) + "(" + strings.Join(arguments, ", ") + ")", nil
+}
+
+func (m *mapper) MapComparison(value *code.Comparison) (string, error) {
+	left, err := code.MapValue[string](value.Left, m)
+	if err != nil {
+		return "", err
+	}
+
+	right, err := code.MapValue[string](value.Right, m)
+	if err != nil {
+		return "", err
+	}
+
+	switch code.TypeOf(value.Left).(type) {
+	case *code.Model:
+		equals := m.fromRuntime("equals") + "(" + left + ", " + right + ")"
+		if value.Operator == code.ComparisonOperatorNotEqual {
+			return "!" + equals, nil
+		}
+
+		return equals, nil
+	case *code.Rune:
+		if value.Operator.IsOrdering() {
+			// Strings are ordered by their UTF-16 code units, which doesn't match the order of code points outside of
+			// the basic multilingual plane.
+			left = left + ".codePointAt(0)!"
+			right = right + ".codePointAt(0)!"
+		}
+	}
+
+	return "(" + left + " " + typescriptComparison(value.Operator) + " " + right + ")", nil
+}
+
+func (m *mapper) MapConditional(value *code.Conditional) (string, error) {
+	ifs := make([]string, 0, len(value.Ifs))
+	for _, ifNode := range value.Ifs {
+		result, err := m.MapIf(ifNode)
+		if err != nil {
+			return "", err
+		}
+
+		ifs = append(ifs, result)
+	}
+
+	result := strings.Join(ifs, " else ")
+	if value.Else != nil {
+		elseBlock, err := m.MapBlock(value.Else)
+		if err != nil {
+			return "", err
+		}
+
+		result += " else " + elseBlock
+	}
+
+	return result, nil
+}
+
+func (m *mapper) MapConstantDef(value *code.ConstantDef) (string, error) {
+	if nilValue, ok := value.Value.(*code.Nil); ok {
+		return m.declareNil(exported(value.Visibility)+"const", value.Name, nilValue)
+	}
+
+	constant, err := code.MapConstantValue[string](value.Value, m)
+	if err != nil {
+		return "", err
+	}
+
+	typ := code.TypeOf(value.Value.(code.Value))
+	if isCollection(typ) {
+		// The collection is created each time that it's used instead of shared, so that changing the collection doesn't
+		// change the constant.
+		tsType, err := code.MapType[string](typ, m)
+		if err != nil {
+			return "", err
+		}
+
+		return exported(value.Visibility) + "function " + identifier(value.Name) + "(): " + tsType + " {\n" + indent + "return " + constant + ";\n}", nil
+	}
+
+	return exported(value.Visibility) + "const " + identifier(value.Name) + " = " + constant + ";", nil
+}
+
+func (m *mapper) MapContinue(value *code.Continue) (string, error) {
+	return "continue", nil
+}
+
+func (m *mapper) MapConversion(value *code.Conversion) (string, error) {
+	operand, err := code.MapValue[string](value.Value, m)
+	if err != nil {
+		return "", err
+	}
+
+	from := code.TypeOf(value.Value)
+	_, fromInt64 := from.(*code.Int64)
+	_, toInt64 := value.To.(*code.Int64)
+
+	switch {
+	case value.Overflow == code.OverflowSaturate && toInt64:
+		return m.fromRuntime("saturateInt64") + "(" + operand + ")", nil
+	case value.Overflow == code.OverflowSaturate:
+		minimum, maximum := code.IntegerRange(value.To)
+		return fmt.Sprintf("%s(%s, %d, %d)", m.fromRuntime("saturate"), operand, minimum, maximum), nil
+	case fromInt64 && toInt64:
+		return operand, nil
+	case fromInt64:
+		// Only the low bits are kept, so the bigint can be safely converted into a number afterwards.
+		if _, toUint8 := value.To.(*code.Uint8); toUint8 {
+			return "Number(BigInt.asUintN(8, " + operand + "))", nil
+		}
+
+		if _, toInt32 := value.To.(*code.Int32); toInt32 {
+			return "Number(BigInt.asIntN(32, " + operand + "))", nil
+		}
+
+		return "Number(" + operand + ")", nil
+	case toInt64:
+		return "BigInt(" + operand + ")", nil
+	case value.Overflow == code.OverflowWrap:
+		return wrapNumber(value.To, operand), nil
+	default:
+		// Every other number already has a value that fits.
+		return operand, nil
+	}
+}
+
+func (m *mapper) MapDeclare(value *code.Declare) (string, error) {
+	if nilValue, ok := value.Value.(*code.Nil); ok {
+		declaration, err := m.declareNil("let", value.Name, nilValue)
+		if err != nil {
+			return "", err
+		}
+
+		return strings.TrimSuffix(declaration, ";"), nil
+	}
+
+	initial, err := code.MapValue[string](value.Value, m)
+	if err != nil {
+		return "", err
+	}
+
+	return "let " + identifier(value.Name) + " = " + initial, nil
+}
+
+// declareNil declares a variable that starts out as nil. Nil on its own doesn't tell TypeScript the type of the
+// variable, so it needs to be written explicitly.
+func (m *mapper) declareNil(keyword, name string, value *code.Nil) (string, error) {
+	typ, err := code.MapType[string](value.Type, m)
+	if err != nil {
+		return "", err
+	}
+
+	zero, err := m.MapNil(value)
+	if err != nil {
+		return "", err
+	}
+
+	return keyword + " " + identifier(name) + ": " + typ + " = " + zero + ";", nil
+}
+
+func (m *mapper) MapEmptyList(value *code.EmptyList) (string, error) {
+	return m.zeroValue(&code.List{Item: value.Type})
+}
+
+func (m *mapper) MapEqualOverride(value *code.EqualOverride) (string, error) {
+	block, err := m.MapBlock(value.Block)
+	if err != nil {
+		return "", err
+	}
+
+	return "equals(" + identifier(value.OtherName) + ": " + m.self.Name + "): boolean " + block, nil
+}
+
+func (m *mapper) MapFieldDef(value *code.FieldDef) (string, error) {
+	typ, err := code.MapType[string](value.Type, m)
+	if err != nil {
+		return "", err
+	}
+
+	zero, err := m.zeroValue(value.Type)
+	if err != nil {
+		return "", err
+	}
+
+	return identifier(value.Name) + ": " + typ + " = " + zero + ";", nil
+}
+
+func (m *mapper) MapFloat64(value *code.Float64) (string, error) {
+	return "number", nil
+}
+
+func (m *mapper) MapFor(value *code.For) (string, error) {
+	var initialization, afterEach string
+	var err error
+	if value.Initialization != nil {
+		initialization, err = code.MapStatement[string](value.Initialization, m)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	condition, err := code.MapValue[string](value.Condition, m)
+	if err != nil {
+		return "", err
+	}
+
+	if value.AfterEach != nil {
+		afterEach, err = code.MapStatement[string](value.AfterEach, m)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	block, err := m.MapBlock(value.Block)
+	if err != nil {
+		return "", err
+	}
+
+	return "for (" + initialization + "; " + condition + "; " + afterEach + ") " + block, nil
+}
+
+func (m *mapper) MapForEach(value *code.ForEach) (string, error) {
+	iterable, err := code.MapValue[string](value.Iterable, m)
+	if err != nil {
+		return "", err
+	}
+
+	block, err := m.MapBlock(value.Block)
+	if err != nil {
+		return "", err
+	}
+
+	// Strings iterate over their code points and maps iterate over their keys, which is exactly what ForEach needs. The
+	// other collections are copied, so that changing the collection in the loop doesn't change which items are visited.
+	if isCollection(code.TypeOf(value.Iterable)) {
+		iterable = "[..." + iterable + "]"
+	}
+
+	return "for (const " + identifier(value.ItemName) + " of " + iterable + ") " + block, nil
+}
+
+func (m *mapper) MapFunctionDef(value *code.FunctionDef) (string, error) {
+	arguments := make([]string, 0, len(value.Arguments))
+	for _, argument := range value.Arguments {
+		result, err := m.MapArgumentDef(argument)
+		if err != nil {
+			return "", err
+		}
+
+		arguments = append(arguments, result)
+	}
+
+	returnType, err := code.MapType[string](value.ReturnType, m)
+	if err != nil {
+		return "", err
+	}
+
+	block, err := m.MapBlock(value.Block)
+	if err != nil {
+		return "", err
+	}
+
+	signature := identifier(value.Name) + "(" + strings.Join(arguments, ", ") + "): " + returnType
+	if m.self == nil {
+		signature = exported(value.Visibility) + "function " + signature
+	}
+
+	return signature + " " + block, nil
+}
+
+func (m *mapper) MapFunctionRef(value *code.FunctionRef) (string, error) {
+	// Calls refer to the definition of the function once the AST is mapped to code.
+	return "", fmt.Errorf("unresolved reference to function %q", value.Name)
+}
+
+func (m *mapper) MapHashOverride(value *code.HashOverride) (string, error) {
+	block, err := m.MapBlock(value.Block)
+	if err != nil {
+		return "", err
+	}
+
+	return "hash(): bigint " + block, nil
+}
+
+func (m *mapper) MapIf(value *code.If) (string, error) {
+	condition, err := code.MapValue[string](value.Condition, m)
+	if err != nil {
+		return "", err
+	}
+
+	block, err := m.MapBlock(value.Block)
+	if err != nil {
+		return "", err
+	}
+
+	return "if (" + condition + ") " + block, nil
+}
+
+// MapImport imports the whole module as a namespace, so that its declarations are qualified like they are in
+// AgnosticScript.
+func (m *mapper) MapImport(value *code.Import) (string, error) {
+	return "import * as " + identifier(value.Module) + " from " + quote("./"+value.Module) + ";", nil
+}
+
+func (m *mapper) MapInt32(value *code.Int32) (string, error) {
+	return "number", nil
+}
+
+func (m *mapper) MapInt64(value *code.Int64) (string, error) {
+	return "bigint", nil
+}
+
+func (m *mapper) MapKeyValue(value *code.KeyValue) (string, error) {
+	key, err := code.MapValue[string](value.Key, m)
+	if err != nil {
+		return "", err
+	}
+
+	mapValue, err := code.MapValue[string](value.Value, m)
+	if err != nil {
+		return "", err
+	}
+
+	return "[" + key + ", " + mapValue + "]", nil
+}
+
+func (m *mapper) MapLength(value *code.Length) (string, error) {
+	of, err := code.MapValue[string](value.Of, m)
+	if err != nil {
+		return "", err
+	}
+
+	return m.fromRuntime("length") + "(" + of + ")", nil
+}
+
+func (m *mapper) MapList(value *code.List) (string, error) {
+	item, err := code.MapType[string](value.Item, m)
+	if err != nil {
+		return "", err
+	}
+
+	if strings.Contains(item, " ") {
+		// Union types need to be wrapped so that the brackets apply to the whole union.
+		return "(" + item + ")[]", nil
+	}
+
+	return item + "[]", nil
+}
+
+func (m *mapper) MapLiteralBool(value *code.LiteralBool) (string, error) {
+	return strconv.FormatBool(value.Value), nil
+}
+
+func (m *mapper) MapLiteralBytes(value *code.LiteralBytes) (string, error) {
+	values := make([]string, 0, len(value.Value))
+	for _, b := range value.Value {
+		values = append(values, strconv.FormatUint(uint64(b), 10))
+	}
+
+	return "new Uint8Array([" + strings.Join(values, ", ") + "])", nil
+}
+
+func (m *mapper) MapLiteralFloat64(value *code.LiteralFloat64) (string, error) {
+	return languages.FormatFloat(value.Value), nil
+}
+
+func (m *mapper) MapLiteralInt32(value *code.LiteralInt32) (string, error) {
+	return strconv.FormatInt(int64(value.Value), 10), nil
+}
+
+func (m *mapper) MapLiteralInt64(value *code.LiteralInt64) (string, error) {
+	return strconv.FormatInt(value.Value, 10) + "n", nil
+}
+
+func (m *mapper) MapLiteralList(value *code.LiteralList) (string, error) {
+	values, err := m.mapValues(value.Values)
+	if err != nil {
+		return "", err
+	}
+
+	return "[" + strings.Join(values, ", ") + "]", nil
+}
+
+func (m *mapper) MapLiteralMap(value *code.LiteralMap) (string, error) {
+	entries := make([]string, 0, len(value.Values))
+	for _, keyValue := range value.Values {
+		result, err := m.MapKeyValue(keyValue)
+		if err != nil {
+			return "", err
+		}
+
+		entries = append(entries, result)
+	}
+
+	return "new " + m.fromRuntime("HashMap") + "([" + strings.Join(entries, ", ") + "])", nil
+}
+
+func (m *mapper) MapLiteralRune(value *code.LiteralRune) (string, error) {
+	// Runes are represented as strings that contain a single code point.
+	return quote(string(value.Value)), nil
+}
+
+func (m *mapper) MapLiteralSet(value *code.LiteralSet) (string, error) {
+	values, err := m.mapValues(value.Values)
+	if err != nil {
+		return "", err
+	}
+
+	return "new " + m.fromRuntime("HashSet") + "([" + strings.Join(values, ", ") + "])", nil
+}
+
+func (m *mapper) MapLiteralString(value *code.LiteralString) (string, error) {
+	return quote(value.Value), nil
+}
+
+func (m *mapper) MapLiteralUint8(value *code.LiteralUint8) (string, error) {
+	return strconv.FormatUint(uint64(value.Value), 10), nil
+}
+
+func (m *mapper) MapLookup(value *code.Lookup) (string, error) {
+	from, err := code.MapValue[string](value.From, m)
+	if err != nil {
+		return "", err
+	}
+
+	key, err := code.MapValue[string](value.Key, m)
+	if err != nil {
+		return "", err
+	}
+
+	return m.fromRuntime("lookup") + "(" + from + ", " + key + ")", nil
+}
+
+func (m *mapper) MapMap(value *code.Map) (string, error) {
+	key, err := code.MapType[string](value.Key, m)
+	if err != nil {
+		return "", err
+	}
+
+	mapValue, err := code.MapType[string](value.Value, m)
+	if err != nil {
+		return "", err
+	}
+
+	return m.fromRuntime("HashMap") + "<" + key + ", " + mapValue + ">", nil
+}
+
+func (m *mapper) MapMethodCall(value *code.MethodCall) (string, error) {
+	receiver, err := code.MapValue[string](value.Receiver, m)
+	if err != nil {
+		return "", err
+	}
+
+	arguments, err := m.mapValues(value.Arguments)
+	if err != nil {
+		return "", err
+	}
+
+	return receiver + "." + identifier(value.Name) + "(" + strings.Join(arguments, ", ") + ")", nil
+}
+
+func (m *mapper) MapModel(value *code.Model) (string, error) {
+	// Models are references that can be nil.
+	return m.qualifier(value.Module) + value.Name + " | null", nil
+}
+
+func (m *mapper) MapModelDef(value *code.ModelDef) (string, error) {
+	var members []string
+	for _, field := range value.Fields {
+		result, err := m.MapFieldDef(field)
+		if err != nil {
+			return "", err
+		}
+
+		members = append(members, result)
+	}
+
+	m.self = value
+	defer func() { m.self = nil }()
+
+	var methods []string
+	for _, method := range value.Methods {
+		result, err := m.MapFunctionDef(method)
+		if err != nil {
+			return "", err
+		}
+
+		methods = append(methods, result)
+	}
+
+	if value.EqualOverride != nil {
+		result, err := m.MapEqualOverride(value.EqualOverride)
+		if err != nil {
+			return "", err
+		}
+
+		methods = append(methods, result)
+	}
+
+	if value.HashOverride != nil {
+		result, err := m.MapHashOverride(value.HashOverride)
+		if err != nil {
+			return "", err
+		}
+
+		methods = append(methods, result)
+	}
+
+	body := strings.Join(members, "\n")
+	if len(methods) > 0 {
+		if body != "" {
+			body += "\n\n"
+		}
+		body += strings.Join(methods, "\n\n")
+	}
+
+	if body == "" {
+		return exported(value.Visibility) + "class " + value.Name + " {}", nil
+	}
+
+	return exported(value.Visibility) + "class " + value.Name + " {\n" + languages.Indent(body, indent) + "\n}", nil
+}
+
+func (m *mapper) MapModule(value *code.Module) (string, error) {
+	var declarations []string
+	for _, constant := range value.Constants {
+		result, err := m.MapConstantDef(constant)
+		if err != nil {
+			return "", fmt.Errorf("constant %q: %w", constant.Name, err)
+		}
+
+		declarations = append(declarations, result)
+	}
+
+	for _, model := range value.Models {
+		result, err := m.MapModelDef(model)
+		if err != nil {
+			return "", fmt.Errorf("model %q: %w", model.Name, err)
+		}
+
+		declarations = append(declarations, result)
+	}
+
+	for _, function := range value.Functions {
+		result, err := m.MapFunctionDef(function)
+		if err != nil {
+			return "", fmt.Errorf("function %q: %w", function.Name, err)
+		}
+
+		declarations = append(declarations, result)
+	}
+
+	var sb strings.Builder
+	sb.WriteString("// Code generated by agnostic. DO NOT EDIT.\n\n")
+	var imports []string
+	if m.usesRuntime {
+		imports = append(imports, "import * as "+runtimeNamespace+" from "+quote(runtimeImport)+";")
+	}
+
+	for _, i := range value.Imports {
+		result, err := m.MapImport(i)
+		if err != nil {
+			return "", err
+		}
+
+		imports = append(imports, result)
+	}
+
+	if len(imports) > 0 {
+		sb.WriteString(strings.Join(imports, "\n") + "\n\n")
+	}
+
+	sb.WriteString(strings.Join(declarations, "\n\n"))
+	sb.WriteString("\n")
+
+	return sb.String(), nil
+}
+
+func (m *mapper) MapNew(value *code.New) (string, error) {
+	return "new " + m.qualifier(value.Model.Module) + value.Model.Name + "()", nil
+}
+
+func (m *mapper) MapNil(value *code.Nil) (string, error) {
+	return m.zeroValue(value.Type)
+}
+
+func (m *mapper) MapPop(value *code.Pop) (string, error) {
+	list, err := code.MapValue[string](value.List, m)
+	if err != nil {
+		return "", err
+	}
+
+	return m.fromRuntime("pop") + "(" + list + ")", nil
+}
+
+func (m *mapper) MapProperty(value *code.Property) (string, error) {
+	of, err := code.MapValue[string](value.Of, m)
+	if err != nil {
+		return "", err
+	}
+
+	if _, isSelf := value.Of.(*code.Self); !isSelf {
+		// Models can be null, but accessing a property of null is always a mistake.
+		of += "!"
+	}
+
+	return of + "." + identifier(value.Name), nil
+}
+
+func (m *mapper) MapPush(value *code.Push) (string, error) {
+	list, err := code.MapValue[string](value.List, m)
+	if err != nil {
+		return "", err
+	}
+
+	item, err := code.MapValue[string](value.Value, m)
+	if err != nil {
+		return "", err
+	}
+
+	return list + ".push(" + item + ")", nil
+}
+
+func (m *mapper) MapReturn(value *code.Return) (string, error) {
+	result, err := code.MapValue[string](value.Value, m)
+	if err != nil {
+		return "", err
+	}
+
+	return "return " + result, nil
+}
+
+func (m *mapper) MapRoot(value *code.Root) (string, error) {
+	return "", fmt.Errorf("a root can't be generated as a single TypeScript file")
+}
+
+func (m *mapper) MapRune(value *code.Rune) (string, error) {
+	return "string", nil
+}
+
+func (m *mapper) MapSelf(value *code.Self) (string, error) {
+	return "this", nil
+}
+
+func (m *mapper) MapSet(value *code.Set) (string, error) {
+	item, err := code.MapType[string](value.Item, m)
+	if err != nil {
+		return "", err
+	}
+
+	return m.fromRuntime("HashSet") + "<" + item + ">", nil
+}
+
+func (m *mapper) MapSetContains(value *code.SetContains) (string, error) {
+	set, err := code.MapValue[string](value.Set, m)
+	if err != nil {
+		return "", err
+	}
+
+	item, err := code.MapValue[string](value.Value, m)
+	if err != nil {
+		return "", err
+	}
+
+	return set + ".has(" + item + ")", nil
+}
+
+func (m *mapper) MapString(value *code.String) (string, error) {
+	return "string", nil
+}
+
+func (m *mapper) MapUint8(value *code.Uint8) (string, error) {
+	return "number", nil
+}
+
+func (m *mapper) MapUnary(value *code.Unary) (string, error) {
+	operand, err := code.MapValue[string](value.Value, m)
+	if err != nil {
+		return "", err
+	}
+
+	// Negating a negative literal would otherwise produce the decrement operator.
+	if strings.HasPrefix(operand, "-") {
+		operand = "(" + operand + ")"
+	}
+
+	if value.Overflow == code.OverflowWrap {
+		if _, isInt64 := value.Type.(*code.Int64); isInt64 {
+			return "BigInt.asIntN(64, -" + operand + ")", nil
+		}
+
+		return wrapNumber(value.Type, "(-"+operand+")"), nil
+	}
+
+	return "(" + value.Operator.Symbol() + operand + ")", nil
+}
+
+func (m *mapper) MapVariable(value *code.Variable) (string, error) {
+	if constant, ok := value.Definition.(*code.ConstantDef); ok {
+		name := m.qualifier(constant.Module.Name) + identifier(constant.Name)
+		if isCollection(code.TypeOf(constant.Value.(code.Value))) {
+			return name + "()", nil
+		}
+
+		return name, nil
+	}
+
+	return identifier(value.Name), nil
+}
+
+func (m *mapper) MapVoid(value *code.Void) (string, error) {
+	return "void", nil
+}
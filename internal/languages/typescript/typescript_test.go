@@ -0,0 +1,81 @@
+package typescript
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/JosephNaberhaus/agnostic/code"
+	"github.com/JosephNaberhaus/agnostic/internal/languages"
+	"github.com/JosephNaberhaus/agnostic/internal/languages/languagetest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate(t *testing.T) {
+	languagetest.RunGolden(t, generateWithoutRuntime, typeCheck)
+}
+
+// generateWithoutRuntime leaves out the runtime so that it isn't repeated in every golden file.
+func generateWithoutRuntime(root *code.Root) ([]languages.File, error) {
+	files, err := Generate(root)
+	if err != nil {
+		return nil, err
+	}
+
+	return slices.DeleteFunc(files, func(file languages.File) bool {
+		return file.Path == runtimeFilename
+	}), nil
+}
+
+// typeCheck compiles the generated files with tsc in strict mode. It's skipped if tsc isn't installed.
+func typeCheck(t *testing.T, files []languages.File) {
+	tsc, err := exec.LookPath("tsc")
+	if err != nil {
+		t.Skip("tsc is not installed")
+	}
+
+	dir := t.TempDir()
+	args := []string{"--strict", "--noEmit", "--target", "es2020", "--moduleResolution", "node"}
+	files = append(files, languages.File{Path: runtimeFilename, Contents: runtime})
+	for _, file := range files {
+		path := filepath.Join(dir, file.Path)
+		require.NoError(t, os.WriteFile(path, []byte(file.Contents), 0o644))
+		args = append(args, path)
+	}
+
+	output, err := exec.Command(tsc, args...).CombinedOutput()
+	require.NoError(t, err, string(output))
+}
+
+// TestRuntime compiles testdata/runtime_test.ts along with the runtime and runs it with node. It's skipped if tsc or
+// node isn't installed.
+func TestRuntime(t *testing.T) {
+	tsc, err := exec.LookPath("tsc")
+	if err != nil {
+		t.Skip("tsc is not installed")
+	}
+
+	node, err := exec.LookPath("node")
+	if err != nil {
+		t.Skip("node is not installed")
+	}
+
+	test, err := os.ReadFile(filepath.Join("testdata", "runtime_test.ts"))
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	args := []string{"--strict", "--target", "es2020", "--module", "commonjs", "--outDir", filepath.Join(dir, "out")}
+	for _, file := range []languages.File{{Path: runtimeFilename, Contents: runtime}, {Path: "runtime_test.ts", Contents: string(test)}} {
+		path := filepath.Join(dir, file.Path)
+		require.NoError(t, os.WriteFile(path, []byte(file.Contents), 0o644))
+		args = append(args, path)
+	}
+
+	output, err := exec.Command(tsc, args...).CombinedOutput()
+	require.NoError(t, err, string(output))
+
+	output, err = exec.Command(node, filepath.Join(dir, "out", "runtime_test.js")).CombinedOutput()
+	require.NoError(t, err, string(output))
+}
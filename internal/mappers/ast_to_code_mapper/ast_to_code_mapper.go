@@ -1,9 +1,10 @@
 package ast_to_code_mapper
 
 import (
+	"slices"
+
 	"github.com/JosephNaberhaus/agnostic/ast"
 	"github.com/JosephNaberhaus/agnostic/code"
-	"github.com/JosephNaberhaus/agnostic/internal/mappers/populate_metadata_mapper"
 	"github.com/JosephNaberhaus/agnostic/internal/utils/stack"
 )
 
@@ -15,11 +16,18 @@ type Mapper struct {
 	stack stack.Stack[code.Node]
 	// List of diferred calls. These will be hanlded once the rest of the AST tree is exhausted.
 	deferred []deferred
+	// The method calls found so far, whose receivers are marked once every body is mapped.
+	methodCalls []methodCall
+	// The problems found so far.
+	diagnostics Diagnostics
 }
 
-// MapRoot is the only valid entry-point into this Mapper.
+// MapRoot is the only valid entry-point into this Mapper. Mapping carries on past any problems that are found, so the
+// returned tree is complete but may be missing metadata wherever there was a problem. If there are any errors, they
+// are returned as Diagnostics.
 func (m *Mapper) MapRoot(original ast.Root) (code.Node, error) {
 	value := &code.Root{}
+	value.Position = code.Position(original.Position)
 	m.stack.Push(value)
 	defer m.stack.Pop()
 
@@ -42,16 +50,26 @@ func (m *Mapper) MapRoot(original ast.Root) (code.Node, error) {
 	}
 	m.stack = curStack
 
-	err = code.MapNodeOnlyError(value, populate_metadata_mapper.Mapper{Stack: m.stack})
-	if err != nil {
-		return nil, err
+	m.markModifiedReceivers()
+
+	m.populate(value)
+
+	m.diagnostics.sort()
+	if m.diagnostics.HasErrors() {
+		return value, m.diagnostics
 	}
 
 	return value, nil
 }
 
+// Diagnostics returns all of the problems found by MapRoot, sorted by where they were found.
+func (m *Mapper) Diagnostics() Diagnostics {
+	return m.diagnostics
+}
+
 func (m *Mapper) MapAddToSet(original ast.AddToSet) (code.Node, error) {
 	value := &code.AddToSet{}
+	value.Position = code.Position(original.Position)
 	m.stack.Push(value)
 	defer m.stack.Pop()
 
@@ -66,16 +84,14 @@ func (m *Mapper) MapAddToSet(original ast.AddToSet) (code.Node, error) {
 		return nil, err
 	}
 
-	err = code.MapNodeOnlyError(value, populate_metadata_mapper.Mapper{Stack: m.stack})
-	if err != nil {
-		return nil, err
-	}
+	m.populate(value)
 
 	return value, nil
 }
 
 func (m *Mapper) MapArgumentDef(original ast.ArgumentDef) (code.Node, error) {
 	value := &code.ArgumentDef{}
+	value.Position = code.Position(original.Position)
 	m.stack.Push(value)
 	defer m.stack.Pop()
 
@@ -87,16 +103,14 @@ func (m *Mapper) MapArgumentDef(original ast.ArgumentDef) (code.Node, error) {
 		return nil, err
 	}
 
-	err = code.MapNodeOnlyError(value, populate_metadata_mapper.Mapper{Stack: m.stack})
-	if err != nil {
-		return nil, err
-	}
+	m.populate(value)
 
 	return value, nil
 }
 
 func (m *Mapper) MapAssignment(original ast.Assignment) (code.Node, error) {
 	value := &code.Assignment{}
+	value.Position = code.Position(original.Position)
 	m.stack.Push(value)
 	defer m.stack.Pop()
 
@@ -111,61 +125,92 @@ func (m *Mapper) MapAssignment(original ast.Assignment) (code.Node, error) {
 		return nil, err
 	}
 
-	err = code.MapNodeOnlyError(value, populate_metadata_mapper.Mapper{Stack: m.stack})
-	if err != nil {
-		return nil, err
-	}
+	m.populate(value)
 
 	return value, nil
 }
 
-func (m *Mapper) MapBlock(original ast.Block) (code.Node, error) {
-	value := &code.Block{}
+func (m *Mapper) MapBinary(original ast.Binary) (code.Node, error) {
+	value := &code.Binary{}
+	value.Position = code.Position(original.Position)
 	m.stack.Push(value)
 	defer m.stack.Pop()
 
 	var err error
-	value.Statements, err = mapAstNodesTo[code.Statement](original.Statements, m)
+	value.Left, err = mapAstNodeTo[code.Value](original.Left, m)
 	if err != nil {
 		return nil, err
 	}
 
-	err = code.MapNodeOnlyError(value, populate_metadata_mapper.Mapper{Stack: m.stack})
+	value.Operator = code.BinaryOperator(original.Operator)
+
+	value.Right, err = mapAstNodeTo[code.Value](original.Right, m)
 	if err != nil {
 		return nil, err
 	}
 
+	m.populate(value)
+
+	return value, nil
+}
+
+func (m *Mapper) MapBlock(original ast.Block) (code.Node, error) {
+	value := &code.Block{}
+	value.Position = code.Position(original.Position)
+	m.stack.Push(value)
+	defer m.stack.Pop()
+
+	// Statements are added as they're mapped so that a statement can refer to the variables declared before it.
+	for _, originalStatement := range original.Statements {
+		statement, err := mapAstNodeTo[code.Statement](originalStatement, m)
+		if err != nil {
+			return nil, err
+		}
+
+		value.Statements = append(value.Statements, statement)
+	}
+
+	m.populate(value)
+
 	return value, nil
 }
 
 func (m *Mapper) MapBool(original ast.Bool) (code.Node, error) {
 	value := &code.Bool{}
+	value.Position = code.Position(original.Position)
 	m.stack.Push(value)
 	defer m.stack.Pop()
 
-	err := code.MapNodeOnlyError(value, populate_metadata_mapper.Mapper{Stack: m.stack})
-	if err != nil {
-		return nil, err
-	}
+	m.populate(value)
 
 	return value, nil
 }
 
 func (m *Mapper) MapBreak(original ast.Break) (code.Node, error) {
 	value := &code.Break{}
+	value.Position = code.Position(original.Position)
 	m.stack.Push(value)
 	defer m.stack.Pop()
 
-	err := code.MapNodeOnlyError(value, populate_metadata_mapper.Mapper{Stack: m.stack})
-	if err != nil {
-		return nil, err
-	}
+	m.populate(value)
+
+	return value, nil
+}
+
+func (m *Mapper) MapBytes(original ast.Bytes) (code.Node, error) {
+	value := &code.Bytes{}
+	value.Position = code.Position(original.Position)
+	m.stack.Push(value)
+	defer m.stack.Pop()
+
+	m.populate(value)
 
 	return value, nil
 }
 
 func (m *Mapper) MapCall(original ast.Call) (code.Node, error) {
 	value := &code.Call{}
+	value.Position = code.Position(original.Position)
 	m.stack.Push(value)
 	defer m.stack.Pop()
 
@@ -175,21 +220,50 @@ func (m *Mapper) MapCall(original ast.Call) (code.Node, error) {
 		return nil, err
 	}
 
-	value.Function, err = mapAstNodeTo[code.Callable](original.Function, m)
+	// A definition embedded in the call refers to the function of the same name in the module, so that every call to a
+	// function shares its definition just like a reference does.
+	function := original.Function
+	if definition, ok := function.(ast.FunctionDef); ok {
+		function = ast.FunctionRef{Name: definition.Name, Position: definition.Position}
+	}
+
+	value.Function, err = mapAstNodeTo[code.Callable](function, m)
 	if err != nil {
 		return nil, err
 	}
 
-	err = code.MapNodeOnlyError(value, populate_metadata_mapper.Mapper{Stack: m.stack})
+	m.populate(value)
+
+	return value, nil
+}
+
+func (m *Mapper) MapComparison(original ast.Comparison) (code.Node, error) {
+	value := &code.Comparison{}
+	value.Position = code.Position(original.Position)
+	m.stack.Push(value)
+	defer m.stack.Pop()
+
+	var err error
+	value.Left, err = mapAstNodeTo[code.Value](original.Left, m)
 	if err != nil {
 		return nil, err
 	}
 
+	value.Operator = code.ComparisonOperator(original.Operator)
+
+	value.Right, err = mapAstNodeTo[code.Value](original.Right, m)
+	if err != nil {
+		return nil, err
+	}
+
+	m.populate(value)
+
 	return value, nil
 }
 
 func (m *Mapper) MapConditional(original ast.Conditional) (code.Node, error) {
 	value := &code.Conditional{}
+	value.Position = code.Position(original.Position)
 	m.stack.Push(value)
 	defer m.stack.Pop()
 
@@ -206,20 +280,19 @@ func (m *Mapper) MapConditional(original ast.Conditional) (code.Node, error) {
 		return nil, err
 	}
 
-	err = code.MapNodeOnlyError(value, populate_metadata_mapper.Mapper{Stack: m.stack})
-	if err != nil {
-		return nil, err
-	}
+	m.populate(value)
 
 	return value, nil
 }
 
 func (m *Mapper) MapConstantDef(original ast.ConstantDef) (code.Node, error) {
 	value := &code.ConstantDef{}
+	value.Position = code.Position(original.Position)
 	m.stack.Push(value)
 	defer m.stack.Pop()
 
 	value.Name = original.Name
+	value.Visibility = code.Visibility(original.Visibility)
 
 	var err error
 	value.Value, err = mapAstNodeTo[code.ConstantValue](original.Value, m)
@@ -227,29 +300,47 @@ func (m *Mapper) MapConstantDef(original ast.ConstantDef) (code.Node, error) {
 		return nil, err
 	}
 
-	err = code.MapNodeOnlyError(value, populate_metadata_mapper.Mapper{Stack: m.stack})
-	if err != nil {
-		return nil, err
-	}
+	m.populate(value)
 
 	return value, nil
 }
 
 func (m *Mapper) MapContinue(original ast.Continue) (code.Node, error) {
 	value := &code.Continue{}
+	value.Position = code.Position(original.Position)
 	m.stack.Push(value)
 	defer m.stack.Pop()
 
-	err := code.MapNodeOnlyError(value, populate_metadata_mapper.Mapper{Stack: m.stack})
+	m.populate(value)
+
+	return value, nil
+}
+
+func (m *Mapper) MapConversion(original ast.Conversion) (code.Node, error) {
+	value := &code.Conversion{}
+	value.Position = code.Position(original.Position)
+	m.stack.Push(value)
+	defer m.stack.Pop()
+
+	var err error
+	value.Value, err = mapAstNodeTo[code.Value](original.Value, m)
 	if err != nil {
 		return nil, err
 	}
 
+	value.To, err = mapAstNodeTo[code.Type](original.To, m)
+	if err != nil {
+		return nil, err
+	}
+
+	m.populate(value)
+
 	return value, nil
 }
 
 func (m *Mapper) MapDeclare(original ast.Declare) (code.Node, error) {
 	value := &code.Declare{}
+	value.Position = code.Position(original.Position)
 	m.stack.Push(value)
 	defer m.stack.Pop()
 
@@ -261,16 +352,14 @@ func (m *Mapper) MapDeclare(original ast.Declare) (code.Node, error) {
 		return nil, err
 	}
 
-	err = code.MapNodeOnlyError(value, populate_metadata_mapper.Mapper{Stack: m.stack})
-	if err != nil {
-		return nil, err
-	}
+	m.populate(value)
 
 	return value, nil
 }
 
 func (m *Mapper) MapEmptyList(original ast.EmptyList) (code.Node, error) {
 	value := &code.EmptyList{}
+	value.Position = code.Position(original.Position)
 	m.stack.Push(value)
 	defer m.stack.Pop()
 
@@ -280,41 +369,44 @@ func (m *Mapper) MapEmptyList(original ast.EmptyList) (code.Node, error) {
 		return nil, err
 	}
 
-	err = code.MapNodeOnlyError(value, populate_metadata_mapper.Mapper{Stack: m.stack})
-	if err != nil {
-		return nil, err
-	}
+	m.populate(value)
 
 	return value, nil
 }
 
 func (m *Mapper) MapEqualOverride(original ast.EqualOverride) (code.Node, error) {
 	value := &code.EqualOverride{}
+	value.Position = code.Position(original.Position)
 	m.stack.Push(value)
 	defer m.stack.Pop()
 
-	var err error
-	value.Block, err = mapAstNodeTo[*code.Block](original.Block, m)
-	if err != nil {
-		return nil, err
-	}
-
+	// The name is set first so that it's in scope within the block.
 	value.OtherName = original.OtherName
 
-	err = code.MapNodeOnlyError(value, populate_metadata_mapper.Mapper{Stack: m.stack})
-	if err != nil {
-		return nil, err
-	}
+	// Defer because code inside the override might refer to a model that hasn't been processed yet.
+	m.queueDeferred(func() error {
+		var err error
+		value.Block, err = mapAstNodeTo[*code.Block](original.Block, m)
+		if err != nil {
+			return err
+		}
+
+		return nil
+	})
+
+	m.populate(value)
 
 	return value, nil
 }
 
 func (m *Mapper) MapFieldDef(original ast.FieldDef) (code.Node, error) {
 	value := &code.FieldDef{}
+	value.Position = code.Position(original.Position)
 	m.stack.Push(value)
 	defer m.stack.Pop()
 
 	value.Name = original.Name
+	value.Visibility = code.Visibility(original.Visibility)
 
 	var err error
 	value.Type, err = mapAstNodeTo[code.Type](original.Type, m)
@@ -322,20 +414,37 @@ func (m *Mapper) MapFieldDef(original ast.FieldDef) (code.Node, error) {
 		return nil, err
 	}
 
-	err = code.MapNodeOnlyError(value, populate_metadata_mapper.Mapper{Stack: m.stack})
-	if err != nil {
-		return nil, err
-	}
+	m.populate(value)
+
+	return value, nil
+}
+
+func (m *Mapper) MapFloat64(original ast.Float64) (code.Node, error) {
+	value := &code.Float64{}
+	value.Position = code.Position(original.Position)
+	m.stack.Push(value)
+	defer m.stack.Pop()
+
+	m.populate(value)
 
 	return value, nil
 }
 
 func (m *Mapper) MapFor(original ast.For) (code.Node, error) {
 	value := &code.For{}
+	value.Position = code.Position(original.Position)
 	m.stack.Push(value)
 	defer m.stack.Pop()
 
+	// The initialization is mapped first so that the variable it declares is in scope within the rest of the loop.
 	var err error
+	if original.Initialization.IsSet() {
+		value.Initialization, err = mapAstNodeTo[code.Statement](original.Initialization.Value(), m)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	if original.AfterEach.IsSet() {
 		value.AfterEach, err = mapAstNodeTo[code.Statement](original.AfterEach.Value(), m)
 		if err != nil {
@@ -353,49 +462,39 @@ func (m *Mapper) MapFor(original ast.For) (code.Node, error) {
 		return nil, err
 	}
 
-	if original.Initialization.IsSet() {
-		value.Initialization, err = mapAstNodeTo[code.Statement](original.Initialization.Value(), m)
-		if err != nil {
-			return nil, err
-		}
-	}
-
-	err = code.MapNodeOnlyError(value, populate_metadata_mapper.Mapper{Stack: m.stack})
-	if err != nil {
-		return nil, err
-	}
+	m.populate(value)
 
 	return value, nil
 }
 
 func (m *Mapper) MapForEach(original ast.ForEach) (code.Node, error) {
 	value := &code.ForEach{}
+	value.Position = code.Position(original.Position)
 	m.stack.Push(value)
 	defer m.stack.Pop()
 
+	// The iterable is mapped before the item name is set because the item isn't in scope within the iterable.
 	var err error
-	value.Block, err = mapAstNodeTo[*code.Block](original.Block, m)
+	value.Iterable, err = mapAstNodeTo[code.Value](original.Iterable, m)
 	if err != nil {
 		return nil, err
 	}
 
 	value.ItemName = original.ItemName
 
-	value.Iterable, err = mapAstNodeTo[code.Value](original.Iterable, m)
+	value.Block, err = mapAstNodeTo[*code.Block](original.Block, m)
 	if err != nil {
 		return nil, err
 	}
 
-	err = code.MapNodeOnlyError(value, populate_metadata_mapper.Mapper{Stack: m.stack})
-	if err != nil {
-		return nil, err
-	}
+	m.populate(value)
 
 	return value, nil
 }
 
 func (m *Mapper) MapFunctionDef(original ast.FunctionDef) (code.Node, error) {
 	value := &code.FunctionDef{}
+	value.Position = code.Position(original.Position)
 	m.stack.Push(value)
 	defer m.stack.Pop()
 
@@ -406,6 +505,7 @@ func (m *Mapper) MapFunctionDef(original ast.FunctionDef) (code.Node, error) {
 	}
 
 	value.Name = original.Name
+	value.Visibility = code.Visibility(original.Visibility)
 
 	value.ReturnType, err = mapAstNodeTo[code.Type](original.ReturnType, m)
 	if err != nil {
@@ -422,9 +522,26 @@ func (m *Mapper) MapFunctionDef(original ast.FunctionDef) (code.Node, error) {
 		return nil
 	})
 
-	err = code.MapNodeOnlyError(value, populate_metadata_mapper.Mapper{Stack: m.stack})
-	if err != nil {
-		return nil, err
+	m.populate(value)
+
+	return value, nil
+}
+
+// MapFunctionRef maps the reference to the definition of the function that it refers to, so that every call to a
+// function shares the definition in its module. The reference itself is only kept if it couldn't be resolved.
+func (m *Mapper) MapFunctionRef(original ast.FunctionRef) (code.Node, error) {
+	value := &code.FunctionRef{}
+	value.Position = code.Position(original.Position)
+	m.stack.Push(value)
+	defer m.stack.Pop()
+
+	value.Module = original.Module
+	value.Name = original.Name
+
+	m.populate(value)
+
+	if value.Definition != nil {
+		return value.Definition, nil
 	}
 
 	return value, nil
@@ -432,25 +549,29 @@ func (m *Mapper) MapFunctionDef(original ast.FunctionDef) (code.Node, error) {
 
 func (m *Mapper) MapHashOverride(original ast.HashOverride) (code.Node, error) {
 	value := &code.HashOverride{}
+	value.Position = code.Position(original.Position)
 	m.stack.Push(value)
 	defer m.stack.Pop()
 
-	var err error
-	value.Block, err = mapAstNodeTo[*code.Block](original.Block, m)
-	if err != nil {
-		return nil, err
-	}
+	// Defer because code inside the override might refer to a model that hasn't been processed yet.
+	m.queueDeferred(func() error {
+		var err error
+		value.Block, err = mapAstNodeTo[*code.Block](original.Block, m)
+		if err != nil {
+			return err
+		}
 
-	err = code.MapNodeOnlyError(value, populate_metadata_mapper.Mapper{Stack: m.stack})
-	if err != nil {
-		return nil, err
-	}
+		return nil
+	})
+
+	m.populate(value)
 
 	return value, nil
 }
 
 func (m *Mapper) MapIf(original ast.If) (code.Node, error) {
 	value := &code.If{}
+	value.Position = code.Position(original.Position)
 	m.stack.Push(value)
 	defer m.stack.Pop()
 
@@ -465,29 +586,53 @@ func (m *Mapper) MapIf(original ast.If) (code.Node, error) {
 		return nil, err
 	}
 
-	err = code.MapNodeOnlyError(value, populate_metadata_mapper.Mapper{Stack: m.stack})
-	if err != nil {
-		return nil, err
-	}
+	m.populate(value)
+
+	return value, nil
+}
+
+func (m *Mapper) MapImport(original ast.Import) (code.Node, error) {
+	value := &code.Import{}
+	value.Position = code.Position(original.Position)
+	m.stack.Push(value)
+	defer m.stack.Pop()
+
+	value.Module = original.Module
+
+	// Defer because the imported module might not have been processed yet.
+	m.queueDeferred(func() error {
+		m.populate(value)
+		return nil
+	})
+
+	return value, nil
+}
+
+func (m *Mapper) MapInt32(original ast.Int32) (code.Node, error) {
+	value := &code.Int32{}
+	value.Position = code.Position(original.Position)
+	m.stack.Push(value)
+	defer m.stack.Pop()
+
+	m.populate(value)
 
 	return value, nil
 }
 
 func (m *Mapper) MapInt64(original ast.Int64) (code.Node, error) {
 	value := &code.Int64{}
+	value.Position = code.Position(original.Position)
 	m.stack.Push(value)
 	defer m.stack.Pop()
 
-	err := code.MapNodeOnlyError(value, populate_metadata_mapper.Mapper{Stack: m.stack})
-	if err != nil {
-		return nil, err
-	}
+	m.populate(value)
 
 	return value, nil
 }
 
 func (m *Mapper) MapKeyValue(original ast.KeyValue) (code.Node, error) {
 	value := &code.KeyValue{}
+	value.Position = code.Position(original.Position)
 	m.stack.Push(value)
 	defer m.stack.Pop()
 
@@ -502,16 +647,14 @@ func (m *Mapper) MapKeyValue(original ast.KeyValue) (code.Node, error) {
 		return nil, err
 	}
 
-	err = code.MapNodeOnlyError(value, populate_metadata_mapper.Mapper{Stack: m.stack})
-	if err != nil {
-		return nil, err
-	}
+	m.populate(value)
 
 	return value, nil
 }
 
 func (m *Mapper) MapLength(original ast.Length) (code.Node, error) {
 	value := &code.Length{}
+	value.Position = code.Position(original.Position)
 	m.stack.Push(value)
 	defer m.stack.Pop()
 
@@ -521,16 +664,14 @@ func (m *Mapper) MapLength(original ast.Length) (code.Node, error) {
 		return nil, err
 	}
 
-	err = code.MapNodeOnlyError(value, populate_metadata_mapper.Mapper{Stack: m.stack})
-	if err != nil {
-		return nil, err
-	}
+	m.populate(value)
 
 	return value, nil
 }
 
 func (m *Mapper) MapList(original ast.List) (code.Node, error) {
 	value := &code.List{}
+	value.Position = code.Position(original.Position)
 	m.stack.Push(value)
 	defer m.stack.Pop()
 
@@ -540,46 +681,79 @@ func (m *Mapper) MapList(original ast.List) (code.Node, error) {
 		return nil, err
 	}
 
-	err = code.MapNodeOnlyError(value, populate_metadata_mapper.Mapper{Stack: m.stack})
-	if err != nil {
-		return nil, err
-	}
+	m.populate(value)
 
 	return value, nil
 }
 
 func (m *Mapper) MapLiteralBool(original ast.LiteralBool) (code.Node, error) {
 	value := &code.LiteralBool{}
+	value.Position = code.Position(original.Position)
 	m.stack.Push(value)
 	defer m.stack.Pop()
 
 	value.Value = original.Value
 
-	err := code.MapNodeOnlyError(value, populate_metadata_mapper.Mapper{Stack: m.stack})
-	if err != nil {
-		return nil, err
-	}
+	m.populate(value)
+
+	return value, nil
+}
+
+func (m *Mapper) MapLiteralBytes(original ast.LiteralBytes) (code.Node, error) {
+	value := &code.LiteralBytes{}
+	value.Position = code.Position(original.Position)
+	m.stack.Push(value)
+	defer m.stack.Pop()
+
+	value.Value = slices.Clone(original.Value)
+
+	m.populate(value)
+
+	return value, nil
+}
+
+func (m *Mapper) MapLiteralFloat64(original ast.LiteralFloat64) (code.Node, error) {
+	value := &code.LiteralFloat64{}
+	value.Position = code.Position(original.Position)
+	m.stack.Push(value)
+	defer m.stack.Pop()
+
+	value.Value = original.Value
+
+	m.populate(value)
+
+	return value, nil
+}
+
+func (m *Mapper) MapLiteralInt32(original ast.LiteralInt32) (code.Node, error) {
+	value := &code.LiteralInt32{}
+	value.Position = code.Position(original.Position)
+	m.stack.Push(value)
+	defer m.stack.Pop()
+
+	value.Value = original.Value
+
+	m.populate(value)
 
 	return value, nil
 }
 
 func (m *Mapper) MapLiteralInt64(original ast.LiteralInt64) (code.Node, error) {
 	value := &code.LiteralInt64{}
+	value.Position = code.Position(original.Position)
 	m.stack.Push(value)
 	defer m.stack.Pop()
 
 	value.Value = original.Value
 
-	err := code.MapNodeOnlyError(value, populate_metadata_mapper.Mapper{Stack: m.stack})
-	if err != nil {
-		return nil, err
-	}
+	m.populate(value)
 
 	return value, nil
 }
 
 func (m *Mapper) MapLiteralList(original ast.LiteralList) (code.Node, error) {
 	value := &code.LiteralList{}
+	value.Position = code.Position(original.Position)
 	m.stack.Push(value)
 	defer m.stack.Pop()
 
@@ -589,16 +763,14 @@ func (m *Mapper) MapLiteralList(original ast.LiteralList) (code.Node, error) {
 		return nil, err
 	}
 
-	err = code.MapNodeOnlyError(value, populate_metadata_mapper.Mapper{Stack: m.stack})
-	if err != nil {
-		return nil, err
-	}
+	m.populate(value)
 
 	return value, nil
 }
 
 func (m *Mapper) MapLiteralMap(original ast.LiteralMap) (code.Node, error) {
 	value := &code.LiteralMap{}
+	value.Position = code.Position(original.Position)
 	m.stack.Push(value)
 	defer m.stack.Pop()
 
@@ -608,31 +780,27 @@ func (m *Mapper) MapLiteralMap(original ast.LiteralMap) (code.Node, error) {
 		return nil, err
 	}
 
-	err = code.MapNodeOnlyError(value, populate_metadata_mapper.Mapper{Stack: m.stack})
-	if err != nil {
-		return nil, err
-	}
+	m.populate(value)
 
 	return value, nil
 }
 
 func (m *Mapper) MapLiteralRune(original ast.LiteralRune) (code.Node, error) {
 	value := &code.LiteralRune{}
+	value.Position = code.Position(original.Position)
 	m.stack.Push(value)
 	defer m.stack.Pop()
 
 	value.Value = original.Value
 
-	err := code.MapNodeOnlyError(value, populate_metadata_mapper.Mapper{Stack: m.stack})
-	if err != nil {
-		return nil, err
-	}
+	m.populate(value)
 
 	return value, nil
 }
 
 func (m *Mapper) MapLiteralSet(original ast.LiteralSet) (code.Node, error) {
 	value := &code.LiteralSet{}
+	value.Position = code.Position(original.Position)
 	m.stack.Push(value)
 	defer m.stack.Pop()
 
@@ -642,31 +810,40 @@ func (m *Mapper) MapLiteralSet(original ast.LiteralSet) (code.Node, error) {
 		return nil, err
 	}
 
-	err = code.MapNodeOnlyError(value, populate_metadata_mapper.Mapper{Stack: m.stack})
-	if err != nil {
-		return nil, err
-	}
+	m.populate(value)
 
 	return value, nil
 }
 
 func (m *Mapper) MapLiteralString(original ast.LiteralString) (code.Node, error) {
 	value := &code.LiteralString{}
+	value.Position = code.Position(original.Position)
 	m.stack.Push(value)
 	defer m.stack.Pop()
 
 	value.Value = original.Value
 
-	err := code.MapNodeOnlyError(value, populate_metadata_mapper.Mapper{Stack: m.stack})
-	if err != nil {
-		return nil, err
-	}
+	m.populate(value)
+
+	return value, nil
+}
+
+func (m *Mapper) MapLiteralUint8(original ast.LiteralUint8) (code.Node, error) {
+	value := &code.LiteralUint8{}
+	value.Position = code.Position(original.Position)
+	m.stack.Push(value)
+	defer m.stack.Pop()
+
+	value.Value = original.Value
+
+	m.populate(value)
 
 	return value, nil
 }
 
 func (m *Mapper) MapLookup(original ast.Lookup) (code.Node, error) {
 	value := &code.Lookup{}
+	value.Position = code.Position(original.Position)
 	m.stack.Push(value)
 	defer m.stack.Pop()
 
@@ -681,16 +858,14 @@ func (m *Mapper) MapLookup(original ast.Lookup) (code.Node, error) {
 		return nil, err
 	}
 
-	err = code.MapNodeOnlyError(value, populate_metadata_mapper.Mapper{Stack: m.stack})
-	if err != nil {
-		return nil, err
-	}
+	m.populate(value)
 
 	return value, nil
 }
 
 func (m *Mapper) MapMap(original ast.Map) (code.Node, error) {
 	value := &code.Map{}
+	value.Position = code.Position(original.Position)
 	m.stack.Push(value)
 	defer m.stack.Pop()
 
@@ -705,103 +880,142 @@ func (m *Mapper) MapMap(original ast.Map) (code.Node, error) {
 		return nil, err
 	}
 
-	err = code.MapNodeOnlyError(value, populate_metadata_mapper.Mapper{Stack: m.stack})
-	if err != nil {
-		return nil, err
-	}
+	m.populate(value)
 
 	return value, nil
 }
 
 func (m *Mapper) MapModel(original ast.Model) (code.Node, error) {
 	value := &code.Model{}
+	value.Position = code.Position(original.Position)
+	m.stack.Push(value)
+	defer m.stack.Pop()
+
+	value.Module = original.Module
+	value.Name = original.Name
+
+	if value.Module != "" {
+		// Defer because the module of the model might not have been processed yet.
+		m.queueDeferred(func() error {
+			m.populate(value)
+			return nil
+		})
+
+		return value, nil
+	}
+
+	m.populate(value)
+
+	return value, nil
+}
+
+func (m *Mapper) MapMethodCall(original ast.MethodCall) (code.Node, error) {
+	value := &code.MethodCall{}
+	value.Position = code.Position(original.Position)
 	m.stack.Push(value)
 	defer m.stack.Pop()
 
+	var err error
+	value.Arguments, err = mapAstNodesTo[code.Value](original.Arguments, m)
+	if err != nil {
+		return nil, err
+	}
+
 	value.Name = original.Name
 
-	err := code.MapNodeOnlyError(value, populate_metadata_mapper.Mapper{Stack: m.stack})
+	value.Receiver, err = mapAstNodeTo[code.Value](original.Receiver, m)
 	if err != nil {
 		return nil, err
 	}
 
+	m.populate(value)
+	m.methodCalls = append(m.methodCalls, methodCall{stack: m.stack.Copy(), value: value})
+
 	return value, nil
 }
 
 func (m *Mapper) MapModelDef(original ast.ModelDef) (code.Node, error) {
 	value := &code.ModelDef{}
+	value.Position = code.Position(original.Position)
 	m.stack.Push(value)
 	defer m.stack.Pop()
 
 	value.Name = original.Name
+	value.Visibility = code.Visibility(original.Visibility)
 
-	// Defer because something inside this model might refer to a mode that hasn't been processed yet.
-	m.queueDeferred(func() error {
-		var err error
-		value.EqualOverride, err = mapAstNodeTo[*code.EqualOverride](original.EqualOverride, m)
-		if err != nil {
-			return err
-		}
-
-		value.Fields, err = mapAstNodesTo[*code.FieldDef](original.Fields, m)
-		if err != nil {
-			return err
-		}
+	// The fields only refer to models by name, so they're mapped right away. This lets the types of properties be
+	// found while mapping the deferred function bodies.
+	var err error
+	value.Fields, err = mapAstNodesTo[*code.FieldDef](original.Fields, m)
+	if err != nil {
+		return nil, err
+	}
 
-		value.HashOverride, err = mapAstNodeTo[*code.HashOverride](original.HashOverride, m)
+	// The overrides defer their own blocks, so mapping them right away only records that the model has them.
+	if original.EqualOverride.IsSet() {
+		value.EqualOverride, err = mapAstNodeTo[*code.EqualOverride](original.EqualOverride.Value(), m)
 		if err != nil {
-			return err
+			return nil, err
 		}
+	}
 
-		value.Methods, err = mapAstNodesTo[*code.FunctionDef](original.Methods, m)
+	if original.HashOverride.IsSet() {
+		value.HashOverride, err = mapAstNodeTo[*code.HashOverride](original.HashOverride.Value(), m)
 		if err != nil {
-			return err
+			return nil, err
 		}
+	}
 
-		return nil
-	})
-
-	err := code.MapNodeOnlyError(value, populate_metadata_mapper.Mapper{Stack: m.stack})
+	// The methods defer their own blocks. Mapping their signatures right away lets method calls be resolved wherever
+	// they are.
+	value.Methods, err = mapAstNodesTo[*code.FunctionDef](original.Methods, m)
 	if err != nil {
 		return nil, err
 	}
 
+	m.populate(value)
+
 	return value, nil
 }
 
 func (m *Mapper) MapModule(original ast.Module) (code.Node, error) {
 	value := &code.Module{}
+	value.Position = code.Position(original.Position)
 	m.stack.Push(value)
 	defer m.stack.Pop()
 
+	// The name and imports come first since the rest of the module is resolved against them.
+	value.Name = original.Name
+
 	var err error
-	value.Constants, err = mapAstNodesTo[*code.ConstantDef](original.Constants, m)
+	value.Imports, err = mapAstNodesTo[*code.Import](original.Imports, m)
 	if err != nil {
 		return nil, err
 	}
 
-	value.Functions, err = mapAstNodesTo[*code.FunctionDef](original.Functions, m)
+	value.Constants, err = mapAstNodesTo[*code.ConstantDef](original.Constants, m)
 	if err != nil {
 		return nil, err
 	}
 
-	value.Models, err = mapAstNodesTo[*code.ModelDef](original.Models, m)
+	value.Functions, err = mapAstNodesTo[*code.FunctionDef](original.Functions, m)
 	if err != nil {
 		return nil, err
 	}
 
-	value.Name = original.Name
-
-	err = code.MapNodeOnlyError(value, populate_metadata_mapper.Mapper{Stack: m.stack})
+	value.Models, err = mapAstNodesTo[*code.ModelDef](original.Models, m)
 	if err != nil {
 		return nil, err
 	}
 
+	m.populate(value)
+
 	return value, nil
 }
 
 func (m *Mapper) MapNew(original ast.New) (code.Node, error) {
 	value := &code.New{}
+	value.Position = code.Position(original.Position)
 	m.stack.Push(value)
 	defer m.stack.Pop()
 
@@ -811,16 +1025,14 @@ func (m *Mapper) MapNew(original ast.New) (code.Node, error) {
 		return nil, err
 	}
 
-	err = code.MapNodeOnlyError(value, populate_metadata_mapper.Mapper{Stack: m.stack})
-	if err != nil {
-		return nil, err
-	}
+	m.populate(value)
 
 	return value, nil
 }
 
 func (m *Mapper) MapNil(original ast.Nil) (code.Node, error) {
 	value := &code.Nil{}
+	value.Position = code.Position(original.Position)
 	m.stack.Push(value)
 	defer m.stack.Pop()
 
@@ -830,16 +1042,14 @@ func (m *Mapper) MapNil(original ast.Nil) (code.Node, error) {
 		return nil, err
 	}
 
-	err = code.MapNodeOnlyError(value, populate_metadata_mapper.Mapper{Stack: m.stack})
-	if err != nil {
-		return nil, err
-	}
+	m.populate(value)
 
 	return value, nil
 }
 
 func (m *Mapper) MapPop(original ast.Pop) (code.Node, error) {
 	value := &code.Pop{}
+	value.Position = code.Position(original.Position)
 	m.stack.Push(value)
 	defer m.stack.Pop()
 
@@ -849,16 +1059,14 @@ func (m *Mapper) MapPop(original ast.Pop) (code.Node, error) {
 		return nil, err
 	}
 
-	err = code.MapNodeOnlyError(value, populate_metadata_mapper.Mapper{Stack: m.stack})
-	if err != nil {
-		return nil, err
-	}
+	m.populate(value)
 
 	return value, nil
 }
 
 func (m *Mapper) MapProperty(original ast.Property) (code.Node, error) {
 	value := &code.Property{}
+	value.Position = code.Position(original.Position)
 	m.stack.Push(value)
 	defer m.stack.Pop()
 
@@ -870,16 +1078,14 @@ func (m *Mapper) MapProperty(original ast.Property) (code.Node, error) {
 		return nil, err
 	}
 
-	err = code.MapNodeOnlyError(value, populate_metadata_mapper.Mapper{Stack: m.stack})
-	if err != nil {
-		return nil, err
-	}
+	m.populate(value)
 
 	return value, nil
 }
 
 func (m *Mapper) MapPush(original ast.Push) (code.Node, error) {
 	value := &code.Push{}
+	value.Position = code.Position(original.Position)
 	m.stack.Push(value)
 	defer m.stack.Pop()
 
@@ -894,16 +1100,14 @@ func (m *Mapper) MapPush(original ast.Push) (code.Node, error) {
 		return nil, err
 	}
 
-	err = code.MapNodeOnlyError(value, populate_metadata_mapper.Mapper{Stack: m.stack})
-	if err != nil {
-		return nil, err
-	}
+	m.populate(value)
 
 	return value, nil
 }
 
 func (m *Mapper) MapReturn(original ast.Return) (code.Node, error) {
 	value := &code.Return{}
+	value.Position = code.Position(original.Position)
 	m.stack.Push(value)
 	defer m.stack.Pop()
 
@@ -913,37 +1117,36 @@ func (m *Mapper) MapReturn(original ast.Return) (code.Node, error) {
 		return nil, err
 	}
 
-	err = code.MapNodeOnlyError(value, populate_metadata_mapper.Mapper{Stack: m.stack})
-	if err != nil {
-		return nil, err
-	}
+	m.populate(value)
 
 	return value, nil
 }
 
 func (m *Mapper) MapRune(original ast.Rune) (code.Node, error) {
 	value := &code.Rune{}
+	value.Position = code.Position(original.Position)
 	m.stack.Push(value)
 	defer m.stack.Pop()
 
+	m.populate(value)
+
 	return value, nil
 }
 
 func (m *Mapper) MapSelf(original ast.Self) (code.Node, error) {
 	value := &code.Self{}
+	value.Position = code.Position(original.Position)
 	m.stack.Push(value)
 	defer m.stack.Pop()
 
-	err := code.MapNodeOnlyError(value, populate_metadata_mapper.Mapper{Stack: m.stack})
-	if err != nil {
-		return nil, err
-	}
+	m.populate(value)
 
 	return value, nil
 }
 
 func (m *Mapper) MapSet(original ast.Set) (code.Node, error) {
 	value := &code.Set{}
+	value.Position = code.Position(original.Position)
 	m.stack.Push(value)
 	defer m.stack.Pop()
 
@@ -953,16 +1156,14 @@ func (m *Mapper) MapSet(original ast.Set) (code.Node, error) {
 		return nil, err
 	}
 
-	err = code.MapNodeOnlyError(value, populate_metadata_mapper.Mapper{Stack: m.stack})
-	if err != nil {
-		return nil, err
-	}
+	m.populate(value)
 
 	return value, nil
 }
 
 func (m *Mapper) MapSetContains(original ast.SetContains) (code.Node, error) {
 	value := &code.SetContains{}
+	value.Position = code.Position(original.Position)
 	m.stack.Push(value)
 	defer m.stack.Pop()
 
@@ -977,51 +1178,73 @@ func (m *Mapper) MapSetContains(original ast.SetContains) (code.Node, error) {
 		return nil, err
 	}
 
-	err = code.MapNodeOnlyError(value, populate_metadata_mapper.Mapper{Stack: m.stack})
-	if err != nil {
-		return nil, err
-	}
+	m.populate(value)
 
 	return value, nil
 }
 
 func (m *Mapper) MapString(original ast.String) (code.Node, error) {
 	value := &code.String{}
+	value.Position = code.Position(original.Position)
+	m.stack.Push(value)
+	defer m.stack.Pop()
+
+	m.populate(value)
+
+	return value, nil
+}
+
+func (m *Mapper) MapUint8(original ast.Uint8) (code.Node, error) {
+	value := &code.Uint8{}
+	value.Position = code.Position(original.Position)
 	m.stack.Push(value)
 	defer m.stack.Pop()
 
-	err := code.MapNodeOnlyError(value, populate_metadata_mapper.Mapper{Stack: m.stack})
+	m.populate(value)
+
+	return value, nil
+}
+
+func (m *Mapper) MapUnary(original ast.Unary) (code.Node, error) {
+	value := &code.Unary{}
+	value.Position = code.Position(original.Position)
+	m.stack.Push(value)
+	defer m.stack.Pop()
+
+	value.Operator = code.UnaryOperator(original.Operator)
+
+	var err error
+	value.Value, err = mapAstNodeTo[code.Value](original.Value, m)
 	if err != nil {
 		return nil, err
 	}
 
+	m.populate(value)
+
 	return value, nil
 }
 
 func (m *Mapper) MapVariable(original ast.Variable) (code.Node, error) {
 	value := &code.Variable{}
+	value.Position = code.Position(original.Position)
 	m.stack.Push(value)
 	defer m.stack.Pop()
 
+	value.Module = original.Module
 	value.Name = original.Name
 
-	err := code.MapNodeOnlyError(value, populate_metadata_mapper.Mapper{Stack: m.stack})
-	if err != nil {
-		return nil, err
-	}
+	m.populate(value)
 
 	return value, nil
 }
 
 func (m *Mapper) MapVoid(original ast.Void) (code.Node, error) {
 	value := &code.Void{}
+	value.Position = code.Position(original.Position)
 	m.stack.Push(value)
 	defer m.stack.Pop()
 
-	err := code.MapNodeOnlyError(value, populate_metadata_mapper.Mapper{Stack: m.stack})
-	if err != nil {
-		return nil, err
-	}
+	m.populate(value)
 
 	return value, nil
 }
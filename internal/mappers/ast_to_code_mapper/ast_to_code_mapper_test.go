@@ -0,0 +1,1199 @@
+package ast_to_code_mapper
+
+import (
+	"testing"
+
+	"github.com/JosephNaberhaus/agnostic/ast"
+	"github.com/JosephNaberhaus/agnostic/code"
+	"github.com/JosephNaberhaus/agnostic/internal/agnosticscript"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mapSource parses the AgnosticScript source and maps it to code.
+func mapSource(t *testing.T, source string) (*code.Root, error) {
+	t.Helper()
+
+	root, err := agnosticscript.Parse("test.as", source)
+	require.NoError(t, err)
+
+	mapper := &Mapper{}
+	result, err := mapper.MapRoot(root)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.(*code.Root), nil
+}
+
+func TestMapRoot_NameResolution(t *testing.T) {
+	root, err := mapSource(t, `
+module test {
+	const limit = 10
+
+	model Point {
+		x int64
+
+		equals(other) {
+			var same = other
+			return true
+		}
+	}
+
+	func f(items list[int64]) list[int64] {
+		var total = limit
+		for item in items {
+			total = item
+		}
+		for var i = 0; i < limit; i = total {
+		}
+		return items
+	}
+}
+`)
+	require.NoError(t, err)
+
+	module := root.Modules[0]
+	function := module.Functions[0]
+	statements := function.Block.Statements
+	total := statements[0].(*code.Declare)
+	forEach := statements[1].(*code.ForEach)
+	loop := statements[2].(*code.For)
+
+	assert.Same(t, module.Constants[0], total.Value.(*code.Variable).Definition)
+
+	assignment := forEach.Block.Statements[0].(*code.Assignment)
+	assert.Same(t, total, assignment.To.(*code.Variable).Definition)
+	assert.Same(t, forEach, assignment.From.(*code.Variable).Definition)
+
+	assert.Same(t, loop.Initialization, loop.Condition.(*code.Comparison).Left.(*code.Variable).Definition)
+	assert.Same(t, total, loop.AfterEach.(*code.Assignment).From.(*code.Variable).Definition)
+
+	assert.Same(t, function.Arguments[0], statements[3].(*code.Return).Value.(*code.Variable).Definition)
+
+	equalOverride := module.Models[0].EqualOverride
+	assert.Same(t, equalOverride, equalOverride.Block.Statements[0].(*code.Declare).Value.(*code.Variable).Definition)
+}
+
+func TestMapRoot_QualifiedByOwnModule(t *testing.T) {
+	root, err := mapSource(t, `
+module test {
+	const scale = 2
+
+	model Point {
+		x int64
+
+		func g() int64 {
+			return test.h(self) * test.scale
+		}
+	}
+
+	func h(p test.Point) int64 {
+		return p.x
+	}
+}
+`)
+	require.NoError(t, err)
+
+	module := root.Modules[0]
+	binary := module.Models[0].Methods[0].Block.Statements[0].(*code.Return).Value.(*code.Binary)
+	assert.Same(t, module.Functions[0], binary.Left.(*code.Call).Function)
+	assert.Same(t, module.Constants[0], binary.Right.(*code.Variable).Definition)
+}
+
+func TestMapRoot_NameResolutionErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		source   string
+		expected string
+	}{
+		{
+			name:     "undefined variable",
+			source:   `func f() int64 { return x }`,
+			expected: `undefined variable "x"`,
+		},
+		{
+			name:     "used before declaration",
+			source:   `func f() { var x = y var y = 1 }`,
+			expected: `undefined variable "y"`,
+		},
+		{
+			name:     "out of scope",
+			source:   `func f() int64 { if true { var x = 1 } return x }`,
+			expected: `undefined variable "x"`,
+		},
+		{
+			name:     "item used in iterable",
+			source:   `func f() { for x in x { } }`,
+			expected: `undefined variable "x"`,
+		},
+		{
+			name:     "variable of the calling function",
+			source:   `func f() { var x = 1 g() } func g() int64 { return x }`,
+			expected: `undefined variable "x"`,
+		},
+		{
+			name:     "redeclared variable",
+			source:   `func f() { var x = 1 var x = 2 }`,
+			expected: `shadowed declaration: "x" is already declared`,
+		},
+		{
+			name:     "shadowed argument",
+			source:   `func f(x int64) { if true { var x = 1 } }`,
+			expected: `shadowed declaration: "x" is already declared`,
+		},
+		{
+			name:     "shadowed constant",
+			source:   `const x = 1 func f(x int64) { }`,
+			expected: `shadowed declaration: "x" is already declared`,
+		},
+		{
+			name:     "shadowing item",
+			source:   `func f(x list[int64]) { for x in x { } }`,
+			expected: `shadowed declaration: "x" is already declared`,
+		},
+		{
+			name:     "duplicate argument",
+			source:   `func f(x int64, x bool) { }`,
+			expected: `shadowed declaration: "x" is already declared`,
+		},
+		{
+			name:     "variable named after the module",
+			source:   `func f() { var test = 1 }`,
+			expected: `shadowed declaration: "test" is the enclosing module`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := mapSource(t, "module test { "+tt.source+" }")
+			assert.EqualError(t, err, tt.expected)
+		})
+	}
+}
+
+func TestMapRoot_FunctionRefs(t *testing.T) {
+	root, err := mapSource(t, `
+module test {
+	func f() int64 {
+		return g(1) + g(2)
+	}
+
+	func g(x int64) int64 {
+		return x
+	}
+}
+`)
+	require.NoError(t, err)
+
+	functions := root.Modules[0].Functions
+	sum := functions[0].Block.Statements[0].(*code.Return).Value.(*code.Binary)
+
+	// Every call refers to the definition in the module rather than a copy of it.
+	assert.Same(t, functions[1], sum.Left.(*code.Call).Function)
+	assert.Same(t, functions[1], sum.Right.(*code.Call).Function)
+}
+
+func TestMapRoot_FunctionDefCalls(t *testing.T) {
+	g := ast.FunctionDef{Name: "g", ReturnType: ast.Void{}}
+	root := ast.Root{Modules: []ast.Module{{
+		Name: "test",
+		Functions: []ast.FunctionDef{
+			{
+				Name:       "f",
+				ReturnType: ast.Void{},
+				Block:      ast.Block{Statements: []ast.Statement{ast.Call{Function: g}, ast.Call{Function: g}}},
+			},
+			g,
+		},
+	}}}
+
+	mapper := &Mapper{}
+	result, err := mapper.MapRoot(root)
+	require.NoError(t, err)
+
+	// A definition embedded in a call resolves to the definition in the module rather than a copy of it.
+	functions := result.(*code.Root).Modules[0].Functions
+	assert.Same(t, functions[1], functions[0].Block.Statements[0].(*code.Call).Function)
+	assert.Same(t, functions[1], functions[0].Block.Statements[1].(*code.Call).Function)
+}
+
+func TestMapRoot_FunctionRefErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		function ast.Callable
+		expected string
+	}{
+		{
+			name:     "undefined function",
+			function: ast.FunctionRef{Name: "g"},
+			expected: `undefined function "g"`,
+		},
+		{
+			name:     "function of a module that isn't imported",
+			function: ast.FunctionRef{Name: "g", Module: "other"},
+			expected: `module "other" is not imported`,
+		},
+		{
+			name:     "definition of an undefined function",
+			function: ast.FunctionDef{Name: "h", ReturnType: ast.Void{}},
+			expected: `undefined function "h"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := ast.Root{Modules: []ast.Module{
+				{
+					Name: "test",
+					Functions: []ast.FunctionDef{{
+						Name:       "f",
+						ReturnType: ast.Void{},
+						Block:      ast.Block{Statements: []ast.Statement{ast.Call{Function: tt.function}}},
+					}},
+				},
+				{
+					Name:      "other",
+					Functions: []ast.FunctionDef{{Name: "g", ReturnType: ast.Void{}}},
+				},
+			}}
+
+			mapper := &Mapper{}
+			_, err := mapper.MapRoot(root)
+			assert.EqualError(t, err, tt.expected)
+		})
+	}
+}
+
+func TestMapRoot_Imports(t *testing.T) {
+	root, err := mapSource(t, `
+module geometry {
+	const origin = 0
+
+	model Point {
+		x int64
+		next Point
+	}
+
+	func area(p Point) int64 {
+		return p.x
+	}
+}
+
+module test {
+	import geometry
+
+	func f(p geometry.Point) int64 {
+		var q = new(geometry.Point)
+		q.next = p.next
+		return geometry.area(q) + geometry.origin
+	}
+}
+`)
+	require.NoError(t, err)
+
+	geometry, test := root.Modules[0], root.Modules[1]
+	assert.Same(t, geometry, test.Imports[0].Definition)
+
+	function := test.Functions[0]
+	assert.Same(t, test, function.Module)
+	assert.Same(t, geometry, geometry.Functions[0].Module)
+	assert.Same(t, geometry, geometry.Constants[0].Module)
+	assert.Same(t, geometry, geometry.Models[0].Module)
+
+	// A model is named by the module that defines it, whether or not the name was qualified.
+	point := function.Arguments[0].Type.(*code.Model)
+	assert.Equal(t, "geometry", point.Module)
+	assert.Equal(t, "geometry", geometry.Models[0].Fields[1].Type.(*code.Model).Module)
+	assert.True(t, code.SameType(point, geometry.Models[0].Fields[1].Type))
+
+	sum := function.Block.Statements[2].(*code.Return).Value.(*code.Binary)
+	assert.Same(t, geometry.Functions[0], sum.Left.(*code.Call).Function)
+	assert.Same(t, geometry.Constants[0], sum.Right.(*code.Variable).Definition)
+}
+
+func TestMapRoot_ImportErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		source   string
+		expected string
+	}{
+		{
+			name:     "undefined module",
+			source:   `module test { import missing }`,
+			expected: `undefined module "missing"`,
+		},
+		{
+			name:     "import of itself",
+			source:   `module test { import test }`,
+			expected: `import cycle: test -> test`,
+		},
+		{
+			name: "import cycle",
+			source: `
+module a { import b }
+module b { import c }
+module c { import a }
+`,
+			expected: "import cycle: a -> b -> c -> a\nimport cycle: b -> c -> a -> b\nimport cycle: c -> a -> b -> c",
+		},
+		{
+			name: "missing model",
+			source: `
+module test { import other func f(p other.Line) { } }
+module other { }
+`,
+			expected: `module "other" has no model "Line"`,
+		},
+		{
+			name: "missing function",
+			source: `
+module test { import other func f() { other.g() } }
+module other { }
+`,
+			expected: `module "other" has no function "g"`,
+		},
+		{
+			name: "missing constant",
+			source: `
+module test { import other func f() int64 { return other.limit } }
+module other { }
+`,
+			expected: `module "other" has no constant "limit"`,
+		},
+		{
+			name: "declaration shadows an import",
+			source: `
+module test { import other func f(other int64) { } }
+module other { }
+`,
+			expected: `shadowed declaration: "other" is an imported module`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := mapSource(t, tt.source)
+			assert.EqualError(t, err, tt.expected)
+		})
+	}
+}
+
+func TestMapRoot_Visibility(t *testing.T) {
+	root, err := mapSource(t, `
+module geometry {
+	private const scale = 2
+
+	model Point {
+		x int64
+		private y int64
+
+		private func scaled() int64 {
+			return self.y * scale
+		}
+
+		func area() int64 {
+			return self.x * self.scaled()
+		}
+	}
+
+	private func helper(p Point) int64 {
+		return p.y
+	}
+}
+
+module test {
+	import geometry
+
+	func f(p geometry.Point) int64 {
+		return p.area() + p.x
+	}
+}
+`)
+	require.NoError(t, err)
+
+	geometry, test := root.Modules[0], root.Modules[1]
+	point := geometry.Models[0]
+	assert.Equal(t, code.VisibilityPrivate, geometry.Constants[0].Visibility)
+	assert.Equal(t, code.VisibilityPublic, point.Visibility)
+	assert.Equal(t, code.VisibilityPrivate, point.Fields[1].Visibility)
+	assert.Equal(t, code.VisibilityPrivate, point.Methods[0].Visibility)
+	assert.Equal(t, code.VisibilityPrivate, geometry.Functions[0].Visibility)
+
+	sum := test.Functions[0].Block.Statements[0].(*code.Return).Value.(*code.Binary)
+	assert.Same(t, point.Fields[0], sum.Right.(*code.Property).Field)
+}
+
+func TestMapRoot_VisibilityErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		source   string
+		expected string
+	}{
+		{
+			name:     "private model",
+			source:   `func f(p other.Line) { }`,
+			expected: `model "Line" is private to module "other"`,
+		},
+		{
+			name:     "private function",
+			source:   `func f() { other.g() }`,
+			expected: `function "g" is private to module "other"`,
+		},
+		{
+			name:     "private constant",
+			source:   `func f() int64 { return other.limit }`,
+			expected: `constant "limit" is private to module "other"`,
+		},
+		{
+			name:     "private field",
+			source:   `func f(p other.Point) int64 { return p.y }`,
+			expected: `field "y" of model "Point" is private to module "other"`,
+		},
+		{
+			name:     "private method",
+			source:   `func f(p other.Point) { p.reset() }`,
+			expected: `method "reset" of model "Point" is private to module "other"`,
+		},
+		{
+			name:     "function exposing a private model",
+			source:   `private model Secret {} func f(secrets list[Secret]) { }`,
+			expected: `function "f" exposes private model "Secret"`,
+		},
+		{
+			name:     "field exposing a private model",
+			source:   `private model Secret {} model Box { secret Secret }`,
+			expected: `field "secret" of model "Box" exposes private model "Secret"`,
+		},
+		{
+			name:     "method exposing a private model",
+			source:   `private model Secret {} model Box { func open() Secret { return nil(Secret) } }`,
+			expected: `method "open" of model "Box" exposes private model "Secret"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := mapSource(t, `
+module test {
+	import other
+	`+tt.source+`
+}
+
+module other {
+	private const limit = 10
+
+	private model Line {}
+
+	model Point {
+		private y int64
+
+		private func reset() {
+			self.y = 0
+		}
+	}
+
+	private func g() {}
+}
+`)
+			assert.EqualError(t, err, tt.expected)
+		})
+	}
+}
+
+func TestMapRoot_MethodCalls(t *testing.T) {
+	root, err := mapSource(t, `
+module test {
+	model Counter {
+		count int64
+
+		func get() int64 {
+			return self.count
+		}
+
+		func incrementTwice() {
+			self.increment()
+			self.increment()
+		}
+
+		func increment() {
+			self.count = self.get() + 1
+		}
+	}
+
+	func f(counter Counter) int64 {
+		counter.incrementTwice()
+		return counter.get()
+	}
+}
+`)
+	require.NoError(t, err)
+
+	module := root.Modules[0]
+	methods := module.Models[0].Methods
+	statements := module.Functions[0].Block.Statements
+	incrementTwice := statements[0].(*code.MethodCall)
+	get := statements[1].(*code.Return).Value.(*code.MethodCall)
+
+	assert.Same(t, methods[2], methods[1].Block.Statements[0].(*code.MethodCall).Method)
+	assert.Same(t, methods[1], incrementTwice.Method)
+	assert.Same(t, methods[0], get.Method)
+	assert.Equal(t, "int64", code.TypeName(code.TypeOf(get)))
+
+	// Calling a method that modifies its model through self modifies the model too, even when the called method is
+	// defined later.
+	assert.False(t, methods[0].ModifiesSelf)
+	assert.True(t, methods[1].ModifiesSelf)
+	assert.True(t, methods[2].ModifiesSelf)
+	assert.True(t, module.Functions[0].Arguments[0].Modified)
+	assert.Equal(t, code.UsageMutate, incrementTwice.Receiver.(*code.Variable).Usage)
+	assert.Equal(t, code.UsageRead, get.Receiver.(*code.Variable).Usage)
+}
+
+func TestMapRoot_Types(t *testing.T) {
+	root, err := mapSource(t, `
+module test {
+	const names = map{"a": [1, 2]}
+
+	model Node {
+		next Node
+		label string
+
+		func first() rune {
+			return self.next.label[0]
+		}
+
+		equals(other) {
+			return self.label == other.label
+		}
+	}
+
+	model Leaf {
+		next Leaf
+	}
+
+	func f(node Node, leaf Leaf) {
+		var a = names["a"][0]
+		var b = len(names)
+		var c = contains(set{'x'}, 'y')
+		var d = pop(list[Node]{})
+		var e = node.next
+		var g = nil(set[bool])
+		for key in names {
+			var h = key
+		}
+		var i = "a" + node.label
+		var j = -len(names) * 2 < 3
+		var k = node != node.next && !c
+		var l = leaf.next != nil(Leaf) && leaf == leaf
+	}
+}
+`)
+	require.NoError(t, err)
+
+	module := root.Modules[0]
+	statements := module.Functions[0].Block.Statements
+	typeOfDeclare := func(i int) string {
+		return code.TypeName(code.TypeOf(statements[i].(*code.Declare).Value))
+	}
+
+	assert.Equal(t, "map[string, list[int64]]", code.TypeName(code.TypeOf(module.Constants[0].Value.(code.Value))))
+	assert.Equal(t, "rune", code.TypeName(code.TypeOf(module.Models[0].Methods[0].Block.Statements[0].(*code.Return).Value)))
+	assert.Equal(t, "int64", typeOfDeclare(0))
+	assert.Equal(t, "int64", typeOfDeclare(1))
+	assert.Equal(t, "bool", typeOfDeclare(2))
+	assert.Equal(t, "Node", typeOfDeclare(3))
+	assert.Equal(t, "Node", typeOfDeclare(4))
+	assert.Equal(t, "set[bool]", typeOfDeclare(5))
+
+	forEach := statements[6].(*code.ForEach)
+	assert.Equal(t, "string", code.TypeName(code.TypeOf(forEach.Block.Statements[0].(*code.Declare).Value)))
+
+	assert.Equal(t, "string", typeOfDeclare(7))
+	assert.Equal(t, "bool", typeOfDeclare(8))
+	assert.Equal(t, "bool", typeOfDeclare(9))
+	assert.Equal(t, "bool", typeOfDeclare(10))
+}
+
+func TestMapRoot_Numbers(t *testing.T) {
+	root, err := mapSource(t, `
+module test {
+	func f(x int32, y float64, data bytes) {
+		var a = x * int32(2)
+		var b = int64(y)
+		var c = float64(x)
+		var d = data[0] + uint8(1)
+		var e = len(data)
+		var g = uint8(x)
+	}
+}
+`)
+	require.NoError(t, err)
+
+	statements := root.Modules[0].Functions[0].Block.Statements
+	valueOfDeclare := func(i int) code.Value {
+		return statements[i].(*code.Declare).Value
+	}
+
+	assert.Equal(t, "int32", code.TypeName(code.TypeOf(valueOfDeclare(0))))
+	assert.Equal(t, code.OverflowWrap, valueOfDeclare(0).(*code.Binary).Overflow)
+	assert.Equal(t, "int64", code.TypeName(code.TypeOf(valueOfDeclare(1))))
+	assert.Equal(t, code.OverflowSaturate, valueOfDeclare(1).(*code.Conversion).Overflow)
+	assert.Equal(t, "float64", code.TypeName(code.TypeOf(valueOfDeclare(2))))
+	assert.Equal(t, code.OverflowNone, valueOfDeclare(2).(*code.Conversion).Overflow)
+	assert.Equal(t, "uint8", code.TypeName(code.TypeOf(valueOfDeclare(3))))
+	assert.Equal(t, "int64", code.TypeName(code.TypeOf(valueOfDeclare(4))))
+	assert.Equal(t, code.OverflowWrap, valueOfDeclare(5).(*code.Conversion).Overflow)
+}
+
+func TestMapRoot_TypeErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		source   string
+		expected string
+	}{
+		{
+			name:     "mismatched list items",
+			source:   `func f() { var x = [1, "a"] }`,
+			expected: "mismatched item types int64 and string",
+		},
+		{
+			name:     "mismatched map values",
+			source:   `func f() { var x = map{1: true, 2: 'b'} }`,
+			expected: "mismatched value types bool and rune",
+		},
+		{
+			name:     "list index",
+			source:   `func f(x list[bool]) { var y = x["a"] }`,
+			expected: "the index of a list must be of type int64, not string",
+		},
+		{
+			name:     "map key",
+			source:   `func f(x map[string, bool]) { var y = x[1] }`,
+			expected: "the key of a map must be of type string, not int64",
+		},
+		{
+			name:     "lookup in a set",
+			source:   `func f(x set[bool]) { var y = x[1] }`,
+			expected: "cannot lookup a value in type set[bool]",
+		},
+		{
+			name:     "set contains",
+			source:   `func f(x set[bool]) { var y = contains(x, 1) }`,
+			expected: "the value checked for in a set must be of type bool, not int64",
+		},
+		{
+			name:     "length of a bool",
+			source:   `func f() { var y = len(true) }`,
+			expected: "cannot take the length of type bool",
+		},
+		{
+			name:     "pop from a set",
+			source:   `func f(x set[bool]) { pop(x) }`,
+			expected: "cannot pop from type set[bool]",
+		},
+		{
+			name:     "property of an int",
+			source:   `func f(x int64) { var y = x.z }`,
+			expected: `cannot access property "z" of type int64`,
+		},
+		{
+			name:     "undefined field",
+			source:   `model M { } func f(x M) { var y = x.z }`,
+			expected: `model "M" has no field "z"`,
+		},
+		{
+			name:     "undefined model",
+			source:   `func f() { var y = new(M) }`,
+			expected: `undefined model "M"`,
+		},
+		{
+			name:     "method of an int",
+			source:   `func f(x int64) { x.g() }`,
+			expected: `cannot call method "g" on type int64`,
+		},
+		{
+			name:     "undefined method",
+			source:   `model M { g int64 } func f(x M) { x.g() }`,
+			expected: `model "M" has no method "g"`,
+		},
+		{
+			name:     "self outside of a model",
+			source:   `func f() { var y = self }`,
+			expected: "self used outside of a model",
+		},
+		{
+			name:     "iterating over an int",
+			source:   `func f() { for x in 1 { var y = x } }`,
+			expected: `function "f": cannot iterate over type int64`,
+		},
+		{
+			name:     "mismatched operands",
+			source:   `func f() { var y = 1 + "a" }`,
+			expected: "mismatched operand types int64 and string",
+		},
+		{
+			name:     "subtracting strings",
+			source:   `func f() { var y = "a" - "b" }`,
+			expected: "operator - is not defined for type string",
+		},
+		{
+			name:     "and of integers",
+			source:   `func f() { var y = 1 && 2 }`,
+			expected: "operator && is not defined for type int64",
+		},
+		{
+			name:     "ordering bools",
+			source:   `func f() { var y = true < false }`,
+			expected: "operator < is not defined for type bool",
+		},
+		{
+			name:     "ordering models without an equal override",
+			source:   `model M { } func f(x M) { var y = x < x }`,
+			expected: "operator < is not defined for type M",
+		},
+		{
+			name:     "ordering models",
+			source:   `model M { equals(other) { return true } } func f(x M) { var y = x < x }`,
+			expected: "operator < is not defined for type M",
+		},
+		{
+			name:     "negating a string",
+			source:   `func f() { var y = -"a" }`,
+			expected: "operator - is not defined for type string",
+		},
+		{
+			name:     "not of an int",
+			source:   `func f(x int64) { var y = !x }`,
+			expected: "operator ! is not defined for type int64",
+		},
+		{
+			name:     "mismatched integer types",
+			source:   `func f(x int32) { var y = x + 1 }`,
+			expected: "mismatched operand types int32 and int64",
+		},
+		{
+			name:     "modulo of floats",
+			source:   `func f() { var y = 1.5 % 2.0 }`,
+			expected: "operator % is not defined for type float64",
+		},
+		{
+			name:     "negating a uint8",
+			source:   `func f(x uint8) { var y = -x }`,
+			expected: "operator - is not defined for type uint8",
+		},
+		{
+			name:     "converting a string",
+			source:   `func f() { var y = int32("a") }`,
+			expected: "cannot convert type string to int32",
+		},
+		{
+			name:     "bytes index",
+			source:   `func f(x bytes) { var y = x[uint8(0)] }`,
+			expected: "the index of bytes must be of type int64, not uint8",
+		},
+		{
+			name:     "float map key",
+			source:   `func f(x map[float64, bool]) { }`,
+			expected: "the key of a map can't be of type float64",
+		},
+		{
+			name:     "bytes set item",
+			source:   `func f() { var y = set{bytes{1}} }`,
+			expected: "the item of a set can't be of type bytes",
+		},
+		{
+			name:     "list set item",
+			source:   `func f() { var y = set{[1]} }`,
+			expected: "the item of a set can't be of type list[int64]",
+		},
+		{
+			name:     "set map key",
+			source:   `func f(x map[set[int64], bool]) { }`,
+			expected: "the key of a map can't be of type set[int64]",
+		},
+		{
+			name:     "map literal key",
+			source:   `func f() { var y = map{map{1: 2}: 3} }`,
+			expected: "the key of a map can't be of type map[int64, int64]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := mapSource(t, "module test { "+tt.source+" }")
+			assert.EqualError(t, err, tt.expected)
+		})
+	}
+}
+
+func TestMapRoot_TypeCheckErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		source   string
+		expected string
+	}{
+		{
+			name:     "assignment",
+			source:   `func f() { var x = 1 x = "a" }`,
+			expected: `function "f": the assigned value must be of type int64, not string`,
+		},
+		{
+			name:     "push",
+			source:   `func f(x list[bool]) { push(x, 1) }`,
+			expected: `function "f": the value pushed onto a list must be of type bool, not int64`,
+		},
+		{
+			name:     "push onto a set",
+			source:   `func f(x set[bool]) { push(x, true) }`,
+			expected: `function "f": cannot push onto type set[bool]`,
+		},
+		{
+			name:     "insert",
+			source:   `func f(x set[rune]) { insert(x, "a") }`,
+			expected: `function "f": the value added to a set must be of type rune, not string`,
+		},
+		{
+			name:     "insert into a list",
+			source:   `func f(x list[rune]) { insert(x, 'a') }`,
+			expected: `function "f": cannot add to type list[rune]`,
+		},
+		{
+			name:     "argument count",
+			source:   `func f() { g(1, 2) } func g(a int64) { }`,
+			expected: `function "f": "g" takes 1 arguments but 2 were given`,
+		},
+		{
+			name:     "argument type",
+			source:   `func f() { g(true) } func g(a int64) { }`,
+			expected: `function "f": argument "a" of "g" must be of type int64, not bool`,
+		},
+		{
+			name:     "method argument count",
+			source:   `model M { func g(a int64) { } } func f(x M) { x.g() }`,
+			expected: `function "f": "g" takes 1 arguments but 0 were given`,
+		},
+		{
+			name:     "method argument type",
+			source:   `model M { func g(a int64) { } func h() { self.g("a") } }`,
+			expected: `method "h" of model "M": argument "a" of "g" must be of type int64, not string`,
+		},
+		{
+			name:     "return from a void function",
+			source:   `func f() { return 1 }`,
+			expected: `function "f": cannot return a value from a function without a return type`,
+		},
+		{
+			name:     "return type",
+			source:   `func f() string { return 'a' }`,
+			expected: `function "f": the returned value must be of type string, not rune`,
+		},
+		{
+			name:     "if condition",
+			source:   `func f(x int64) { if x + 1 { } }`,
+			expected: `function "f": the condition of an if must be of type bool, not int64`,
+		},
+		{
+			name:     "loop condition",
+			source:   `func f() { for "a" { } }`,
+			expected: `function "f": the condition of a loop must be of type bool, not string`,
+		},
+		{
+			name:     "method",
+			source:   `model M { x int64 func m() { self.x = true } }`,
+			expected: `method "m" of model "M": the assigned value must be of type int64, not bool`,
+		},
+		{
+			name:     "equal override",
+			source:   `model M { equals(other) { return other } }`,
+			expected: `equal override of model "M": the returned value must be of type bool, not M`,
+		},
+		{
+			name:     "hash override",
+			source:   `model M { hash { return "a" } }`,
+			expected: `hash override of model "M": the returned value must be of type int64, not string`,
+		},
+		{
+			name:     "iterate over an int without using the item",
+			source:   `func f(a int64) { for x in a { } }`,
+			expected: `function "f": cannot iterate over type int64`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := mapSource(t, "module test { "+tt.source+" }")
+			assert.EqualError(t, err, tt.expected)
+		})
+	}
+}
+
+// The parser rejects duplicate definitions itself, so these are built directly.
+func TestMapRoot_DuplicateDefinitions(t *testing.T) {
+	function := ast.FunctionDef{Name: "f", ReturnType: ast.Void{}}
+	tests := []struct {
+		name     string
+		root     ast.Root
+		expected string
+	}{
+		{
+			name:     "module",
+			root:     ast.Root{Modules: []ast.Module{{Name: "test"}, {Name: "test"}}},
+			expected: `module "test" is already defined`,
+		},
+		{
+			name:     "function",
+			root:     ast.Root{Modules: []ast.Module{{Name: "test", Functions: []ast.FunctionDef{function, function}}}},
+			expected: `function "f" is already defined in module "test"`,
+		},
+		{
+			name: "function with the name of a model",
+			root: ast.Root{Modules: []ast.Module{{
+				Name:      "test",
+				Models:    []ast.ModelDef{{Name: "f"}},
+				Functions: []ast.FunctionDef{function},
+			}}},
+			expected: `function "f" is already defined in module "test"`,
+		},
+		{
+			name: "field",
+			root: ast.Root{Modules: []ast.Module{{
+				Name: "test",
+				Models: []ast.ModelDef{{
+					Name:   "M",
+					Fields: []ast.FieldDef{{Name: "x", Type: ast.Int64{}}, {Name: "x", Type: ast.Bool{}}},
+				}},
+			}}},
+			expected: `field "x" is already defined in model "M"`,
+		},
+		{
+			name: "method with the name of a field",
+			root: ast.Root{Modules: []ast.Module{{
+				Name: "test",
+				Models: []ast.ModelDef{{
+					Name:    "M",
+					Fields:  []ast.FieldDef{{Name: "f", Type: ast.Int64{}}},
+					Methods: []ast.FunctionDef{function},
+				}},
+			}}},
+			expected: `method "f" is already defined in model "M"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mapper := &Mapper{}
+			_, err := mapper.MapRoot(tt.root)
+			assert.EqualError(t, err, tt.expected)
+		})
+	}
+}
+
+func TestMapRoot_AssignmentTarget(t *testing.T) {
+	root := ast.Root{Modules: []ast.Module{{
+		Name: "test",
+		Functions: []ast.FunctionDef{{
+			Name:       "f",
+			ReturnType: ast.Void{},
+			Block: ast.Block{Statements: []ast.Statement{
+				ast.Assignment{To: ast.LiteralInt64{Value: 1}, From: ast.LiteralInt64{Value: 2}},
+			}},
+		}},
+	}}}
+
+	mapper := &Mapper{}
+	_, err := mapper.MapRoot(root)
+	assert.EqualError(t, err, `function "f": only a variable, property or lookup can be assigned to`)
+}
+
+func TestMapRoot_ControlFlow(t *testing.T) {
+	_, err := mapSource(t, `
+module test {
+	model M {
+		equals(other) {
+			if true {
+				return true
+			} else if false {
+				return false
+			} else {
+				return true
+			}
+		}
+	}
+
+	func f(items list[int64]) int64 {
+		for item in items {
+			if true {
+				break
+			}
+			continue
+		}
+		return g()
+	}
+
+	func g() int64 {
+		return f(list[int64]{})
+	}
+}
+`)
+	assert.NoError(t, err)
+}
+
+func TestMapRoot_ControlFlowErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		source   string
+		expected string
+	}{
+		{
+			name:     "break outside of a loop",
+			source:   `func f() { if true { break } }`,
+			expected: `function "f": break used outside of a loop`,
+		},
+		{
+			name:     "continue outside of a loop",
+			source:   `func f() { continue }`,
+			expected: `function "f": continue used outside of a loop`,
+		},
+		{
+			name:     "self outside of a method",
+			source:   `model M { } func f() M { return self }`,
+			expected: "self used outside of a model",
+		},
+		{
+			name:     "missing return",
+			source:   `func f() int64 { }`,
+			expected: `function "f": missing return`,
+		},
+		{
+			name:     "missing else",
+			source:   `func f() int64 { if true { return 1 } }`,
+			expected: `function "f": missing return`,
+		},
+		{
+			name:     "missing return in a branch",
+			source:   `func f() int64 { if true { return 1 } else { var x = 1 } }`,
+			expected: `function "f": missing return`,
+		},
+		{
+			name:     "return inside of a loop",
+			source:   `func f(x list[int64]) int64 { for y in x { return y } }`,
+			expected: `function "f": missing return`,
+		},
+		{
+			name:     "missing return in an override",
+			source:   `model M { hash { var x = 1 } }`,
+			expected: `hash override of model "M": missing return`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := mapSource(t, "module test { "+tt.source+" }")
+			assert.EqualError(t, err, tt.expected)
+		})
+	}
+}
+
+func TestMapRoot_Diagnostics(t *testing.T) {
+	original, err := agnosticscript.Parse("test.as", `
+module test {
+	func b() int64 {
+		var x = len(y)
+		var z = x.field
+		return "b"
+	}
+
+	func a() {
+		b()
+		break
+	}
+}
+`)
+	require.NoError(t, err)
+
+	mapper := &Mapper{}
+	result, err := mapper.MapRoot(original)
+	require.Error(t, err)
+
+	// The rest of the tree is still mapped.
+	root := result.(*code.Root)
+	require.Len(t, root.Modules[0].Functions, 2)
+	require.Len(t, root.Modules[0].Functions[1].Block.Statements, 2)
+
+	var diagnostics Diagnostics
+	require.ErrorAs(t, err, &diagnostics)
+	assert.Equal(t, mapper.Diagnostics(), diagnostics)
+
+	// The unknown type of y doesn't cause any further errors and the call of b doesn't report the errors of b again. The
+	// diagnostics are in the order of the source.
+	var lines []string
+	for _, diagnostic := range diagnostics {
+		lines = append(lines, diagnostic.String())
+	}
+	assert.Equal(t, []string{
+		`test.as:4:15: error: module "test" > function "b" > Block > Declare > Length > Variable: undefined variable "y"`,
+		`test.as:6:3: error: module "test" > function "b" > Block > Return: function "b": the returned value must be of type int64, not string`,
+		`test.as:11:3: error: module "test" > function "a" > Block > Break: function "a": break used outside of a loop`,
+	}, lines)
+	assert.Equal(t, "undefined variable \"y\"\nfunction \"b\": the returned value must be of type int64, not string\nfunction \"a\": break used outside of a loop", err.Error())
+}
+
+func TestMapRoot_UnreachableStatement(t *testing.T) {
+	original, err := agnosticscript.Parse("test.as", `module test {
+	func f() int64 {
+		return 1
+		var x = 2
+		var y = 3
+	}
+
+	func g() {
+		for true {
+			break
+			var x = 1
+		}
+	}
+
+	model M {
+		func m() int64 {
+			if true {
+				return 1
+			} else {
+				continue
+			}
+			return 3
+		}
+	}
+}`)
+	require.NoError(t, err)
+
+	mapper := &Mapper{}
+	result, err := mapper.MapRoot(original)
+	require.Error(t, err)
+
+	// Unreachable statements are only a warning that's reported at the first statement that can't be reached. The
+	// rest of the block is still checked.
+	var lines []string
+	for _, diagnostic := range mapper.Diagnostics() {
+		lines = append(lines, diagnostic.String())
+	}
+	assert.Equal(t, []string{
+		`test.as:4:3: warning: module "test" > function "f" > Block > Declare: function "f": unreachable statement`,
+		`test.as:11:4: warning: module "test" > function "g" > Block > For > Block > Declare: function "g": unreachable statement`,
+		`test.as:20:5: error: module "test" > model "M" > function "m" > Block > Conditional > Block > Continue: method "m" of model "M": continue used outside of a loop`,
+		`test.as:22:4: warning: module "test" > model "M" > function "m" > Block > Return: method "m" of model "M": unreachable statement`,
+	}, lines)
+
+	// The unreachable statements are dropped.
+	root := result.(*code.Root)
+	assert.Len(t, root.Modules[0].Functions[0].Block.Statements, 1)
+	assert.Len(t, root.Modules[0].Functions[1].Block.Statements[0].(*code.For).Block.Statements, 1)
+}
+
+func TestMapRoot_UnreachableStatementIsOnlyAWarning(t *testing.T) {
+	_, err := mapSource(t, `module test { func f() int64 { return 1 var x = 2 } }`)
+	assert.NoError(t, err)
+}
+
+func TestMapRoot_Positions(t *testing.T) {
+	root, err := mapSource(t, "module test {\n\tfunc f() int64 {\n\t\treturn 1\n\t}\n}")
+	require.NoError(t, err)
+
+	function := root.Modules[0].Functions[0]
+	statement := function.Block.Statements[0].(*code.Return)
+	assert.Equal(t, code.Position{Filename: "test.as", Line: 2, Column: 2, Offset: 15, EndOffset: 45}, function.Position)
+	assert.Equal(t, code.Position{Filename: "test.as", Line: 3, Column: 3, Offset: 34, EndOffset: 42}, code.PositionOf(statement))
+	assert.Equal(t, "test.as:3:10", code.PositionOf(statement.Value).String())
+}
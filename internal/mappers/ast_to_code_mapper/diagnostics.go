@@ -0,0 +1,137 @@
+package ast_to_code_mapper
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/JosephNaberhaus/agnostic/code"
+)
+
+// Severity is how serious a Diagnostic is.
+type Severity int
+
+const (
+	// SeverityError means that the code is invalid and can't be generated.
+	SeverityError Severity = iota
+	// SeverityWarning means that the code is valid but likely to be a mistake.
+	SeverityWarning
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityError:
+		return "error"
+	case SeverityWarning:
+		return "warning"
+	default:
+		return fmt.Sprintf("Severity(%d)", int(s))
+	}
+}
+
+// Diagnostic is a problem that was found while mapping.
+type Diagnostic struct {
+	Severity Severity
+	// The path from the root to the node that the problem was found in.
+	Path []code.Node
+	// A description of the problem.
+	Message string
+}
+
+// Position returns the position of the innermost node of the path that has one. It's the zero Position if none of
+// them do, which is the case when the AST was built by hand.
+func (d Diagnostic) Position() code.Position {
+	for i := len(d.Path) - 1; i >= 0; i-- {
+		if position := code.PositionOf(d.Path[i]); position.IsValid() {
+			return position
+		}
+	}
+
+	return code.Position{}
+}
+
+// PathString returns a human-readable form of the path, such as `module "test" > function "f" > Block > Return`.
+func (d Diagnostic) PathString() string {
+	segments := make([]string, 0, len(d.Path))
+	for _, node := range d.Path {
+		if _, ok := node.(*code.Root); ok {
+			continue
+		}
+
+		segments = append(segments, pathSegment(node))
+	}
+
+	return strings.Join(segments, " > ")
+}
+
+func (d Diagnostic) String() string {
+	if position := d.Position(); position.IsValid() {
+		return fmt.Sprintf("%s: %s: %s: %s", position, d.Severity, d.PathString(), d.Message)
+	}
+
+	return fmt.Sprintf("%s: %s: %s", d.Severity, d.PathString(), d.Message)
+}
+
+// pathSegment describes a single node of a path. Nodes with a name are described by it.
+func pathSegment(node code.Node) string {
+	switch node := node.(type) {
+	case *code.Module:
+		return fmt.Sprintf("module %q", node.Name)
+	case *code.ModelDef:
+		return fmt.Sprintf("model %q", node.Name)
+	case *code.FunctionDef:
+		return fmt.Sprintf("function %q", node.Name)
+	case *code.ConstantDef:
+		return fmt.Sprintf("constant %q", node.Name)
+	case *code.FieldDef:
+		return fmt.Sprintf("field %q", node.Name)
+	case *code.ArgumentDef:
+		return fmt.Sprintf("argument %q", node.Name)
+	default:
+		return strings.TrimPrefix(fmt.Sprintf("%T", node), "*code.")
+	}
+}
+
+// Diagnostics is a list of the problems found while mapping. MapRoot returns it as its error when it contains at least
+// one error.
+type Diagnostics []Diagnostic
+
+func (d Diagnostics) Error() string {
+	messages := make([]string, 0, len(d))
+	for _, diagnostic := range d {
+		messages = append(messages, diagnostic.Message)
+	}
+
+	return strings.Join(messages, "\n")
+}
+
+// HasErrors returns whether any of the diagnostics has SeverityError.
+func (d Diagnostics) HasErrors() bool {
+	return slices.ContainsFunc(d, func(diagnostic Diagnostic) bool {
+		return diagnostic.Severity == SeverityError
+	})
+}
+
+// sort orders the diagnostics by where they are in the source, then by path, then by severity, and then by message.
+func (d Diagnostics) sort() {
+	slices.SortStableFunc(d, func(a, b Diagnostic) int {
+		aPosition, bPosition := a.Position(), b.Position()
+		return cmp.Or(
+			strings.Compare(aPosition.Filename, bPosition.Filename),
+			cmp.Compare(aPosition.Offset, bPosition.Offset),
+			strings.Compare(a.PathString(), b.PathString()),
+			cmp.Compare(a.Severity, b.Severity),
+			strings.Compare(a.Message, b.Message),
+		)
+	})
+}
+
+// report records a problem with the node at the top of the stack.
+func (m *Mapper) report(severity Severity, err error) {
+	m.diagnostics = append(m.diagnostics, Diagnostic{
+		Severity: severity,
+		Path:     m.stack.Copy(),
+		Message:  err.Error(),
+	})
+}
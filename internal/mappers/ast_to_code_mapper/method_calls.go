@@ -0,0 +1,32 @@
+package ast_to_code_mapper
+
+import (
+	"github.com/JosephNaberhaus/agnostic/code"
+	"github.com/JosephNaberhaus/agnostic/internal/mappers/populate_metadata_mapper"
+	"github.com/JosephNaberhaus/agnostic/internal/utils/stack"
+)
+
+type methodCall struct {
+	// The stack as it was when the call was mapped.
+	stack stack.Stack[code.Node]
+	value *code.MethodCall
+}
+
+// markModifiedReceivers records which method calls modify their receiver. Whether a method modifies its model is only
+// known once its body is mapped, which can be after the calls to it. Calling a modifying method on self also makes the
+// calling method modify its model, so this repeats until no more calls are found.
+func (m *Mapper) markModifiedReceivers() {
+	marked := map[*code.MethodCall]bool{}
+	for changed := true; changed; {
+		changed = false
+		for _, call := range m.methodCalls {
+			if marked[call.value] || call.value.Method == nil || !call.value.Method.ModifiesSelf {
+				continue
+			}
+
+			marked[call.value] = true
+			changed = true
+			populate_metadata_mapper.Mapper{Stack: call.stack}.MarkModifiedReceiver(call.value)
+		}
+	}
+}
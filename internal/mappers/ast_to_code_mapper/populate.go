@@ -0,0 +1,57 @@
+package ast_to_code_mapper
+
+import (
+	"errors"
+
+	"github.com/JosephNaberhaus/agnostic/code"
+	"github.com/JosephNaberhaus/agnostic/internal/mappers/control_flow_mapper"
+	"github.com/JosephNaberhaus/agnostic/internal/mappers/populate_metadata_mapper"
+	"github.com/JosephNaberhaus/agnostic/internal/mappers/type_check_mapper"
+)
+
+// populate fills in the metadata of a node and then checks it. This must be called once all of the node's children are
+// mapped, since the metadata of a node is derived from the metadata of its children.
+//
+// Problems are reported rather than returned so that mapping can carry on and find the rest of them. A value whose
+// metadata couldn't be populated is left without a type, which the later checks treat as already reported.
+func (m *Mapper) populate(value code.Node) {
+	err := code.MapNodeOnlyError(value, populate_metadata_mapper.Mapper{Stack: m.stack})
+	if err != nil {
+		m.report(SeverityError, err)
+		return
+	}
+
+	err = code.MapNodeOnlyError(value, type_check_mapper.Mapper{Stack: m.stack})
+	if err != nil {
+		m.report(SeverityError, err)
+	}
+
+	err = code.MapNodeOnlyError(value, control_flow_mapper.Mapper{Stack: m.stack})
+	if err != nil {
+		m.reportControlFlow(err)
+	}
+}
+
+// reportControlFlow reports each of the problems found by the control flow checks. A problem with a single statement
+// is reported at that statement, and unreachable statements are only warnings.
+func (m *Mapper) reportControlFlow(err error) {
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		for _, err := range joined.Unwrap() {
+			m.reportControlFlow(err)
+		}
+		return
+	}
+
+	var statementErr control_flow_mapper.StatementError
+	if errors.As(err, &statementErr) {
+		m.stack.Push(statementErr.Statement)
+		defer m.stack.Pop()
+	}
+
+	if errors.Is(err, control_flow_mapper.ErrUnreachable) {
+		m.report(SeverityWarning, err)
+		return
+	}
+
+	m.report(SeverityError, err)
+}
@@ -0,0 +1,128 @@
+package control_flow_mapper
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/JosephNaberhaus/agnostic/code"
+)
+
+// StatementError is a problem with one of the statements of a block that's only found once the whole block is mapped.
+// It should be reported at the statement rather than at the block.
+type StatementError struct {
+	Statement code.Statement
+	Err       error
+}
+
+func (e StatementError) Error() string {
+	return e.Err.Error()
+}
+
+func (e StatementError) Unwrap() error {
+	return e.Err
+}
+
+// ErrUnreachable is wrapped by the error for a statement that can never run. It's only a warning since the statement is
+// dropped from its block, which keeps the languages that reject unreachable code happy.
+var ErrUnreachable = errors.New("unreachable statement")
+
+// errorf creates an error that names the function or override, and the model, that the current node is in. Like
+// fmt.Errorf, it wraps the errors of any %w verbs.
+func (m Mapper) errorf(format string, args ...any) error {
+	err := fmt.Errorf(format, args...)
+	for i := len(m.Stack) - 1; i >= 0; i-- {
+		switch node := m.Stack[i].(type) {
+		case *code.FunctionDef:
+			// Methods are the direct children of their model.
+			if i > 0 {
+				if model, ok := m.Stack[i-1].(*code.ModelDef); ok {
+					return fmt.Errorf("method %q of model %q: %w", node.Name, model.Name, err)
+				}
+			}
+
+			return fmt.Errorf("function %q: %w", node.Name, err)
+		case *code.EqualOverride:
+			return fmt.Errorf("equal override of model %q: %w", m.Stack[i-1].(*code.ModelDef).Name, err)
+		case *code.HashOverride:
+			return fmt.Errorf("hash override of model %q: %w", m.Stack[i-1].(*code.ModelDef).Name, err)
+		}
+	}
+
+	return err
+}
+
+// inLoop returns whether the current node is inside a loop of the innermost function or override.
+func (m Mapper) inLoop() bool {
+	for i := len(m.Stack) - 1; i >= 0; i-- {
+		switch m.Stack[i].(type) {
+		case *code.For, *code.ForEach:
+			return true
+		case *code.FunctionDef, *code.EqualOverride, *code.HashOverride:
+			return false
+		}
+	}
+
+	return false
+}
+
+// checkReturns returns an error if the block is the body of a function or override that returns a value but the end
+// of the block can be reached.
+func (m Mapper) checkReturns(block *code.Block) error {
+	if len(m.Stack) < 2 {
+		return nil
+	}
+
+	switch parent := m.Stack[len(m.Stack)-2].(type) {
+	case *code.FunctionDef:
+		// The function of a call is a copy of a definition that's checked on its own.
+		if len(m.Stack) >= 3 {
+			if _, ok := m.Stack[len(m.Stack)-3].(*code.Call); ok {
+				return nil
+			}
+		}
+
+		if _, ok := parent.ReturnType.(*code.Void); ok {
+			return nil
+		}
+	case *code.EqualOverride, *code.HashOverride:
+	default:
+		return nil
+	}
+
+	if !blockTerminates(block) {
+		return m.errorf("missing return")
+	}
+
+	return nil
+}
+
+// terminates returns whether the statement always transfers control elsewhere, so that the statements after it can't
+// be reached. A conditional terminates if it has an else block and all of its blocks terminate. Loops never terminate
+// because they can always be exited with a break.
+func terminates(statement code.Statement) bool {
+	switch statement := statement.(type) {
+	case *code.Break, *code.Continue, *code.Return:
+		return true
+	case *code.Conditional:
+		if statement.Else == nil || !blockTerminates(statement.Else) {
+			return false
+		}
+
+		for _, branch := range statement.Ifs {
+			if !blockTerminates(branch.Block) {
+				return false
+			}
+		}
+
+		return true
+	default:
+		return false
+	}
+}
+
+// blockTerminates returns whether any statement of the block terminates. The statements after it are unreachable, which
+// is reported separately, so the end of the block can't be reached either.
+func blockTerminates(block *code.Block) bool {
+	return slices.ContainsFunc(block.Statements, terminates)
+}
@@ -0,0 +1,105 @@
+package populate_metadata_mapper
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/JosephNaberhaus/agnostic/code"
+)
+
+// lookupModule finds the module with the given name. The other modules are only known once every module is mapped, so
+// this must only be used from nodes whose mapping was deferred.
+func (m Mapper) lookupModule(name string) (*code.Module, error) {
+	if module, ok := m.enclosingModule(); ok && module.Name == name {
+		return module, nil
+	}
+
+	for _, node := range m.Stack {
+		root, ok := node.(*code.Root)
+		if !ok {
+			continue
+		}
+
+		for _, module := range root.Modules {
+			if module.Name == name {
+				return module, nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("undefined module %q", name)
+}
+
+// qualifiedModule returns the module that a qualified name refers to. An empty qualifier refers to the enclosing
+// module, while any other module must be imported by the enclosing module.
+func (m Mapper) qualifiedModule(qualifier string) (*code.Module, error) {
+	module, ok := m.enclosingModule()
+	if !ok {
+		return nil, fmt.Errorf("reference outside of a module")
+	}
+
+	if qualifier == "" || qualifier == module.Name {
+		return module, nil
+	}
+
+	if !imports(module, qualifier) {
+		return nil, fmt.Errorf("module %q is not imported", qualifier)
+	}
+
+	return m.lookupModule(qualifier)
+}
+
+// checkVisible returns an error if the definition is private to the named module and is used from another module.
+func (m Mapper) checkVisible(visibility code.Visibility, module string, definition string) error {
+	if visibility == code.VisibilityPublic {
+		return nil
+	}
+
+	if enclosing, ok := m.enclosingModule(); ok && enclosing.Name == module {
+		return nil
+	}
+
+	return fmt.Errorf("%s is private to module %q", definition, module)
+}
+
+// imports returns whether the module imports the module with the given name.
+func imports(module *code.Module, name string) bool {
+	return slices.ContainsFunc(module.Imports, func(i *code.Import) bool {
+		return i.Module == name
+	})
+}
+
+// importCycle returns the names of the modules on a path of imports that leads from the imported module back to the
+// module that imports it, or nil if there is no such path. The path starts and ends with the importing module's name.
+func (m Mapper) importCycle(module *code.Module, imported string) []string {
+	visited := map[string]bool{}
+
+	var visit func(name string, path []string) []string
+	visit = func(name string, path []string) []string {
+		path = append(path, name)
+		if name == module.Name {
+			return path
+		}
+
+		if visited[name] {
+			return nil
+		}
+		visited[name] = true
+
+		definition, err := m.lookupModule(name)
+		if err != nil {
+			// Imports of undefined modules are reported by the import itself.
+			return nil
+		}
+
+		for _, i := range definition.Imports {
+			if cycle := visit(i.Module, slices.Clip(path)); cycle != nil {
+				return cycle
+			}
+		}
+
+		return nil
+	}
+
+	return visit(imported, []string{module.Name})
+}
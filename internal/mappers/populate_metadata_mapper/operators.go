@@ -0,0 +1,76 @@
+package populate_metadata_mapper
+
+import (
+	"fmt"
+
+	"github.com/JosephNaberhaus/agnostic/code"
+)
+
+// binaryType returns the type of the result of the binary operation. Addition works on numbers and strings, where it
+// concatenates them, while the other arithmetic operators only work on numbers and the logical operators only work on
+// booleans. Modulo isn't defined for floats since languages disagree on what it means.
+func binaryType(operator code.BinaryOperator, operand code.Type) (code.Type, error) {
+	switch operand.(type) {
+	case *code.Int32, *code.Int64, *code.Uint8:
+		if operator != code.BinaryOperatorAnd && operator != code.BinaryOperatorOr {
+			return operand, nil
+		}
+	case *code.Float64:
+		if operator != code.BinaryOperatorAnd && operator != code.BinaryOperatorOr && operator != code.BinaryOperatorModulo {
+			return operand, nil
+		}
+	case *code.String:
+		if operator == code.BinaryOperatorAdd {
+			return operand, nil
+		}
+	case *code.Bool:
+		if operator == code.BinaryOperatorAnd || operator == code.BinaryOperatorOr {
+			return operand, nil
+		}
+	}
+
+	return nil, fmt.Errorf("operator %s is not defined for type %s", operator.Symbol(), code.TypeName(operand))
+}
+
+// checkComparable returns an error if values of the type can't be compared with the operator. Numbers and runes can be
+// ordered, and primitives and models can be checked for equality. Models are compared with their equal override, or by
+// identity if they don't have one.
+func (m Mapper) checkComparable(operator code.ComparisonOperator, operand code.Type) error {
+	switch operand.(type) {
+	case *code.Float64, *code.Int32, *code.Int64, *code.Rune, *code.Uint8:
+		return nil
+	case *code.Bool, *code.Model, *code.String:
+		if !operator.IsOrdering() {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("operator %s is not defined for type %s", operator.Symbol(), code.TypeName(operand))
+}
+
+// unaryType returns the type of the result of the unary operation. Negation only works on signed numbers and not only
+// works on booleans.
+func unaryType(operator code.UnaryOperator, operand code.Type) (code.Type, error) {
+	switch operand.(type) {
+	case *code.Float64, *code.Int32, *code.Int64:
+		if operator == code.UnaryOperatorNegate {
+			return operand, nil
+		}
+	case *code.Bool:
+		if operator == code.UnaryOperatorNot {
+			return operand, nil
+		}
+	}
+
+	return nil, fmt.Errorf("operator %s is not defined for type %s", operator.Symbol(), code.TypeName(operand))
+}
+
+// operandType returns the type shared by both operands of a binary operator.
+func operandType(left, right code.Value) (code.Type, error) {
+	leftType, rightType := code.TypeOf(left), code.TypeOf(right)
+	if !code.SameType(leftType, rightType) {
+		return nil, fmt.Errorf("mismatched operand types %s and %s", code.TypeName(leftType), code.TypeName(rightType))
+	}
+
+	return leftType, nil
+}
@@ -1,6 +1,10 @@
 package populate_metadata_mapper
 
 import (
+	"fmt"
+	"math"
+	"strings"
+
 	"github.com/JosephNaberhaus/agnostic/code"
 	"github.com/JosephNaberhaus/agnostic/internal/utils/stack"
 )
@@ -11,198 +15,604 @@ type Mapper struct {
 	Stack stack.Stack[code.Node]
 }
 
-func (m Mapper) MapAddToSet(value code.AddToSet) error {
+func (m Mapper) MapAddToSet(value *code.AddToSet) error {
+	m.setUsage(value.Set, code.UsageMutate)
+	m.setUsage(value.Value, code.UsageOwned)
 	return nil
 }
 
-func (m Mapper) MapArgumentDef(value code.ArgumentDef) error {
-	return nil
+func (m Mapper) MapArgumentDef(value *code.ArgumentDef) error {
+	return m.checkShadowing(value.Name)
 }
 
-func (m Mapper) MapAssignment(value code.Assignment) error {
+func (m Mapper) MapAssignment(value *code.Assignment) error {
+	m.setUsage(value.To, code.UsageAssign)
+	m.setUsage(value.From, code.UsageOwned)
+	if lookup, ok := value.To.(*code.Lookup); ok {
+		// The key is stored when assigning into a map.
+		m.setUsage(lookup.Key, code.UsageOwned)
+	}
 	return nil
 }
 
-func (m Mapper) MapBlock(value code.Block) error {
+func (m Mapper) MapBinary(value *code.Binary) error {
+	m.setUsage(value.Left, code.UsageRead)
+	m.setUsage(value.Right, code.UsageRead)
+	if !known(value.Left, value.Right) {
+		return nil
+	}
+
+	operand, err := operandType(value.Left, value.Right)
+	if err != nil {
+		return err
+	}
+
+	value.Type, err = binaryType(value.Operator, operand)
+	value.Overflow = code.ArithmeticOverflow(value.Type)
+	return err
+}
+
+func (m Mapper) MapBlock(value *code.Block) error {
 	return nil
 }
 
-func (m Mapper) MapBool(value code.Bool) error {
+func (m Mapper) MapBool(value *code.Bool) error {
 	return nil
 }
 
-func (m Mapper) MapBreak(value code.Break) error {
+func (m Mapper) MapBreak(value *code.Break) error {
 	return nil
 }
 
-func (m Mapper) MapCall(value code.Call) error {
+func (m Mapper) MapBytes(value *code.Bytes) error {
 	return nil
 }
 
-func (m Mapper) MapConditional(value code.Conditional) error {
+func (m Mapper) MapCall(value *code.Call) error {
+	m.setUsages(value.Arguments, code.UsageArgument)
+
+	switch function := value.Function.(type) {
+	case *code.FunctionDef:
+		value.Type = function.ReturnType
+		return nil
+	case *code.FunctionRef:
+		// The reference couldn't be resolved, which is already reported.
+		return nil
+	default:
+		return fmt.Errorf("unsupported callable %T", value.Function)
+	}
+}
+
+func (m Mapper) MapComparison(value *code.Comparison) error {
+	m.setUsage(value.Left, code.UsageRead)
+	m.setUsage(value.Right, code.UsageRead)
+	if !known(value.Left, value.Right) {
+		return nil
+	}
+
+	operand, err := operandType(value.Left, value.Right)
+	if err != nil {
+		return err
+	}
+
+	if err := m.checkComparable(value.Operator, operand); err != nil {
+		return err
+	}
+
+	value.Type = &code.Bool{}
 	return nil
 }
 
-func (m Mapper) MapConstantDef(value code.ConstantDef) error {
+func (m Mapper) MapConditional(value *code.Conditional) error {
 	return nil
 }
 
-func (m Mapper) MapContinue(value code.Continue) error {
+func (m Mapper) MapConstantDef(value *code.ConstantDef) error {
+	value.Module, _ = m.enclosingModule()
 	return nil
 }
 
-func (m Mapper) MapDeclare(value code.Declare) error {
+func (m Mapper) MapContinue(value *code.Continue) error {
 	return nil
 }
 
-func (m Mapper) MapEmptyList(value code.EmptyList) error {
+func (m Mapper) MapConversion(value *code.Conversion) error {
+	m.setUsage(value.Value, code.UsageRead)
+	value.Type = value.To
+	if !known(value.Value) {
+		return nil
+	}
+
+	from := code.TypeOf(value.Value)
+	if !code.IsNumeric(from) || !code.IsNumeric(value.To) {
+		return fmt.Errorf("cannot convert type %s to %s", code.TypeName(from), code.TypeName(value.To))
+	}
+
+	value.Overflow = code.ConversionOverflow(from, value.To)
 	return nil
 }
 
-func (m Mapper) MapEqualOverride(value code.EqualOverride) error {
+func (m Mapper) MapDeclare(value *code.Declare) error {
+	m.setUsage(value.Value, code.UsageOwned)
+	return m.checkShadowing(value.Name)
+}
+
+func (m Mapper) MapEmptyList(value *code.EmptyList) error {
+	value.ValueMetadata.Type = &code.List{Item: value.Type}
 	return nil
 }
 
-func (m Mapper) MapFieldDef(value code.FieldDef) error {
+func (m Mapper) MapEqualOverride(value *code.EqualOverride) error {
+	return m.checkShadowing(value.OtherName)
+}
+
+func (m Mapper) MapFieldDef(value *code.FieldDef) error {
 	return nil
 }
 
-func (m Mapper) MapFor(value code.For) error {
+func (m Mapper) MapFloat64(value *code.Float64) error {
 	return nil
 }
 
-func (m Mapper) MapForEach(value code.ForEach) error {
+func (m Mapper) MapFor(value *code.For) error {
 	return nil
 }
 
-func (m Mapper) MapFunctionDef(value code.FunctionDef) error {
+func (m Mapper) MapForEach(value *code.ForEach) error {
+	return m.checkShadowing(value.ItemName)
+}
+
+func (m Mapper) MapFunctionDef(value *code.FunctionDef) error {
+	value.Module, _ = m.enclosingModule()
+
+	for i, argument := range value.Arguments {
+		for _, previous := range value.Arguments[:i] {
+			if previous.Name == argument.Name {
+				return fmt.Errorf("shadowed declaration: %q is already declared", argument.Name)
+			}
+		}
+	}
+
 	return nil
 }
 
-func (m Mapper) MapHashOverride(value code.HashOverride) error {
+// MapFunctionRef resolves the reference to a function of the enclosing module, or of the imported module that qualifies
+// it. Function bodies are mapped after every module's functions, so the definition is always mapped by the time a call
+// refers to it.
+func (m Mapper) MapFunctionRef(value *code.FunctionRef) error {
+	module, err := m.qualifiedModule(value.Module)
+	if err != nil {
+		return err
+	}
+
+	for _, function := range module.Functions {
+		if function.Name == value.Name {
+			value.Definition = function
+			return m.checkVisible(function.Visibility, module.Name, fmt.Sprintf("function %q", function.Name))
+		}
+	}
+
+	if value.Module != "" {
+		return fmt.Errorf("module %q has no function %q", module.Name, value.Name)
+	}
+
+	return fmt.Errorf("undefined function %q", value.Name)
+}
+
+func (m Mapper) MapHashOverride(value *code.HashOverride) error {
 	return nil
 }
 
-func (m Mapper) MapIf(value code.If) error {
+func (m Mapper) MapIf(value *code.If) error {
 	return nil
 }
 
-func (m Mapper) MapInt64(value code.Int64) error {
+// MapImport resolves the imported module. Imports are mapped once every module is, so this is also where cycles in the
+// dependency graph are found.
+func (m Mapper) MapImport(value *code.Import) error {
+	module, ok := m.enclosingModule()
+	if !ok {
+		return fmt.Errorf("import outside of a module")
+	}
+
+	for _, previous := range module.Imports {
+		if previous == value {
+			break
+		}
+
+		if previous.Module == value.Module {
+			return fmt.Errorf("module %q is already imported", value.Module)
+		}
+	}
+
+	var err error
+	value.Definition, err = m.lookupModule(value.Module)
+	if err != nil {
+		return err
+	}
+
+	if cycle := m.importCycle(module, value.Module); cycle != nil {
+		return fmt.Errorf("import cycle: %s", strings.Join(cycle, " -> "))
+	}
+
 	return nil
 }
 
-func (m Mapper) MapKeyValue(value code.KeyValue) error {
+func (m Mapper) MapInt32(value *code.Int32) error {
 	return nil
 }
 
-func (m Mapper) MapLength(value code.Length) error {
+func (m Mapper) MapInt64(value *code.Int64) error {
 	return nil
 }
 
-func (m Mapper) MapList(value code.List) error {
+func (m Mapper) MapKeyValue(value *code.KeyValue) error {
+	m.setUsage(value.Key, code.UsageOwned)
+	m.setUsage(value.Value, code.UsageOwned)
 	return nil
 }
 
-func (m Mapper) MapLiteralBool(value code.LiteralBool) error {
+func (m Mapper) MapLength(value *code.Length) error {
+	if !known(value.Of) {
+		return nil
+	}
+
+	switch of := code.TypeOf(value.Of); of.(type) {
+	case *code.Bytes, *code.List, *code.Map, *code.Set, *code.String:
+	default:
+		return fmt.Errorf("cannot take the length of type %s", code.TypeName(of))
+	}
+
+	value.Type = &code.Int64{}
 	return nil
 }
 
-func (m Mapper) MapLiteralInt64(value code.LiteralInt64) error {
+func (m Mapper) MapList(value *code.List) error {
 	return nil
 }
 
-func (m Mapper) MapLiteralList(value code.LiteralList) error {
+func (m Mapper) MapLiteralBool(value *code.LiteralBool) error {
+	value.Type = &code.Bool{}
 	return nil
 }
 
-func (m Mapper) MapLiteralMap(value code.LiteralMap) error {
+func (m Mapper) MapLiteralBytes(value *code.LiteralBytes) error {
+	value.Type = &code.Bytes{}
 	return nil
 }
 
-func (m Mapper) MapLiteralRune(value code.LiteralRune) error {
+func (m Mapper) MapLiteralFloat64(value *code.LiteralFloat64) error {
+	if math.IsInf(value.Value, 0) || math.IsNaN(value.Value) {
+		return fmt.Errorf("float literal %v must be finite", value.Value)
+	}
+
+	value.Type = &code.Float64{}
 	return nil
 }
 
-func (m Mapper) MapLiteralSet(value code.LiteralSet) error {
+func (m Mapper) MapLiteralInt32(value *code.LiteralInt32) error {
+	value.Type = &code.Int32{}
 	return nil
 }
 
-func (m Mapper) MapLiteralString(value code.LiteralString) error {
+func (m Mapper) MapLiteralInt64(value *code.LiteralInt64) error {
+	value.Type = &code.Int64{}
 	return nil
 }
 
-func (m Mapper) MapLookup(value code.Lookup) error {
+func (m Mapper) MapLiteralList(value *code.LiteralList) error {
+	m.setUsages(value.Values, code.UsageOwned)
+	if !known(value.Values...) {
+		return nil
+	}
+
+	item, err := commonType(value.Values, "item")
+	if err != nil {
+		return err
+	}
+
+	value.Type = &code.List{Item: item}
 	return nil
 }
 
-func (m Mapper) MapMap(value code.Map) error {
+func (m Mapper) MapLiteralMap(value *code.LiteralMap) error {
+	keys := make([]code.Value, 0, len(value.Values))
+	values := make([]code.Value, 0, len(value.Values))
+	for _, keyValue := range value.Values {
+		keys = append(keys, keyValue.Key)
+		values = append(values, keyValue.Value)
+	}
+
+	if !known(keys...) || !known(values...) {
+		return nil
+	}
+
+	key, err := commonType(keys, "key")
+	if err != nil {
+		return err
+	}
+
+	if err := checkHashable(key, "the key of a map"); err != nil {
+		return err
+	}
+
+	mapValue, err := commonType(values, "value")
+	if err != nil {
+		return err
+	}
+
+	value.Type = &code.Map{Key: key, Value: mapValue}
 	return nil
 }
 
-func (m Mapper) MapModel(value code.Model) error {
+func (m Mapper) MapLiteralRune(value *code.LiteralRune) error {
+	value.Type = &code.Rune{}
 	return nil
 }
 
-func (m Mapper) MapModelDef(value code.ModelDef) error {
+func (m Mapper) MapLiteralSet(value *code.LiteralSet) error {
+	m.setUsages(value.Values, code.UsageOwned)
+	if !known(value.Values...) {
+		return nil
+	}
+
+	item, err := commonType(value.Values, "item")
+	if err != nil {
+		return err
+	}
+
+	if err := checkHashable(item, "the item of a set"); err != nil {
+		return err
+	}
+
+	value.Type = &code.Set{Item: item}
 	return nil
 }
 
-func (m Mapper) MapModule(value code.Module) error {
+func (m Mapper) MapLiteralString(value *code.LiteralString) error {
+	value.Type = &code.String{}
 	return nil
 }
 
-func (m Mapper) MapNew(value code.New) error {
+func (m Mapper) MapLiteralUint8(value *code.LiteralUint8) error {
+	value.Type = &code.Uint8{}
 	return nil
 }
 
-func (m Mapper) MapNil(value code.Nil) error {
+func (m Mapper) MapLookup(value *code.Lookup) error {
+	m.setUsage(value.From, code.UsageRead)
+	m.setUsage(value.Key, code.UsageRead)
+	if !known(value.From, value.Key) {
+		return nil
+	}
+
+	switch from := code.TypeOf(value.From).(type) {
+	case *code.Bytes:
+		value.Type = &code.Uint8{}
+		return expectType(value.Key, &code.Int64{}, "the index of bytes")
+	case *code.List:
+		value.Type = from.Item
+		return expectType(value.Key, &code.Int64{}, "the index of a list")
+	case *code.Map:
+		value.Type = from.Value
+		return expectType(value.Key, from.Key, "the key of a map")
+	case *code.String:
+		value.Type = &code.Rune{}
+		return expectType(value.Key, &code.Int64{}, "the index of a string")
+	default:
+		return fmt.Errorf("cannot lookup a value in type %s", code.TypeName(from))
+	}
+}
+
+func (m Mapper) MapMap(value *code.Map) error {
+	return checkHashable(value.Key, "the key of a map")
+}
+
+// MapModel resolves the module that defines the model, which is recorded so that the same model is named the same way
+// in every module. Whether a model of the enclosing module exists is checked where the model is used, while a model
+// of another module is checked here.
+func (m Mapper) MapModel(value *code.Model) error {
+	module, err := m.qualifiedModule(value.Module)
+	if err != nil {
+		return err
+	}
+
+	qualified := value.Module != ""
+	value.Module = module.Name
+	if !qualified {
+		return nil
+	}
+
+	modelDef, err := m.lookupModel(value)
+	if err != nil {
+		return err
+	}
+
+	return m.checkVisible(modelDef.Visibility, module.Name, fmt.Sprintf("model %q", modelDef.Name))
+}
+
+func (m Mapper) MapMethodCall(value *code.MethodCall) error {
+	m.setUsages(value.Arguments, code.UsageArgument)
+	// Whether the receiver is modified is only known once the body of the method is mapped. See MarkModifiedReceiver.
+	m.setUsage(value.Receiver, code.UsageRead)
+	if !known(value.Receiver) {
+		return nil
+	}
+
+	model, ok := code.TypeOf(value.Receiver).(*code.Model)
+	if !ok {
+		return fmt.Errorf("cannot call method %q on type %s", value.Name, code.TypeName(code.TypeOf(value.Receiver)))
+	}
+
+	modelDef, err := m.lookupModel(model)
+	if err != nil {
+		return err
+	}
+
+	for _, method := range modelDef.Methods {
+		if method.Name == value.Name {
+			value.Method = method
+			value.Type = method.ReturnType
+			return m.checkVisible(method.Visibility, model.Module, fmt.Sprintf("method %q of model %q", method.Name, model.Name))
+		}
+	}
+
+	return fmt.Errorf("model %q has no method %q", model.Name, value.Name)
+}
+
+// MarkModifiedReceiver records that the receiver of the call is modified because the called method modifies its model.
+func (m Mapper) MarkModifiedReceiver(value *code.MethodCall) {
+	m.setUsage(value.Receiver, code.UsageMutate)
+}
+
+func (m Mapper) MapModelDef(value *code.ModelDef) error {
+	value.Module, _ = m.enclosingModule()
 	return nil
 }
 
-func (m Mapper) MapPop(value code.Pop) error {
+func (m Mapper) MapModule(value *code.Module) error {
 	return nil
 }
 
-func (m Mapper) MapProperty(value code.Property) error {
+func (m Mapper) MapNew(value *code.New) error {
+	if _, err := m.lookupModel(value.Model); err != nil {
+		return err
+	}
+
+	value.Type = value.Model
 	return nil
 }
 
-func (m Mapper) MapPush(value code.Push) error {
+func (m Mapper) MapNil(value *code.Nil) error {
+	value.ValueMetadata.Type = value.Type
 	return nil
 }
 
-func (m Mapper) MapReturn(value code.Return) error {
+func (m Mapper) MapPop(value *code.Pop) error {
+	m.setUsage(value.List, code.UsageMutate)
+	if !known(value.List) {
+		return nil
+	}
+
+	list, ok := code.TypeOf(value.List).(*code.List)
+	if !ok {
+		return fmt.Errorf("cannot pop from type %s", code.TypeName(code.TypeOf(value.List)))
+	}
+
+	value.Type = list.Item
 	return nil
 }
 
-func (m Mapper) MapRoot(value code.Root) error {
+func (m Mapper) MapProperty(value *code.Property) error {
+	m.setUsage(value.Of, code.UsageRead)
+	if !known(value.Of) {
+		return nil
+	}
+
+	model, ok := code.TypeOf(value.Of).(*code.Model)
+	if !ok {
+		return fmt.Errorf("cannot access property %q of type %s", value.Name, code.TypeName(code.TypeOf(value.Of)))
+	}
+
+	modelDef, err := m.lookupModel(model)
+	if err != nil {
+		return err
+	}
+
+	for _, field := range modelDef.Fields {
+		if field.Name == value.Name {
+			value.Field = field
+			value.Type = field.Type
+			return m.checkVisible(field.Visibility, model.Module, fmt.Sprintf("field %q of model %q", field.Name, model.Name))
+		}
+	}
+
+	return fmt.Errorf("model %q has no field %q", model.Name, value.Name)
+}
+
+func (m Mapper) MapPush(value *code.Push) error {
+	m.setUsage(value.List, code.UsageMutate)
+	m.setUsage(value.Value, code.UsageOwned)
 	return nil
 }
 
-func (m Mapper) MapRune(value code.Rune) error {
+func (m Mapper) MapReturn(value *code.Return) error {
+	m.setUsage(value.Value, code.UsageOwned)
 	return nil
 }
 
-func (m Mapper) MapSelf(value code.Self) error {
+func (m Mapper) MapRoot(value *code.Root) error {
 	return nil
 }
 
-func (m Mapper) MapSet(value code.Set) error {
+func (m Mapper) MapRune(value *code.Rune) error {
 	return nil
 }
 
-func (m Mapper) MapSetContains(value code.SetContains) error {
+func (m Mapper) MapSelf(value *code.Self) error {
+	model, ok := m.enclosingModel()
+	if !ok {
+		return fmt.Errorf("self used outside of a model")
+	}
+
+	value.Type = m.modelType(model)
 	return nil
 }
 
-func (m Mapper) MapString(value code.String) error {
+func (m Mapper) MapSet(value *code.Set) error {
+	return checkHashable(value.Item, "the item of a set")
+}
+
+func (m Mapper) MapSetContains(value *code.SetContains) error {
+	if !known(value.Set, value.Value) {
+		return nil
+	}
+
+	set, ok := code.TypeOf(value.Set).(*code.Set)
+	if !ok {
+		return fmt.Errorf("cannot check whether type %s contains a value", code.TypeName(code.TypeOf(value.Set)))
+	}
+
+	value.Type = &code.Bool{}
+	return expectType(value.Value, set.Item, "the value checked for in a set")
+}
+
+func (m Mapper) MapString(value *code.String) error {
 	return nil
 }
 
-func (m Mapper) MapVariable(value code.Variable) error {
+func (m Mapper) MapUint8(value *code.Uint8) error {
 	return nil
 }
 
-func (m Mapper) MapVoid(value code.Void) error {
+func (m Mapper) MapUnary(value *code.Unary) error {
+	m.setUsage(value.Value, code.UsageRead)
+	if !known(value.Value) {
+		return nil
+	}
+
+	var err error
+	value.Type, err = unaryType(value.Operator, code.TypeOf(value.Value))
+	value.Overflow = code.ArithmeticOverflow(value.Type)
+	return err
+}
+
+func (m Mapper) MapVariable(value *code.Variable) error {
+	if value.Module != "" {
+		return m.resolveQualified(value)
+	}
+
+	var err error
+	definition, ok := m.resolve(value.Name, len(m.Stack)-1)
+	if !ok {
+		return fmt.Errorf("undefined variable %q", value.Name)
+	}
+
+	value.Definition = definition
+	value.Type, err = m.definitionType(definition)
+	return err
+}
+
+func (m Mapper) MapVoid(value *code.Void) error {
 	return nil
 }
@@ -0,0 +1,106 @@
+package populate_metadata_mapper
+
+import (
+	"fmt"
+
+	"github.com/JosephNaberhaus/agnostic/code"
+)
+
+// resolve finds the definition of the variable with the given name by walking outward from the node at the given
+// index of the stack. Blocks only contain the statements that come before the current node at this point, so only
+// variables declared earlier are visible. Outside of the innermost function only the module's constants are visible.
+func (m Mapper) resolve(name string, from int) (code.Definition, bool) {
+	inFunction := true
+	for i := from; i >= 0; i-- {
+		switch node := m.Stack[i].(type) {
+		case *code.Block:
+			if !inFunction {
+				continue
+			}
+
+			for j := len(node.Statements) - 1; j >= 0; j-- {
+				if declare, ok := node.Statements[j].(*code.Declare); ok && declare.Name == name {
+					return declare, true
+				}
+			}
+		case *code.For:
+			if !inFunction {
+				continue
+			}
+
+			if declare, ok := node.Initialization.(*code.Declare); ok && declare.Name == name {
+				return declare, true
+			}
+		case *code.ForEach:
+			if inFunction && node.ItemName == name {
+				return node, true
+			}
+		case *code.EqualOverride:
+			if inFunction && node.OtherName == name {
+				return node, true
+			}
+		case *code.FunctionDef:
+			if !inFunction {
+				continue
+			}
+
+			for _, argument := range node.Arguments {
+				if argument.Name == name {
+					return argument, true
+				}
+			}
+
+			// The variables of a calling function aren't visible.
+			inFunction = false
+		case *code.Module:
+			for _, constant := range node.Constants {
+				if constant.Name == name {
+					return constant, true
+				}
+			}
+
+			return nil, false
+		}
+	}
+
+	return nil, false
+}
+
+// checkShadowing returns an error if the current node declares a name that is already visible.
+func (m Mapper) checkShadowing(name string) error {
+	if _, ok := m.resolve(name, len(m.Stack)-2); ok {
+		return fmt.Errorf("shadowed declaration: %q is already declared", name)
+	}
+
+	// A qualified name would be ambiguous if the module could also be a variable.
+	if module, ok := m.enclosingModule(); ok && imports(module, name) {
+		return fmt.Errorf("shadowed declaration: %q is an imported module", name)
+	} else if ok && module.Name == name {
+		return fmt.Errorf("shadowed declaration: %q is the enclosing module", name)
+	}
+
+	return nil
+}
+
+// resolveQualified resolves a variable that is qualified by the name of a module. Only the constants of a module can be
+// referred to from outside of it.
+func (m Mapper) resolveQualified(value *code.Variable) error {
+	module, err := m.qualifiedModule(value.Module)
+	if err != nil {
+		return err
+	}
+
+	for _, constant := range module.Constants {
+		if constant.Name == value.Name {
+			value.Definition = constant
+			value.Type, err = m.definitionType(constant)
+			if err != nil {
+				return err
+			}
+
+			return m.checkVisible(constant.Visibility, module.Name, fmt.Sprintf("constant %q", constant.Name))
+		}
+	}
+
+	return fmt.Errorf("module %q has no constant %q", module.Name, value.Name)
+}
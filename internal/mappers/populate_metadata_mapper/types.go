@@ -0,0 +1,140 @@
+package populate_metadata_mapper
+
+import (
+	"fmt"
+
+	"github.com/JosephNaberhaus/agnostic/code"
+)
+
+// enclosingModule returns the module that contains the current node.
+func (m Mapper) enclosingModule() (*code.Module, bool) {
+	for i := len(m.Stack) - 1; i >= 0; i-- {
+		if module, ok := m.Stack[i].(*code.Module); ok {
+			return module, true
+		}
+	}
+
+	return nil, false
+}
+
+// enclosingModel returns the innermost model that contains the current node.
+func (m Mapper) enclosingModel() (*code.ModelDef, bool) {
+	for i := len(m.Stack) - 1; i >= 0; i-- {
+		if model, ok := m.Stack[i].(*code.ModelDef); ok {
+			return model, true
+		}
+	}
+
+	return nil, false
+}
+
+// lookupModel finds the definition of a model in the module that defines it.
+func (m Mapper) lookupModel(model *code.Model) (*code.ModelDef, error) {
+	module, err := m.lookupModule(model.Module)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, modelDef := range module.Models {
+		if modelDef.Name == model.Name {
+			return modelDef, nil
+		}
+	}
+
+	if enclosing, ok := m.enclosingModule(); ok && enclosing != module {
+		return nil, fmt.Errorf("module %q has no model %q", module.Name, model.Name)
+	}
+
+	return nil, fmt.Errorf("undefined model %q", model.Name)
+}
+
+// modelType returns the type of the values of a model of the enclosing module.
+func (m Mapper) modelType(model *code.ModelDef) *code.Model {
+	typ := &code.Model{Name: model.Name}
+	if module, ok := m.enclosingModule(); ok {
+		typ.Module = module.Name
+	}
+
+	return typ
+}
+
+// definitionType returns the type of the variables that refer to the definition.
+func (m Mapper) definitionType(definition code.Definition) (code.Type, error) {
+	switch definition := definition.(type) {
+	case *code.ArgumentDef:
+		return definition.Type, nil
+	case *code.ConstantDef:
+		// Every constant value is also a value.
+		return code.TypeOf(definition.Value.(code.Value)), nil
+	case *code.Declare:
+		return code.TypeOf(definition.Value), nil
+	case *code.EqualOverride:
+		model, ok := m.enclosingModel()
+		if !ok {
+			return nil, fmt.Errorf("equal override outside of a model")
+		}
+
+		return m.modelType(model), nil
+	case *code.FieldDef:
+		return definition.Type, nil
+	case *code.ForEach:
+		if !known(definition.Iterable) {
+			return nil, nil
+		}
+
+		// An iterable without items is reported by the type checker, so its items are left without a type.
+		return code.ItemType(code.TypeOf(definition.Iterable)), nil
+	default:
+		return nil, fmt.Errorf("cannot determine the type of %T", definition)
+	}
+}
+
+// known returns whether the types of all of the values are known. The type of a value is unknown when there was a
+// problem with it, which has already been reported, so nothing that depends on the type should be checked.
+func known(values ...code.Value) bool {
+	for _, value := range values {
+		if code.TypeOf(value) == nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+// commonType returns the type shared by all of the values. It's an error for the values to have different types or for
+// there to be no values, since the type can't be inferred then.
+func commonType(values []code.Value, description string) (code.Type, error) {
+	if len(values) == 0 {
+		return nil, fmt.Errorf("cannot infer the %s type of an empty literal", description)
+	}
+
+	typ := code.TypeOf(values[0])
+	for _, value := range values[1:] {
+		if other := code.TypeOf(value); !code.SameType(typ, other) {
+			return nil, fmt.Errorf("mismatched %s types %s and %s", description, code.TypeName(typ), code.TypeName(other))
+		}
+	}
+
+	return typ, nil
+}
+
+// expectType returns an error if the value doesn't have the expected type.
+func expectType(value code.Value, expected code.Type, description string) error {
+	if actual := code.TypeOf(value); !code.SameType(actual, expected) {
+		return fmt.Errorf("%s must be of type %s, not %s", description, code.TypeName(expected), code.TypeName(actual))
+	}
+
+	return nil
+}
+
+// checkHashable returns an error if values of the type can't be used as the keys of a map or the items of a set.
+// Collections aren't hashable in every language, and languages disagree on whether -0 and 0 or two NaNs are the same
+// key.
+func checkHashable(typ code.Type, description string) error {
+	switch typ.(type) {
+	case *code.Bytes, *code.Float64, *code.List, *code.Map, *code.Set:
+		return fmt.Errorf("%s can't be of type %s", description, code.TypeName(typ))
+	}
+
+	return nil
+}
@@ -0,0 +1,47 @@
+package populate_metadata_mapper
+
+import (
+	"github.com/JosephNaberhaus/agnostic/code"
+)
+
+// setUsage records how a value is used by its parent. Values are populated before their parents, so the usage of a
+// property or lookup is propagated to the value that it's applied to.
+func (m Mapper) setUsage(value code.Value, usage code.Usage) {
+	switch value := value.(type) {
+	case *code.Lookup:
+		value.Usage = usage
+		m.setUsage(value.From, usage.Access())
+	case *code.Property:
+		value.Usage = usage
+		m.setUsage(value.Of, usage.Access())
+	case *code.Self:
+		if usage == code.UsageMutate {
+			if function, ok := m.enclosingFunction(); ok {
+				function.ModifiesSelf = true
+			}
+		}
+	case *code.Variable:
+		value.Usage = usage
+		if argument, ok := value.Definition.(*code.ArgumentDef); ok && usage.Access() == code.UsageMutate {
+			argument.Modified = true
+		}
+	}
+}
+
+// setUsages records the same usage for each of the values.
+func (m Mapper) setUsages(values []code.Value, usage code.Usage) {
+	for _, value := range values {
+		m.setUsage(value, usage)
+	}
+}
+
+// enclosingFunction returns the innermost function that contains the current node.
+func (m Mapper) enclosingFunction() (*code.FunctionDef, bool) {
+	for i := len(m.Stack) - 1; i >= 0; i-- {
+		if function, ok := m.Stack[i].(*code.FunctionDef); ok {
+			return function, true
+		}
+	}
+
+	return nil, false
+}
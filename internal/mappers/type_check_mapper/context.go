@@ -0,0 +1,98 @@
+package type_check_mapper
+
+import (
+	"fmt"
+
+	"github.com/JosephNaberhaus/agnostic/code"
+)
+
+// errorf creates an error that names the function or override, and the model, that the current node is in.
+func (m Mapper) errorf(format string, args ...any) error {
+	message := fmt.Sprintf(format, args...)
+	for i := len(m.Stack) - 1; i >= 0; i-- {
+		switch node := m.Stack[i].(type) {
+		case *code.FunctionDef:
+			// Methods are the direct children of their model.
+			if i > 0 {
+				if model, ok := m.Stack[i-1].(*code.ModelDef); ok {
+					return fmt.Errorf("method %q of model %q: %s", node.Name, model.Name, message)
+				}
+			}
+
+			return fmt.Errorf("function %q: %s", node.Name, message)
+		case *code.EqualOverride:
+			return fmt.Errorf("equal override of model %q: %s", m.Stack[i-1].(*code.ModelDef).Name, message)
+		case *code.HashOverride:
+			return fmt.Errorf("hash override of model %q: %s", m.Stack[i-1].(*code.ModelDef).Name, message)
+		}
+	}
+
+	return fmt.Errorf("%s", message)
+}
+
+// returnType returns the type that the innermost function or override containing the current node must return.
+func (m Mapper) returnType() (code.Type, bool) {
+	for i := len(m.Stack) - 1; i >= 0; i-- {
+		switch node := m.Stack[i].(type) {
+		case *code.FunctionDef:
+			return node.ReturnType, true
+		case *code.EqualOverride:
+			return &code.Bool{}, true
+		case *code.HashOverride:
+			return &code.Int64{}, true
+		}
+	}
+
+	return nil, false
+}
+
+// expectType returns an error if the value doesn't have the expected type. Unknown types were already reported when
+// they were populated, so they're not checked.
+func (m Mapper) expectType(value code.Value, expected code.Type, description string) error {
+	if expected == nil {
+		return nil
+	}
+
+	if actual := code.TypeOf(value); actual != nil && !code.SameType(actual, expected) {
+		return m.errorf("%s must be of type %s, not %s", description, code.TypeName(expected), code.TypeName(actual))
+	}
+
+	return nil
+}
+
+// checkArguments returns an error if the arguments of a call don't match the arguments of the function that it calls.
+func (m Mapper) checkArguments(function *code.FunctionDef, arguments []code.Value) error {
+	if len(arguments) != len(function.Arguments) {
+		return m.errorf("%q takes %d arguments but %d were given", function.Name, len(function.Arguments), len(arguments))
+	}
+
+	for i, argument := range function.Arguments {
+		err := m.expectType(arguments[i], argument.Type, fmt.Sprintf("argument %q of %q", argument.Name, function.Name))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// definition is something that is defined by name, such as a function of a module or a field of a model.
+type definition struct {
+	kind string
+	name string
+}
+
+// duplicate returns the first definition whose name is already used by an earlier one. The parser reports these
+// itself, but an AST that comes from elsewhere might still have them.
+func duplicate(definitions []definition) (definition, bool) {
+	seen := make(map[string]struct{}, len(definitions))
+	for _, d := range definitions {
+		if _, ok := seen[d.name]; ok {
+			return d, true
+		}
+
+		seen[d.name] = struct{}{}
+	}
+
+	return definition{}, false
+}
@@ -0,0 +1,358 @@
+package type_check_mapper
+
+import (
+	"github.com/JosephNaberhaus/agnostic/code"
+	"github.com/JosephNaberhaus/agnostic/internal/utils/stack"
+)
+
+var _ code.NodeMapperOnlyError = Mapper{}
+
+// Mapper checks that the values used by a node have the types that the node expects. It relies on the types that are
+// computed by the populate_metadata_mapper, so it must run on a node after its metadata is populated.
+type Mapper struct {
+	Stack stack.Stack[code.Node]
+}
+
+func (m Mapper) MapAddToSet(value *code.AddToSet) error {
+	if code.TypeOf(value.Set) == nil {
+		return nil
+	}
+
+	set, ok := code.TypeOf(value.Set).(*code.Set)
+	if !ok {
+		return m.errorf("cannot add to type %s", code.TypeName(code.TypeOf(value.Set)))
+	}
+
+	return m.expectType(value.Value, set.Item, "the value added to a set")
+}
+
+func (m Mapper) MapArgumentDef(value *code.ArgumentDef) error {
+	return nil
+}
+
+func (m Mapper) MapAssignment(value *code.Assignment) error {
+	switch value.To.(type) {
+	case *code.Lookup, *code.Property, *code.Variable:
+	default:
+		return m.errorf("only a variable, property or lookup can be assigned to")
+	}
+
+	return m.expectType(value.From, code.TypeOf(value.To), "the assigned value")
+}
+
+func (m Mapper) MapBinary(value *code.Binary) error {
+	return nil
+}
+
+func (m Mapper) MapBlock(value *code.Block) error {
+	return nil
+}
+
+func (m Mapper) MapBool(value *code.Bool) error {
+	return nil
+}
+
+func (m Mapper) MapBreak(value *code.Break) error {
+	return nil
+}
+
+func (m Mapper) MapBytes(value *code.Bytes) error {
+	return nil
+}
+
+func (m Mapper) MapCall(value *code.Call) error {
+	switch function := value.Function.(type) {
+	case *code.FunctionDef:
+		return m.checkArguments(function, value.Arguments)
+	case *code.FunctionRef:
+		// The reference couldn't be resolved, which is already reported.
+		return nil
+	default:
+		return m.errorf("unsupported callable %T", value.Function)
+	}
+}
+
+func (m Mapper) MapComparison(value *code.Comparison) error {
+	return nil
+}
+
+func (m Mapper) MapConditional(value *code.Conditional) error {
+	return nil
+}
+
+func (m Mapper) MapConstantDef(value *code.ConstantDef) error {
+	return nil
+}
+
+func (m Mapper) MapContinue(value *code.Continue) error {
+	return nil
+}
+
+func (m Mapper) MapConversion(value *code.Conversion) error {
+	return nil
+}
+
+func (m Mapper) MapDeclare(value *code.Declare) error {
+	return nil
+}
+
+func (m Mapper) MapEmptyList(value *code.EmptyList) error {
+	return nil
+}
+
+func (m Mapper) MapEqualOverride(value *code.EqualOverride) error {
+	return nil
+}
+
+func (m Mapper) MapFieldDef(value *code.FieldDef) error {
+	return nil
+}
+
+func (m Mapper) MapFloat64(value *code.Float64) error {
+	return nil
+}
+
+func (m Mapper) MapFor(value *code.For) error {
+	return m.expectType(value.Condition, &code.Bool{}, "the condition of a loop")
+}
+
+func (m Mapper) MapForEach(value *code.ForEach) error {
+	if typ := code.TypeOf(value.Iterable); typ != nil && code.ItemType(typ) == nil {
+		return m.errorf("cannot iterate over type %s", code.TypeName(typ))
+	}
+
+	return nil
+}
+
+func (m Mapper) MapFunctionDef(value *code.FunctionDef) error {
+	return nil
+}
+
+func (m Mapper) MapFunctionRef(value *code.FunctionRef) error {
+	return nil
+}
+
+func (m Mapper) MapHashOverride(value *code.HashOverride) error {
+	return nil
+}
+
+func (m Mapper) MapIf(value *code.If) error {
+	return m.expectType(value.Condition, &code.Bool{}, "the condition of an if")
+}
+
+func (m Mapper) MapImport(value *code.Import) error {
+	return nil
+}
+
+func (m Mapper) MapInt32(value *code.Int32) error {
+	return nil
+}
+
+func (m Mapper) MapInt64(value *code.Int64) error {
+	return nil
+}
+
+func (m Mapper) MapKeyValue(value *code.KeyValue) error {
+	return nil
+}
+
+func (m Mapper) MapLength(value *code.Length) error {
+	return nil
+}
+
+func (m Mapper) MapList(value *code.List) error {
+	return nil
+}
+
+func (m Mapper) MapLiteralBool(value *code.LiteralBool) error {
+	return nil
+}
+
+func (m Mapper) MapLiteralBytes(value *code.LiteralBytes) error {
+	return nil
+}
+
+func (m Mapper) MapLiteralFloat64(value *code.LiteralFloat64) error {
+	return nil
+}
+
+func (m Mapper) MapLiteralInt32(value *code.LiteralInt32) error {
+	return nil
+}
+
+func (m Mapper) MapLiteralInt64(value *code.LiteralInt64) error {
+	return nil
+}
+
+func (m Mapper) MapLiteralList(value *code.LiteralList) error {
+	return nil
+}
+
+func (m Mapper) MapLiteralMap(value *code.LiteralMap) error {
+	return nil
+}
+
+func (m Mapper) MapLiteralRune(value *code.LiteralRune) error {
+	return nil
+}
+
+func (m Mapper) MapLiteralSet(value *code.LiteralSet) error {
+	return nil
+}
+
+func (m Mapper) MapLiteralString(value *code.LiteralString) error {
+	return nil
+}
+
+func (m Mapper) MapLiteralUint8(value *code.LiteralUint8) error {
+	return nil
+}
+
+func (m Mapper) MapLookup(value *code.Lookup) error {
+	return nil
+}
+
+func (m Mapper) MapMap(value *code.Map) error {
+	return nil
+}
+
+func (m Mapper) MapModel(value *code.Model) error {
+	return nil
+}
+
+func (m Mapper) MapMethodCall(value *code.MethodCall) error {
+	// The method is only resolved if the receiver's type is known.
+	if value.Method == nil {
+		return nil
+	}
+
+	return m.checkArguments(value.Method, value.Arguments)
+}
+
+func (m Mapper) MapModelDef(value *code.ModelDef) error {
+	// Fields and methods are both accessed as members of the model.
+	members := make([]definition, 0, len(value.Fields)+len(value.Methods))
+	for _, field := range value.Fields {
+		members = append(members, definition{kind: "field", name: field.Name})
+	}
+	for _, method := range value.Methods {
+		members = append(members, definition{kind: "method", name: method.Name})
+	}
+
+	if d, ok := duplicate(members); ok {
+		return m.errorf("%s %q is already defined in model %q", d.kind, d.name, value.Name)
+	}
+
+	return nil
+}
+
+func (m Mapper) MapModule(value *code.Module) error {
+	// Constants, models and functions are all referred to by their name alone.
+	definitions := make([]definition, 0, len(value.Constants)+len(value.Models)+len(value.Functions))
+	for _, constant := range value.Constants {
+		definitions = append(definitions, definition{kind: "constant", name: constant.Name})
+	}
+	for _, model := range value.Models {
+		definitions = append(definitions, definition{kind: "model", name: model.Name})
+	}
+	for _, function := range value.Functions {
+		definitions = append(definitions, definition{kind: "function", name: function.Name})
+	}
+
+	if d, ok := duplicate(definitions); ok {
+		return m.errorf("%s %q is already defined in module %q", d.kind, d.name, value.Name)
+	}
+
+	return m.checkExposure(value)
+}
+
+func (m Mapper) MapNew(value *code.New) error {
+	return nil
+}
+
+func (m Mapper) MapNil(value *code.Nil) error {
+	return nil
+}
+
+func (m Mapper) MapPop(value *code.Pop) error {
+	return nil
+}
+
+func (m Mapper) MapProperty(value *code.Property) error {
+	return nil
+}
+
+func (m Mapper) MapPush(value *code.Push) error {
+	if code.TypeOf(value.List) == nil {
+		return nil
+	}
+
+	list, ok := code.TypeOf(value.List).(*code.List)
+	if !ok {
+		return m.errorf("cannot push onto type %s", code.TypeName(code.TypeOf(value.List)))
+	}
+
+	return m.expectType(value.Value, list.Item, "the value pushed onto a list")
+}
+
+func (m Mapper) MapReturn(value *code.Return) error {
+	returnType, ok := m.returnType()
+	if !ok {
+		return m.errorf("return used outside of a function")
+	}
+
+	if _, ok := returnType.(*code.Void); ok {
+		return m.errorf("cannot return a value from a function without a return type")
+	}
+
+	return m.expectType(value.Value, returnType, "the returned value")
+}
+
+func (m Mapper) MapRoot(value *code.Root) error {
+	modules := make([]definition, 0, len(value.Modules))
+	for _, module := range value.Modules {
+		modules = append(modules, definition{kind: "module", name: module.Name})
+	}
+
+	if d, ok := duplicate(modules); ok {
+		return m.errorf("module %q is already defined", d.name)
+	}
+
+	return nil
+}
+
+func (m Mapper) MapRune(value *code.Rune) error {
+	return nil
+}
+
+func (m Mapper) MapSelf(value *code.Self) error {
+	return nil
+}
+
+func (m Mapper) MapSet(value *code.Set) error {
+	return nil
+}
+
+func (m Mapper) MapSetContains(value *code.SetContains) error {
+	return nil
+}
+
+func (m Mapper) MapString(value *code.String) error {
+	return nil
+}
+
+func (m Mapper) MapUint8(value *code.Uint8) error {
+	return nil
+}
+
+func (m Mapper) MapUnary(value *code.Unary) error {
+	return nil
+}
+
+func (m Mapper) MapVariable(value *code.Variable) error {
+	return nil
+}
+
+func (m Mapper) MapVoid(value *code.Void) error {
+	return nil
+}
@@ -0,0 +1,97 @@
+package type_check_mapper
+
+import (
+	"github.com/JosephNaberhaus/agnostic/code"
+)
+
+// checkExposure returns an error if a public declaration of the module uses one of the module's private models in its
+// type. Other modules could reach the model through the declaration without being able to name it.
+func (m Mapper) checkExposure(module *code.Module) error {
+	for _, constant := range module.Constants {
+		if constant.Visibility == code.VisibilityPrivate {
+			continue
+		}
+
+		if model, ok := privateModel(module, code.TypeOf(constant.Value.(code.Value))); ok {
+			return m.errorf("constant %q exposes private model %q", constant.Name, model.Name)
+		}
+	}
+
+	for _, function := range module.Functions {
+		if function.Visibility == code.VisibilityPrivate {
+			continue
+		}
+
+		if model, ok := signatureModel(module, function); ok {
+			return m.errorf("function %q exposes private model %q", function.Name, model.Name)
+		}
+	}
+
+	for _, modelDef := range module.Models {
+		if modelDef.Visibility == code.VisibilityPrivate {
+			continue
+		}
+
+		for _, field := range modelDef.Fields {
+			if field.Visibility == code.VisibilityPrivate {
+				continue
+			}
+
+			if model, ok := privateModel(module, field.Type); ok {
+				return m.errorf("field %q of model %q exposes private model %q", field.Name, modelDef.Name, model.Name)
+			}
+		}
+
+		for _, method := range modelDef.Methods {
+			if method.Visibility == code.VisibilityPrivate {
+				continue
+			}
+
+			if model, ok := signatureModel(module, method); ok {
+				return m.errorf("method %q of model %q exposes private model %q", method.Name, modelDef.Name, model.Name)
+			}
+		}
+	}
+
+	return nil
+}
+
+// signatureModel returns a private model of the module that is used by the arguments or return type of the function.
+func signatureModel(module *code.Module, function *code.FunctionDef) (*code.ModelDef, bool) {
+	for _, argument := range function.Arguments {
+		if model, ok := privateModel(module, argument.Type); ok {
+			return model, true
+		}
+	}
+
+	return privateModel(module, function.ReturnType)
+}
+
+// privateModel returns a private model of the module that the type is, or that the type contains.
+func privateModel(module *code.Module, typ code.Type) (*code.ModelDef, bool) {
+	switch typ := typ.(type) {
+	case *code.List:
+		return privateModel(module, typ.Item)
+	case *code.Map:
+		if model, ok := privateModel(module, typ.Key); ok {
+			return model, true
+		}
+
+		return privateModel(module, typ.Value)
+	case *code.Set:
+		return privateModel(module, typ.Item)
+	case *code.Model:
+		if typ.Module != module.Name {
+			// The models of other modules can only be named if they're public.
+			return nil, false
+		}
+
+		for _, model := range module.Models {
+			if model.Name == typ.Name && model.Visibility == code.VisibilityPrivate {
+				return model, true
+			}
+		}
+	}
+
+	return nil, false
+}
@@ -1 +1,218 @@
-package ast_gen
+// Command ast-gen compiles AgnosticScript into the source code of another language.
+//
+// Usage:
+//
+//	ast-gen --target <language> [--out-dir <dir>] [--module <name>] <file>...
+//
+// Each file is either AgnosticScript or, if its name ends in ".json", an ast.Root encoded as JSON in the format
+// described by ast/ast.schema.json. Every module of the input files is compiled together. The agnosticscript target
+// prints each module back as AgnosticScript in its canonical format, which makes an AST that was encoded as JSON
+// readable.
+//
+// The exit code tells which stage failed: 1 for invalid usage, 2 for syntax errors, 3 for semantic errors, and 4 when
+// the output couldn't be generated or written.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/JosephNaberhaus/agnostic/ast"
+	"github.com/JosephNaberhaus/agnostic/code"
+	"github.com/JosephNaberhaus/agnostic/internal/agnosticscript"
+	"github.com/JosephNaberhaus/agnostic/internal/languages"
+	"github.com/JosephNaberhaus/agnostic/internal/languages/cpp"
+	"github.com/JosephNaberhaus/agnostic/internal/languages/golang"
+	"github.com/JosephNaberhaus/agnostic/internal/languages/java"
+	"github.com/JosephNaberhaus/agnostic/internal/languages/python"
+	"github.com/JosephNaberhaus/agnostic/internal/languages/rust"
+	"github.com/JosephNaberhaus/agnostic/internal/languages/typescript"
+	"github.com/JosephNaberhaus/agnostic/internal/mappers/ast_to_code_mapper"
+)
+
+const (
+	exitOK = iota
+	exitUsage
+	exitParse
+	exitSemantic
+	exitEmit
+)
+
+// agnosticScriptTarget is the target that prints the AST of each module instead of compiling it.
+const agnosticScriptTarget = "agnosticscript"
+
+var targets = map[string]func(root *code.Root) ([]languages.File, error){
+	"cpp":        cpp.Generate,
+	"go":         golang.Generate,
+	"java":       java.Generate,
+	"python":     python.Generate,
+	"rust":       rust.Generate,
+	"typescript": typescript.Generate,
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run executes the command with the given arguments and returns its exit code.
+func run(args []string, stdout, stderr io.Writer) int {
+	targetNames := []string{agnosticScriptTarget}
+	for name := range targets {
+		targetNames = append(targetNames, name)
+	}
+	slices.Sort(targetNames)
+
+	flags := flag.NewFlagSet("ast-gen", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	target := flags.String("target", "", "the language to generate: "+strings.Join(targetNames, ", "))
+	outDir := flags.String("out-dir", ".", "the directory to write the generated files to")
+	moduleName := flags.String("module", "", "only generate the module with this name")
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: ast-gen --target <language> [--out-dir <dir>] [--module <name>] <file>...")
+		flags.PrintDefaults()
+	}
+
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+
+		return exitUsage
+	}
+
+	generate, ok := targets[*target]
+	if !ok && *target != agnosticScriptTarget {
+		fmt.Fprintf(stderr, "unknown target %q; expected one of: %s\n", *target, strings.Join(targetNames, ", "))
+		return exitUsage
+	}
+
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return exitUsage
+	}
+
+	root, err := parse(flags.Args())
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitParse
+	}
+
+	if *moduleName != "" && !slices.ContainsFunc(root.Modules, func(m ast.Module) bool { return m.Name == *moduleName }) {
+		fmt.Fprintf(stderr, "module %q is not defined\n", *moduleName)
+		return exitSemantic
+	}
+
+	mapper := &ast_to_code_mapper.Mapper{}
+	compiled, err := mapper.MapRoot(root)
+	for _, diagnostic := range mapper.Diagnostics() {
+		fmt.Fprintln(stderr, diagnostic)
+	}
+	if err != nil {
+		var diagnostics ast_to_code_mapper.Diagnostics
+		if !errors.As(err, &diagnostics) {
+			fmt.Fprintln(stderr, err)
+		}
+
+		return exitSemantic
+	}
+
+	var files []languages.File
+	if *target == agnosticScriptTarget {
+		files = printModules(root, *moduleName)
+	} else {
+		codeRoot := compiled.(*code.Root)
+		if *moduleName != "" {
+			codeRoot = &code.Root{
+				Modules:      slices.DeleteFunc(slices.Clone(codeRoot.Modules), func(m *code.Module) bool { return m.Name != *moduleName }),
+				RootMetadata: codeRoot.RootMetadata,
+			}
+		}
+
+		files, err = generate(codeRoot)
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+			return exitEmit
+		}
+	}
+
+	for _, file := range files {
+		path := filepath.Join(*outDir, filepath.FromSlash(file.Path))
+		if err := write(path, file.Contents); err != nil {
+			fmt.Fprintln(stderr, err)
+			return exitEmit
+		}
+
+		fmt.Fprintln(stdout, path)
+	}
+
+	return exitOK
+}
+
+// parse parses each of the files and combines their modules into a single root. Files that end in ".json" are decoded
+// rather than parsed.
+func parse(filenames []string) (ast.Root, error) {
+	var root ast.Root
+	definedIn := map[string]string{}
+	for _, filename := range filenames {
+		source, err := os.ReadFile(filename)
+		if err != nil {
+			return ast.Root{}, err
+		}
+
+		var fileRoot ast.Root
+		if filepath.Ext(filename) == ".json" {
+			if err := json.Unmarshal(source, &fileRoot); err != nil {
+				return ast.Root{}, fmt.Errorf("%s: %w", filename, err)
+			}
+		} else {
+			fileRoot, err = agnosticscript.Parse(filename, string(source))
+			if err != nil {
+				return ast.Root{}, err
+			}
+		}
+
+		for _, module := range fileRoot.Modules {
+			if other, exists := definedIn[module.Name]; exists {
+				return ast.Root{}, fmt.Errorf("%s: module %q is already defined in %s", filename, module.Name, other)
+			}
+			definedIn[module.Name] = filename
+
+			root.Modules = append(root.Modules, module)
+		}
+	}
+
+	return root, nil
+}
+
+// printModules prints each module into its own AgnosticScript file. If the module name isn't empty, only that module is
+// printed.
+func printModules(root ast.Root, moduleName string) []languages.File {
+	var files []languages.File
+	for _, module := range root.Modules {
+		if moduleName != "" && module.Name != moduleName {
+			continue
+		}
+
+		files = append(files, languages.File{
+			Path:     module.Name + ".as",
+			Contents: agnosticscript.Print(ast.Root{Modules: []ast.Module{module}}),
+		})
+	}
+
+	return files
+}
+
+func write(path, contents string) error {
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, []byte(contents), 0o644)
+}
@@ -0,0 +1,179 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/JosephNaberhaus/agnostic/internal/agnosticscript"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const program = `
+module example {
+	model Point {
+		x int64
+	}
+
+	func main() int64 {
+		var points = list[Point]{}
+		push(points, new(Point))
+		return len(points)
+	}
+}
+`
+
+// writeSource writes the source to a file in a temporary directory and returns the file's path.
+func writeSource(t *testing.T, source string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "example.as")
+	require.NoError(t, os.WriteFile(path, []byte(source), 0o644))
+	return path
+}
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		target   string
+		expected string
+	}{
+		{target: "cpp", expected: "example.cpp"},
+		{target: "go", expected: "example/example.go"},
+		{target: "java", expected: "example/Point.java"},
+		{target: "python", expected: "example.py"},
+		{target: "rust", expected: "example.rs"},
+		{target: "typescript", expected: "example.ts"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			input := writeSource(t, program)
+			outDir := t.TempDir()
+
+			var stdout, stderr bytes.Buffer
+			code := run([]string{"--target", tt.target, "--out-dir", outDir, input}, &stdout, &stderr)
+			require.Equal(t, exitOK, code, stderr.String())
+
+			expected := filepath.Join(outDir, filepath.FromSlash(tt.expected))
+			assert.FileExists(t, expected)
+			assert.Contains(t, stdout.String(), expected+"\n")
+		})
+	}
+}
+
+func TestRun_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		source   string
+		args     []string
+		expected int
+		message  string
+	}{
+		{
+			name:     "unknown target",
+			source:   program,
+			args:     []string{"--target", "cobol"},
+			expected: exitUsage,
+			message:  `unknown target "cobol"`,
+		},
+		{
+			name:     "syntax error",
+			source:   "module example {\n\tfunc main() {\n\t\tvar = 1\n\t}\n}",
+			args:     []string{"--target", "go"},
+			expected: exitParse,
+			message:  "example.as:3:7: expected identifier but found \"=\"",
+		},
+		{
+			name:     "type error",
+			source:   "module example {\n\tfunc main() {\n\t\tvar x = len(1)\n\t}\n}",
+			args:     []string{"--target", "go"},
+			expected: exitSemantic,
+			message:  `example.as:3:11: error: module "example" > function "main" > Block > Declare > Length: cannot take the length of type int64`,
+		},
+		{
+			name:     "undefined module",
+			source:   program,
+			args:     []string{"--target", "go", "--module", "other"},
+			expected: exitSemantic,
+			message:  `module "other" is not defined`,
+		},
+		{
+			name:     "model named after its module",
+			source:   "module example {\n\tmodel Example {\n\t\tx int64\n\t}\n}",
+			args:     []string{"--target", "java"},
+			expected: exitEmit,
+			message:  `model "Example" has the same name as the class of its module`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := writeSource(t, tt.source)
+			args := append(tt.args, "--out-dir", t.TempDir(), input)
+
+			var stdout, stderr bytes.Buffer
+			assert.Equal(t, tt.expected, run(args, &stdout, &stderr))
+			assert.Contains(t, stderr.String(), tt.message)
+		})
+	}
+}
+
+func TestRun_JSON(t *testing.T) {
+	root, err := agnosticscript.Parse("example.as", program)
+	require.NoError(t, err)
+
+	data, err := json.Marshal(root)
+	require.NoError(t, err)
+
+	jsonInput := filepath.Join(t.TempDir(), "example.json")
+	require.NoError(t, os.WriteFile(jsonInput, data, 0o644))
+
+	var outputs []string
+	for _, input := range []string{writeSource(t, program), jsonInput} {
+		outDir := t.TempDir()
+
+		var stdout, stderr bytes.Buffer
+		code := run([]string{"--target", "go", "--out-dir", outDir, input}, &stdout, &stderr)
+		require.Equal(t, exitOK, code, stderr.String())
+
+		output, err := os.ReadFile(filepath.Join(outDir, "example", "example.go"))
+		require.NoError(t, err)
+		outputs = append(outputs, string(output))
+	}
+
+	assert.Equal(t, outputs[0], outputs[1])
+}
+
+func TestRun_Module(t *testing.T) {
+	input := writeSource(t, program+"\nmodule other {\n\tconst x = 1\n}\n")
+	outDir := t.TempDir()
+
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"--target", "python", "--out-dir", outDir, "--module", "other", input}, &stdout, &stderr)
+	require.Equal(t, exitOK, code, stderr.String())
+
+	assert.FileExists(t, filepath.Join(outDir, "other.py"))
+	assert.NoFileExists(t, filepath.Join(outDir, "example.py"))
+}
+
+func TestRun_AgnosticScript(t *testing.T) {
+	root, err := agnosticscript.Parse("example.as", program)
+	require.NoError(t, err)
+
+	data, err := json.Marshal(root)
+	require.NoError(t, err)
+
+	input := filepath.Join(t.TempDir(), "example.json")
+	require.NoError(t, os.WriteFile(input, data, 0o644))
+	outDir := t.TempDir()
+
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"--target", "agnosticscript", "--out-dir", outDir, input}, &stdout, &stderr)
+	require.Equal(t, exitOK, code, stderr.String())
+
+	output, err := os.ReadFile(filepath.Join(outDir, "example.as"))
+	require.NoError(t, err)
+	assert.Equal(t, strings.TrimPrefix(program, "\n"), string(output))
+}
@@ -1,6 +1,10 @@
 package find
 
-import "github.com/JosephNaberhaus/agnostic/tool/generator/model"
+import (
+	"slices"
+
+	"github.com/JosephNaberhaus/agnostic/tool/generator/model"
+)
 
 func AllNodeTypes(specs []model.Spec) []string {
 	nodeTypeSet := map[string]struct{}{}
@@ -15,6 +19,9 @@ func AllNodeTypes(specs []model.Spec) []string {
 		allNodeTypes = append(allNodeTypes, nodeType)
 	}
 
+	// Sort so that the generated code doesn't change between runs.
+	slices.Sort(allNodeTypes)
+
 	return allNodeTypes
 }
 
@@ -1,31 +1,46 @@
 package gen
 
 import (
+	"fmt"
 	"regexp"
+	"slices"
 	"strings"
+
+	"github.com/JosephNaberhaus/agnostic/tool/generator/model"
 )
 
 func removeTypePrefix(str string) string {
 	return strings.ReplaceAll(str, "~", "")
 }
 
-func makePointer(str string) string {
-	if strings.HasPrefix(str, "[]") {
-		// For slices we might need to make the underlying type a pointer.
-		return "[]" + makePointer(strings.TrimPrefix(str, "[]"))
-	}
+// pointerMaker returns a function that makes the type into a pointer if it's a node.
+func pointerMaker(enums []model.Spec) func(string) string {
+	var makePointer func(string) string
+	makePointer = func(str string) string {
+		if strings.HasPrefix(str, "[]") {
+			// For slices we might need to make the underlying type a pointer.
+			return "[]" + makePointer(strings.TrimPrefix(str, "[]"))
+		}
 
-	if strings.HasPrefix(str, "~") {
-		// Types are implemented by interfaces which are already pointers.
-		return str
-	}
+		if strings.HasPrefix(str, "~") {
+			// Types are implemented by interfaces which are already pointers.
+			return str
+		}
 
-	if strings.ToLower(str[:1]) == str[:1] {
-		// Primitive types don't need to be pointers.
-		return str
+		if strings.ToLower(str[:1]) == str[:1] {
+			// Primitive types don't need to be pointers.
+			return str
+		}
+
+		if slices.ContainsFunc(enums, func(enum model.Spec) bool { return enum.Name == str }) {
+			// Enums are just integers.
+			return str
+		}
+
+		return "*" + str
 	}
 
-	return "*" + str
+	return makePointer
 }
 
 func removeOptional(str string) string {
@@ -39,6 +54,139 @@ func removeOptional(str string) string {
 	return matches[1]
 }
 
+// isRequired returns whether a property with the type must always be set. Lists can be left out since they're empty
+// by default.
+func isRequired(str string) bool {
+	return removeOptional(str) == str && !strings.HasPrefix(str, "[]")
+}
+
+// requiredProperties returns the sorted keys of the properties of the spec that must always be set.
+func requiredProperties(spec model.Spec) []string {
+	var required []string
+	for key, value := range spec.Properties {
+		if isRequired(value) {
+			required = append(required, key)
+		}
+	}
+	slices.Sort(required)
+
+	return required
+}
+
+// isRequiredBinary returns whether the binary format requires a property with the type. Primitives are left out when
+// they're zero, so only nodes, node types, and enums can be required.
+func isRequiredBinary(str string) bool {
+	return isRequired(str) && strings.ToUpper(str[:1]) == str[:1]
+}
+
+// requiredBinaryFields returns the sorted keys of the properties of the spec that the binary format requires.
+func requiredBinaryFields(spec model.Spec) []string {
+	var required []string
+	for key, value := range spec.Properties {
+		if isRequiredBinary(value) {
+			required = append(required, key)
+		}
+	}
+	slices.Sort(required)
+
+	return required
+}
+
 func title(str string) string {
 	return strings.ToUpper(str[:1]) + str[1:]
 }
+
+// jsonProperty is how a property of an AST node is encoded as JSON.
+type jsonProperty struct {
+	// The type of the property in the struct that's encoded.
+	Type string
+	// The functions that convert the property to and from Type, or empty if it's already Type.
+	To   string
+	From string
+	// Whether the property is left out when it isn't set.
+	OmitEmpty bool
+}
+
+// jsonPropertyOf returns how a property with the type is encoded. Properties whose type is a node type need a kind to
+// be decoded, so they're wrapped in a kinded.
+func jsonPropertyOf(str string) (jsonProperty, error) {
+	if inner := removeOptional(str); inner != str {
+		if strings.HasPrefix(inner, "~") {
+			return jsonProperty{Type: "*kinded[" + removeTypePrefix(inner) + "]", To: "wrapOptional", From: "unwrapOptional", OmitEmpty: true}, nil
+		}
+
+		if strings.HasPrefix(inner, "[]") {
+			return jsonProperty{}, fmt.Errorf("optional lists aren't supported: %s", str)
+		}
+
+		return jsonProperty{Type: "*" + inner, To: "optionalPointer", From: "pointerOptional", OmitEmpty: true}, nil
+	}
+
+	if strings.HasPrefix(str, "[]~") {
+		return jsonProperty{Type: "[]kinded[" + strings.TrimPrefix(str, "[]~") + "]", To: "wrapEach", From: "unwrapEach"}, nil
+	}
+
+	if strings.HasPrefix(str, "~") {
+		return jsonProperty{Type: "kinded[" + removeTypePrefix(str) + "]", To: "wrap", From: "unwrap"}, nil
+	}
+
+	return jsonProperty{Type: str}, nil
+}
+
+// binaryCodecMaker returns a function that returns the codec that encodes a property with the type in the binary
+// format of the AST.
+func binaryCodecMaker(enums []model.Spec) func(string) (string, error) {
+	var codecOf func(string) (string, error)
+	codecOf = func(str string) (string, error) {
+		if inner := removeOptional(str); inner != str {
+			codec, err := codecOf(inner)
+			if err != nil {
+				return "", err
+			}
+
+			return "optionalCodec[" + removeTypePrefix(inner) + ", " + codec + "]", nil
+		}
+
+		switch str {
+		case "bool":
+			return "boolCodec", nil
+		case "float64":
+			return "float64Codec", nil
+		case "int32", "rune":
+			return "signedCodec[int32]", nil
+		case "int64":
+			return "signedCodec[int64]", nil
+		case "string":
+			return "stringCodec", nil
+		case "uint8":
+			return "uint8Codec", nil
+		case "[]uint8":
+			return "bytesCodec", nil
+		}
+
+		if element, isList := strings.CutPrefix(str, "[]"); isList {
+			codec, err := codecOf(element)
+			if err != nil {
+				return "", err
+			}
+
+			return "listCodec[" + removeTypePrefix(element) + ", " + codec + "]", nil
+		}
+
+		if nodeType, isNodeType := strings.CutPrefix(str, "~"); isNodeType {
+			return "kindCodec[" + nodeType + "]", nil
+		}
+
+		if strings.ToLower(str[:1]) == str[:1] {
+			return "", fmt.Errorf("the binary format doesn't support %s", str)
+		}
+
+		if slices.ContainsFunc(enums, func(enum model.Spec) bool { return enum.Name == str }) {
+			return "enumCodec[" + str + ", *" + str + "]", nil
+		}
+
+		return "nodeCodec[" + str + ", *" + str + "]", nil
+	}
+
+	return codecOf
+}
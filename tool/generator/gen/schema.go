@@ -0,0 +1,163 @@
+package gen
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/JosephNaberhaus/agnostic/tool/generator/find"
+	"github.com/JosephNaberhaus/agnostic/tool/generator/model"
+)
+
+// object is a JSON object of the schema.
+type object = map[string]any
+
+// writeSchema writes the JSON Schema of the format that AST nodes are encoded in. Each node, node type, and enum has a
+// definition under the name it has in the spec.
+func writeSchema(specs, enums []model.Spec, outputDir string) error {
+	definitions := object{
+		"Position": object{
+			"type": "object",
+			"properties": object{
+				"filename":  object{"type": "string"},
+				"line":      object{"type": "integer", "minimum": 0},
+				"column":    object{"type": "integer", "minimum": 0},
+				"offset":    object{"type": "integer", "minimum": 0},
+				"endOffset": object{"type": "integer", "minimum": 0},
+			},
+			"additionalProperties": false,
+		},
+	}
+
+	define := func(name string, definition object) error {
+		if _, exists := definitions[name]; exists {
+			return fmt.Errorf("%s is defined more than once", name)
+		}
+
+		definitions[name] = definition
+		return nil
+	}
+
+	for _, enum := range enums {
+		if err := define(enum.Name, object{"enum": enum.Values}); err != nil {
+			return err
+		}
+	}
+
+	for nodeType, implementations := range find.ImplementationsByNodeType(specs) {
+		options := make([]any, 0, len(implementations))
+		for _, implementation := range implementations {
+			options = append(options, reference(implementation))
+		}
+
+		definition := object{
+			"type":     "object",
+			"required": []string{"kind"},
+			"oneOf":    options,
+		}
+		if err := define(nodeType, definition); err != nil {
+			return err
+		}
+	}
+
+	for _, spec := range specs {
+		properties := object{"position": reference("Position")}
+		if len(spec.Types) > 0 {
+			properties["kind"] = object{"const": spec.Name}
+		}
+
+		required := []string{}
+		for key, value := range spec.Properties {
+			schema, err := propertySchema(value, specs, enums)
+			if err != nil {
+				return fmt.Errorf("property %s of %s: %w", key, spec.Name, err)
+			}
+
+			properties[key] = schema
+			if isRequired(value) {
+				required = append(required, key)
+			}
+		}
+		slices.Sort(required)
+
+		definition := object{
+			"type":                 "object",
+			"properties":           properties,
+			"required":             required,
+			"additionalProperties": false,
+		}
+		if err := define(spec.Name, definition); err != nil {
+			return err
+		}
+	}
+
+	schema := object{
+		"$schema":     "https://json-schema.org/draft/2020-12/schema",
+		"title":       "Agnostic AST",
+		"description": "An ast.Root encoded as JSON. Generated by tool/generator; run `just gen` to regenerate it.",
+		"$ref":        "#/$defs/Root",
+		"$defs":       definitions,
+	}
+
+	data, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	schemaFile := filepath.Join(outputDir, schemaFilename)
+	return os.WriteFile(schemaFile, append(data, '\n'), 0o644)
+}
+
+// propertySchema returns the schema of a property with the type. Optional properties and lists may be null.
+func propertySchema(str string, specs, enums []model.Spec) (object, error) {
+	if inner := removeOptional(str); inner != str {
+		schema, err := propertySchema(inner, specs, enums)
+		if err != nil {
+			return nil, err
+		}
+
+		return object{"anyOf": []any{schema, object{"type": "null"}}}, nil
+	}
+
+	switch str {
+	case "bool":
+		return object{"type": "boolean"}, nil
+	case "float64":
+		return object{"type": "number"}, nil
+	case "int32", "rune":
+		return object{"type": "integer", "minimum": math.MinInt32, "maximum": math.MaxInt32}, nil
+	case "int64":
+		return object{"type": "integer", "minimum": math.MinInt64, "maximum": math.MaxInt64}, nil
+	case "string":
+		return object{"type": "string"}, nil
+	case "uint8":
+		return object{"type": "integer", "minimum": 0, "maximum": math.MaxUint8}, nil
+	case "[]uint8":
+		return object{"type": []string{"string", "null"}, "contentEncoding": "base64"}, nil
+	}
+
+	if element, isList := strings.CutPrefix(str, "[]"); isList {
+		items, err := propertySchema(element, specs, enums)
+		if err != nil {
+			return nil, err
+		}
+
+		return object{"type": []string{"array", "null"}, "items": items}, nil
+	}
+
+	name := removeTypePrefix(str)
+	isDefined := func(spec model.Spec) bool { return spec.Name == name }
+	if slices.ContainsFunc(specs, isDefined) || slices.ContainsFunc(enums, isDefined) || slices.Contains(find.AllNodeTypes(specs), name) {
+		return reference(name), nil
+	}
+
+	return nil, fmt.Errorf("unknown type %s", str)
+}
+
+func reference(name string) object {
+	return object{"$ref": "#/$defs/" + name}
+}
@@ -11,25 +11,42 @@ import (
 )
 
 const (
-	astPackage   = "ast"
-	astDirectory = "../../ast"
+	astPackage    = "ast"
+	astDirectory  = "../../ast"
+	astNodePrefix = ""
 
 	codePackage   = "code"
 	codeDirectory = "../../code"
+	// Code nodes are always handled by pointer so that their metadata can be populated in-place.
+	codeNodePrefix = "*"
 
 	astFilename      = "ast_gen.go"
+	binaryFilename   = "binary_gen.go"
 	codeFilename     = "code_gen.go"
+	enumFilename     = "enum_gen.go"
+	jsonFilename     = "json_gen.go"
 	mapperFilename   = "mapper_gen.go"
 	nodeTypeFilename = "node_type_gen.go"
 	optionalFilename = "optional_gen.go"
+	positionFilename = "position_gen.go"
+	schemaFilename   = "ast.schema.json"
 )
 
 //go:embed ast.go.tmpl
 var astTemplate string
 
+//go:embed binary.go.tmpl
+var binaryTemplate string
+
 //go:embed code.go.tmpl
 var codeTemplate string
 
+//go:embed enum.go.tmpl
+var enumTemplate string
+
+//go:embed json.go.tmpl
+var jsonTemplate string
+
 //go:embed mapper.go.tmpl
 var mapperTemplate string
 
@@ -39,14 +56,22 @@ var nodeTypesTemplate string
 //go:embed optional.go.tmpl
 var optionalTemplate string
 
-func WriteAST(specs []model.Spec) error {
+//go:embed position.go.tmpl
+var positionTemplate string
+
+func WriteAST(specs, enums []model.Spec) error {
 	astFile := filepath.Join(astDirectory, astFilename)
-	err := executeTemplate(astTemplate, astFile, specs)
+	err := executeTemplate(astTemplate, astFile, specs, enums)
+	if err != nil {
+		return err
+	}
+
+	err = writeEnums(enums, astPackage, astDirectory)
 	if err != nil {
 		return err
 	}
 
-	err = writeMapper(specs, astPackage, astDirectory)
+	err = writeMapper(specs, astPackage, astNodePrefix, astDirectory)
 	if err != nil {
 		return err
 	}
@@ -61,17 +86,42 @@ func WriteAST(specs []model.Spec) error {
 		return err
 	}
 
+	err = writePosition(astPackage, astDirectory)
+	if err != nil {
+		return err
+	}
+
+	err = writeJSON(specs, enums, astPackage, astDirectory)
+	if err != nil {
+		return err
+	}
+
+	err = writeSchema(specs, enums, astDirectory)
+	if err != nil {
+		return err
+	}
+
+	err = writeBinary(specs, enums, astPackage, astDirectory)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
-func WriteCode(specs []model.Spec) error {
+func WriteCode(specs, enums []model.Spec) error {
 	codeFile := filepath.Join(codeDirectory, codeFilename)
-	err := executeTemplate(codeTemplate, codeFile, specs)
+	err := executeTemplate(codeTemplate, codeFile, specs, enums)
 	if err != nil {
 		return err
 	}
 
-	err = writeMapper(specs, codePackage, codeDirectory)
+	err = writeEnums(enums, codePackage, codeDirectory)
+	if err != nil {
+		return err
+	}
+
+	err = writeMapper(specs, codePackage, codeNodePrefix, codeDirectory)
 	if err != nil {
 		return err
 	}
@@ -81,22 +131,29 @@ func WriteCode(specs []model.Spec) error {
 		return err
 	}
 
+	err = writePosition(codePackage, codeDirectory)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
-func writeMapper(specs []model.Spec, packageName, outputDir string) error {
+func writeMapper(specs []model.Spec, packageName, nodePrefix, outputDir string) error {
 	data := struct {
 		Package                   string
+		NodePrefix                string
 		ImplementationsByNodeType map[string][]string
 		Specs                     []model.Spec
 	}{
 		Package:                   packageName,
+		NodePrefix:                nodePrefix,
 		ImplementationsByNodeType: find.ImplementationsByNodeType(specs),
 		Specs:                     specs,
 	}
 
 	mapperFile := filepath.Join(outputDir, mapperFilename)
-	return executeTemplate(mapperTemplate, mapperFile, data)
+	return executeTemplate(mapperTemplate, mapperFile, data, nil)
 }
 
 func writeNodeTypes(specs []model.Spec, packageName, outputDir string) error {
@@ -109,7 +166,7 @@ func writeNodeTypes(specs []model.Spec, packageName, outputDir string) error {
 	}
 
 	nodeTypesFile := filepath.Join(outputDir, nodeTypeFilename)
-	return executeTemplate(nodeTypesTemplate, nodeTypesFile, data)
+	return executeTemplate(nodeTypesTemplate, nodeTypesFile, data, nil)
 }
 
 func writeOptional(packageName, outputDir string) error {
@@ -120,10 +177,64 @@ func writeOptional(packageName, outputDir string) error {
 	}
 
 	optionalFile := filepath.Join(outputDir, optionalFilename)
-	return executeTemplate(optionalTemplate, optionalFile, data)
+	return executeTemplate(optionalTemplate, optionalFile, data, nil)
+}
+
+func writePosition(packageName, outputDir string) error {
+	data := struct {
+		Package string
+	}{
+		Package: packageName,
+	}
+
+	positionFile := filepath.Join(outputDir, positionFilename)
+	return executeTemplate(positionTemplate, positionFile, data, nil)
+}
+
+func writeJSON(specs, enums []model.Spec, packageName, outputDir string) error {
+	data := struct {
+		Package string
+		Specs   []model.Spec
+		Enums   []model.Spec
+	}{
+		Package: packageName,
+		Specs:   specs,
+		Enums:   enums,
+	}
+
+	jsonFile := filepath.Join(outputDir, jsonFilename)
+	return executeTemplate(jsonTemplate, jsonFile, data, nil)
+}
+
+func writeBinary(specs, enums []model.Spec, packageName, outputDir string) error {
+	data := struct {
+		Package string
+		Specs   []model.Spec
+	}{
+		Package: packageName,
+		Specs:   specs,
+	}
+
+	binaryFile := filepath.Join(outputDir, binaryFilename)
+	return executeTemplate(binaryTemplate, binaryFile, data, enums)
+}
+
+func writeEnums(enums []model.Spec, packageName, outputDir string) error {
+	data := struct {
+		Package string
+		Enums   []model.Spec
+	}{
+		Package: packageName,
+		Enums:   enums,
+	}
+
+	enumFile := filepath.Join(outputDir, enumFilename)
+	return executeTemplate(enumTemplate, enumFile, data, nil)
 }
 
-func executeTemplate(templateText, outputFile string, data any) error {
+// executeTemplate writes the output of the template to the file. Properties whose type is one of the enums are never
+// made into pointers, and are encoded as enums in the binary format.
+func executeTemplate(templateText, outputFile string, data any, enums []model.Spec) error {
 	err := os.MkdirAll(filepath.Dir(outputFile), os.ModePerm)
 	if err != nil {
 		return err
@@ -131,10 +242,15 @@ func executeTemplate(templateText, outputFile string, data any) error {
 
 	tmpl := template.New("template ")
 	tmpl.Funcs(template.FuncMap{
-		"makePointer":      makePointer,
-		"removeOptional":   removeOptional,
-		"removeTypePrefix": removeTypePrefix,
-		"title":            title,
+		"binaryCodec":          binaryCodecMaker(enums),
+		"isRequiredBinary":     isRequiredBinary,
+		"jsonProperty":         jsonPropertyOf,
+		"makePointer":          pointerMaker(enums),
+		"removeOptional":       removeOptional,
+		"removeTypePrefix":     removeTypePrefix,
+		"requiredBinaryFields": requiredBinaryFields,
+		"requiredProperties":   requiredProperties,
+		"title":                title,
 	})
 
 	tmpl, err = tmpl.Parse(templateText)
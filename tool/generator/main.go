@@ -26,12 +26,15 @@ func main() {
 		return strings.Compare(a.Name, b.Name)
 	})
 
-	err = gen.WriteAST(specs)
+	enums := slices.DeleteFunc(slices.Clone(specs), func(spec model.Spec) bool { return !spec.IsEnum() })
+	nodes := slices.DeleteFunc(specs, model.Spec.IsEnum)
+
+	err = gen.WriteAST(nodes, enums)
 	if err != nil {
 		handleErr(err)
 	}
 
-	err = gen.WriteCode(specs)
+	err = gen.WriteCode(nodes, enums)
 	if err != nil {
 		handleErr(err)
 	}
@@ -4,4 +4,11 @@ type Spec struct {
 	Name       string            `yaml:"name"`
 	Types      []string          `yaml:"types"`
 	Properties map[string]string `yaml:"properties"`
+	// The values of an enum. A spec with values is an enum rather than a node.
+	Values []string `yaml:"values"`
+}
+
+// IsEnum returns whether the spec is for an enum rather than a node.
+func (s Spec) IsEnum() bool {
+	return len(s.Values) > 0
 }
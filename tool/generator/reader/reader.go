@@ -52,5 +52,9 @@ func loadSpec(path string) (model.Spec, error) {
 		return model.Spec{}, fmt.Errorf("error parsing %s: %w", path, err)
 	}
 
+	if _, ok := spec.Properties["position"]; ok {
+		return model.Spec{}, fmt.Errorf("error parsing %s: every node already has a position property", path)
+	}
+
 	return spec, nil
 }